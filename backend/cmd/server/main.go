@@ -1,114 +1,524 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
-	"github.com/joho/godotenv"
 	"golang.org/x/crypto/bcrypt"
-	"gopkg.in/gomail.v2"
-	"gorm.io/driver/sqlite"
-	"gorm.io/gorm"
 
+	"github.com/eskokado/startup-auth-go/backend/configs"
+	"github.com/eskokado/startup-auth-go/backend/internal/database"
 	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/internal/jobs"
+	"github.com/eskokado/startup-auth-go/backend/internal/logging"
+	"github.com/eskokado/startup-auth-go/backend/internal/metrics"
 	"github.com/eskokado/startup-auth-go/backend/internal/middleware"
+	"github.com/eskokado/startup-auth-go/backend/internal/openapi"
+	swaggeruiassets "github.com/eskokado/startup-auth-go/backend/internal/openapi/swaggerui/assets"
 	"github.com/eskokado/startup-auth-go/backend/internal/providers"
 	provider "github.com/eskokado/startup-auth-go/backend/internal/providers"
 	repository "github.com/eskokado/startup-auth-go/backend/internal/repositories"
+	"github.com/eskokado/startup-auth-go/backend/internal/tracing"
 	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	domainproviders "github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
 	service "github.com/eskokado/startup-auth-go/backend/pkg/domain/services"
+	"github.com/eskokado/startup-auth-go/backend/pkg/i18n"
 )
 
 func main() {
-	// Carregar variáveis de ambiente
-	_ = godotenv.Load(".env")
-	sender := gomail.NewDialer(
-		os.Getenv("SMTP_HOST"),
-		parsePort(os.Getenv("SMTP_PORT")),
-		os.Getenv("SMTP_USERNAME"),
-		os.Getenv("SMTP_PASSWORD"),
-	)
+	// Subcomando: server migrate [up|down [n]|status]
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Carregar e validar a configuração (padrões, YAML, .env e ambiente)
+	cfg, err := configs.LoadConfig("")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 
-	// 1. Configurar o banco de dados (SQLite para exemplo)
-	db, err := gorm.Open(sqlite.Open("test.db"), &gorm.Config{})
+	// Logs estruturados com segredos escondidos; o pacote log padrão também
+	// passa a escrever por aqui
+	logger, err := logging.New(os.Stdout, cfg.Log.Format, cfg.Log.Level)
 	if err != nil {
-		panic("failed to connect database")
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-	db.AutoMigrate(&repository.GormUser{})
+	slog.SetDefault(logger)
+
+	// SIGINT/SIGTERM param os jobs e iniciam o encerramento do servidor
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// 2. Inicializar repositório
-	userRepo := repository.NewGormUserRepository(db)
+	sender, err := providers.NewMailSender(providers.MailTransportConfig{
+		Transport:    cfg.SMTP.Transport,
+		SMTPHost:     cfg.SMTP.Host,
+		SMTPPort:     cfg.SMTP.Port,
+		SMTPUsername: cfg.SMTP.Username,
+		SMTPPassword: cfg.SMTP.Password,
+		FileDir:      cfg.SMTP.FileDir,
+		MailboxSize:  cfg.SMTP.MailboxSize,
+		Logger:       logger,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// 1. Configurar o banco de dados e aplicar migrações pendentes
+	db, err := database.Open(databaseConfig(cfg.Database))
+	if err != nil {
+		panic("failed to connect database: " + err.Error())
+	}
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		panic(err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		panic(err)
+	}
+
+	// 1.1 Métricas do Prometheus, alimentadas pelos decorators e pelo middleware HTTP
+	appMetrics := metrics.New()
+
+	// 1.2 Spans do OpenTelemetry; desligado, o traceparent recebido continua sendo propagado
+	tracerConfig := tracing.TracerConfig{SampleRatio: cfg.Tracing.SampleRatio}
+	if cfg.Tracing.Enabled {
+		tracerConfig.Exporter, err = tracing.NewExporter(cfg.Tracing.Exporter, tracing.OTLPConfig{
+			Endpoint:    cfg.Tracing.OTLPEndpoint,
+			ServiceName: cfg.Tracing.ServiceName,
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+	tracer := tracing.NewTracer(tracerConfig)
+	// Clientes HTTP de saída (captcha, S3) propagam o traceparent
+	tracedHTTP := func(timeout time.Duration) *http.Client {
+		return &http.Client{Timeout: timeout, Transport: tracing.NewTransport(nil, tracer)}
+	}
+
+	// 2. Inicializar repositórios
+	userRepo := repository.NewInstrumentedUserRepository(
+		repository.NewTracedUserRepository(repository.NewGormUserRepository(db), tracer), appMetrics,
+	)
+	apiKeyRepo := repository.NewGormAPIKeyRepository(db)
+	auditRepo := repository.NewGormAuditRepository(db)
+	outboxRepo := repository.NewGormOutboxRepository(db)
+	txManager := repository.NewGormTxManager(db)
 
 	// 3. Inicializar serviços
-	emailService := service.NewEmailService(sender)
+	emailTemplates, err := service.NewEmailTemplates(cfg.SMTP.TemplatesDir, cfg.SMTP.DefaultLocale)
+	if err != nil {
+		panic(err)
+	}
+	translator, err := i18n.NewTranslator(cfg.Server.DefaultLocale)
+	if err != nil {
+		panic(err)
+	}
+	emailService := providers.NewTracedEmailService(service.NewEmailService(sender, service.EmailConfig{
+		From:           cfg.SMTP.From,
+		FrontendURL:    cfg.SMTP.FrontendResetURL,
+		EmailChangeURL: cfg.SMTP.FrontendEmailChangeURL,
+		ResetTTL:       cfg.PasswordReset.TTL,
+		ExportTTL:      cfg.Export.LinkTTL,
+		EmailChangeTTL: cfg.EmailChange.TTL,
+		Templates:      emailTemplates,
+	}), tracer)
 
 	// 4. Inicializar redis
 	rdb := redis.NewClient(&redis.Options{
-		Addr:     "localhost:6379", // Endereço do Redis
-		Password: "",               // Senha
-		DB:       0,                // Banco padrão
+		Addr:     cfg.Redis.Addr,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
 	})
 
 	// 4. Inicializar provedores
-	cryptoProvider := provider.NewBcryptProvider(bcrypt.DefaultCost)
-	tokenProvider := provider.NewJWTProvider("secret-key", 24*time.Hour)
-	blacklistProvider := providers.NewRedisBlacklist(rdb)
+	cryptoProvider := provider.NewTracedCrypto(provider.NewBcryptProvider(bcrypt.DefaultCost), tracer)
+	tokenProvider := provider.NewInstrumentedTokenProvider(
+		provider.NewTracedTokenProvider(provider.NewJWTProvider(cfg.JWT.Secret, cfg.JWT.TTL), tracer), appMetrics,
+	)
+	blacklistProvider := providers.NewInstrumentedBlacklist(
+		providers.NewTracedBlacklist(providers.NewRedisBlacklist(rdb), tracer), appMetrics,
+	)
+	revocationStore, err := providers.NewTokenStore(cfg.Session.TokenStore, blacklistProvider)
+	if err != nil {
+		panic(err)
+	}
+	tokenStore := providers.NewInstrumentedTokenStore(revocationStore, appMetrics)
+
+	urlSigner := providers.NewHMACURLSigner(cfg.JWT.Secret)
+
+	// Verificação humana no cadastro e no esqueci a senha; nil quando desligada
+	humanVerifier, err := providers.NewHumanVerifier(providers.HumanVerifierConfig{
+		Kind:          cfg.Human.Verifier,
+		PowDifficulty: cfg.Human.PowDifficulty,
+		PowTTL:        cfg.Human.PowTTL,
+		Captcha: providers.CaptchaConfig{
+			SiteKey:    cfg.Human.CaptchaSiteKey,
+			Secret:     cfg.Human.CaptchaSecret,
+			HTTPClient: tracedHTTP(10 * time.Second),
+		},
+	}, urlSigner, blacklistProvider)
+	if err != nil {
+		panic(err)
+	}
+	if humanVerifier != nil && cfg.Human.RiskThreshold > 0 {
+		attempts, err := providers.NewRateLimiter(cfg.RateLimit.Backend, domainproviders.RateLimitSlidingWindow, rdb)
+		if err != nil {
+			panic(err)
+		}
+		humanVerifier = providers.NewRiskBasedHumanVerifier(humanVerifier, attempts, domainproviders.RateLimit{
+			Limit:  cfg.Human.RiskThreshold,
+			Window: cfg.Human.RiskWindow,
+		})
+	}
+	exportStore, err := providers.NewFileExportStore(cfg.Export.Dir)
+	if err != nil {
+		panic(err)
+	}
+
+	// Avatares: diretório local ou serviço compatível com S3
+	blobStore, err := providers.NewBlobStore(providers.BlobStoreConfig{
+		Store: cfg.Blob.Store,
+		Dir:   cfg.Blob.Dir,
+		S3: providers.S3Config{
+			Endpoint:   cfg.Blob.S3Endpoint,
+			Region:     cfg.Blob.S3Region,
+			Bucket:     cfg.Blob.S3Bucket,
+			AccessKey:  cfg.Blob.S3AccessKey,
+			SecretKey:  cfg.Blob.S3SecretKey,
+			HTTPClient: tracedHTTP(30 * time.Second),
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	imageProcessor := providers.NewStdImageProcessor(cfg.Avatar.MaxPixels)
+
+	// 4.1 Auditoria: banco de dados e, opcionalmente, arquivo JSON lines
+	var auditLogger domainproviders.AuditLogger = auditRepo
+	// O apagamento de contas também anonimiza o arquivo
+	var auditEraser domainproviders.AuditEraser
+	if path := cfg.Audit.LogFile; path != "" {
+		fileLogger, err := providers.OpenJSONLinesAuditFile(path)
+		if err != nil {
+			panic(err)
+		}
+		defer fileLogger.Close()
+		auditLogger = providers.NewMultiAuditLogger(auditRepo, fileLogger)
+		auditEraser = fileLogger
+	}
 
 	// 5. Inicializar casos de uso
-	registerUseCase := usecase.NewRegisterUsecase(userRepo, cryptoProvider)
-	loggerUseCase := usecase.NewLoginUsecase(
-		userRepo, cryptoProvider, tokenProvider, blacklistProvider,
-	)
-	logoutUseCase := usecase.NewLogoutUsecase(blacklistProvider)
-	requestPasswordResetUC := usecase.NewRequestPasswordReset(userRepo, emailService)
-	resetPasswordUC := usecase.NewResetPassword(userRepo)
-	updateNameUC := usecase.NewUpdateNameUseCase(userRepo)
-	updatePasswordUC := usecase.NewUpdatePasswordUseCase(userRepo, cryptoProvider)
-
-	// 6. Criar handlers HTTP
-	registerHTTPHandler := handlers.NewRegisterHandler(registerUseCase, userRepo)
-	loggerHTTPHandler := handlers.NewLoginHandler(loggerUseCase)
-	logoutHTTPHandler := handlers.NewLogoutHandler(logoutUseCase)
-	forgotPasswordHandler := handlers.NewForgotPasswordHandler(requestPasswordResetUC)
+	registerUseCase := usecase.NewAuditedRegister(
+		usecase.NewInstrumentedRegister(usecase.NewRegisterUsecase(userRepo, cryptoProvider), appMetrics), auditLogger,
+	)
+	loggerUseCase := usecase.NewAuditedLogin(
+		usecase.NewInstrumentedLogin(
+			usecase.NewLoginUsecase(userRepo, cryptoProvider, tokenProvider, tokenStore, cfg.JWT.TTL), appMetrics,
+		), auditLogger,
+	)
+	logoutUseCase := usecase.NewAuditedLogout(
+		usecase.NewLogoutUsecase(tokenProvider, tokenStore), auditLogger,
+	)
+	requestPasswordResetUC := usecase.NewAuditedRequestPasswordReset(
+		usecase.NewInstrumentedRequestPasswordReset(
+			usecase.NewRequestPasswordReset(userRepo, outboxRepo, txManager, cfg.PasswordReset.TTL), appMetrics,
+		), auditLogger,
+	)
+	resetPasswordUC := usecase.NewAuditedResetPassword(
+		usecase.NewInstrumentedResetPassword(usecase.NewResetPassword(userRepo), appMetrics), userRepo, auditLogger,
+	)
+	updateNameUC := usecase.NewAuditedUpdateName(
+		usecase.NewUpdateNameUseCase(userRepo), auditLogger,
+	)
+	getProfileUC := usecase.NewGetProfileUsecase(userRepo)
+	updateProfileUC := usecase.NewAuditedUpdateProfile(
+		usecase.NewUpdateProfileUsecase(userRepo), auditLogger,
+	)
+	updatePasswordUC := usecase.NewAuditedUpdatePassword(
+		usecase.NewUpdatePasswordUseCase(userRepo, cryptoProvider), auditLogger,
+	)
+	requestEmailChangeUC := usecase.NewAuditedRequestEmailChange(
+		usecase.NewRequestEmailChangeUsecase(userRepo, outboxRepo, txManager, cryptoProvider, cfg.EmailChange.TTL), auditLogger,
+	)
+	confirmEmailChangeUC := usecase.NewAuditedConfirmEmailChange(
+		usecase.NewConfirmEmailChangeUsecase(userRepo, tokenStore, cfg.JWT.TTL), userRepo, auditLogger,
+	)
+	cancelEmailChangeUC := usecase.NewAuditedCancelEmailChange(
+		usecase.NewCancelEmailChangeUsecase(userRepo), userRepo, auditLogger,
+	)
+	uploadAvatarUC := usecase.NewAuditedUploadAvatar(
+		usecase.NewUploadAvatarUsecase(userRepo, blobStore, imageProcessor, usecase.AvatarOptions{
+			MaxBytes:  int64(cfg.Avatar.MaxBytes),
+			PublicURL: cfg.Export.PublicURL + "/avatars",
+		}), auditLogger,
+	)
+	getAvatarUC := usecase.NewGetAvatarUsecase(blobStore)
+	introspectTokenUC := usecase.NewIntrospectTokenUsecase(tokenProvider, tokenStore)
+	createAPIKeyUC := usecase.NewCreateAPIKeyUsecase(apiKeyRepo)
+	listAPIKeysUC := usecase.NewListAPIKeysUsecase(apiKeyRepo)
+	revokeAPIKeyUC := usecase.NewRevokeAPIKeyUsecase(apiKeyRepo)
+	authenticateAPIKeyUC := usecase.NewAuthenticateAPIKeyUsecase(apiKeyRepo)
+	listAuditEventsUC := usecase.NewAuditedListAuditEvents(
+		usecase.NewListAuditEventsUsecase(auditRepo), auditLogger,
+	)
+	deactivateAccountUC := usecase.NewAuditedDeactivateAccount(
+		usecase.NewDeactivateAccountUsecase(
+			userRepo, apiKeyRepo, txManager, cryptoProvider, tokenStore, cfg.JWT.TTL, cfg.Account.DeletionGrace,
+		), auditLogger,
+	)
+	restoreAccountUC := usecase.NewAuditedRestoreAccount(
+		usecase.NewRestoreAccountUsecase(userRepo, cryptoProvider, cfg.Account.DeletionGrace), auditLogger,
+	)
+	purgeAccountsUC := usecase.NewPurgeAccountsUsecase(
+		userRepo, apiKeyRepo, auditRepo, outboxRepo, txManager, blobStore, exportStore, auditEraser, auditLogger,
+		usecase.PurgeOptions{
+			Grace:     cfg.Account.DeletionGrace,
+			AvatarURL: cfg.Export.PublicURL + "/avatars",
+		},
+	)
+
+	exportUserDataUC := usecase.NewAuditedExportUserData(
+		usecase.NewExportUserDataUsecase(
+			userRepo, apiKeyRepo, auditRepo, outboxRepo, exportStore, urlSigner, emailService,
+			usecase.ExportOptions{
+				AsyncThreshold: cfg.Export.AsyncThreshold,
+				LinkTTL:        cfg.Export.LinkTTL,
+				DownloadURL:    cfg.Export.PublicURL + "/exports",
+			},
+		), auditLogger,
+	)
+	downloadUserDataExportUC := usecase.NewDownloadUserDataExportUsecase(exportStore, urlSigner)
+	listOutboxMessagesUC := usecase.NewAuditedListOutboxMessages(
+		usecase.NewListOutboxMessagesUsecase(outboxRepo), auditLogger,
+	)
+	replayOutboxMessageUC := usecase.NewAuditedReplayOutboxMessage(
+		usecase.NewReplayOutboxMessageUsecase(outboxRepo), auditLogger,
+	)
+
+	// 5.1 Apagamento definitivo das contas desativadas em segundo plano
+	go jobs.NewAccountPurger(purgeAccountsUC, cfg.Account.PurgeInterval,
+		logger.With(slog.String("job", "account_purger")),
+	).Run(ctx)
+
+	// 5.2 Remoção das exportações com link expirado
+	go jobs.NewExportCleaner(exportStore, cfg.Export.LinkTTL,
+		logger.With(slog.String("job", "export_cleaner")),
+	).Run(ctx)
+
+	// 5.3 Entrega das mensagens da outbox (e-mails) fora da requisição
+	go jobs.NewOutboxDispatcher(outboxRepo, jobs.EmailOutboxHandlers(emailService), jobs.OutboxOptions{
+		PollInterval: cfg.Outbox.PollInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+		MaxAttempts:  cfg.Outbox.MaxAttempts,
+		BackoffBase:  cfg.Outbox.BackoffBase,
+		BackoffMax:   cfg.Outbox.BackoffMax,
+	}, logger.With(slog.String("job", "outbox_dispatcher"))).Run(ctx)
+
+	// 6. Modo de sessão por cookie (opcional)
+	var sessionCookie *middleware.SessionCookie
+	if cfg.Session.CookieMode {
+		sessionCookie = middleware.NewSessionCookie(middleware.SessionCookieConfig{
+			Domain:     cfg.Session.CookieDomain,
+			Secure:     cfg.Session.CookieSecure,
+			SameSite:   cfg.Session.CookieSameSite,
+			MaxAge:     cfg.JWT.TTL,
+			CSRFSecret: cfg.Session.CSRFSecret,
+		})
+	}
+
+	// 7. Criar handlers HTTP
+	registerHTTPHandler := handlers.NewRegisterHandler(registerUseCase, userRepo, humanVerifier)
+	loggerHTTPHandler := handlers.NewLoginHandler(loggerUseCase, sessionCookie)
+	logoutHTTPHandler := handlers.NewLogoutHandler(logoutUseCase, sessionCookie)
+	forgotPasswordHandler := handlers.NewForgotPasswordHandler(requestPasswordResetUC, humanVerifier)
 	resetPasswordHandler := handlers.NewResetPasswordHandler(resetPasswordUC)
 	updateNameHandler := handlers.NewUpdateNameHandler(updateNameUC)
 	updatePasswordHandler := handlers.NewUpdatePasswordHandler(updatePasswordUC)
+	getProfileHandler := handlers.NewGetProfileHandler(getProfileUC)
+	updateProfileHandler := handlers.NewUpdateProfileHandler(updateProfileUC)
+	changeEmailHandler := handlers.NewChangeEmailHandler(requestEmailChangeUC)
+	confirmEmailChangeHandler := handlers.NewConfirmEmailChangeHandler(confirmEmailChangeUC)
+	cancelEmailChangeHandler := handlers.NewCancelEmailChangeHandler(cancelEmailChangeUC)
+	uploadAvatarHandler := handlers.NewUploadAvatarHandler(uploadAvatarUC, int64(cfg.Avatar.MaxBytes))
+	getAvatarHandler := handlers.NewGetAvatarHandler(getAvatarUC)
+	introspectHandler := handlers.NewIntrospectHandler(introspectTokenUC)
+	revokeHandler := handlers.NewRevokeHandler(logoutUseCase)
+	createAPIKeyHandler := handlers.NewCreateAPIKeyHandler(createAPIKeyUC)
+	listAPIKeysHandler := handlers.NewListAPIKeysHandler(listAPIKeysUC)
+	revokeAPIKeyHandler := handlers.NewRevokeAPIKeyHandler(revokeAPIKeyUC)
+	listAuditEventsHandler := handlers.NewListAuditEventsHandler(listAuditEventsUC)
+	deleteAccountHandler := handlers.NewDeleteAccountHandler(deactivateAccountUC, sessionCookie)
+	restoreAccountHandler := handlers.NewRestoreAccountHandler(restoreAccountUC)
+	exportUserDataHandler := handlers.NewExportUserDataHandler(exportUserDataUC)
+	downloadUserDataExportHandler := handlers.NewDownloadUserDataExportHandler(downloadUserDataExportUC)
+	listOutboxMessagesHandler := handlers.NewListOutboxMessagesHandler(listOutboxMessagesUC)
+	replayOutboxMessageHandler := handlers.NewReplayOutboxMessageHandler(replayOutboxMessageUC)
 
-	// 7. Configurar roteador Gin
-	router := gin.Default()
+	// 8. Configurar roteador Gin
+	router := gin.New()
+	// Sem proxies confiáveis, X-Forwarded-For e X-Real-IP são ignorados e
+	// ClientIP é o endereço da conexão; senão qualquer cliente escolheria o
+	// próprio IP e escaparia das cotas e da allowlist
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		panic(err)
+	}
 
-	// 7.1 Configurar CORS (ANTES dos middlewares de autenticação)
+	// Duração por rota, span e log da requisição; antes do ProblemMiddleware
+	// para ver o status final. O log estruturado substitui o logger do gin
+	router.Use(middleware.MetricsMiddleware(appMetrics))
+	router.Use(middleware.TracingMiddleware(tracer))
+	router.Use(middleware.RequestLogMiddleware(logger))
+	router.Use(middleware.RecoveryMiddleware())
+
+	// Erros registrados por handlers e middlewares viram application/problem+json,
+	// no idioma do perfil ou do Accept-Language
+	router.Use(middleware.ProblemMiddleware(middleware.NewLocalizer(translator, userRepo)))
+
+	// 8.1 Configurar CORS (ANTES dos middlewares de autenticação)
 	router.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"http://localhost:3000"}, // Frontend URL
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowOrigins:     cfg.Server.CORSOrigins,
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", middleware.CSRFHeaderName, handlers.HumanProofHeader, tracing.TraceparentHeader, tracing.TracestateHeader, middleware.RequestIDHeader},
+		ExposeHeaders:    []string{"Content-Length", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After", middleware.RequestIDHeader},
 		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
+		MaxAge:           cfg.Server.CORSMaxAge,
 	}))
 
-	// 7.2 Criar middleware de autenticação (DEPOIS do CORS)
-	authMiddleware := middleware.JWTAuthMiddleware(tokenProvider, blacklistProvider)
+	// IP e user agent disponíveis para a auditoria
+	router.Use(middleware.RequestInfoMiddleware())
+
+	// Cotas por IP, e-mail, usuário ou rota; acima delas a resposta é 429
+	if cfg.RateLimit.Enabled {
+		rateLimiter, err := providers.NewRateLimiter(cfg.RateLimit.Backend, cfg.RateLimit.Algorithm, rdb)
+		if err != nil {
+			panic(err)
+		}
+		var rules []middleware.RateLimitRule
+		for _, rule := range cfg.RateLimitRules() {
+			rules = append(rules, middleware.RateLimitRule{
+				Method: rule.Method,
+				Path:   rule.Path,
+				Key:    rule.Key,
+				Limit:  domainproviders.RateLimit{Limit: rule.Limit, Window: rule.Window},
+			})
+		}
+		router.Use(middleware.RateLimitMiddleware(rateLimiter, rules, cfg.RateLimitAllowlist()))
+	}
+
+	// 8.2 Proteção CSRF para requisições autenticadas pelo cookie
+	if sessionCookie != nil {
+		router.Use(middleware.CSRFMiddleware(sessionCookie))
+	}
 
-	// 8. Registrar rotas
+	// 8.3 Corpo JSON e query conferidos com o documento OpenAPI
+	spec, err := openapi.NewSpec()
+	if err != nil {
+		panic(err)
+	}
+	router.Use(middleware.RequestValidationMiddleware(spec))
+
+	// 8.4 Criar middleware de autenticação (DEPOIS do CORS)
+	authMiddleware := middleware.JWTAuthMiddleware(tokenProvider, tokenStore, sessionCookie)
+	// Rotas de usuário também aceitam "Authorization: ApiKey <chave>"
+	userAuthMiddleware := middleware.APIKeyAuthMiddleware(authenticateAPIKeyUC, authMiddleware)
+	clientAuthMiddleware := middleware.ClientAuthMiddleware(cfg.OAuthClients())
+	adminMiddleware := middleware.RequireAdmin(cfg.AdminUserIDs())
+
+	// 9. Registrar rotas
 	router.POST("/auth/register", registerHTTPHandler.Handle)
 	router.POST("/auth/login", loggerHTTPHandler.Handle)
 	router.DELETE("/auth/logout", authMiddleware, logoutHTTPHandler.Handle)
 	router.POST("/auth/forgot-password", forgotPasswordHandler.Handle)
 	router.POST("/auth/reset-password", resetPasswordHandler.Handle)
-	router.PUT("/user/name/:userID", authMiddleware, updateNameHandler.Handle)
+	router.POST("/auth/restore", restoreAccountHandler.Handle)
+	router.POST("/auth/email-change/confirm", confirmEmailChangeHandler.Handle)
+	router.POST("/auth/email-change/cancel", cancelEmailChangeHandler.Handle)
+	router.PUT("/user/name/:userID", userAuthMiddleware, middleware.RequireScope(entity.ScopeUserWrite), updateNameHandler.Handle)
 	router.PUT("/user/password/:userID", authMiddleware, updatePasswordHandler.Handle)
+	router.PUT("/user/email", authMiddleware, changeEmailHandler.Handle)
+	router.PUT("/user/avatar", authMiddleware, uploadAvatarHandler.Handle)
+	router.GET("/avatars/:id", getAvatarHandler.Handle)
+	router.GET("/user/me", userAuthMiddleware, middleware.RequireScope(entity.ScopeUserRead), getProfileHandler.Handle)
+	router.PATCH("/user/me", userAuthMiddleware, middleware.RequireScope(entity.ScopeUserWrite), updateProfileHandler.Handle)
+	router.DELETE("/user/me", authMiddleware, deleteAccountHandler.Handle)
+	router.GET("/user/me/export", authMiddleware, exportUserDataHandler.Handle)
+	router.GET("/exports/:file", downloadUserDataExportHandler.Handle)
+	router.POST("/user/api-keys", authMiddleware, createAPIKeyHandler.Handle)
+	router.GET("/user/api-keys", authMiddleware, listAPIKeysHandler.Handle)
+	router.DELETE("/user/api-keys/:id", authMiddleware, revokeAPIKeyHandler.Handle)
+	router.POST("/oauth/introspect", clientAuthMiddleware, introspectHandler.Handle)
+	router.POST("/oauth/revoke", clientAuthMiddleware, revokeHandler.Handle)
+	router.GET("/admin/audit", authMiddleware, adminMiddleware, listAuditEventsHandler.Handle)
+	router.GET("/admin/outbox", authMiddleware, adminMiddleware, listOutboxMessagesHandler.Handle)
+	router.POST("/admin/outbox/:id/replay", authMiddleware, adminMiddleware, replayOutboxMessageHandler.Handle)
 
-	// 9. Iniciar o servidor
-	router.Run(":8080")
-}
+	// 9.1 Desafio da verificação humana, com HUMAN_VERIFIER diferente de none
+	if humanVerifier != nil {
+		router.GET("/auth/human-challenge", handlers.NewHumanChallengeHandler(humanVerifier).Handle)
+	}
+
+	// 9.2 Caixa de e-mails de desenvolvimento, só com EMAIL_TRANSPORT=mailbox e
+	// APP_ENV=development ou test
+	if mailbox, ok := sender.(service.Mailbox); ok && cfg.DevEndpoints() {
+		router.GET("/dev/mailbox", handlers.NewListDevMailboxHandler(mailbox).Handle)
+		router.DELETE("/dev/mailbox", handlers.NewClearDevMailboxHandler(mailbox).Handle)
+	}
+
+	// 9.3 Contrato da API e Swagger UI
+	router.GET("/openapi.json", handlers.NewOpenAPIHandler(spec.JSON()).Handle)
+	swaggerUIHandler := handlers.NewSwaggerUIHandler("/openapi.json", "/docs/assets", swaggeruiassets.FS())
+	router.GET("/docs", swaggerUIHandler.Handle)
+	router.GET("/docs/assets/:file", swaggerUIHandler.HandleAsset)
+
+	// 9.4 Métricas do Prometheus, com METRICS_ENABLED
+	if cfg.Metrics.Enabled {
+		router.GET("/metrics", handlers.NewMetricsHandler(appMetrics.Registry, cfg.Metrics.Token).Handle)
+	}
 
-func parsePort(port string) int {
-	var p int
-	fmt.Sscanf(port, "%d", &p)
-	return p
+	// 10. Iniciar o servidor
+	server := &http.Server{
+		Addr:              ":" + cfg.Server.Port,
+		Handler:           router,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("server failed", slog.Any("error", err))
+			stop()
+		}
+	}()
+	logger.Info("server started", slog.String("addr", server.Addr))
+
+	// 11. Encerramento: termina as requisições em andamento e envia os spans
+	// ainda no buffer antes de sair
+	<-ctx.Done()
+	stop()
+	logger.Info("shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error("server shutdown failed", slog.Any("error", err))
+	}
+	if err := tracer.Shutdown(shutdownCtx); err != nil {
+		logger.Error("tracer shutdown failed", slog.Any("error", err))
+	}
 }
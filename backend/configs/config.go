@@ -1,38 +1,869 @@
 package configs
 
 import (
-	"github.com/go-chi/jwtauth"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
 )
 
-type conf struct {
-	DBDriver      string `mapstructure:"DB_DRIVER"`
-	DBHost        string `mapstructure:"DB_HOST"`
-	DBPort        string `mapstructure:"DB_PORT"`
-	DBUser        string `mapstructure:"DB_USER"`
-	DBPassword    string `mapstructure:"DB_PASSWORD"`
-	DBName        string `mapstructure:"DB_NAME"`
-	WebServerPort string `mapstructure:"WEB_SERVER_PORT"`
-	JWTSecret     string `mapstructure:"JWT_SECRET"`
-	JwtExperesIn  int    `mapstructure:"JWT_EXPIRESIN"`
-	TokenAuth     *jwtauth.JWTAuth
-}
-
-func LoadConfig(path string) (*conf, error) {
-	var cfg *conf
-	viper.SetConfigName("app_config")
-	viper.SetConfigType("env")
-	viper.AddConfigPath(path)
-	viper.SetConfigFile(".env")
-	viper.AutomaticEnv()
-	err := viper.ReadInConfig()
+const minSecretLength = 32
+
+// Valores de exemplo que nunca devem ser aceitos como segredo
+var weakSecrets = map[string]bool{
+	"secret":              true,
+	"secret-key":          true,
+	"changeme":            true,
+	"password":            true,
+	"troque-este-segredo": true,
+}
+
+type Config struct {
+	Server        ServerConfig        `mapstructure:"server"`
+	Database      DatabaseConfig      `mapstructure:"database"`
+	Redis         RedisConfig         `mapstructure:"redis"`
+	JWT           JWTConfig           `mapstructure:"jwt"`
+	Session       SessionConfig       `mapstructure:"session"`
+	OAuth         OAuthConfig         `mapstructure:"oauth"`
+	Audit         AuditConfig         `mapstructure:"audit"`
+	SMTP          SMTPConfig          `mapstructure:"smtp"`
+	PasswordReset PasswordResetConfig `mapstructure:"password_reset"`
+	EmailChange   EmailChangeConfig   `mapstructure:"email_change"`
+	Account       AccountConfig       `mapstructure:"account"`
+	Export        ExportConfig        `mapstructure:"export"`
+	Outbox        OutboxConfig        `mapstructure:"outbox"`
+	Blob          BlobConfig          `mapstructure:"blob"`
+	Avatar        AvatarConfig        `mapstructure:"avatar"`
+	RateLimit     RateLimitConfig     `mapstructure:"rate_limit"`
+	Human         HumanConfig         `mapstructure:"human"`
+	Metrics       MetricsConfig       `mapstructure:"metrics"`
+	Tracing       TracingConfig       `mapstructure:"tracing"`
+	Log           LogConfig           `mapstructure:"log"`
+}
+
+type ServerConfig struct {
+	// Environment é production, development ou test; recursos só de
+	// desenvolvimento (ex.: GET /dev/mailbox) exigem development ou test
+	Environment string   `mapstructure:"environment"`
+	Port        string   `mapstructure:"port"`
+	CORSOrigins []string `mapstructure:"cors_origins"`
+	// TrustedProxies são os IPs/CIDRs cujo X-Forwarded-For é aceito como IP do
+	// cliente; vazio usa sempre o endereço da conexão
+	TrustedProxies []string      `mapstructure:"trusted_proxies"`
+	CORSMaxAge     time.Duration `mapstructure:"cors_max_age"`
+	// Idioma das mensagens de erro quando nem o perfil nem o Accept-Language combinam
+	DefaultLocale string `mapstructure:"default_locale"`
+	// ShutdownTimeout limita a espera pelas requisições em andamento e pelo
+	// envio dos spans pendentes ao receber SIGINT/SIGTERM
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+}
+
+type DatabaseConfig struct {
+	Driver   string `mapstructure:"driver"`
+	Host     string `mapstructure:"host"`
+	Port     string `mapstructure:"port"`
+	User     string `mapstructure:"user"`
+	Password string `mapstructure:"password"`
+	Name     string `mapstructure:"name"`
+	SSLMode  string `mapstructure:"ssl_mode"`
+}
+
+type RedisConfig struct {
+	Addr     string `mapstructure:"addr"`
+	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+}
+
+type JWTConfig struct {
+	Secret string        `mapstructure:"secret"`
+	TTL    time.Duration `mapstructure:"ttl"`
+}
+
+type SessionConfig struct {
+	TokenStore     string `mapstructure:"token_store"`
+	CookieMode     bool   `mapstructure:"cookie_mode"`
+	CookieDomain   string `mapstructure:"cookie_domain"`
+	CookieSecure   bool   `mapstructure:"cookie_secure"`
+	CookieSameSite string `mapstructure:"cookie_same_site"`
+	CSRFSecret     string `mapstructure:"csrf_secret"`
+}
+
+type OAuthConfig struct {
+	// Clients no formato "id:segredo"
+	Clients []string `mapstructure:"clients"`
+}
+
+type AuditConfig struct {
+	// LogFile é reescrito pelo apagamento de contas para anonimizar os eventos
+	LogFile      string   `mapstructure:"log_file"`
+	AdminUserIDs []string `mapstructure:"admin_user_ids"`
+}
+
+type SMTPConfig struct {
+	Host             string `mapstructure:"host"`
+	Port             int    `mapstructure:"port"`
+	Username         string `mapstructure:"username"`
+	Password         string `mapstructure:"password"`
+	From             string `mapstructure:"from"`
+	FrontendResetURL string `mapstructure:"frontend_reset_url"`
+	// Página do frontend que recebe email_change_token ou email_change_cancel_token
+	FrontendEmailChangeURL string `mapstructure:"frontend_email_change_url"`
+	// TemplatesDir sobrepõe os modelos de e-mail embutidos; vazio usa só os embutidos
+	TemplatesDir  string `mapstructure:"templates_dir"`
+	DefaultLocale string `mapstructure:"default_locale"`
+	// Transport: smtp, mailbox (memória, GET /dev/mailbox), file (maildir) ou log
+	Transport   string `mapstructure:"transport"`
+	FileDir     string `mapstructure:"file_dir"`
+	MailboxSize int    `mapstructure:"mailbox_size"`
+}
+
+type PasswordResetConfig struct {
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+type EmailChangeConfig struct {
+	// TTL é a validade dos links de confirmação e cancelamento
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+type AccountConfig struct {
+	// Período em que a conta desativada ainda pode ser restaurada
+	DeletionGrace time.Duration `mapstructure:"deletion_grace"`
+	PurgeInterval time.Duration `mapstructure:"purge_interval"`
+}
+
+type ExportConfig struct {
+	Dir     string        `mapstructure:"dir"`
+	LinkTTL time.Duration `mapstructure:"link_ttl"`
+	// Acima deste número de eventos de auditoria a exportação segue por e-mail
+	AsyncThreshold int `mapstructure:"async_threshold"`
+	// URL pública da API, usada nos links de download e nas URLs dos avatares
+	PublicURL string `mapstructure:"public_url"`
+}
+
+type OutboxConfig struct {
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	BatchSize    int           `mapstructure:"batch_size"`
+	// Após MaxAttempts falhas a mensagem vai para a dead letter
+	MaxAttempts int           `mapstructure:"max_attempts"`
+	BackoffBase time.Duration `mapstructure:"backoff_base"`
+	BackoffMax  time.Duration `mapstructure:"backoff_max"`
+}
+
+type BlobConfig struct {
+	// Store: file (diretório local) ou s3 (qualquer serviço compatível com S3)
+	Store       string `mapstructure:"store"`
+	Dir         string `mapstructure:"dir"`
+	S3Endpoint  string `mapstructure:"s3_endpoint"`
+	S3Region    string `mapstructure:"s3_region"`
+	S3Bucket    string `mapstructure:"s3_bucket"`
+	S3AccessKey string `mapstructure:"s3_access_key"`
+	S3SecretKey string `mapstructure:"s3_secret_key"`
+}
+
+type AvatarConfig struct {
+	MaxBytes int `mapstructure:"max_bytes"`
+	// MaxPixels barra imagens pequenas no disco mas enormes ao decodificar
+	MaxPixels int `mapstructure:"max_pixels"`
+}
+
+type RateLimitConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Backend: redis (cota compartilhada entre instâncias) ou memory
+	Backend string `mapstructure:"backend"`
+	// Algorithm: token_bucket ou sliding_window
+	Algorithm string `mapstructure:"algorithm"`
+	// Rules no formato "MÉTODO /caminho limite/janela chave", ex.:
+	// "POST /auth/login 5/15m email"; chave: ip, user, email ou route
+	Rules []string `mapstructure:"rules"`
+	// IPs e redes (CIDR) internos que nunca são limitados
+	Allowlist []string `mapstructure:"allowlist"`
+}
+
+// HumanConfig controla a verificação humana do cadastro e do esqueci a senha.
+type HumanConfig struct {
+	// Verifier: none, pow (prova de trabalho, sem serviço externo), hcaptcha ou turnstile
+	Verifier string `mapstructure:"verifier"`
+	// PowDifficulty em bits zero; cada bit a mais dobra o custo do cliente
+	PowDifficulty  int           `mapstructure:"pow_difficulty"`
+	PowTTL         time.Duration `mapstructure:"pow_ttl"`
+	CaptchaSiteKey string        `mapstructure:"captcha_site_key"`
+	CaptchaSecret  string        `mapstructure:"captcha_secret"`
+	// A prova só é exigida depois de RiskThreshold tentativas do mesmo IP ou
+	// para o mesmo e-mail em RiskWindow; 0 exige sempre
+	RiskThreshold int           `mapstructure:"risk_threshold"`
+	RiskWindow    time.Duration `mapstructure:"risk_window"`
+}
+
+// MetricsConfig controla o endpoint /metrics do Prometheus; as métricas são
+// coletadas mesmo com ele desligado. Fora de development e test o endpoint
+// exige Token, pois fica na porta pública da API.
+type MetricsConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Token, se informado, é exigido como "Authorization: Bearer <token>"
+	Token string `mapstructure:"token"`
+}
+
+// TracingConfig controla os spans do OpenTelemetry; desligado, o traceparent
+// recebido continua sendo propagado.
+type TracingConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Exporter: otlp (OTLP/HTTP com JSON) ou stdout (uma linha JSON por span)
+	Exporter     string `mapstructure:"exporter"`
+	OTLPEndpoint string `mapstructure:"otlp_endpoint"`
+	ServiceName  string `mapstructure:"service_name"`
+	// SampleRatio entre 0 e 1, para traces iniciados neste serviço
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+// LogConfig controla os logs estruturados (log/slog) da aplicação.
+type LogConfig struct {
+	// Format: json (uma linha JSON por registro) ou text (chave=valor)
+	Format string `mapstructure:"format"`
+	// Level: debug, info, warn ou error
+	Level string `mapstructure:"level"`
+}
+
+// RateLimitRule é uma regra de RATE_LIMIT_RULES já interpretada.
+type RateLimitRule struct {
+	Method string
+	Path   string // Caminho no formato do gin, ex.: /user/api-keys/:id
+	Limit  int
+	Window time.Duration
+	Key    string
+}
+
+var rateLimitKeys = map[string]bool{"ip": true, "user": true, "email": true, "route": true}
+
+// ParseRateLimitRule interpreta "POST /auth/login 5/15m email".
+func ParseRateLimitRule(raw string) (RateLimitRule, error) {
+	fields := strings.Fields(raw)
+	if len(fields) != 4 {
+		return RateLimitRule{}, fmt.Errorf("rule %q must use the format \"METHOD /path limit/window key\"", raw)
+	}
+
+	rule := RateLimitRule{Method: strings.ToUpper(fields[0]), Path: fields[1], Key: strings.ToLower(fields[3])}
+	if !strings.HasPrefix(rule.Path, "/") {
+		return RateLimitRule{}, fmt.Errorf("rule %q: path must start with /", raw)
+	}
+	limit, window, _ := strings.Cut(fields[2], "/")
+	var err error
+	if rule.Limit, err = strconv.Atoi(limit); err != nil || rule.Limit < 1 {
+		return RateLimitRule{}, fmt.Errorf("rule %q: limit must be a positive number", raw)
+	}
+	if rule.Window, err = time.ParseDuration(window); err != nil || rule.Window < time.Second {
+		return RateLimitRule{}, fmt.Errorf("rule %q: window must be a duration of at least 1s", raw)
+	}
+	if !rateLimitKeys[rule.Key] {
+		return RateLimitRule{}, fmt.Errorf("rule %q: key must be ip, user, email or route", raw)
+	}
+	return rule, nil
+}
+
+// setting liga a chave do YAML à variável de ambiente e ao valor padrão.
+// Um padrão nil indica campo obrigatório.
+type setting struct {
+	key      string
+	env      string
+	fallback any
+}
+
+var settings = []setting{
+	{"server.environment", "APP_ENV", "production"},
+	{"server.port", "WEB_SERVER_PORT", "8080"},
+	{"server.cors_origins", "CORS_ALLOWED_ORIGINS", "http://localhost:3000"},
+	{"server.cors_max_age", "CORS_MAX_AGE", 12 * time.Hour},
+	{"server.trusted_proxies", "TRUSTED_PROXIES", ""},
+	{"server.default_locale", "DEFAULT_LOCALE", "pt-BR"},
+	{"server.shutdown_timeout", "SERVER_SHUTDOWN_TIMEOUT", 15 * time.Second},
+	{"database.driver", "DB_DRIVER", "sqlite"},
+	{"database.host", "DB_HOST", "localhost"},
+	{"database.port", "DB_PORT", ""},
+	{"database.user", "DB_USER", ""},
+	{"database.password", "DB_PASSWORD", ""},
+	{"database.name", "DB_NAME", "test.db"},
+	{"database.ssl_mode", "DB_SSLMODE", "disable"},
+	{"redis.addr", "REDIS_ADDR", "localhost:6379"},
+	{"redis.password", "REDIS_PASSWORD", ""},
+	{"redis.db", "REDIS_DB", 0},
+	{"jwt.secret", "JWT_SECRET", nil},
+	{"jwt.ttl", "JWT_TTL", 24 * time.Hour},
+	{"session.token_store", "TOKEN_STORE_MODE", "allowlist"},
+	{"session.cookie_mode", "AUTH_COOKIE_MODE", false},
+	{"session.cookie_domain", "AUTH_COOKIE_DOMAIN", ""},
+	{"session.cookie_secure", "AUTH_COOKIE_SECURE", true},
+	{"session.cookie_same_site", "AUTH_COOKIE_SAMESITE", "lax"},
+	{"session.csrf_secret", "CSRF_SECRET", ""},
+	{"oauth.clients", "OAUTH_CLIENTS", ""},
+	{"audit.log_file", "AUDIT_LOG_FILE", ""},
+	{"audit.admin_user_ids", "ADMIN_USER_IDS", ""},
+	{"smtp.host", "SMTP_HOST", ""},
+	{"smtp.port", "SMTP_PORT", 587},
+	{"smtp.username", "SMTP_USERNAME", ""},
+	{"smtp.password", "SMTP_PASSWORD", ""},
+	{"smtp.from", "FROM_EMAIL", nil},
+	{"smtp.frontend_reset_url", "FRONTEND_RESET_URL", nil},
+	{"smtp.frontend_email_change_url", "FRONTEND_EMAIL_CHANGE_URL", nil},
+	{"smtp.templates_dir", "EMAIL_TEMPLATES_DIR", ""},
+	{"smtp.default_locale", "EMAIL_DEFAULT_LOCALE", "pt-BR"},
+	{"smtp.transport", "EMAIL_TRANSPORT", "smtp"},
+	{"smtp.file_dir", "EMAIL_FILE_DIR", "mail"},
+	{"smtp.mailbox_size", "EMAIL_MAILBOX_SIZE", 100},
+	{"password_reset.ttl", "PASSWORD_RESET_TTL", time.Hour},
+	{"email_change.ttl", "EMAIL_CHANGE_TTL", 24 * time.Hour},
+	{"account.deletion_grace", "ACCOUNT_DELETION_GRACE", 30 * 24 * time.Hour},
+	{"account.purge_interval", "ACCOUNT_PURGE_INTERVAL", time.Hour},
+	{"export.dir", "EXPORT_DIR", "exports"},
+	{"export.link_ttl", "EXPORT_LINK_TTL", 15 * time.Minute},
+	{"export.async_threshold", "EXPORT_ASYNC_THRESHOLD", 1000},
+	{"export.public_url", "PUBLIC_API_URL", "http://localhost:8080"},
+	{"outbox.poll_interval", "OUTBOX_POLL_INTERVAL", 5 * time.Second},
+	{"outbox.batch_size", "OUTBOX_BATCH_SIZE", 50},
+	{"outbox.max_attempts", "OUTBOX_MAX_ATTEMPTS", 8},
+	{"outbox.backoff_base", "OUTBOX_BACKOFF_BASE", 30 * time.Second},
+	{"outbox.backoff_max", "OUTBOX_BACKOFF_MAX", time.Hour},
+	{"blob.store", "BLOB_STORE", "file"},
+	{"blob.dir", "BLOB_DIR", "blobs"},
+	{"blob.s3_endpoint", "S3_ENDPOINT", ""},
+	{"blob.s3_region", "S3_REGION", "us-east-1"},
+	{"blob.s3_bucket", "S3_BUCKET", ""},
+	{"blob.s3_access_key", "S3_ACCESS_KEY", ""},
+	{"blob.s3_secret_key", "S3_SECRET_KEY", ""},
+	{"avatar.max_bytes", "AVATAR_MAX_BYTES", 5 << 20},
+	{"avatar.max_pixels", "AVATAR_MAX_PIXELS", 40_000_000},
+	{"rate_limit.enabled", "RATE_LIMIT_ENABLED", true},
+	{"rate_limit.backend", "RATE_LIMIT_BACKEND", "redis"},
+	{"rate_limit.algorithm", "RATE_LIMIT_ALGORITHM", "token_bucket"},
+	{"rate_limit.rules", "RATE_LIMIT_RULES", []string{
+		"POST /auth/login 20/1m ip",
+		"POST /auth/login 5/15m email",
+		"POST /auth/register 10/1h ip",
+		"POST /auth/forgot-password 5/1h ip",
+		"POST /auth/forgot-password 3/1h email",
+		"POST /auth/reset-password 10/1h ip",
+		"PUT /user/email 5/1h user",
+	}},
+	{"rate_limit.allowlist", "RATE_LIMIT_ALLOWLIST", ""},
+	{"human.verifier", "HUMAN_VERIFIER", "pow"},
+	{"human.pow_difficulty", "HUMAN_POW_DIFFICULTY", 16},
+	{"human.pow_ttl", "HUMAN_POW_TTL", 5 * time.Minute},
+	{"human.captcha_site_key", "HUMAN_CAPTCHA_SITE_KEY", ""},
+	{"human.captcha_secret", "HUMAN_CAPTCHA_SECRET", ""},
+	{"human.risk_threshold", "HUMAN_RISK_THRESHOLD", 5},
+	{"human.risk_window", "HUMAN_RISK_WINDOW", time.Hour},
+	{"metrics.enabled", "METRICS_ENABLED", false},
+	{"metrics.token", "METRICS_TOKEN", ""},
+	{"tracing.enabled", "TRACING_ENABLED", false},
+	{"tracing.exporter", "TRACING_EXPORTER", "otlp"},
+	{"tracing.otlp_endpoint", "TRACING_OTLP_ENDPOINT", "http://localhost:4318"},
+	{"tracing.service_name", "TRACING_SERVICE_NAME", "startup-auth-go"},
+	{"tracing.sample_ratio", "TRACING_SAMPLE_RATIO", 1.0},
+	{"log.format", "LOG_FORMAT", "json"},
+	{"log.level", "LOG_LEVEL", "info"},
+}
+
+// LoadConfig lê, em ordem crescente de prioridade: valores padrão, o arquivo
+// YAML informado (ou CONFIG_FILE), o arquivo .env e as variáveis de ambiente.
+// Retorna *ConfigError listando cada chave inválida.
+func LoadConfig(configFile string) (*Config, error) {
+	cfg, err := load(configFile)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	err = viper.Unmarshal(&cfg)
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// LoadDatabaseConfig valida apenas as chaves do banco, usado pelo subcomando migrate.
+func LoadDatabaseConfig(configFile string) (*DatabaseConfig, error) {
+	cfg, err := load(configFile)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+
+	var problems []Problem
+	for _, p := range cfg.problems() {
+		if strings.HasPrefix(p.Key, "database.") {
+			problems = append(problems, p)
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &ConfigError{Problems: problems}
+	}
+	return &cfg.Database, nil
+}
+
+func load(configFile string) (*Config, error) {
+	// .env não sobrescreve variáveis já definidas no ambiente
+	_ = godotenv.Load(".env")
+
+	v := viper.New()
+	for _, s := range settings {
+		if s.fallback != nil {
+			v.SetDefault(s.key, s.fallback)
+		}
+		if err := v.BindEnv(s.key, s.env); err != nil {
+			return nil, err
+		}
+	}
+
+	if configFile == "" {
+		configFile = os.Getenv("CONFIG_FILE")
+	}
+	if configFile != "" {
+		v.SetConfigFile(configFile)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", configFile, err)
+		}
+	}
+
+	if problems := checkTypes(v); len(problems) > 0 {
+		return nil, &ConfigError{Problems: problems}
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+	cfg.normalize()
+	return &cfg, nil
+}
+
+// checkTypes garante que números, booleanos e durações sejam válidos antes da
+// decodificação, para que o erro cite a variável de ambiente correspondente.
+func checkTypes(v *viper.Viper) []Problem {
+	var problems []Problem
+	for _, s := range settings {
+		raw := v.GetString(s.key)
+		var err error
+		switch s.fallback.(type) {
+		case int:
+			_, err = strconv.Atoi(raw)
+		case float64:
+			_, err = strconv.ParseFloat(raw, 64)
+		case bool:
+			_, err = strconv.ParseBool(raw)
+		case time.Duration:
+			_, err = time.ParseDuration(raw)
+		}
+		if err != nil {
+			problems = append(problems, Problem{Env: s.env, Key: s.key, Message: fmt.Sprintf("invalid value %q", raw)})
+		}
+	}
+	return problems
+}
+
+func (c *Config) normalize() {
+	c.Server.Environment = strings.ToLower(c.Server.Environment)
+	c.Server.CORSOrigins = trimAll(c.Server.CORSOrigins)
+	c.Server.TrustedProxies = trimAll(c.Server.TrustedProxies)
+	c.OAuth.Clients = trimAll(c.OAuth.Clients)
+	c.Audit.AdminUserIDs = trimAll(c.Audit.AdminUserIDs)
+	c.Session.CookieSameSite = strings.ToLower(c.Session.CookieSameSite)
+	c.SMTP.Transport = strings.ToLower(c.SMTP.Transport)
+	c.Blob.Store = strings.ToLower(c.Blob.Store)
+	c.Export.PublicURL = strings.TrimRight(c.Export.PublicURL, "/")
+	c.RateLimit.Backend = strings.ToLower(c.RateLimit.Backend)
+	c.RateLimit.Algorithm = strings.ToLower(c.RateLimit.Algorithm)
+	c.RateLimit.Rules = trimAll(c.RateLimit.Rules)
+	c.RateLimit.Allowlist = trimAll(c.RateLimit.Allowlist)
+	c.Human.Verifier = strings.ToLower(c.Human.Verifier)
+	c.Metrics.Token = strings.TrimSpace(c.Metrics.Token)
+	c.Tracing.Exporter = strings.ToLower(c.Tracing.Exporter)
+	c.Tracing.OTLPEndpoint = strings.TrimRight(c.Tracing.OTLPEndpoint, "/")
+	c.Log.Format = strings.ToLower(c.Log.Format)
+	c.Log.Level = strings.ToLower(c.Log.Level)
+}
+
+// Validate verifica campos obrigatórios, valores permitidos e a força dos segredos.
+func (c *Config) Validate() error {
+	if problems := c.problems(); len(problems) > 0 {
+		return &ConfigError{Problems: problems}
+	}
+	return nil
+}
+
+func (c *Config) problems() []Problem {
+	var problems []Problem
+	add := func(env, message string) {
+		problems = append(problems, Problem{Env: env, Key: yamlKey(env), Message: message})
+	}
+
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port < 1 || port > 65535 {
+		add("WEB_SERVER_PORT", "must be a port number between 1 and 65535")
+	}
+	if c.Server.CORSMaxAge < 0 {
+		add("CORS_MAX_AGE", "must not be negative")
+	}
+	for _, raw := range c.Server.TrustedProxies {
+		if _, err := parsePrefix(raw); err != nil {
+			add("TRUSTED_PROXIES", fmt.Sprintf("entry %q must be an IP or a CIDR", raw))
+		}
+	}
+	if c.Server.DefaultLocale == "" {
+		add("DEFAULT_LOCALE", "is required")
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		add("SERVER_SHUTDOWN_TIMEOUT", "must be greater than zero")
+	}
+	switch c.Server.Environment {
+	case "production", "development", "test":
+	default:
+		add("APP_ENV", "must be production, development or test")
+	}
+
+	switch c.Database.Driver {
+	case "sqlite":
+	case "postgres", "mysql":
+		if c.Database.User == "" {
+			add("DB_USER", "is required for "+c.Database.Driver)
+		}
+		if c.Database.Name == "" {
+			add("DB_NAME", "is required for "+c.Database.Driver)
+		}
+	default:
+		add("DB_DRIVER", "must be one of sqlite, postgres, mysql")
+	}
+
+	if c.Redis.DB < 0 {
+		add("REDIS_DB", "must not be negative")
+	}
+
+	if msg := secretProblem(c.JWT.Secret); msg != "" {
+		add("JWT_SECRET", msg)
+	}
+	if c.JWT.TTL <= 0 {
+		add("JWT_TTL", "must be greater than zero")
+	}
+
+	switch c.Session.TokenStore {
+	case "allowlist", "denylist":
+	default:
+		add("TOKEN_STORE_MODE", "must be allowlist or denylist")
+	}
+	switch c.Session.CookieSameSite {
+	case "lax", "strict":
+	case "none":
+		if !c.Session.CookieSecure {
+			add("AUTH_COOKIE_SAMESITE", "none requires AUTH_COOKIE_SECURE=true")
+		}
+	default:
+		add("AUTH_COOKIE_SAMESITE", "must be lax, strict or none")
+	}
+	if c.Session.CookieMode {
+		if msg := secretProblem(c.Session.CSRFSecret); msg != "" {
+			add("CSRF_SECRET", msg)
+		}
+	}
+
+	for _, client := range c.OAuth.Clients {
+		id, secret, ok := strings.Cut(client, ":")
+		if !ok || id == "" {
+			add("OAUTH_CLIENTS", fmt.Sprintf("entry %q must use the format id:secret", client))
+			continue
+		}
+		if msg := secretProblem(secret); msg != "" {
+			add("OAUTH_CLIENTS", fmt.Sprintf("secret of client %q %s", id, msg))
+		}
+	}
+
+	if c.SMTP.Port < 1 || c.SMTP.Port > 65535 {
+		add("SMTP_PORT", "must be a port number between 1 and 65535")
+	}
+	if c.SMTP.From == "" {
+		add("FROM_EMAIL", "is required")
+	}
+	if c.SMTP.FrontendResetURL == "" {
+		add("FRONTEND_RESET_URL", "is required")
+	}
+	if c.SMTP.FrontendEmailChangeURL == "" {
+		add("FRONTEND_EMAIL_CHANGE_URL", "is required")
+	}
+	if c.SMTP.DefaultLocale == "" {
+		add("EMAIL_DEFAULT_LOCALE", "is required")
+	}
+	switch c.SMTP.Transport {
+	case "smtp", "log":
+	case "mailbox":
+		// A caixa é servida sem autenticação em /dev/mailbox
+		if !c.DevEndpoints() {
+			add("EMAIL_TRANSPORT", "mailbox requires APP_ENV=development or test")
+		}
+	case "file":
+		if c.SMTP.FileDir == "" {
+			add("EMAIL_FILE_DIR", "is required when EMAIL_TRANSPORT=file")
+		}
+	default:
+		add("EMAIL_TRANSPORT", "must be smtp, mailbox, file or log")
+	}
+	if c.SMTP.MailboxSize < 1 {
+		add("EMAIL_MAILBOX_SIZE", "must be at least 1")
+	}
+	if c.PasswordReset.TTL <= 0 {
+		add("PASSWORD_RESET_TTL", "must be greater than zero")
+	}
+	if c.EmailChange.TTL <= 0 {
+		add("EMAIL_CHANGE_TTL", "must be greater than zero")
+	}
+	if c.Account.DeletionGrace < 0 {
+		add("ACCOUNT_DELETION_GRACE", "must not be negative")
+	}
+	if c.Account.PurgeInterval <= 0 {
+		add("ACCOUNT_PURGE_INTERVAL", "must be greater than zero")
+	}
+	if c.Export.Dir == "" {
+		add("EXPORT_DIR", "is required")
+	}
+	if c.Export.LinkTTL <= 0 {
+		add("EXPORT_LINK_TTL", "must be greater than zero")
+	}
+	if c.Export.AsyncThreshold < 0 {
+		add("EXPORT_ASYNC_THRESHOLD", "must not be negative")
+	}
+	if u, err := url.Parse(c.Export.PublicURL); err != nil || u.Scheme == "" || u.Host == "" {
+		add("PUBLIC_API_URL", "must be an absolute URL")
+	}
+	if c.Outbox.PollInterval <= 0 {
+		add("OUTBOX_POLL_INTERVAL", "must be greater than zero")
+	}
+	if c.Outbox.BatchSize < 1 {
+		add("OUTBOX_BATCH_SIZE", "must be at least 1")
+	}
+	if c.Outbox.MaxAttempts < 1 {
+		add("OUTBOX_MAX_ATTEMPTS", "must be at least 1")
+	}
+	if c.Outbox.BackoffBase <= 0 {
+		add("OUTBOX_BACKOFF_BASE", "must be greater than zero")
+	}
+	if c.Outbox.BackoffMax < c.Outbox.BackoffBase {
+		add("OUTBOX_BACKOFF_MAX", "must not be lower than OUTBOX_BACKOFF_BASE")
+	}
+	switch c.Blob.Store {
+	case "file":
+		if c.Blob.Dir == "" {
+			add("BLOB_DIR", "is required when BLOB_STORE=file")
+		}
+	case "s3":
+		if u, err := url.Parse(c.Blob.S3Endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+			add("S3_ENDPOINT", "must be an absolute URL when BLOB_STORE=s3")
+		}
+		if c.Blob.S3Bucket == "" {
+			add("S3_BUCKET", "is required when BLOB_STORE=s3")
+		}
+		if c.Blob.S3AccessKey == "" {
+			add("S3_ACCESS_KEY", "is required when BLOB_STORE=s3")
+		}
+		if c.Blob.S3SecretKey == "" {
+			add("S3_SECRET_KEY", "is required when BLOB_STORE=s3")
+		}
+	default:
+		add("BLOB_STORE", "must be file or s3")
+	}
+	if c.Avatar.MaxBytes < 1 {
+		add("AVATAR_MAX_BYTES", "must be at least 1")
+	}
+	if c.Avatar.MaxPixels < 1 {
+		add("AVATAR_MAX_PIXELS", "must be at least 1")
+	}
+	// O backend também conta as tentativas da verificação humana
+	if c.RateLimit.Backend != "redis" && c.RateLimit.Backend != "memory" {
+		add("RATE_LIMIT_BACKEND", "must be redis or memory")
+	}
+	if c.RateLimit.Enabled {
+		if c.RateLimit.Algorithm != "token_bucket" && c.RateLimit.Algorithm != "sliding_window" {
+			add("RATE_LIMIT_ALGORITHM", "must be token_bucket or sliding_window")
+		}
+		for _, raw := range c.RateLimit.Rules {
+			if _, err := ParseRateLimitRule(raw); err != nil {
+				add("RATE_LIMIT_RULES", err.Error())
+			}
+		}
+		for _, raw := range c.RateLimit.Allowlist {
+			if _, err := parsePrefix(raw); err != nil {
+				add("RATE_LIMIT_ALLOWLIST", fmt.Sprintf("entry %q must be an IP or a CIDR", raw))
+			}
+		}
+	}
+	switch c.Human.Verifier {
+	case "none":
+	case "pow":
+		if c.Human.PowDifficulty < 1 || c.Human.PowDifficulty > 32 {
+			add("HUMAN_POW_DIFFICULTY", "must be between 1 and 32")
+		}
+		if c.Human.PowTTL <= 0 {
+			add("HUMAN_POW_TTL", "must be greater than zero")
+		}
+	case "hcaptcha", "turnstile":
+		if c.Human.CaptchaSiteKey == "" {
+			add("HUMAN_CAPTCHA_SITE_KEY", "is required when HUMAN_VERIFIER="+c.Human.Verifier)
+		}
+		if c.Human.CaptchaSecret == "" {
+			add("HUMAN_CAPTCHA_SECRET", "is required when HUMAN_VERIFIER="+c.Human.Verifier)
+		}
+	default:
+		add("HUMAN_VERIFIER", "must be none, pow, hcaptcha or turnstile")
+	}
+	if c.Human.RiskThreshold < 0 {
+		add("HUMAN_RISK_THRESHOLD", "must not be negative")
+	}
+	if c.Human.RiskThreshold > 0 && c.Human.RiskWindow <= 0 {
+		add("HUMAN_RISK_WINDOW", "must be greater than zero")
+	}
+	if c.Metrics.Enabled && c.Metrics.Token == "" && !c.DevEndpoints() {
+		add("METRICS_TOKEN", "is required when METRICS_ENABLED=true outside APP_ENV=development or test")
+	}
+	if c.Tracing.Enabled {
+		switch c.Tracing.Exporter {
+		case "stdout":
+		case "otlp":
+			if u, err := url.Parse(c.Tracing.OTLPEndpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				add("TRACING_OTLP_ENDPOINT", "must be an http or https URL")
+			}
+		default:
+			add("TRACING_EXPORTER", "must be otlp or stdout")
+		}
+		if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+			add("TRACING_SAMPLE_RATIO", "must be between 0 and 1")
+		}
+	}
+	if c.Log.Format != "json" && c.Log.Format != "text" {
+		add("LOG_FORMAT", "must be json or text")
+	}
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		add("LOG_LEVEL", "must be debug, info, warn or error")
+	}
+
+	return problems
+}
+
+// OAuthClients devolve os clientes OAuth indexados pelo id.
+func (c *Config) OAuthClients() map[string]string {
+	clients := make(map[string]string, len(c.OAuth.Clients))
+	for _, client := range c.OAuth.Clients {
+		if id, secret, ok := strings.Cut(client, ":"); ok {
+			clients[id] = secret
+		}
+	}
+	return clients
+}
+
+// DevEndpoints indica se as rotas /dev/* podem ser expostas.
+func (c *Config) DevEndpoints() bool {
+	return c.Server.Environment == "development" || c.Server.Environment == "test"
+}
+
+func (c *Config) AdminUserIDs() map[string]bool {
+	ids := make(map[string]bool, len(c.Audit.AdminUserIDs))
+	for _, id := range c.Audit.AdminUserIDs {
+		ids[id] = true
+	}
+	return ids
+}
+
+// RateLimitRules devolve as regras válidas de RATE_LIMIT_RULES.
+func (c *Config) RateLimitRules() []RateLimitRule {
+	rules := make([]RateLimitRule, 0, len(c.RateLimit.Rules))
+	for _, raw := range c.RateLimit.Rules {
+		if rule, err := ParseRateLimitRule(raw); err == nil {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// RateLimitAllowlist devolve as redes de RATE_LIMIT_ALLOWLIST; IPs isolados
+// viram redes de um endereço.
+func (c *Config) RateLimitAllowlist() []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(c.RateLimit.Allowlist))
+	for _, raw := range c.RateLimit.Allowlist {
+		if prefix, err := parsePrefix(raw); err == nil {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+func parsePrefix(raw string) (netip.Prefix, error) {
+	if strings.Contains(raw, "/") {
+		prefix, err := netip.ParsePrefix(raw)
+		return prefix.Masked(), err
+	}
+	addr, err := netip.ParseAddr(raw)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+func secretProblem(secret string) string {
+	switch {
+	case secret == "":
+		return "is required"
+	case weakSecrets[strings.ToLower(secret)]:
+		return "uses a well-known placeholder value"
+	case len(secret) < minSecretLength:
+		return fmt.Sprintf("must be at least %d characters", minSecretLength)
+	}
+	return ""
+}
+
+func yamlKey(env string) string {
+	for _, s := range settings {
+		if s.env == env {
+			return s.key
+		}
+	}
+	return ""
+}
+
+func trimAll(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
+// Problem identifica a configuração inválida pela variável de ambiente e pela chave YAML.
+type Problem struct {
+	Env     string
+	Key     string
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s (%s): %s", p.Env, p.Key, p.Message)
+}
+
+type ConfigError struct {
+	Problems []Problem
+}
+
+func (e *ConfigError) Error() string {
+	lines := make([]string, 0, len(e.Problems))
+	for _, p := range e.Problems {
+		lines = append(lines, "  "+p.String())
+	}
+	return "invalid configuration:\n" + strings.Join(lines, "\n")
+}
+
+// Keys devolve as variáveis de ambiente com problema, na ordem encontrada.
+func (e *ConfigError) Keys() []string {
+	keys := make([]string, 0, len(e.Problems))
+	for _, p := range e.Problems {
+		keys = append(keys, p.Env)
 	}
-	cfg.TokenAuth = jwtauth.New("HS256", []byte(cfg.JWTSecret), nil)
-	return cfg, err
+	return keys
 }
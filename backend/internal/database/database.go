@@ -0,0 +1,81 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+)
+
+type Config struct {
+	Driver   string
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Name     string
+	// SSLMode é usado apenas pelo PostgreSQL (padrão "disable")
+	SSLMode string
+}
+
+// Open seleciona o driver GORM conforme cfg.Driver. Sem driver, usa SQLite.
+func Open(cfg Config) (*gorm.DB, error) {
+	dialector, err := Dialector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	// TranslateError converte violações de índice único em gorm.ErrDuplicatedKey
+	return gorm.Open(dialector, &gorm.Config{TranslateError: true})
+}
+
+func Dialector(cfg Config) (gorm.Dialector, error) {
+	switch cfg.Driver {
+	case "", DriverSQLite, "sqlite3":
+		return sqlite.Open(orDefault(cfg.Name, "test.db")), nil
+	case DriverPostgres, "postgresql":
+		return postgres.Open(PostgresDSN(cfg)), nil
+	case DriverMySQL:
+		return mysql.Open(MySQLDSN(cfg)), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", msgerror.AnErrInvalidDBDriver, cfg.Driver)
+	}
+}
+
+func PostgresDSN(cfg Config) string {
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		orDefault(cfg.Host, "localhost"),
+		orDefault(cfg.Port, "5432"),
+		cfg.User,
+		cfg.Password,
+		cfg.Name,
+		orDefault(cfg.SSLMode, "disable"),
+	)
+}
+
+func MySQLDSN(cfg Config) string {
+	return fmt.Sprintf(
+		"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC",
+		cfg.User,
+		cfg.Password,
+		orDefault(cfg.Host, "localhost"),
+		orDefault(cfg.Port, "3306"),
+		cfg.Name,
+	)
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
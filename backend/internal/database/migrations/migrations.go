@@ -0,0 +1,8 @@
+// Package migrations contém os scripts SQL versionados de cada dialeto.
+// Arquivos seguem o padrão NNNN_descricao.up.sql / NNNN_descricao.down.sql.
+package migrations
+
+import "embed"
+
+//go:embed sqlite/*.sql postgres/*.sql mysql/*.sql
+var FS embed.FS
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS gorm_users (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    image_url VARCHAR(255),
+    created_at DATETIME(3) NULL,
+    password_reset_token VARCHAR(255),
+    password_reset_expires DATETIME(3) NULL,
+    UNIQUE INDEX idx_gorm_users_email (email)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS gorm_api_keys (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(32) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL,
+    scopes VARCHAR(255) NOT NULL,
+    expires_at DATETIME(3) NULL,
+    last_used_at DATETIME(3) NULL,
+    revoked_at DATETIME(3) NULL,
+    created_at DATETIME(3) NULL,
+    INDEX idx_gorm_api_keys_user_id (user_id),
+    UNIQUE INDEX idx_gorm_api_keys_prefix (prefix)
+);
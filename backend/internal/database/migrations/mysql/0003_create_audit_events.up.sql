@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS gorm_audit_events (
+    id VARCHAR(36) PRIMARY KEY,
+    action VARCHAR(64) NOT NULL,
+    outcome VARCHAR(16) NOT NULL,
+    actor_id VARCHAR(36),
+    target VARCHAR(255),
+    ip VARCHAR(64),
+    user_agent VARCHAR(512),
+    reason VARCHAR(255),
+    created_at DATETIME(3) NULL,
+    INDEX idx_gorm_audit_events_action (action),
+    INDEX idx_gorm_audit_events_outcome (outcome),
+    INDEX idx_gorm_audit_events_actor_id (actor_id),
+    INDEX idx_gorm_audit_events_target (target),
+    INDEX idx_gorm_audit_events_created_at (created_at)
+);
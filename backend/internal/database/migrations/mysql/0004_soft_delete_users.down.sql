@@ -0,0 +1,2 @@
+DROP INDEX idx_gorm_users_deleted_at ON gorm_users;
+ALTER TABLE gorm_users DROP COLUMN deleted_at;
@@ -0,0 +1,2 @@
+ALTER TABLE gorm_users ADD COLUMN deleted_at DATETIME(3) NULL;
+CREATE INDEX idx_gorm_users_deleted_at ON gorm_users (deleted_at);
@@ -0,0 +1 @@
+ALTER TABLE gorm_users ADD COLUMN version INT NOT NULL DEFAULT 1;
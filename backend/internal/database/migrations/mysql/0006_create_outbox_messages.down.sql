@@ -0,0 +1 @@
+DROP TABLE IF EXISTS gorm_outbox_messages;
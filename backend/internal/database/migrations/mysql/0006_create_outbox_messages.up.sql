@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS gorm_outbox_messages (
+    id VARCHAR(36) PRIMARY KEY,
+    topic VARCHAR(64) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME(3) NOT NULL,
+    last_error VARCHAR(1024),
+    created_at DATETIME(3) NULL,
+    sent_at DATETIME(3) NULL,
+    INDEX idx_gorm_outbox_messages_topic (topic),
+    INDEX idx_gorm_outbox_messages_created_at (created_at),
+    INDEX idx_gorm_outbox_messages_due (status, next_attempt_at)
+);
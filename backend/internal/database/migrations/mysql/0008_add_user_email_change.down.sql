@@ -0,0 +1,4 @@
+ALTER TABLE gorm_users DROP COLUMN email_change_expires;
+ALTER TABLE gorm_users DROP COLUMN email_change_cancel_token;
+ALTER TABLE gorm_users DROP COLUMN email_change_token;
+ALTER TABLE gorm_users DROP COLUMN pending_email;
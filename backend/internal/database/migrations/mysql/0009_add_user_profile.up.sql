@@ -0,0 +1,2 @@
+ALTER TABLE gorm_users ADD COLUMN timezone VARCHAR(64);
+ALTER TABLE gorm_users ADD COLUMN attributes TEXT;
@@ -0,0 +1,2 @@
+DROP INDEX idx_gorm_outbox_messages_user_id ON gorm_outbox_messages;
+ALTER TABLE gorm_outbox_messages DROP COLUMN user_id;
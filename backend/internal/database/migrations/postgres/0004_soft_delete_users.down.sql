@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_gorm_users_deleted_at;
+ALTER TABLE gorm_users DROP COLUMN deleted_at;
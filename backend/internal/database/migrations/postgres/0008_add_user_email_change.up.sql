@@ -0,0 +1,4 @@
+ALTER TABLE gorm_users ADD COLUMN pending_email VARCHAR(255);
+ALTER TABLE gorm_users ADD COLUMN email_change_token VARCHAR(255);
+ALTER TABLE gorm_users ADD COLUMN email_change_cancel_token VARCHAR(255);
+ALTER TABLE gorm_users ADD COLUMN email_change_expires TIMESTAMPTZ;
@@ -0,0 +1,2 @@
+ALTER TABLE gorm_users DROP COLUMN attributes;
+ALTER TABLE gorm_users DROP COLUMN timezone;
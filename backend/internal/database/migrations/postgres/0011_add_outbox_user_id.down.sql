@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_gorm_outbox_messages_user_id;
+ALTER TABLE gorm_outbox_messages DROP COLUMN user_id;
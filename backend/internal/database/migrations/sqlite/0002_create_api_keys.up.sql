@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS gorm_api_keys (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(32) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL,
+    scopes VARCHAR(255) NOT NULL,
+    expires_at DATETIME,
+    last_used_at DATETIME,
+    revoked_at DATETIME,
+    created_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_gorm_api_keys_user_id ON gorm_api_keys (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gorm_api_keys_prefix ON gorm_api_keys (prefix);
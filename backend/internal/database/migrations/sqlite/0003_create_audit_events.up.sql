@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS gorm_audit_events (
+    id VARCHAR(36) PRIMARY KEY,
+    action VARCHAR(64) NOT NULL,
+    outcome VARCHAR(16) NOT NULL,
+    actor_id VARCHAR(36),
+    target VARCHAR(255),
+    ip VARCHAR(64),
+    user_agent VARCHAR(512),
+    reason VARCHAR(255),
+    created_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_gorm_audit_events_action ON gorm_audit_events (action);
+CREATE INDEX IF NOT EXISTS idx_gorm_audit_events_outcome ON gorm_audit_events (outcome);
+CREATE INDEX IF NOT EXISTS idx_gorm_audit_events_actor_id ON gorm_audit_events (actor_id);
+CREATE INDEX IF NOT EXISTS idx_gorm_audit_events_target ON gorm_audit_events (target);
+CREATE INDEX IF NOT EXISTS idx_gorm_audit_events_created_at ON gorm_audit_events (created_at);
@@ -0,0 +1 @@
+ALTER TABLE gorm_users DROP COLUMN version;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS gorm_outbox_messages (
+    id VARCHAR(36) PRIMARY KEY,
+    topic VARCHAR(64) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME NOT NULL,
+    last_error VARCHAR(1024),
+    created_at DATETIME,
+    sent_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_gorm_outbox_messages_topic ON gorm_outbox_messages (topic);
+CREATE INDEX IF NOT EXISTS idx_gorm_outbox_messages_created_at ON gorm_outbox_messages (created_at);
+CREATE INDEX IF NOT EXISTS idx_gorm_outbox_messages_due ON gorm_outbox_messages (status, next_attempt_at);
@@ -0,0 +1 @@
+ALTER TABLE gorm_users ADD COLUMN locale VARCHAR(16);
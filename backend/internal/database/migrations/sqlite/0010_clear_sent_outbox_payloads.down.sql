@@ -0,0 +1,2 @@
+-- O conteúdo apagado não tem como voltar
+SELECT 1;
@@ -0,0 +1 @@
+UPDATE gorm_outbox_messages SET payload = '' WHERE status = 'sent';
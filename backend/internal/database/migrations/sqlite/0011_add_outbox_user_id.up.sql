@@ -0,0 +1,2 @@
+ALTER TABLE gorm_outbox_messages ADD COLUMN user_id VARCHAR(36);
+CREATE INDEX IF NOT EXISTS idx_gorm_outbox_messages_user_id ON gorm_outbox_messages (user_id);
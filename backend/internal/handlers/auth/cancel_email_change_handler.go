@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+type CancelEmailChangeHandler struct {
+	useCase usecase.CancelEmailChangeInterface
+}
+
+func NewCancelEmailChangeHandler(uc usecase.CancelEmailChangeInterface) *CancelEmailChangeHandler {
+	return &CancelEmailChangeHandler{useCase: uc}
+}
+
+func (h *CancelEmailChangeHandler) Handle(c *gin.Context) {
+	var input dto.EmailChangeTokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(msgerror.AnErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.useCase.Execute(c.Request.Context(), input.Token); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+type ChangeEmailHandler struct {
+	requestEmailChangeUseCase usecase.RequestEmailChangeInterface
+}
+
+func NewChangeEmailHandler(requestEmailChangeUseCase usecase.RequestEmailChangeInterface) *ChangeEmailHandler {
+	return &ChangeEmailHandler{
+		requestEmailChangeUseCase: requestEmailChangeUseCase,
+	}
+}
+
+func (h *ChangeEmailHandler) Handle(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		_ = c.Error(msgerror.AnErrUnauthorized)
+		return
+	}
+
+	userID, err := vo.ParseID(userIDStr.(string))
+	if err != nil {
+		_ = c.Error(msgerror.AnErrInvalidID)
+		return
+	}
+
+	var input dto.ChangeEmailInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(msgerror.AnErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.requestEmailChangeUseCase.Execute(c.Request.Context(), userID, input.NewEmail, input.Password); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// O e-mail só muda depois da confirmação pelo novo endereço
+	c.JSON(http.StatusAccepted, gin.H{"message": "confirmation sent to the new email"})
+}
@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"net/http"
+
+	service "github.com/eskokado/startup-auth-go/backend/pkg/domain/services"
+	"github.com/gin-gonic/gin"
+)
+
+// ClearDevMailboxHandler esvazia a caixa em memória entre testes ponta a ponta.
+type ClearDevMailboxHandler struct {
+	mailbox service.Mailbox
+}
+
+func NewClearDevMailboxHandler(mailbox service.Mailbox) *ClearDevMailboxHandler {
+	return &ClearDevMailboxHandler{
+		mailbox: mailbox,
+	}
+}
+
+func (h *ClearDevMailboxHandler) Handle(c *gin.Context) {
+	h.mailbox.Clear()
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+type ConfirmEmailChangeHandler struct {
+	useCase usecase.ConfirmEmailChangeInterface
+}
+
+func NewConfirmEmailChangeHandler(uc usecase.ConfirmEmailChangeInterface) *ConfirmEmailChangeHandler {
+	return &ConfirmEmailChangeHandler{useCase: uc}
+}
+
+func (h *ConfirmEmailChangeHandler) Handle(c *gin.Context) {
+	var input dto.EmailChangeTokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(msgerror.AnErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.useCase.Execute(c.Request.Context(), input.Token); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// As sessões foram revogadas; o frontend precisa de um novo login
+	c.Status(http.StatusNoContent)
+}
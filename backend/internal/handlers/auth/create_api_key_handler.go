@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+type CreateAPIKeyHandler struct {
+	createAPIKeyUseCase usecase.CreateAPIKeyInterface
+}
+
+func NewCreateAPIKeyHandler(createAPIKeyUseCase usecase.CreateAPIKeyInterface) *CreateAPIKeyHandler {
+	return &CreateAPIKeyHandler{
+		createAPIKeyUseCase: createAPIKeyUseCase,
+	}
+}
+
+func (h *CreateAPIKeyHandler) Handle(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		_ = c.Error(msgerror.AnErrUnauthorized)
+		return
+	}
+
+	userID, err := vo.ParseID(userIDStr.(string))
+	if err != nil {
+		_ = c.Error(msgerror.AnErrInvalidID)
+		return
+	}
+
+	var input dto.CreateAPIKeyInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(msgerror.AnErrInvalidRequestBody)
+		return
+	}
+
+	params := dto.CreateAPIKeyParams{
+		Name:   input.Name,
+		Scopes: input.Scopes,
+	}
+	if input.ExpiresAt != nil {
+		params.ExpiresAt = *input.ExpiresAt
+	}
+
+	result, err := h.createAPIKeyUseCase.Execute(c.Request.Context(), userID, params)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// A chave em texto puro só é exibida nesta resposta
+	c.JSON(http.StatusCreated, dto.CreateAPIKeyOutput{
+		APIKeyOutput: toAPIKeyOutput(result.APIKeyResult),
+		Key:          result.Key,
+	})
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/middleware"
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+type DeleteAccountHandler struct {
+	useCase       usecase.DeactivateAccountInterface
+	sessionCookie *middleware.SessionCookie
+}
+
+func NewDeleteAccountHandler(
+	useCase usecase.DeactivateAccountInterface,
+	sessionCookie *middleware.SessionCookie,
+) *DeleteAccountHandler {
+	return &DeleteAccountHandler{
+		useCase:       useCase,
+		sessionCookie: sessionCookie,
+	}
+}
+
+// Handle desativa a conta autenticada; o apagamento definitivo ocorre após o
+// período de carência informado em purge_after.
+func (h *DeleteAccountHandler) Handle(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		_ = c.Error(msgerror.AnErrUnauthorized)
+		return
+	}
+
+	userID, err := vo.ParseID(userIDStr.(string))
+	if err != nil {
+		_ = c.Error(msgerror.AnErrInvalidID)
+		return
+	}
+
+	var input dto.DeleteAccountInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(msgerror.AnErrInvalidRequestBody)
+		return
+	}
+
+	purgeAfter, err := h.useCase.Execute(c.Request.Context(), userID, input.Password)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if h.sessionCookie != nil {
+		h.sessionCookie.Clear(c)
+	}
+
+	c.JSON(http.StatusAccepted, dto.DeleteAccountOutput{PurgeAfter: purgeAfter})
+}
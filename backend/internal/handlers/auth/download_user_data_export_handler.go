@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"time"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+type DownloadUserDataExportHandler struct {
+	useCase usecase.DownloadUserDataExportInterface
+}
+
+func NewDownloadUserDataExportHandler(useCase usecase.DownloadUserDataExportInterface) *DownloadUserDataExportHandler {
+	return &DownloadUserDataExportHandler{useCase: useCase}
+}
+
+func (h *DownloadUserDataExportHandler) Handle(c *gin.Context) {
+	var input dto.ExportDownloadInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		_ = c.Error(msgerror.AnErrInvalidQuery)
+		return
+	}
+
+	result, err := h.useCase.Execute(c.Request.Context(), c.Param("file"), time.Unix(input.Expires, 0), input.Signature)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	sendExportFile(c, result)
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+type ExportUserDataHandler struct {
+	useCase usecase.ExportUserDataInterface
+}
+
+func NewExportUserDataHandler(useCase usecase.ExportUserDataInterface) *ExportUserDataHandler {
+	return &ExportUserDataHandler{useCase: useCase}
+}
+
+// Handle devolve o arquivo na própria resposta ou, para contas grandes,
+// responde 202 e envia o link de download por e-mail.
+func (h *ExportUserDataHandler) Handle(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		_ = c.Error(msgerror.AnErrUnauthorized)
+		return
+	}
+
+	userID, err := vo.ParseID(userIDStr.(string))
+	if err != nil {
+		_ = c.Error(msgerror.AnErrInvalidID)
+		return
+	}
+
+	var input dto.ExportInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		_ = c.Error(msgerror.AnErrInvalidQuery)
+		return
+	}
+
+	result, err := h.useCase.Execute(c.Request.Context(), userID, input.Format)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if result.Pending {
+		c.JSON(http.StatusAccepted, gin.H{"message": "export is being prepared and will be sent by email"})
+		return
+	}
+
+	sendExportFile(c, result)
+}
+
+func sendExportFile(c *gin.Context, result dto.UserDataExportResult) {
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="user-data-%s"`, result.FileName))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, result.ContentType, result.Data)
+}
@@ -4,35 +4,42 @@ import (
 	"net/http"
 
 	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
 	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
 	"github.com/gin-gonic/gin"
 )
 
 type ForgotPasswordHandler struct {
-	useCase usecase.RequestPasswordResetInterface
+	useCase       usecase.RequestPasswordResetInterface
+	humanVerifier providers.HumanVerifier
 }
 
-func NewForgotPasswordHandler(uc usecase.RequestPasswordResetInterface) *ForgotPasswordHandler {
-	return &ForgotPasswordHandler{useCase: uc}
+// humanVerifier pode ser nil (sem verificação humana).
+func NewForgotPasswordHandler(uc usecase.RequestPasswordResetInterface, humanVerifier providers.HumanVerifier) *ForgotPasswordHandler {
+	return &ForgotPasswordHandler{useCase: uc, humanVerifier: humanVerifier}
 }
 
 func (h *ForgotPasswordHandler) Handle(c *gin.Context) {
 	var input dto.ForgotPasswordInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(msgerror.AnErrInvalidRequestBody)
 		return
 	}
 
 	email, err := vo.NewEmail(input.Email)
 	if err != nil {
 		// Retorna o erro específico em vez de sempre "invalid email format"
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(err)
+		return
+	}
+	if !verifyHuman(c, h.humanVerifier, email.String()) {
 		return
 	}
 
 	if err := h.useCase.Execute(c.Request.Context(), email); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
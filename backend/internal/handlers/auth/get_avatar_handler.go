@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+type GetAvatarHandler struct {
+	useCase usecase.GetAvatarInterface
+}
+
+func NewGetAvatarHandler(useCase usecase.GetAvatarInterface) *GetAvatarHandler {
+	return &GetAvatarHandler{useCase: useCase}
+}
+
+func (h *GetAvatarHandler) Handle(c *gin.Context) {
+	var query dto.AvatarQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(msgerror.AnErrInvalidImageSize)
+		return
+	}
+
+	image, err := h.useCase.Execute(c.Request.Context(), c.Param("id"), query.Size)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Cada envio gera um id novo, então o conteúdo de uma URL nunca muda
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, image.ContentType, image.Data)
+}
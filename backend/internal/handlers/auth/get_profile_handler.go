@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+type GetProfileHandler struct {
+	getProfileUseCase usecase.GetProfileInterface
+}
+
+func NewGetProfileHandler(getProfileUseCase usecase.GetProfileInterface) *GetProfileHandler {
+	return &GetProfileHandler{getProfileUseCase: getProfileUseCase}
+}
+
+func (h *GetProfileHandler) Handle(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		_ = c.Error(msgerror.AnErrUnauthorized)
+		return
+	}
+
+	userID, err := vo.ParseID(userIDStr.(string))
+	if err != nil {
+		_ = c.Error(msgerror.AnErrInvalidID)
+		return
+	}
+
+	output, err := h.getProfileUseCase.Execute(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// HumanProofHeader leva a solução do desafio ou o token do captcha
+const HumanProofHeader = "X-Human-Proof"
+
+// HumanChallengeHandler entrega ao cliente o desafio da verificação humana.
+type HumanChallengeHandler struct {
+	verifier providers.HumanVerifier
+}
+
+func NewHumanChallengeHandler(verifier providers.HumanVerifier) *HumanChallengeHandler {
+	return &HumanChallengeHandler{verifier: verifier}
+}
+
+func (h *HumanChallengeHandler) Handle(c *gin.Context) {
+	challenge, err := h.verifier.Challenge(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	output := dto.HumanChallengeOutput{
+		Type:       challenge.Type,
+		Challenge:  challenge.Challenge,
+		Difficulty: challenge.Difficulty,
+		SiteKey:    challenge.SiteKey,
+	}
+	if !challenge.ExpiresAt.IsZero() {
+		output.ExpiresAt = &challenge.ExpiresAt
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, output)
+}
+
+// verifyHuman confere a prova do header antes do caso de uso; sem verifier a
+// rota não exige prova. email é o alvo da operação. Devolve false quando o
+// erro já foi registrado.
+func verifyHuman(c *gin.Context, verifier providers.HumanVerifier, email string) bool {
+	if verifier == nil {
+		return true
+	}
+	subject := providers.HumanSubject{IP: c.ClientIP(), Email: email}
+	if err := verifier.Verify(c.Request.Context(), c.GetHeader(HumanProofHeader), subject); err != nil {
+		_ = c.Error(err)
+		return false
+	}
+	return true
+}
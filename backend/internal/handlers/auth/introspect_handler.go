@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/gin-gonic/gin"
+)
+
+type IntrospectHandler struct {
+	introspectUseCase usecase.IntrospectTokenInterface
+}
+
+func NewIntrospectHandler(introspectUseCase usecase.IntrospectTokenInterface) *IntrospectHandler {
+	return &IntrospectHandler{
+		introspectUseCase: introspectUseCase,
+	}
+}
+
+// Handle responde erros no formato do RFC 6749 (seção 5.2), esperado pelos
+// clientes OAuth, e não em problem+json.
+func (h *IntrospectHandler) Handle(c *gin.Context) {
+	// RFC 7662: o token chega como application/x-www-form-urlencoded
+	token := c.PostForm("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request"})
+		return
+	}
+
+	result, err := h.introspectUseCase.Execute(c.Request.Context(), token)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "server_error"})
+		return
+	}
+
+	if !result.Active {
+		c.JSON(http.StatusOK, dto.IntrospectOutput{Active: false})
+		return
+	}
+
+	// Sem client_id: no RFC 7662 ele é o cliente para o qual o token foi
+	// emitido, e nossos JWTs não são emitidos para clientes OAuth
+	output := dto.IntrospectOutput{
+		Active:    true,
+		UserID:    result.UserID,
+		Subject:   result.Subject,
+		Username:  result.Subject,
+		ExpiresAt: result.ExpiresAt,
+		TokenType: result.TokenType,
+	}
+	c.JSON(http.StatusOK, output)
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+type ListAPIKeysHandler struct {
+	listAPIKeysUseCase usecase.ListAPIKeysInterface
+}
+
+func NewListAPIKeysHandler(listAPIKeysUseCase usecase.ListAPIKeysInterface) *ListAPIKeysHandler {
+	return &ListAPIKeysHandler{
+		listAPIKeysUseCase: listAPIKeysUseCase,
+	}
+}
+
+func (h *ListAPIKeysHandler) Handle(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		_ = c.Error(msgerror.AnErrUnauthorized)
+		return
+	}
+
+	userID, err := vo.ParseID(userIDStr.(string))
+	if err != nil {
+		_ = c.Error(msgerror.AnErrInvalidID)
+		return
+	}
+
+	results, err := h.listAPIKeysUseCase.Execute(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	output := make([]dto.APIKeyOutput, 0, len(results))
+	for _, result := range results {
+		output = append(output, toAPIKeyOutput(result))
+	}
+	c.JSON(http.StatusOK, output)
+}
+
+func toAPIKeyOutput(result dto.APIKeyResult) dto.APIKeyOutput {
+	return dto.APIKeyOutput{
+		ID:         result.ID,
+		Name:       result.Name,
+		Prefix:     result.Prefix,
+		Scopes:     result.Scopes,
+		ExpiresAt:  optionalTime(result.ExpiresAt),
+		LastUsedAt: optionalTime(result.LastUsedAt),
+		RevokedAt:  optionalTime(result.RevokedAt),
+		CreatedAt:  result.CreatedAt,
+	}
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
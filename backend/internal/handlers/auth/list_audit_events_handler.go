@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+type ListAuditEventsHandler struct {
+	listAuditEventsUseCase usecase.ListAuditEventsInterface
+}
+
+func NewListAuditEventsHandler(listAuditEventsUseCase usecase.ListAuditEventsInterface) *ListAuditEventsHandler {
+	return &ListAuditEventsHandler{
+		listAuditEventsUseCase: listAuditEventsUseCase,
+	}
+}
+
+func (h *ListAuditEventsHandler) Handle(c *gin.Context) {
+	var input dto.AuditQueryInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		_ = c.Error(fmt.Errorf("%w: %v", msgerror.AnErrInvalidQuery, err))
+		return
+	}
+
+	events, err := h.listAuditEventsUseCase.Execute(c.Request.Context(), dto.AuditQueryParams{
+		Action:  input.Action,
+		Outcome: input.Outcome,
+		ActorID: input.ActorID,
+		Target:  input.Target,
+		IP:      input.IP,
+		From:    input.From,
+		To:      input.To,
+		Limit:   input.Limit,
+		Offset:  input.Offset,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"net/http"
+
+	service "github.com/eskokado/startup-auth-go/backend/pkg/domain/services"
+	"github.com/gin-gonic/gin"
+)
+
+// ListDevMailboxHandler expõe os e-mails retidos pelo transporte "mailbox".
+// Só é registrado com EMAIL_TRANSPORT=mailbox: as mensagens trazem tokens.
+type ListDevMailboxHandler struct {
+	mailbox service.Mailbox
+}
+
+func NewListDevMailboxHandler(mailbox service.Mailbox) *ListDevMailboxHandler {
+	return &ListDevMailboxHandler{
+		mailbox: mailbox,
+	}
+}
+
+func (h *ListDevMailboxHandler) Handle(c *gin.Context) {
+	c.JSON(http.StatusOK, h.mailbox.Messages(c.Query("to")))
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+type ListOutboxMessagesHandler struct {
+	listOutboxMessagesUseCase usecase.ListOutboxMessagesInterface
+}
+
+func NewListOutboxMessagesHandler(listOutboxMessagesUseCase usecase.ListOutboxMessagesInterface) *ListOutboxMessagesHandler {
+	return &ListOutboxMessagesHandler{
+		listOutboxMessagesUseCase: listOutboxMessagesUseCase,
+	}
+}
+
+func (h *ListOutboxMessagesHandler) Handle(c *gin.Context) {
+	var input dto.OutboxQueryInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		_ = c.Error(fmt.Errorf("%w: %v", msgerror.AnErrInvalidQuery, err))
+		return
+	}
+
+	messages, err := h.listOutboxMessagesUseCase.Execute(c.Request.Context(), dto.OutboxQueryParams{
+		Status: input.Status,
+		Topic:  input.Topic,
+		Limit:  input.Limit,
+		Offset: input.Offset,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, messages)
+}
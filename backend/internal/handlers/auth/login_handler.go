@@ -3,41 +3,61 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/eskokado/startup-auth-go/backend/internal/middleware"
 	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
 	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
 	"github.com/gin-gonic/gin"
 )
 
 type LoginHandler struct {
-	loginUseCase usecase.LoginInterface
+	loginUseCase  usecase.LoginInterface
+	sessionCookie *middleware.SessionCookie
 }
 
-func NewLoginHandler(loginUseCase usecase.LoginInterface) *LoginHandler {
+// NewLoginHandler recebe sessionCookie nil para o modo bearer; caso contrário
+// o token é entregue apenas no cookie HttpOnly.
+func NewLoginHandler(
+	loginUseCase usecase.LoginInterface,
+	sessionCookie *middleware.SessionCookie,
+) *LoginHandler {
 	return &LoginHandler{
-		loginUseCase: loginUseCase,
+		loginUseCase:  loginUseCase,
+		sessionCookie: sessionCookie,
 	}
 }
 
 func (h *LoginHandler) Handle(c *gin.Context) {
 	var input dto.LoginInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		_ = c.Error(msgerror.AnErrInvalidRequestBody)
 		return
 	}
 
 	loginResult, err := h.loginUseCase.Execute(c.Request.Context(), input.Email, input.Password)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
+		_ = c.Error(err)
 		return
 	}
 
 	output := dto.LoginOutput{
 		AccessToken: loginResult.Token,
 		User: dto.UserOutput{
-			Id:    loginResult.UserID.String(),
-			Name:  loginResult.Name.String(),
-			Email: loginResult.Email.String(),
+			Id:         loginResult.UserID.String(),
+			Name:       loginResult.Name.String(),
+			Email:      loginResult.Email.String(),
+			ImageURL:   loginResult.ImageURL.String(),
+			Locale:     loginResult.Locale.String(),
+			Timezone:   loginResult.Timezone.String(),
+			Attributes: loginResult.Attributes.Map(),
+			CreatedAt:  loginResult.CreatedAt,
 		},
 	}
+
+	if h.sessionCookie != nil {
+		output.AccessToken = ""
+		output.CSRFToken = h.sessionCookie.Set(c, loginResult.Token)
+	}
+
 	c.JSON(http.StatusOK, output)
 }
@@ -1,43 +1,68 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/eskokado/startup-auth-go/backend/internal/middleware"
 	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
 	"github.com/gin-gonic/gin"
 )
 
 type LogoutHandler struct {
 	logoutUseCase usecase.LogoutInterface
+	sessionCookie *middleware.SessionCookie
 }
 
-func NewLogoutHandler(logoutUseCase usecase.LogoutInterface) *LogoutHandler {
+func NewLogoutHandler(
+	logoutUseCase usecase.LogoutInterface,
+	sessionCookie *middleware.SessionCookie,
+) *LogoutHandler {
 	return &LogoutHandler{
 		logoutUseCase: logoutUseCase,
+		sessionCookie: sessionCookie,
 	}
 }
 
 func (h *LogoutHandler) Handle(c *gin.Context) {
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Authorization header is required"})
-		return
-	}
-
-	parts := strings.Split(authHeader, " ")
-	if len(parts) != 2 || parts[0] != "Bearer" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid authorization format"})
+	token, ok := h.extractToken(c)
+	if !ok {
 		return
 	}
 
-	token := parts[1]
-
 	err := h.logoutUseCase.Execute(c.Request.Context(), token)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to logout"})
+		_ = c.Error(err)
 		return
 	}
 
+	if h.sessionCookie != nil {
+		h.sessionCookie.Clear(c)
+	}
+
 	c.Status(http.StatusOK)
 }
+
+func (h *LogoutHandler) extractToken(c *gin.Context) (string, bool) {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		// Modo cookie: o token vem do cookie de sessão
+		if h.sessionCookie != nil {
+			if token := h.sessionCookie.Token(c); token != "" {
+				return token, true
+			}
+		}
+		_ = c.Error(msgerror.AnErrTokenIsRequired)
+		return "", false
+	}
+
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		_ = c.Error(fmt.Errorf("%w: invalid authorization format", msgerror.AnErrInvalidToken))
+		return "", false
+	}
+
+	return parts[1], true
+}
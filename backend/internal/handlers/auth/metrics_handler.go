@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/metrics"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsHandler expõe as métricas no formato de texto do Prometheus; com
+// token, o scraper precisa enviar "Authorization: Bearer <token>".
+type MetricsHandler struct {
+	registry *metrics.Registry
+	token    string
+}
+
+func NewMetricsHandler(registry *metrics.Registry, token string) *MetricsHandler {
+	return &MetricsHandler{registry: registry, token: token}
+}
+
+func (h *MetricsHandler) Handle(c *gin.Context) {
+	if h.token != "" {
+		expected := "Bearer " + h.token
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), []byte(expected)) != 1 {
+			_ = c.Error(msgerror.AnErrUnauthorized)
+			return
+		}
+	}
+
+	c.Header("Content-Type", metrics.ContentType)
+	c.Status(http.StatusOK)
+	_, _ = h.registry.WriteTo(c.Writer)
+}
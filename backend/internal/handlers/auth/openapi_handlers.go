@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"io/fs"
+	"net/http"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/openapi/swaggerui"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPIHandler serve o documento OpenAPI já serializado.
+type OpenAPIHandler struct {
+	document []byte
+}
+
+func NewOpenAPIHandler(document []byte) *OpenAPIHandler {
+	return &OpenAPIHandler{document: document}
+}
+
+func (h *OpenAPIHandler) Handle(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", h.document)
+}
+
+// SwaggerUIHandler serve a página do Swagger UI apontando para o documento.
+// Os arquivos do Swagger UI vêm do próprio servidor (assetsURL) e a CSP só
+// permite scripts de lá e o de inicialização da página.
+type SwaggerUIHandler struct {
+	page   []byte
+	policy string
+	assets fs.FS
+}
+
+// NewSwaggerUIHandler recebe os arquivos do swagger-ui-dist (ver o pacote
+// swaggerui/assets) e entra em pânico se faltar algum usado pela página.
+func NewSwaggerUIHandler(documentURL, assetsURL string, assets fs.FS) *SwaggerUIHandler {
+	for _, name := range []string{swaggerui.StylesheetFile, swaggerui.BundleFile} {
+		if _, err := fs.Stat(assets, name); err != nil {
+			panic("swagger ui: " + err.Error())
+		}
+	}
+	script := `window.ui = SwaggerUIBundle({ url: "` + documentURL + `", dom_id: "#swagger-ui" });`
+	hash := sha256.Sum256([]byte(script))
+	return &SwaggerUIHandler{
+		page: []byte(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>startup-auth-go API</title>
+  <link rel="stylesheet" href="` + assetsURL + `/` + swaggerui.StylesheetFile + `">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="` + assetsURL + `/` + swaggerui.BundleFile + `"></script>
+  <script>` + script + `</script>
+</body>
+</html>
+`),
+		// O Swagger UI aplica estilos inline e usa data: nos ícones
+		policy: "default-src 'self'; script-src 'self' 'sha256-" + base64.StdEncoding.EncodeToString(hash[:]) + "'; " +
+			"style-src 'self' 'unsafe-inline'; img-src 'self' data:; object-src 'none'; base-uri 'none'; frame-ancestors 'none'",
+		assets: assets,
+	}
+}
+
+func (h *SwaggerUIHandler) Handle(c *gin.Context) {
+	c.Header("Content-Security-Policy", h.policy)
+	c.Data(http.StatusOK, "text/html; charset=utf-8", h.page)
+}
+
+// HandleAsset serve só os arquivos usados pela página, com cache longo: o
+// conteúdo muda apenas com a versão embutida.
+func (h *SwaggerUIHandler) HandleAsset(c *gin.Context) {
+	name := c.Param("file")
+	contentType := map[string]string{
+		swaggerui.StylesheetFile: "text/css; charset=utf-8",
+		swaggerui.BundleFile:     "text/javascript; charset=utf-8",
+	}[name]
+	if contentType == "" {
+		_ = c.Error(msgerror.AnErrDocsNotFound)
+		return
+	}
+	data, err := fs.ReadFile(h.assets, name)
+	if err != nil {
+		_ = c.Error(msgerror.AnErrDocsNotFound)
+		return
+	}
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, contentType, data)
+}
@@ -1,42 +1,43 @@
 package handlers
 
 import (
-	"fmt"
 	"net/http"
 
 	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
 	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
 	"github.com/gin-gonic/gin"
 )
 
 type RegisterHandler struct {
 	registerUseCase usecase.RegisterInterface
 	userRepo        repository.UserRepository
+	humanVerifier   providers.HumanVerifier
 }
 
+// humanVerifier pode ser nil (cadastro sem verificação humana).
 func NewRegisterHandler(
 	registerUseCase usecase.RegisterInterface,
 	userRepo repository.UserRepository,
+	humanVerifier providers.HumanVerifier,
 ) *RegisterHandler {
 	return &RegisterHandler{
 		registerUseCase: registerUseCase,
 		userRepo:        userRepo,
+		humanVerifier:   humanVerifier,
 	}
 }
 
 func (h *RegisterHandler) Handle(c *gin.Context) {
 	var input dto.RegisterUserInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		_ = c.Error(msgerror.AnErrInvalidRequestBody)
 		return
 	}
-
-	// Validação do email ANTES do caso de uso
-	email, err := vo.NewEmail(input.Email)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid email format"})
+	if !verifyHuman(c, h.humanVerifier, input.Email) {
 		return
 	}
 
@@ -47,14 +48,23 @@ func (h *RegisterHandler) Handle(c *gin.Context) {
 		PasswordConfirmation: input.PasswordConfirmation,
 	}
 
+	// O caso de uso valida todos os campos, inclusive o e-mail, de uma vez
 	if err := h.registerUseCase.Execute(c.Request.Context(), params); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to register user: error %s", err)})
+		_ = c.Error(err)
 		return
 	}
 
+	email, err := vo.NewEmail(input.Email)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
 	user, err := h.userRepo.GetByEmail(c.Request.Context(), email)
+	if err == nil && user == nil {
+		err = msgerror.AnErrNoSavedUser
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch user"})
+		_ = c.Error(err)
 		return
 	}
 
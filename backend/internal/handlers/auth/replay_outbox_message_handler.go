@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+type ReplayOutboxMessageHandler struct {
+	replayOutboxMessageUseCase usecase.ReplayOutboxMessageInterface
+}
+
+func NewReplayOutboxMessageHandler(replayOutboxMessageUseCase usecase.ReplayOutboxMessageInterface) *ReplayOutboxMessageHandler {
+	return &ReplayOutboxMessageHandler{
+		replayOutboxMessageUseCase: replayOutboxMessageUseCase,
+	}
+}
+
+func (h *ReplayOutboxMessageHandler) Handle(c *gin.Context) {
+	id, err := vo.ParseID(c.Param("id"))
+	if err != nil {
+		_ = c.Error(msgerror.AnErrInvalidID)
+		return
+	}
+
+	message, err := h.replayOutboxMessageUseCase.Execute(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, message)
+}
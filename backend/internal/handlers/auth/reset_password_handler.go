@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"errors"
 	"net/http"
 
 	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
@@ -21,18 +20,12 @@ func NewResetPasswordHandler(uc usecase.ResetPasswordInterface) *ResetPasswordHa
 func (h *ResetPasswordHandler) Handle(c *gin.Context) {
 	var input dto.ResetPasswordInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		_ = c.Error(msgerror.AnErrInvalidRequestBody)
 		return
 	}
 
 	if err := h.useCase.Execute(c.Request.Context(), input.Token, input.Password); err != nil {
-		switch {
-		case errors.Is(err, msgerror.AnErrInvalidToken),
-			errors.Is(err, msgerror.AnErrExpiredToken):
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to reset password"})
-		}
+		_ = c.Error(err)
 		return
 	}
 
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+type RestoreAccountHandler struct {
+	useCase usecase.RestoreAccountInterface
+}
+
+func NewRestoreAccountHandler(useCase usecase.RestoreAccountInterface) *RestoreAccountHandler {
+	return &RestoreAccountHandler{useCase: useCase}
+}
+
+// Handle reativa uma conta ainda no período de carência. Não emite token:
+// o cliente deve fazer login em seguida.
+func (h *RestoreAccountHandler) Handle(c *gin.Context) {
+	var input dto.RestoreAccountInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		_ = c.Error(msgerror.AnErrInvalidRequestBody)
+		return
+	}
+
+	if _, err := h.useCase.Execute(c.Request.Context(), input.Email, input.Password); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+type RevokeAPIKeyHandler struct {
+	revokeAPIKeyUseCase usecase.RevokeAPIKeyInterface
+}
+
+func NewRevokeAPIKeyHandler(revokeAPIKeyUseCase usecase.RevokeAPIKeyInterface) *RevokeAPIKeyHandler {
+	return &RevokeAPIKeyHandler{
+		revokeAPIKeyUseCase: revokeAPIKeyUseCase,
+	}
+}
+
+func (h *RevokeAPIKeyHandler) Handle(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		_ = c.Error(msgerror.AnErrUnauthorized)
+		return
+	}
+
+	userID, err := vo.ParseID(userIDStr.(string))
+	if err != nil {
+		_ = c.Error(msgerror.AnErrInvalidID)
+		return
+	}
+
+	keyID, err := vo.ParseID(c.Param("id"))
+	if err != nil {
+		_ = c.Error(msgerror.AnErrInvalidID)
+		return
+	}
+
+	if err := h.revokeAPIKeyUseCase.Execute(c.Request.Context(), userID, keyID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
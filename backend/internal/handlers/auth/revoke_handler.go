@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/gin-gonic/gin"
+)
+
+type RevokeHandler struct {
+	logoutUseCase usecase.LogoutInterface
+}
+
+func NewRevokeHandler(logoutUseCase usecase.LogoutInterface) *RevokeHandler {
+	return &RevokeHandler{
+		logoutUseCase: logoutUseCase,
+	}
+}
+
+// Handle responde erros no formato do RFC 6749 (seção 5.2), como o
+// IntrospectHandler.
+func (h *RevokeHandler) Handle(c *gin.Context) {
+	// RFC 7009: token_type_hint é opcional e pode ser ignorado,
+	// pois só emitimos access tokens
+	token := c.PostForm("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request"})
+		return
+	}
+
+	if err := h.logoutUseCase.Execute(c.Request.Context(), token); err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "temporarily_unavailable"})
+		return
+	}
+
+	// Tokens desconhecidos ou já revogados também respondem 200
+	c.Status(http.StatusOK)
+}
@@ -23,31 +23,24 @@ func NewUpdateNameHandler(updateNameUseCase usecase.UpdateNameInterface) *Update
 func (h *UpdateNameHandler) Handle(c *gin.Context) {
 	userIDStr, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		_ = c.Error(msgerror.AnErrUnauthorized)
 		return
 	}
 
 	userID, err := vo.ParseID(userIDStr.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": msgerror.AnErrInvalidID.Error()})
+		_ = c.Error(msgerror.AnErrInvalidID)
 		return
 	}
 
 	var input dto.UpdateNameInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		_ = c.Error(msgerror.AnErrInvalidRequestBody)
 		return
 	}
 
 	if err := h.updateNameUseCase.Execute(c.Request.Context(), userID, input.Name); err != nil {
-		switch err {
-		case msgerror.AnErrUserNotFound:
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		case msgerror.AnErrInvalidName, msgerror.AnErrNameTooShort, msgerror.AnErrNameTooLong:
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update name"})
-		}
+		_ = c.Error(err)
 		return
 	}
 
@@ -23,33 +23,24 @@ func NewUpdatePasswordHandler(updatePasswordUseCase usecase.UpdatePasswordInterf
 func (h *UpdatePasswordHandler) Handle(c *gin.Context) {
 	userIDStr, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		_ = c.Error(msgerror.AnErrUnauthorized)
 		return
 	}
 
 	userID, err := vo.ParseID(userIDStr.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": msgerror.AnErrInvalidID.Error()})
+		_ = c.Error(msgerror.AnErrInvalidID)
 		return
 	}
 
 	var input dto.UpdatePasswordInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		_ = c.Error(msgerror.AnErrInvalidRequestBody)
 		return
 	}
 
 	if err := h.updatePasswordUseCase.Execute(c.Request.Context(), userID, input.CurrentPassword, input.NewPassword); err != nil {
-		switch err {
-		case msgerror.AnErrInvalidCredentials:
-			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
-		case msgerror.AnErrWeakPassword, msgerror.AnErrPasswordInvalid:
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		case msgerror.AnErrUserNotFound:
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update password"})
-		}
+		_ = c.Error(err)
 		return
 	}
 
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+// Folga para os cabeçalhos e delimitadores do multipart além do arquivo
+const multipartOverhead = 64 << 10
+
+var errAvatarRequired = fmt.Errorf("%w: avatar file is required", msgerror.AnErrInvalidRequestBody)
+
+type UploadAvatarHandler struct {
+	uploadAvatarUseCase usecase.UploadAvatarInterface
+	maxBytes            int64
+}
+
+func NewUploadAvatarHandler(uploadAvatarUseCase usecase.UploadAvatarInterface, maxBytes int64) *UploadAvatarHandler {
+	return &UploadAvatarHandler{
+		uploadAvatarUseCase: uploadAvatarUseCase,
+		maxBytes:            maxBytes,
+	}
+}
+
+func (h *UploadAvatarHandler) Handle(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		_ = c.Error(msgerror.AnErrUnauthorized)
+		return
+	}
+
+	userID, err := vo.ParseID(userIDStr.(string))
+	if err != nil {
+		_ = c.Error(msgerror.AnErrInvalidID)
+		return
+	}
+
+	// Corta o envio assim que passa do limite, sem ler o resto do corpo
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBytes+multipartOverhead)
+
+	header, err := c.FormFile("avatar")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			_ = c.Error(msgerror.AnErrImageTooLarge)
+			return
+		}
+		_ = c.Error(errAvatarRequired)
+		return
+	}
+	if header.Size > h.maxBytes {
+		_ = c.Error(msgerror.AnErrImageTooLarge)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		_ = c.Error(errAvatarRequired)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, h.maxBytes+1))
+	if err != nil {
+		_ = c.Error(msgerror.AnErrInvalidRequestBody)
+		return
+	}
+
+	imageURL, err := h.uploadAvatarUseCase.Execute(c.Request.Context(), userID, data)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.UploadAvatarOutput{ImageURL: imageURL})
+}
@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/logging"
+	port "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+)
+
+// AccountPurger executa periodicamente o apagamento das contas com carência vencida.
+type AccountPurger struct {
+	useCase  port.PurgeAccountsInterface
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+func NewAccountPurger(useCase port.PurgeAccountsInterface, interval time.Duration, logger *slog.Logger) *AccountPurger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &AccountPurger{useCase: useCase, interval: interval, logger: logger}
+}
+
+// Run executa uma vez ao iniciar e depois a cada intervalo, até o contexto ser cancelado.
+func (p *AccountPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce esvazia a fila de contas vencidas, um lote por vez.
+func (p *AccountPurger) RunOnce(ctx context.Context) {
+	ctx = logging.WithLogger(ctx, p.logger)
+	for ctx.Err() == nil {
+		erased, err := p.useCase.Execute(ctx)
+		if err != nil {
+			p.logger.Error("account purger failed", slog.Any("error", err))
+			return
+		}
+		if erased == 0 {
+			return
+		}
+		p.logger.Info("account purger erased accounts", slog.Int("count", erased))
+	}
+}
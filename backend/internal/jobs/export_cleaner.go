@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+)
+
+// ExportCleaner remove periodicamente as exportações cujo link já expirou.
+type ExportCleaner struct {
+	store   providers.ExportStore
+	linkTTL time.Duration
+	now     func() time.Time
+	logger  *slog.Logger
+}
+
+// Roda a cada linkTTL: nenhum arquivo fica mais que o dobro da validade do link.
+func NewExportCleaner(store providers.ExportStore, linkTTL time.Duration, logger *slog.Logger) *ExportCleaner {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &ExportCleaner{store: store, linkTTL: linkTTL, now: time.Now, logger: logger}
+}
+
+// Run executa uma vez ao iniciar e depois a cada linkTTL, até o contexto ser cancelado.
+func (c *ExportCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.linkTTL)
+	defer ticker.Stop()
+
+	for {
+		c.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *ExportCleaner) RunOnce(ctx context.Context) {
+	if err := c.store.DeleteOlderThan(ctx, c.now().Add(-c.linkTTL)); err != nil {
+		c.logger.Error("export cleaner failed", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,130 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/logging"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+)
+
+// defaultOutboxLease precisa ser maior que a duração de uma entrega
+const defaultOutboxLease = 5 * time.Minute
+
+// OutboxHandler entrega o payload de um tópico; erro agenda nova tentativa.
+type OutboxHandler func(ctx context.Context, payload []byte) error
+
+type OutboxOptions struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BackoffBase  time.Duration
+	BackoffMax   time.Duration
+	// Lease é o tempo em que uma mensagem reservada fica invisível às demais instâncias
+	Lease time.Duration
+}
+
+// OutboxDispatcher entrega as mensagens pendentes da outbox, com backoff
+// exponencial entre as tentativas e dead letter ao esgotá-las.
+type OutboxDispatcher struct {
+	repo     repository.OutboxRepository
+	handlers map[string]OutboxHandler
+	opts     OutboxOptions
+	logger   *slog.Logger
+	now      func() time.Time
+}
+
+func NewOutboxDispatcher(
+	repo repository.OutboxRepository,
+	handlers map[string]OutboxHandler,
+	opts OutboxOptions,
+	logger *slog.Logger,
+) *OutboxDispatcher {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = defaultOutboxLease
+	}
+	return &OutboxDispatcher{repo: repo, handlers: handlers, opts: opts, logger: logger, now: time.Now}
+}
+
+// Run processa a fila ao iniciar e depois a cada intervalo, até o contexto ser cancelado.
+func (d *OutboxDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce entrega lotes até não restarem mensagens vencidas e devolve quantas
+// foram processadas, com sucesso ou não.
+func (d *OutboxDispatcher) RunOnce(ctx context.Context) int {
+	processed := 0
+	for ctx.Err() == nil {
+		messages, err := d.repo.ClaimDue(ctx, d.now(), d.opts.Lease, d.opts.BatchSize)
+		if err != nil {
+			d.logger.Error("outbox dispatcher failed to claim messages", slog.Any("error", err))
+			return processed
+		}
+
+		for _, message := range messages {
+			d.dispatch(ctx, message)
+		}
+		processed += len(messages)
+
+		if len(messages) < d.opts.BatchSize {
+			return processed
+		}
+	}
+	return processed
+}
+
+func (d *OutboxDispatcher) dispatch(ctx context.Context, message *entity.OutboxMessage) {
+	// Os handlers (e os providers que chamam) registram com a mensagem identificada
+	logger := d.logger.With(slog.String("outbox_message_id", message.ID.String()), slog.String("topic", message.Topic))
+	handlerCtx := logging.WithLogger(ctx, logger)
+
+	handler, ok := d.handlers[message.Topic]
+	if !ok {
+		// Sem handler nenhuma nova tentativa adianta
+		message.MarkFailed(fmt.Errorf("no handler for topic %q", message.Topic), d.now(), 0)
+	} else if err := handler(handlerCtx, message.Payload); err != nil {
+		message.MarkFailed(err, d.now().Add(d.Backoff(message.Attempts+1)), d.opts.MaxAttempts)
+	} else {
+		message.MarkSent(d.now())
+	}
+
+	if message.IsDead() {
+		logger.Error("outbox message moved to dead letter", slog.String("last_error", message.LastError))
+	}
+
+	// Se a gravação falhar, a reserva expira e a mensagem é entregue de novo
+	if err := d.repo.Update(ctx, message); err != nil {
+		logger.Error("outbox dispatcher failed to update message", slog.Any("error", err))
+	}
+}
+
+// Backoff devolve a espera antes da tentativa seguinte à falha número attempt:
+// BackoffBase dobrando a cada falha, limitado a BackoffMax.
+func (d *OutboxDispatcher) Backoff(attempt int) time.Duration {
+	wait := d.opts.BackoffBase
+	for i := 1; i < attempt; i++ {
+		wait *= 2
+		if wait >= d.opts.BackoffMax {
+			return d.opts.BackoffMax
+		}
+	}
+	return min(wait, d.opts.BackoffMax)
+}
@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	service "github.com/eskokado/startup-auth-go/backend/pkg/domain/services"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+)
+
+// EmailOutboxHandlers liga os tópicos de e-mail ao serviço de envio.
+func EmailOutboxHandlers(emailSender service.EmailServiceInterface) map[string]OutboxHandler {
+	return map[string]OutboxHandler{
+		entity.OutboxTopicPasswordResetEmail: func(ctx context.Context, payload []byte) error {
+			var msg entity.PasswordResetEmailPayload
+			if err := json.Unmarshal(payload, &msg); err != nil {
+				return err
+			}
+			email, err := vo.NewEmail(msg.Email)
+			if err != nil {
+				return err
+			}
+			return emailSender.SendResetPasswordEmail(ctx, email, msg.Locale, msg.Token)
+		},
+		entity.OutboxTopicEmailChangeConfirmationEmail: func(ctx context.Context, payload []byte) error {
+			var msg entity.EmailChangeConfirmationPayload
+			if err := json.Unmarshal(payload, &msg); err != nil {
+				return err
+			}
+			email, err := vo.NewEmail(msg.Email)
+			if err != nil {
+				return err
+			}
+			return emailSender.SendEmailChangeConfirmation(ctx, email, msg.Locale, msg.Token)
+		},
+		entity.OutboxTopicEmailChangeNoticeEmail: func(ctx context.Context, payload []byte) error {
+			var msg entity.EmailChangeNoticePayload
+			if err := json.Unmarshal(payload, &msg); err != nil {
+				return err
+			}
+			email, err := vo.NewEmail(msg.Email)
+			if err != nil {
+				return err
+			}
+			newEmail, err := vo.NewEmail(msg.NewEmail)
+			if err != nil {
+				return err
+			}
+			return emailSender.SendEmailChangeNotice(ctx, email, msg.Locale, newEmail, msg.CancelToken)
+		},
+	}
+}
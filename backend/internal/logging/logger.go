@@ -0,0 +1,33 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// New cria o logger da aplicação no formato e nível pedidos. Todo atributo
+// passa por ReplaceAttr, que troca segredos por [REDACTED].
+func New(w io.Writer, format, level string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("%w: %q", msgerror.AnErrInvalidLogLevel, level)
+	}
+	opts := &slog.HandlerOptions{Level: lvl, ReplaceAttr: ReplaceAttr}
+
+	switch format {
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	case FormatText:
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", msgerror.AnErrInvalidLogFormat, format)
+	}
+}
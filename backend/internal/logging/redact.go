@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// Redacted substitui o valor dos atributos sensíveis.
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys são comparados com a chave normalizada (minúscula, sem "_",
+// "-" e "."), por substring: reset_token, X-Api-Key e newPassword também
+// casam. Esconder demais é preferível a vazar um segredo.
+var sensitiveKeys = []string{
+	"password", "passwd", "secret", "token", "authorization", "cookie", "apikey", "csrf",
+}
+
+// IsSensitiveKey informa se um atributo ou cabeçalho com esse nome deve ser
+// escondido.
+func IsSensitiveKey(key string) bool {
+	normalized := strings.ToLower(strings.NewReplacer("_", "", "-", "", ".", "").Replace(key))
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(normalized, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReplaceAttr é o slog.HandlerOptions.ReplaceAttr da aplicação: esconde
+// valores de chaves sensíveis, credenciais no formato de Authorization mesmo
+// sob outras chaves e os cabeçalhos sensíveis de um http.Header.
+func ReplaceAttr(_ []string, a slog.Attr) slog.Attr {
+	if IsSensitiveKey(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		if looksLikeCredential(a.Value.String()) {
+			return slog.String(a.Key, Redacted)
+		}
+	case slog.KindAny:
+		if header, ok := a.Value.Any().(http.Header); ok {
+			return slog.Attr{Key: a.Key, Value: Header(header)}
+		}
+	}
+	return a
+}
+
+// Header converte os cabeçalhos em um grupo, com os sensíveis escondidos.
+func Header(header http.Header) slog.Value {
+	attrs := make([]slog.Attr, 0, len(header))
+	for name, values := range header {
+		value := strings.Join(values, ", ")
+		if IsSensitiveKey(name) || looksLikeCredential(value) {
+			value = Redacted
+		}
+		attrs = append(attrs, slog.String(name, value))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// looksLikeCredential reconhece os esquemas aceitos em Authorization.
+func looksLikeCredential(value string) bool {
+	scheme, _, ok := strings.Cut(value, " ")
+	if !ok {
+		return false
+	}
+	switch strings.ToLower(scheme) {
+	case "bearer", "basic", "apikey":
+		return true
+	}
+	return false
+}
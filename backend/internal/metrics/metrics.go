@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+const namespace = "startup_auth_"
+
+// StorageBuckets vão de 0,5ms a 1s, para Redis e banco de dados
+var StorageBuckets = []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1}
+
+// Metrics reúne os instrumentos do serviço, preenchidos pelo middleware HTTP
+// e pelos decorators de casos de uso, providers e repositórios.
+type Metrics struct {
+	Registry *Registry
+
+	HTTPRequestDuration *HistogramVec // method, route, status
+	Logins              *CounterVec   // result, reason
+	Registrations       *CounterVec   // result, reason
+	PasswordResets      *CounterVec   // stage (requested, completed), result, reason
+	TokenValidations    *CounterVec   // result
+	TokenRevocations    *CounterVec   // scope (token, user), result
+	BlacklistDuration   *HistogramVec // operation, result
+	UserRepoDuration    *HistogramVec // operation, result
+}
+
+func New() *Metrics {
+	r := NewRegistry()
+	return &Metrics{
+		Registry: r,
+		HTTPRequestDuration: r.NewHistogramVec(namespace+"http_request_duration_seconds",
+			"HTTP request latency by route template.", nil, "method", "route", "status"),
+		Logins: r.NewCounterVec(namespace+"logins_total",
+			"Login attempts; reason is the error code of failures.", "result", "reason"),
+		Registrations: r.NewCounterVec(namespace+"registrations_total",
+			"Registration attempts; reason is the error code of failures.", "result", "reason"),
+		PasswordResets: r.NewCounterVec(namespace+"password_resets_total",
+			"Password reset requests and completions.", "stage", "result", "reason"),
+		TokenValidations: r.NewCounterVec(namespace+"token_validations_total",
+			"JWT signature and expiry checks.", "result"),
+		TokenRevocations: r.NewCounterVec(namespace+"token_revocations_total",
+			"Revocations of a single token or of every token of a user.", "scope", "result"),
+		BlacklistDuration: r.NewHistogramVec(namespace+"blacklist_operation_duration_seconds",
+			"BlacklistProvider (Redis) latency.", StorageBuckets, "operation", "result"),
+		UserRepoDuration: r.NewHistogramVec(namespace+"user_repository_operation_duration_seconds",
+			"UserRepository (database) latency.", StorageBuckets, "operation", "result"),
+	}
+}
+
+// Result resume o erro em "success" ou "failure".
+func Result(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
+
+// Reason é o código estável do erro; sucessos ficam com "none". Os códigos
+// formam um conjunto fechado, o que mantém a cardinalidade baixa.
+func Reason(err error) string {
+	if err == nil {
+		return "none"
+	}
+	return msgerror.Code(err)
+}
+
+// Since devolve os segundos decorridos, a unidade dos histogramas.
+func Since(start time.Time) float64 {
+	return time.Since(start).Seconds()
+}
@@ -0,0 +1,263 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType é o formato de texto 0.0.4 lido pelo Prometheus
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets vão de 5ms a 10s, como no client oficial
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Registry guarda as métricas e as escreve no formato de exposição do
+// Prometheus. Só há contadores e histogramas, com rótulos fixos na criação.
+type Registry struct {
+	mu      sync.Mutex
+	metrics []metric
+	names   map[string]bool
+}
+
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]bool)}
+}
+
+type metric interface {
+	write(w *bufio.Writer)
+}
+
+func (r *Registry) register(name string, m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.names[name] {
+		panic("metrics: duplicated metric " + name)
+	}
+	r.names[name] = true
+	r.metrics = append(r.metrics, m)
+}
+
+// WriteTo escreve todas as métricas na ordem em que foram criadas.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	metrics := append([]metric(nil), r.metrics...)
+	r.mu.Unlock()
+
+	counter := &countingWriter{w: w}
+	buf := bufio.NewWriter(counter)
+	for _, m := range metrics {
+		m.write(buf)
+	}
+	err := buf.Flush()
+	return counter.n, err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// series agrupa os valores de uma métrica por combinação de rótulos.
+type series[T any] struct {
+	mu     sync.Mutex
+	name   string
+	help   string
+	labels []string
+	values map[string]*T
+	newT   func() *T
+}
+
+func (s *series[T]) get(values []string) *T {
+	value, ok := s.lookup(values)
+	if !ok {
+		key := strings.Join(values, "\xff")
+		value = s.newT()
+		s.values[key] = value
+	}
+	return value
+}
+
+func (s *series[T]) lookup(values []string) (*T, bool) {
+	if len(values) != len(s.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", s.name, len(s.labels), len(values)))
+	}
+	value, ok := s.values[strings.Join(values, "\xff")]
+	return value, ok
+}
+
+// sorted devolve as combinações em ordem, para a saída ser estável.
+func (s *series[T]) sorted() []string {
+	keys := make([]string, 0, len(s.values))
+	for key := range s.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *series[T]) header(w *bufio.Writer, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", s.name, escapeHelp(s.help), s.name, kind)
+}
+
+// labelPairs monta `a="1",b="2"`; extra entra por último (ex.: le do histograma).
+func (s *series[T]) labelPairs(key string, extra ...string) string {
+	var pairs []string
+	if len(s.labels) > 0 {
+		for i, value := range strings.Split(key, "\xff") {
+			pairs = append(pairs, s.labels[i]+`="`+escapeLabel(value)+`"`)
+		}
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+`="`+escapeLabel(extra[i+1])+`"`)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// CounterVec conta eventos por combinação de rótulos.
+type CounterVec struct {
+	series[float64]
+}
+
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{series[float64]{
+		name: name, help: help, labels: labels,
+		values: make(map[string]*float64), newT: func() *float64 { return new(float64) },
+	}}
+	r.register(name, c)
+	return c
+}
+
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add ignora valores negativos: contadores só crescem.
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	*c.get(labelValues) += v
+}
+
+// Value devolve o total da combinação de rótulos.
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if value, ok := c.lookup(labelValues); ok {
+		return *value
+	}
+	return 0
+}
+
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.header(w, "counter")
+	for _, key := range c.sorted() {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, c.labelPairs(key), formatFloat(*c.values[key]))
+	}
+}
+
+// HistogramVec distribui observações (em segundos, por convenção) em buckets.
+type HistogramVec struct {
+	series[histogram]
+	buckets []float64
+}
+
+type histogram struct {
+	counts []uint64 // Não cumulativos; acumulados na escrita
+	count  uint64
+	sum    float64
+}
+
+// NewHistogramVec usa DefaultBuckets quando buckets é nil.
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	h := &HistogramVec{buckets: buckets}
+	h.series = series[histogram]{
+		name: name, help: help, labels: labels,
+		values: make(map[string]*histogram),
+		newT:   func() *histogram { return &histogram{counts: make([]uint64, len(buckets))} },
+	}
+	r.register(name, h)
+	return h
+}
+
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hist := h.get(labelValues)
+	if i := sort.SearchFloat64s(h.buckets, v); i < len(h.buckets) {
+		hist.counts[i]++
+	}
+	hist.count++
+	hist.sum += v
+}
+
+// Count devolve o número de observações da combinação de rótulos.
+func (h *HistogramVec) Count(labelValues ...string) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if hist, ok := h.lookup(labelValues); ok {
+		return hist.count
+	}
+	return 0
+}
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.header(w, "histogram")
+	for _, key := range h.sorted() {
+		hist := h.values[key]
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += hist.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(key, "le", formatFloat(bound)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(key, "le", "+Inf"), hist.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.labelPairs(key), formatFloat(hist.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.labelPairs(key), hist.count)
+	}
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabel(v string) string { return labelEscaper.Replace(v) }
+func escapeHelp(v string) string  { return helpEscaper.Replace(v) }
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireAdmin libera a rota apenas para os usuários listados em adminIDs.
+// Deve ser usado depois de um middleware de autenticação.
+func RequireAdmin(adminIDs map[string]bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !adminIDs[c.GetString("userID")] {
+			abortWithError(c, msgerror.AnErrForbidden)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyAuthMiddleware aceita "Authorization: ApiKey <chave>" e delega
+// qualquer outro esquema ao middleware informado em fallback (ex.: JWT).
+func APIKeyAuthMiddleware(
+	authenticator usecase.AuthenticateAPIKeyInterface,
+	fallback gin.HandlerFunc,
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scheme, rawKey, found := strings.Cut(c.GetHeader("Authorization"), " ")
+		if !found || scheme != "ApiKey" {
+			fallback(c)
+			return
+		}
+
+		principal, err := authenticator.Execute(c.Request.Context(), rawKey)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		setAuthenticatedUser(c, principal.UserID)
+		c.Set("apiKeyID", principal.APIKeyID)
+		c.Set("apiKeyScopes", principal.Scopes)
+		c.Next()
+	}
+}
+
+// RequireScope restringe requisições autenticadas por API key ao escopo informado.
+// Sessões JWT não possuem escopos e têm acesso total.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rawScopes, exists := c.Get("apiKeyScopes")
+		if !exists {
+			c.Next()
+			return
+		}
+
+		scopes, _ := rawScopes.([]string)
+		for _, s := range scopes {
+			if s == scope {
+				c.Next()
+				return
+			}
+		}
+
+		abortWithError(c, fmt.Errorf("%w: %s", msgerror.AnErrInsufficientScope, scope))
+	}
+}
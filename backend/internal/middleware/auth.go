@@ -1,67 +1,77 @@
 package middleware
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
 	"github.com/gin-gonic/gin"
 )
 
+// JWTAuthMiddleware aceita o token no header Authorization ou, quando
+// sessionCookie não é nil, no cookie de sessão HttpOnly.
 func JWTAuthMiddleware(
 	tokenProvider providers.TokenProvider,
-	blacklistProvider providers.BlacklistProvider,
+	tokenStore providers.TokenStore,
+	sessionCookie *SessionCookie,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// 1. Extrair o token do header
+		// 1. Extrair o token do header ou do cookie
 		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.AbortWithStatusJSON(401, gin.H{"error": "Authorization header is required"})
-			return
+		var tokenString string
+		switch {
+		case authHeader != "":
+			// 2. Verificar formato "Bearer <token>"
+			parts := strings.Split(authHeader, " ")
+			if len(parts) != 2 || parts[0] != "Bearer" {
+				abortWithError(c, fmt.Errorf("%w: invalid authorization format", msgerror.AnErrUnauthorized))
+				return
+			}
+			tokenString = parts[1]
+		case sessionCookie != nil:
+			tokenString = sessionCookie.Token(c)
 		}
 
-		// 2. Verificar formato "Bearer <token>"
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.AbortWithStatusJSON(401, gin.H{"error": "Invalid authorization format"})
+		if tokenString == "" {
+			abortWithError(c, fmt.Errorf("%w: authorization header is required", msgerror.AnErrUnauthorized))
 			return
 		}
 
-		tokenString := parts[1]
-
-		// 3. Verificar e o token está na blacklist
-		keyToken := "startup-auth-go:" + tokenString + ":Token"
-		blacklisted, err := blacklistProvider.ExistsKey(c.Request.Context(), keyToken)
+		// 3. Validar token e obter claims
+		rawClaims, err := tokenProvider.Validate(c.Request.Context(), tokenString)
 		if err != nil {
-			c.AbortWithStatusJSON(500, gin.H{"error": "internal server error"})
+			abortWithError(c, fmt.Errorf("%w: %w", msgerror.AnErrUnauthorized, msgerror.AnErrInvalidToken))
 			return
 		}
-		if !blacklisted {
-			c.AbortWithStatusJSON(401, gin.H{"error": "token revoked"})
+
+		// 4. Converter claims para o tipo correto
+		claims, ok := rawClaims.(providers.Claims)
+		if !ok {
+			abortWithError(c, fmt.Errorf("%w: %w", msgerror.AnErrUnauthorized, msgerror.AnErrInvalidToken))
 			return
 		}
 
-		// 4. Validar token e obter claims
-		rawClaims, err := tokenProvider.Validate(tokenString)
+		// 5. Verificar revogação pelo jti
+		active, err := tokenStore.IsActive(c.Request.Context(), claims)
 		if err != nil {
-			c.AbortWithStatusJSON(401, gin.H{"error": "Invalid token", "details": err.Error()})
+			abortWithError(c, err)
 			return
 		}
-
-		// 5. Converter claims para o tipo correto
-		claims, ok := rawClaims.(providers.Claims)
-		if !ok {
-			c.AbortWithStatusJSON(401, gin.H{"error": "Invalid token claims structure"})
+		if !active {
+			abortWithError(c, msgerror.AnErrTokenRevoked)
 			return
 		}
 
 		// 6. Extrair userID das claims
 		userID := claims.UserID
 		if userID == "" {
-			c.AbortWithStatusJSON(401, gin.H{"error": "UserID not found in token"})
+			abortWithError(c, fmt.Errorf("%w: %w", msgerror.AnErrUnauthorized, msgerror.AnErrInvalidToken))
 			return
 		}
 
-		c.Set("userID", userID)
+		setAuthenticatedUser(c, userID)
+		c.Set("token", tokenString)
 		c.Next()
 	}
 }
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientAuthMiddleware autentica clientes confidenciais (RFC 6749, seção 2.3.1)
+// via HTTP Basic ou pelos parâmetros client_id/client_secret do formulário.
+// Como protege só endpoints OAuth, o erro segue o RFC 6749 e não problem+json.
+func ClientAuthMiddleware(clients map[string]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clientID, clientSecret, ok := c.Request.BasicAuth()
+		if !ok {
+			clientID = c.PostForm("client_id")
+			clientSecret = c.PostForm("client_secret")
+		}
+
+		expected, known := clients[clientID]
+		if clientID == "" || !known ||
+			subtle.ConstantTimeCompare([]byte(clientSecret), []byte(expected)) != 1 {
+			c.Header("WWW-Authenticate", `Basic realm="startup-auth-go"`)
+			c.AbortWithStatusJSON(401, gin.H{"error": "invalid_client"})
+			return
+		}
+
+		c.Set("clientID", clientID)
+		c.Next()
+	}
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+const localeKey = "locale"
+
+// Localizer descobre o idioma da requisição: o idioma do perfil do usuário
+// autenticado, depois o Accept-Language e por fim o padrão do tradutor.
+// O perfil só é consultado quando há algo a traduzir.
+type Localizer struct {
+	translator *i18n.Translator
+	users      repository.UserRepository
+}
+
+// users pode ser nil; nesse caso só o Accept-Language é considerado.
+func NewLocalizer(translator *i18n.Translator, users repository.UserRepository) *Localizer {
+	return &Localizer{translator: translator, users: users}
+}
+
+// Locale negocia o idioma uma única vez por requisição.
+func (l *Localizer) Locale(c *gin.Context) string {
+	if locale := c.GetString(localeKey); locale != "" {
+		return locale
+	}
+
+	locale := l.translator.Match(l.profileLocale(c), c.GetHeader("Accept-Language"))
+	c.Set(localeKey, locale)
+	return locale
+}
+
+// Message traduz o código no idioma da requisição.
+func (l *Localizer) Message(c *gin.Context, code string, params map[string]string) (string, bool) {
+	return l.translator.Message(l.Locale(c), code, params)
+}
+
+// profileLocale ignora falhas: sem perfil, vale o Accept-Language.
+func (l *Localizer) profileLocale(c *gin.Context) string {
+	userID := c.GetString("userID")
+	if l.users == nil || userID == "" {
+		return ""
+	}
+
+	id, err := vo.ParseID(userID)
+	if err != nil {
+		return ""
+	}
+	user, err := l.users.GetByID(c.Request.Context(), id)
+	if err != nil || user == nil {
+		return ""
+	}
+	return user.Locale.String()
+}
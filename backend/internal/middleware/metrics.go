@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsMiddleware mede a duração de cada requisição pelo modelo da rota
+// (ex.: /user/name/:userID), que mantém a cardinalidade baixa; caminhos sem
+// rota ficam como "unmatched". Deve vir antes do ProblemMiddleware para ver
+// o status final das respostas de erro.
+func MetricsMiddleware(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		m.HTTPRequestDuration.Observe(metrics.Since(start),
+			c.Request.Method, route, strconv.Itoa(c.Writer.Status()))
+	}
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/eskokado/startup-auth-go/backend/internal/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+// RequestValidationMiddleware confere corpo JSON e query com o documento
+// OpenAPI antes do handler e responde 400 com os erros por campo. Rotas fora
+// do documento passam direto.
+func RequestValidationMiddleware(spec *openapi.Spec) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := spec.ValidateRequest(c.Request.Method, c.FullPath(), c.Request); err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"sort"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/logging"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+	// ProblemTypePrefix + código forma o "type" de cada problema
+	ProblemTypePrefix = "urn:startup-auth:problem:"
+)
+
+// problemStatus lista os códigos que não são 400; todo código conhecido
+// ausente daqui é um erro de validação do cliente.
+var problemStatus = map[string]int{
+	msgerror.CodeInternal: http.StatusInternalServerError,
+	"unauthorized":        http.StatusUnauthorized,
+	"token_revoked":       http.StatusUnauthorized,
+	"invalid_credentials": http.StatusUnauthorized,
+	"invalid_api_key":     http.StatusUnauthorized,
+	"forbidden":           http.StatusForbidden,
+	"insufficient_scope":  http.StatusForbidden,
+	"invalid_csrf_token":  http.StatusForbidden,
+	"invalid_signature":   http.StatusForbidden,
+	"user_not_found":      http.StatusNotFound,
+	"api_key_not_found":   http.StatusNotFound,
+	"export_not_found":    http.StatusNotFound,
+	"outbox_not_found":    http.StatusNotFound,
+	"avatar_not_found":    http.StatusNotFound,
+	"docs_not_found":      http.StatusNotFound,
+	"user_exists":         http.StatusConflict,
+	"conflict":            http.StatusConflict,
+	"outbox_not_dead":     http.StatusConflict,
+	"image_too_large":     http.StatusRequestEntityTooLarge,
+	"unsupported_image":   http.StatusUnsupportedMediaType,
+	"rate_limited":        http.StatusTooManyRequests,
+
+	"human_verification_required": http.StatusForbidden,
+	"human_verification_failed":   http.StatusForbidden,
+}
+
+// ProblemMiddleware traduz o último erro registrado com c.Error em uma
+// resposta application/problem+json. Handlers e middlewares só registram o
+// erro e retornam; quem já escreveu a resposta não é sobrescrito. Com um
+// localizer, os detalhes saem no idioma da requisição; sem ele, em inglês.
+func ProblemMiddleware(localizer *Localizer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		writeProblem(c, c.Errors.Last().Err, localizer)
+	}
+}
+
+// abortWithError interrompe a cadeia e deixa a resposta para o ProblemMiddleware.
+func abortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// writeProblem responde imediatamente com o problema correspondente a err.
+func writeProblem(c *gin.Context, err error, localizer *Localizer) {
+	problem := NewProblem(err, c.Request.URL.Path)
+	if problem.Status == http.StatusInternalServerError {
+		// O detalhe fica só no log para não vazar erros internos
+		logging.FromContext(c.Request.Context()).Error("request failed",
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.Any("error", err),
+		)
+	}
+
+	if localizer != nil {
+		localizeProblem(c, localizer, &problem, err)
+		c.Header("Content-Language", localizer.Locale(c))
+	}
+
+	c.Header("Content-Type", ProblemContentType)
+	c.AbortWithStatusJSON(problem.Status, problem)
+}
+
+// localizeProblem troca os detalhes pelas mensagens do catálogo; o que não
+// tem tradução (ex.: campos registrados só com Add) mantém o texto original.
+func localizeProblem(c *gin.Context, localizer *Localizer, problem *dto.Problem, err error) {
+	if message, ok := localizer.Message(c, problem.Code, msgerror.Params(err)); ok {
+		problem.Detail = message
+	}
+
+	var valErr *msgerror.ValidationErrors
+	if !errors.As(err, &valErr) {
+		return
+	}
+	for i, field := range problem.Errors {
+		if field.Code == msgerror.CodeInvalid {
+			continue
+		}
+		if message, ok := localizer.Message(c, field.Code, valErr.FieldParams[field.Field]); ok {
+			problem.Errors[i].Detail = message
+		}
+	}
+}
+
+// NewProblem monta o problema a partir do código estável do erro.
+func NewProblem(err error, instance string) dto.Problem {
+	code := msgerror.Code(err)
+	status, ok := problemStatus[code]
+	if !ok {
+		status = http.StatusBadRequest
+	}
+
+	problem := dto.Problem{
+		Type:     ProblemTypePrefix + code,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   err.Error(),
+		Instance: instance,
+		Code:     code,
+	}
+	if status == http.StatusInternalServerError {
+		problem.Detail = "internal server error"
+	}
+
+	var valErr *msgerror.ValidationErrors
+	if errors.As(err, &valErr) {
+		problem.Errors = fieldProblems(valErr)
+	}
+	return problem
+}
+
+// fieldProblems ordena os campos para a resposta ser determinística.
+func fieldProblems(valErr *msgerror.ValidationErrors) []dto.FieldProblem {
+	fields := make([]string, 0, len(valErr.FieldErrors))
+	for field := range valErr.FieldErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	problems := make([]dto.FieldProblem, 0, len(fields))
+	for _, field := range fields {
+		problems = append(problems, dto.FieldProblem{
+			Field:  field,
+			Code:   valErr.FieldCode(field),
+			Detail: valErr.FieldErrors[field],
+		})
+	}
+	return problems
+}
@@ -0,0 +1,216 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/logging"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+// Chaves que separam as cotas de uma regra
+const (
+	RateLimitByIP    = "ip"
+	RateLimitByUser  = "user"
+	RateLimitByEmail = "email"
+	RateLimitByRoute = "route" // Uma cota para todos os clientes
+)
+
+// Guarda as regras por usuário até a autenticação identificar quem chama
+const rateLimitUserKey = "rateLimitUser"
+
+// Corpos maiores que isso não têm o e-mail lido; a regra usa o IP
+const maxRateLimitBody = 64 << 10
+
+// RateLimitRule limita uma rota (método e caminho no formato do gin).
+type RateLimitRule struct {
+	Method string
+	Path   string
+	Key    string
+	Limit  providers.RateLimit
+}
+
+// policy segue o cabeçalho RateLimit-Policy: "5;w=900".
+func (r RateLimitRule) policy() string {
+	return fmt.Sprintf("%d;w=%d", r.Limit.Limit, int(r.Limit.Window.Seconds()))
+}
+
+// rateLimitCheck é o resultado de uma regra aplicada à requisição.
+type rateLimitCheck struct {
+	rule   RateLimitRule
+	result providers.RateLimitResult
+}
+
+// RateLimitMiddleware aplica as regras da rota e responde 429 com Retry-After
+// quando alguma cota acaba. Regras por usuário só valem depois da
+// autenticação; sem usuário autenticado a regra é ignorada. Chamadas da
+// allowlist passam direto e falhas do backend não bloqueiam a requisição.
+func RateLimitMiddleware(limiter providers.RateLimiter, rules []RateLimitRule, allowlist []netip.Prefix) gin.HandlerFunc {
+	byRoute := make(map[string][]RateLimitRule)
+	for _, rule := range rules {
+		route := rule.Method + " " + rule.Path
+		byRoute[route] = append(byRoute[route], rule)
+	}
+
+	return func(c *gin.Context) {
+		routeRules := byRoute[c.Request.Method+" "+c.FullPath()]
+		if len(routeRules) == 0 || allowlisted(c.ClientIP(), allowlist) {
+			c.Next()
+			return
+		}
+
+		var now, later []RateLimitRule
+		for _, rule := range routeRules {
+			if rule.Key == RateLimitByUser {
+				later = append(later, rule)
+			} else {
+				now = append(now, rule)
+			}
+		}
+
+		checks := make([]rateLimitCheck, 0, len(routeRules))
+		if !applyRateLimits(c, limiter, now, &checks) {
+			return
+		}
+		if len(later) > 0 {
+			c.Set(rateLimitUserKey, func(c *gin.Context) {
+				applyRateLimits(c, limiter, later, &checks)
+			})
+		}
+		c.Next()
+	}
+}
+
+// applyUserRateLimits é chamado por setAuthenticatedUser; se alguma cota do
+// usuário acabou, a requisição é interrompida.
+func applyUserRateLimits(c *gin.Context) {
+	value, _ := c.Get(rateLimitUserKey)
+	if apply, ok := value.(func(*gin.Context)); ok {
+		c.Set(rateLimitUserKey, nil)
+		apply(c)
+	}
+}
+
+// applyRateLimits consome uma unidade de cada regra, atualiza os cabeçalhos
+// e devolve false quando a requisição foi recusada.
+func applyRateLimits(c *gin.Context, limiter providers.RateLimiter, rules []RateLimitRule, checks *[]rateLimitCheck) bool {
+	for _, rule := range rules {
+		key, ok := rateLimitKey(c, rule)
+		if !ok {
+			continue
+		}
+		result, err := limiter.Allow(c.Request.Context(), key, rule.Limit)
+		if err != nil {
+			// Falha no backend não bloqueia a requisição
+			logging.FromContext(c.Request.Context()).Warn("rate limit check failed",
+				slog.String("rule", rule.Method+" "+rule.Path),
+				slog.Any("error", err),
+			)
+			continue
+		}
+		*checks = append(*checks, rateLimitCheck{rule: rule, result: result})
+	}
+	if len(*checks) == 0 {
+		return true
+	}
+
+	// Os cabeçalhos mostram a cota mais próxima de acabar
+	tightest := (*checks)[0]
+	policies := make([]string, 0, len(*checks))
+	for _, check := range *checks {
+		policies = append(policies, check.rule.policy())
+		denied := !check.result.Allowed && tightest.result.Allowed
+		lower := check.result.Allowed == tightest.result.Allowed && check.result.Remaining < tightest.result.Remaining
+		if denied || lower {
+			tightest = check
+		}
+	}
+	c.Header("RateLimit-Limit", strconv.Itoa(tightest.rule.Limit.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(tightest.result.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(tightest.result.ResetAfter)))
+	c.Header("RateLimit-Policy", strings.Join(policies, ", "))
+
+	var retryAfter time.Duration
+	for _, check := range *checks {
+		if !check.result.Allowed && check.result.RetryAfter > retryAfter {
+			retryAfter = check.result.RetryAfter
+		}
+	}
+	for _, check := range *checks {
+		if !check.result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(max(1, ceilSeconds(retryAfter))))
+			abortWithError(c, msgerror.AnErrRateLimited)
+			return false
+		}
+	}
+	return true
+}
+
+// rateLimitKey separa a cota pela regra e pelo valor da chave; e-mails ficam
+// só como hash e, sem e-mail no corpo, a regra vale para o IP.
+func rateLimitKey(c *gin.Context, rule RateLimitRule) (string, bool) {
+	prefix := fmt.Sprintf("%s %s %s:", rule.Method, rule.Path, rule.policy())
+	switch rule.Key {
+	case RateLimitByRoute:
+		return prefix + "route", true
+	case RateLimitByUser:
+		userID := c.GetString("userID")
+		return prefix + "user:" + userID, userID != ""
+	case RateLimitByEmail:
+		if email := requestEmail(c); email != "" {
+			sum := sha256.Sum256([]byte(email))
+			return prefix + "email:" + hex.EncodeToString(sum[:]), true
+		}
+	}
+	return prefix + "ip:" + c.ClientIP(), true
+}
+
+// requestEmail lê o campo "email" do corpo JSON e devolve o corpo à requisição.
+func requestEmail(c *gin.Context) string {
+	if c.Request.Body == nil {
+		return ""
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxRateLimitBody+1))
+	c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+	if err != nil || len(body) > maxRateLimitBody {
+		return ""
+	}
+
+	var input struct {
+		Email string `json:"email"`
+	}
+	if json.Unmarshal(body, &input) != nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(input.Email))
+}
+
+func allowlisted(ip string, allowlist []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range allowlist {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
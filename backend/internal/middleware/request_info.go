@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"log/slog"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/logging"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/gin-gonic/gin"
+)
+
+// RequestInfoMiddleware disponibiliza IP e user agent no contexto da requisição
+// para que os casos de uso possam registrá-los na auditoria.
+func RequestInfoMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := providers.WithRequestInfo(c.Request.Context(), providers.RequestInfo{
+			IP:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// setAuthenticatedUser expõe o usuário autenticado ao gin e ao contexto da
+// requisição e aplica as cotas por usuário; se alguma acabou, a cadeia é
+// interrompida e o c.Next seguinte não chama o handler.
+func setAuthenticatedUser(c *gin.Context, userID string) {
+	c.Set("userID", userID)
+	ctx := providers.WithActorID(c.Request.Context(), userID)
+	// Os registros seguintes da requisição já saem com o usuário
+	ctx = logging.WithLogger(ctx, logging.FromContext(ctx).With(slog.String("user_id", userID)))
+	c.Request = c.Request.WithContext(ctx)
+	applyUserRateLimits(c)
+}
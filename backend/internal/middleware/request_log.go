@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/logging"
+	"github.com/eskokado/startup-auth-go/backend/internal/tracing"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength limita o X-Request-ID aceito do cliente
+const maxRequestIDLength = 128
+
+// RequestLogMiddleware substitui o logger de texto do gin: aceita o
+// X-Request-ID recebido (ou gera um), devolve-o na resposta e deixa no
+// contexto um logger com request_id e trace_id, usado pelos casos de uso e
+// providers. Ao fim registra uma linha por requisição, sem query string nem
+// cabeçalhos. Deve vir depois do TracingMiddleware e antes do
+// ProblemMiddleware.
+func RequestLogMiddleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = vo.NewID().String()
+		}
+		c.Header(RequestIDHeader, requestID)
+		c.Set("requestID", requestID)
+
+		ctx := c.Request.Context()
+		requestLogger := logger.With("request_id", requestID)
+		if sc := tracing.SpanContextFromContext(ctx); sc.IsValid() {
+			requestLogger = requestLogger.With("trace_id", sc.TraceID.String(), "span_id", sc.SpanID.String())
+		}
+		ctx = logging.WithRequestID(ctx, requestID)
+		c.Request = c.Request.WithContext(logging.WithLogger(ctx, requestLogger))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []any{
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int("bytes", c.Writer.Size()),
+			slog.String("client_ip", c.ClientIP()),
+			slog.String("user_agent", c.Request.UserAgent()),
+		}
+		if userID := c.GetString("userID"); userID != "" {
+			attrs = append(attrs, slog.String("user_id", userID))
+		}
+		if err := c.Errors.Last(); err != nil {
+			attrs = append(attrs, slog.String("error_code", msgerror.Code(err.Err)))
+		}
+		requestLogger.Log(c.Request.Context(), requestLevel(status), "request", attrs...)
+	}
+}
+
+// RecoveryMiddleware troca o panic por um problem+json 500 e o registra no
+// logger da requisição, com a pilha. Vem logo depois do RequestLogMiddleware,
+// que então registra a requisição com status 500.
+func RecoveryMiddleware() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered any) {
+		logging.FromContext(c.Request.Context()).Error("panic recovered",
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.Any("panic", recovered),
+			slog.String("stack", string(debug.Stack())),
+		)
+		err := fmt.Errorf("panic: %v", recovered)
+		_ = c.Error(err)
+		c.Header("Content-Type", ProblemContentType)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, NewProblem(err, c.Request.URL.Path))
+	})
+}
+
+func requestLevel(status int) slog.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return slog.LevelError
+	case status >= http.StatusBadRequest:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// validRequestID aceita só caracteres que não quebram logs nem cabeçalhos.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	SessionCookieName = "startup_auth_session"
+	CSRFCookieName    = "startup_auth_csrf"
+	CSRFHeaderName    = "X-CSRF-Token"
+)
+
+type SessionCookieConfig struct {
+	Domain     string
+	Path       string
+	Secure     bool
+	SameSite   string
+	MaxAge     time.Duration
+	CSRFSecret string
+}
+
+// SessionCookie guarda o JWT em um cookie HttpOnly e emite um token CSRF
+// derivado da sessão (double-submit assinado com HMAC).
+type SessionCookie struct {
+	domain     string
+	path       string
+	secure     bool
+	sameSite   http.SameSite
+	maxAge     time.Duration
+	csrfSecret []byte
+}
+
+func NewSessionCookie(cfg SessionCookieConfig) *SessionCookie {
+	path := cfg.Path
+	if path == "" {
+		path = "/"
+	}
+
+	return &SessionCookie{
+		domain:     cfg.Domain,
+		path:       path,
+		secure:     cfg.Secure,
+		sameSite:   parseSameSite(cfg.SameSite),
+		maxAge:     cfg.MaxAge,
+		csrfSecret: []byte(cfg.CSRFSecret),
+	}
+}
+
+// Set grava o cookie de sessão e o cookie CSRF (legível pelo JS) e devolve o token CSRF.
+func (s *SessionCookie) Set(c *gin.Context, token string) string {
+	csrfToken := s.CSRFToken(token)
+
+	http.SetCookie(c.Writer, s.cookie(SessionCookieName, token, true, int(s.maxAge.Seconds())))
+	http.SetCookie(c.Writer, s.cookie(CSRFCookieName, csrfToken, false, int(s.maxAge.Seconds())))
+
+	return csrfToken
+}
+
+func (s *SessionCookie) Clear(c *gin.Context) {
+	http.SetCookie(c.Writer, s.cookie(SessionCookieName, "", true, -1))
+	http.SetCookie(c.Writer, s.cookie(CSRFCookieName, "", false, -1))
+}
+
+// Token retorna o JWT armazenado no cookie de sessão, se houver.
+func (s *SessionCookie) Token(c *gin.Context) string {
+	token, err := c.Cookie(SessionCookieName)
+	if err != nil {
+		return ""
+	}
+	return token
+}
+
+func (s *SessionCookie) CSRFToken(sessionToken string) string {
+	mac := hmac.New(sha256.New, s.csrfSecret)
+	mac.Write([]byte(sessionToken))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *SessionCookie) ValidCSRFToken(sessionToken, csrfToken string) bool {
+	if sessionToken == "" || csrfToken == "" {
+		return false
+	}
+	expected := s.CSRFToken(sessionToken)
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(csrfToken)) == 1
+}
+
+func (s *SessionCookie) cookie(name, value string, httpOnly bool, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     s.path,
+		Domain:   s.domain,
+		MaxAge:   maxAge,
+		Secure:   s.secure,
+		HttpOnly: httpOnly,
+		SameSite: s.sameSite,
+	}
+}
+
+func parseSameSite(value string) http.SameSite {
+	switch strings.ToLower(value) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
+
+// CSRFMiddleware exige o cabeçalho X-CSRF-Token em requisições que alteram
+// estado e que são autenticadas pelo cookie de sessão.
+func CSRFMiddleware(sessionCookie *SessionCookie) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		// Requisições com Authorization explícito não dependem do cookie
+		sessionToken := sessionCookie.Token(c)
+		if sessionToken == "" || c.GetHeader("Authorization") != "" {
+			c.Next()
+			return
+		}
+
+		if !sessionCookie.ValidCSRFToken(sessionToken, c.GetHeader(CSRFHeaderName)) {
+			abortWithError(c, msgerror.AnErrInvalidCSRFToken)
+			return
+		}
+
+		c.Next()
+	}
+}
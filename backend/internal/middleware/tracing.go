@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/tracing"
+	"github.com/gin-gonic/gin"
+)
+
+// TracingMiddleware abre o span de servidor da requisição, filho do
+// traceparent recebido quando houver, e o deixa no contexto: casos de uso,
+// repositórios e providers decorados abrem spans filhos dele. Deve vir antes
+// do ProblemMiddleware para ver o status final das respostas de erro.
+func TracingMiddleware(tracer *tracing.Tracer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		if remote, ok := tracing.Extract(c.Request.Header); ok {
+			ctx = tracing.ContextWithRemoteSpanContext(ctx, remote)
+		}
+
+		// O modelo da rota (ex.: /user/name/:userID) mantém os nomes agrupáveis
+		name := c.Request.Method
+		if route := c.FullPath(); route != "" {
+			name += " " + route
+		}
+		ctx, span := tracer.Start(ctx, name, tracing.KindServer,
+			tracing.String("http.request.method", c.Request.Method),
+			tracing.String("http.route", c.FullPath()),
+			tracing.String("url.path", c.Request.URL.Path),
+			tracing.String("client.address", c.ClientIP()),
+			tracing.String("user_agent.original", c.Request.UserAgent()),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(tracing.Int("http.response.status_code", status))
+		if userID := c.GetString("userID"); userID != "" {
+			span.SetAttributes(tracing.String("enduser.id", userID))
+		}
+		// Como na convenção do OTel, só 5xx marcam o span de servidor como erro
+		if status >= http.StatusInternalServerError {
+			if err := c.Errors.Last(); err != nil {
+				span.SetError(err.Err)
+			} else {
+				span.SetError(errStatus(status))
+			}
+		}
+	}
+}
+
+type errStatus int
+
+func (e errStatus) Error() string { return http.StatusText(int(e)) }
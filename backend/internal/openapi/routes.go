@@ -0,0 +1,389 @@
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+)
+
+// auth descreve como a rota se autentica; cada valor vira um requisito de
+// segurança do documento.
+type auth int
+
+const (
+	authNone   auth = iota
+	authJWT         // JWTAuthMiddleware: Bearer ou cookie de sessão
+	authUser        // APIKeyAuthMiddleware: também aceita "ApiKey <chave>"
+	authClient      // ClientAuthMiddleware: cliente OAuth confidencial
+)
+
+// Tipos de conteúdo além do JSON.
+const (
+	contentJSON      = "application/json"
+	contentForm      = "application/x-www-form-urlencoded"
+	contentMultipart = "multipart/form-data"
+	contentBinary    = "application/octet-stream"
+	contentHTML      = "text/html"
+	contentText      = "text/plain"
+)
+
+// Rotas públicas protegidas por HumanVerifier
+const humanProofDescription = "May answer 403 human_verification_required: get a challenge from GET /auth/human-challenge and resend with the answer in the X-Human-Proof header."
+
+// Route é uma rota registrada em cmd/server; o teste de drift compara esta
+// tabela com o main.go.
+type Route struct {
+	Method      string
+	Path        string // No formato do gin, ex.: /user/api-keys/:id
+	Summary     string
+	Description string
+	Tag         string
+	Auth        auth
+	Body        any    // DTO do corpo
+	BodyType    string // Padrão: application/json
+	Query       any    // DTO com tags form
+	Responses   []Reply
+	Errors      []int // Status de erro documentados além do "default"
+}
+
+// Reply é uma resposta de sucesso; Body nil indica corpo vazio.
+type Reply struct {
+	Status      int
+	Description string
+	Body        any
+	ContentType string // Padrão: application/json
+}
+
+// Message é o corpo das respostas 202 que só informam o andamento.
+type Message struct {
+	Message string `json:"message"`
+}
+
+// OAuthError é o corpo de erro do RFC 6749 usado pelos endpoints /oauth.
+type OAuthError struct {
+	Error string `json:"error"`
+}
+
+// OAuthTokenForm é o formulário de introspecção (RFC 7662) e revogação (RFC 7009).
+type OAuthTokenForm struct {
+	Token         string `json:"token" binding:"required"`
+	TokenTypeHint string `json:"token_type_hint"`
+}
+
+// AvatarUpload é o formulário multipart de PUT /user/avatar.
+type AvatarUpload struct {
+	Avatar []byte `json:"avatar" binding:"required"`
+}
+
+// MailboxQuery filtra a caixa de desenvolvimento pelo destinatário.
+type MailboxQuery struct {
+	To string `form:"to"`
+}
+
+// Routes lista todas as rotas da API, na ordem de cmd/server.
+func Routes() []Route {
+	return []Route{
+		{
+			Method: http.MethodPost, Path: "/auth/register", Tag: "auth",
+			Summary:     "Register a new user",
+			Description: humanProofDescription,
+			Body:        dto.RegisterUserInput{},
+			Responses: []Reply{
+				{Status: http.StatusCreated, Description: "User created", Body: dto.RegisterUserOutput{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusConflict},
+		},
+		{
+			Method: http.MethodPost, Path: "/auth/login", Tag: "auth",
+			Summary:     "Log in with email and password",
+			Description: "In cookie mode the token goes to an HttpOnly cookie and the body carries csrf_token instead of access_token.",
+			Body:        dto.LoginInput{},
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Logged in", Body: dto.LoginOutput{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnauthorized},
+		},
+		{
+			Method: http.MethodDelete, Path: "/auth/logout", Tag: "auth", Auth: authJWT,
+			Summary: "Revoke the current token",
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Logged out"},
+			},
+			Errors: []int{http.StatusUnauthorized},
+		},
+		{
+			Method: http.MethodPost, Path: "/auth/forgot-password", Tag: "auth",
+			Summary:     "Send a password reset link",
+			Description: "Answers 204 even when the email is not registered. " + humanProofDescription,
+			Body:        dto.ForgotPasswordInput{},
+			Responses: []Reply{
+				{Status: http.StatusNoContent, Description: "Link sent if the user exists"},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusForbidden},
+		},
+		{
+			Method: http.MethodGet, Path: "/auth/human-challenge", Tag: "auth",
+			Summary:     "Get a human verification challenge",
+			Description: "Only registered when HUMAN_VERIFIER is not none. For type pow, find a solution such that sha256(\"<challenge>:<solution>\") starts with difficulty zero bits and send \"<challenge>:<solution>\"; for captchas, render the widget with site_key and send its token.",
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Challenge", Body: dto.HumanChallengeOutput{}},
+			},
+		},
+		{
+			Method: http.MethodPost, Path: "/auth/reset-password", Tag: "auth",
+			Summary: "Reset the password with a reset token",
+			Body:    dto.ResetPasswordInput{},
+			Responses: []Reply{
+				{Status: http.StatusNoContent, Description: "Password changed"},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusConflict},
+		},
+		{
+			Method: http.MethodPost, Path: "/auth/restore", Tag: "account",
+			Summary: "Restore an account within the deletion grace period",
+			Body:    dto.RestoreAccountInput{},
+			Responses: []Reply{
+				{Status: http.StatusNoContent, Description: "Account restored"},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnauthorized},
+		},
+		{
+			Method: http.MethodPost, Path: "/auth/email-change/confirm", Tag: "account",
+			Summary: "Confirm an email change",
+			Body:    dto.EmailChangeTokenInput{},
+			Responses: []Reply{
+				{Status: http.StatusNoContent, Description: "Email changed"},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusConflict},
+		},
+		{
+			Method: http.MethodPost, Path: "/auth/email-change/cancel", Tag: "account",
+			Summary: "Cancel a pending email change",
+			Body:    dto.EmailChangeTokenInput{},
+			Responses: []Reply{
+				{Status: http.StatusNoContent, Description: "Email change cancelled"},
+			},
+			Errors: []int{http.StatusBadRequest},
+		},
+		{
+			Method: http.MethodPut, Path: "/user/name/:userID", Tag: "user", Auth: authUser,
+			Summary:     "Change the user name",
+			Description: "API keys need the user:write scope.",
+			Body:        dto.UpdateNameInput{},
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Name changed"},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound},
+		},
+		{
+			Method: http.MethodPut, Path: "/user/password/:userID", Tag: "user", Auth: authJWT,
+			Summary: "Change the password",
+			Body:    dto.UpdatePasswordInput{},
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Password changed"},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden},
+		},
+		{
+			Method: http.MethodPut, Path: "/user/email", Tag: "account", Auth: authJWT,
+			Summary: "Request an email change",
+			Body:    dto.ChangeEmailInput{},
+			Responses: []Reply{
+				{Status: http.StatusAccepted, Description: "Confirmation sent to the new email", Body: Message{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusConflict},
+		},
+		{
+			Method: http.MethodPut, Path: "/user/avatar", Tag: "user", Auth: authJWT,
+			Summary:  "Upload the avatar",
+			Body:     AvatarUpload{},
+			BodyType: contentMultipart,
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Avatar stored", Body: dto.UploadAvatarOutput{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType},
+		},
+		{
+			Method: http.MethodGet, Path: "/avatars/:id", Tag: "user",
+			Summary: "Download an avatar",
+			Query:   dto.AvatarQuery{},
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Avatar image", ContentType: "image/*"},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+		},
+		{
+			Method: http.MethodGet, Path: "/user/me", Tag: "user", Auth: authUser,
+			Summary:     "Get the profile",
+			Description: "API keys need the user:read scope.",
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Profile", Body: dto.UserOutput{}},
+			},
+			Errors: []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound},
+		},
+		{
+			Method: http.MethodPatch, Path: "/user/me", Tag: "user", Auth: authUser,
+			Summary:     "Update the profile",
+			Description: "Merge patch: absent fields are kept and null clears a field or an attribute. API keys need the user:write scope.",
+			Body:        dto.UpdateProfileInput{},
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Updated profile", Body: dto.UserOutput{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusConflict},
+		},
+		{
+			Method: http.MethodDelete, Path: "/user/me", Tag: "account", Auth: authJWT,
+			Summary: "Deactivate the account",
+			Body:    dto.DeleteAccountInput{},
+			Responses: []Reply{
+				{Status: http.StatusAccepted, Description: "Account deactivated until purge_after", Body: dto.DeleteAccountOutput{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnauthorized},
+		},
+		{
+			Method: http.MethodGet, Path: "/user/me/export", Tag: "account", Auth: authJWT,
+			Summary:     "Export the user data",
+			Description: "Large exports are sent by email and answer 202.",
+			Query:       dto.ExportInput{},
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Export file (JSON or ZIP)", Body: dto.UserDataExport{}},
+				{Status: http.StatusAccepted, Description: "Export will be sent by email", Body: Message{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnauthorized},
+		},
+		{
+			Method: http.MethodGet, Path: "/exports/:file", Tag: "account",
+			Summary: "Download an export through a signed link",
+			Query:   dto.ExportDownloadInput{},
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Export file", ContentType: contentBinary},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound},
+		},
+		{
+			Method: http.MethodPost, Path: "/user/api-keys", Tag: "api-keys", Auth: authJWT,
+			Summary:     "Create an API key",
+			Description: "The key is only returned here.",
+			Body:        dto.CreateAPIKeyInput{},
+			Responses: []Reply{
+				{Status: http.StatusCreated, Description: "API key created", Body: dto.CreateAPIKeyOutput{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnauthorized},
+		},
+		{
+			Method: http.MethodGet, Path: "/user/api-keys", Tag: "api-keys", Auth: authJWT,
+			Summary: "List the API keys",
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "API keys", Body: []dto.APIKeyOutput{}},
+			},
+			Errors: []int{http.StatusUnauthorized},
+		},
+		{
+			Method: http.MethodDelete, Path: "/user/api-keys/:id", Tag: "api-keys", Auth: authJWT,
+			Summary: "Revoke an API key",
+			Responses: []Reply{
+				{Status: http.StatusNoContent, Description: "API key revoked"},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound},
+		},
+		{
+			Method: http.MethodPost, Path: "/oauth/introspect", Tag: "oauth", Auth: authClient,
+			Summary:     "Introspect a token (RFC 7662)",
+			Description: "Errors follow RFC 6749 instead of problem+json.",
+			Body:        OAuthTokenForm{},
+			BodyType:    contentForm,
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Token state", Body: dto.IntrospectOutput{}},
+			},
+		},
+		{
+			Method: http.MethodPost, Path: "/oauth/revoke", Tag: "oauth", Auth: authClient,
+			Summary:     "Revoke a token (RFC 7009)",
+			Description: "Unknown or already revoked tokens also answer 200. Errors follow RFC 6749 instead of problem+json.",
+			Body:        OAuthTokenForm{},
+			BodyType:    contentForm,
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Token revoked"},
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/admin/audit", Tag: "admin", Auth: authJWT,
+			Summary:     "List audit events",
+			Description: "Admins only (ADMIN_USER_IDS).",
+			Query:       dto.AuditQueryInput{},
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Audit events", Body: []dto.AuditEventOutput{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden},
+		},
+		{
+			Method: http.MethodGet, Path: "/admin/outbox", Tag: "admin", Auth: authJWT,
+			Summary:     "List outbox messages",
+			Description: "Admins only (ADMIN_USER_IDS).",
+			Query:       dto.OutboxQueryInput{},
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Outbox messages", Body: []dto.OutboxMessageOutput{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden},
+		},
+		{
+			Method: http.MethodPost, Path: "/admin/outbox/:id/replay", Tag: "admin", Auth: authJWT,
+			Summary:     "Replay a dead outbox message",
+			Description: "Admins only (ADMIN_USER_IDS).",
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Message queued again", Body: dto.OutboxMessageOutput{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict},
+		},
+		{
+			Method: http.MethodGet, Path: "/dev/mailbox", Tag: "dev",
+			Summary:     "List captured emails",
+			Description: "Only registered with EMAIL_TRANSPORT=mailbox.",
+			Query:       MailboxQuery{},
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Captured emails", Body: []dto.MailboxMessage{}},
+			},
+		},
+		{
+			Method: http.MethodDelete, Path: "/dev/mailbox", Tag: "dev",
+			Summary:     "Clear captured emails",
+			Description: "Only registered with EMAIL_TRANSPORT=mailbox.",
+			Responses: []Reply{
+				{Status: http.StatusNoContent, Description: "Mailbox cleared"},
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/openapi.json", Tag: "docs",
+			Summary: "This document",
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "OpenAPI 3.1 document"},
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/docs", Tag: "docs",
+			Summary: "Swagger UI",
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Swagger UI page", ContentType: contentHTML},
+			},
+			Errors: []int{http.StatusNotFound},
+		},
+		{
+			Method: http.MethodGet, Path: "/docs/assets/:file", Tag: "docs",
+			Summary:     "Swagger UI assets",
+			Description: "Files of the swagger-ui-dist version embedded in the server.",
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Stylesheet or script", ContentType: "text/*"},
+			},
+			Errors: []int{http.StatusNotFound},
+		},
+		{
+			Method: http.MethodGet, Path: "/metrics", Tag: "ops",
+			Summary:     "Prometheus metrics",
+			Description: "Text exposition format 0.0.4. Only registered with METRICS_ENABLED; when METRICS_TOKEN is set it must be sent as a Bearer token.",
+			Responses: []Reply{
+				{Status: http.StatusOK, Description: "Metrics", ContentType: contentText},
+			},
+			Errors: []int{http.StatusUnauthorized},
+		},
+	}
+}
@@ -0,0 +1,226 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+)
+
+// Schema é o subconjunto do JSON Schema 2020-12 usado pelo documento e pelo
+// validador. Type é uma string ou, para valores anuláveis, uma lista
+// ("string", "null"), como no OpenAPI 3.1.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+	Maximum              *int               `json:"maximum,omitempty"`
+}
+
+// types devolve os tipos aceitos, inclusive "null" quando anulável.
+func (s *Schema) types() []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	}
+	return nil
+}
+
+var (
+	timeType           = reflect.TypeOf(time.Time{})
+	optionalStringType = reflect.TypeOf(dto.OptionalString{})
+)
+
+// schemaRegistry gera os schemas a partir das tags json, form e binding dos
+// DTOs; structs nomeados viram componentes referenciados por $ref.
+type schemaRegistry struct {
+	components map[string]*Schema
+	input      bool // Gerando o corpo ou a query de uma requisição
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{components: make(map[string]*Schema)}
+}
+
+// request devolve o schema do corpo de uma requisição (ex.: dto.LoginInput{}).
+func (r *schemaRegistry) request(v any) *Schema {
+	r.input = true
+	defer func() { r.input = false }()
+	return r.schema(reflect.TypeOf(v))
+}
+
+// response devolve o schema do corpo de uma resposta.
+func (r *schemaRegistry) response(v any) *Schema {
+	return r.schema(reflect.TypeOf(v))
+}
+
+// resolve segue o $ref até o componente.
+func (r *schemaRegistry) resolve(s *Schema) *Schema {
+	if s != nil && s.Ref != "" {
+		return r.components[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+	return s
+}
+
+func (r *schemaRegistry) schema(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case optionalStringType:
+		// Merge patch: null limpa o valor
+		return &Schema{Type: []string{"string", "null"}}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := r.schema(t.Elem())
+		if name, ok := s.Type.(string); ok {
+			s.Type = []string{name, "null"}
+		}
+		return s
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schema(t.Elem())}
+	case reflect.Struct:
+		return r.structRef(t)
+	}
+	return &Schema{}
+}
+
+func (r *schemaRegistry) structRef(t reflect.Type) *Schema {
+	name := t.Name()
+	if _, ok := r.components[name]; !ok {
+		// Reserva o nome antes de descer nos campos
+		r.components[name] = &Schema{}
+		*r.components[name] = *r.structSchema(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// structSchema segue as regras do encoding/json: campos embutidos sobem para
+// o objeto e json:"-" fica de fora.
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	r.addFields(s, t)
+	return s
+}
+
+func (r *schemaRegistry) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			r.addFields(s, field.Type)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		prop := r.schema(field.Type)
+		binding := field.Tag.Get("binding")
+		applyBinding(prop, binding)
+		s.Properties[name] = prop
+
+		if r.isRequired(binding, options) {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+func (r *schemaRegistry) isRequired(binding, jsonOptions string) bool {
+	if r.input {
+		// Sem binding o campo pode faltar e o caso de uso o valida
+		return hasRule(binding, "required")
+	}
+	return !strings.Contains(jsonOptions, "omitempty")
+}
+
+// parameters converte um DTO de query (tags form) em parâmetros.
+func (r *schemaRegistry) parameters(v any) []*Parameter {
+	r.input = true
+	defer func() { r.input = false }()
+
+	t := reflect.TypeOf(v)
+	params := make([]*Parameter, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("form")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		schema := r.schema(field.Type)
+		binding := field.Tag.Get("binding")
+		applyBinding(schema, binding)
+		params = append(params, &Parameter{
+			Name:     name,
+			In:       "query",
+			Required: hasRule(binding, "required"),
+			Schema:   schema,
+		})
+	}
+	return params
+}
+
+// applyBinding traduz as regras do validator usadas nos DTOs.
+func applyBinding(s *Schema, binding string) {
+	for _, rule := range strings.Split(binding, ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "oneof":
+			s.Enum = strings.Fields(value)
+		case "min":
+			if n, err := strconv.Atoi(value); err == nil {
+				s.Minimum = &n
+			}
+		case "max":
+			if n, err := strconv.Atoi(value); err == nil {
+				s.Maximum = &n
+			}
+		case "email":
+			s.Format = "email"
+		}
+	}
+}
+
+func hasRule(binding, rule string) bool {
+	for _, r := range strings.Split(binding, ",") {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,227 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	// Version é a versão do contrato; mude ao alterar rotas ou DTOs
+	Version = "1.0.0"
+)
+
+// Spec é o documento montado a partir de Routes e o índice usado pelo
+// validador de requisições.
+type Spec struct {
+	document   *Document
+	registry   *schemaRegistry
+	operations map[string]*operation // Chave: método + caminho do gin
+	raw        []byte
+}
+
+// operation guarda o que o validador precisa de cada rota.
+type operation struct {
+	body     *Schema
+	bodyType string
+	query    []*Parameter
+}
+
+// NewSpec monta o documento a partir de Routes.
+func NewSpec() (*Spec, error) {
+	s := &Spec{
+		registry:   newSchemaRegistry(),
+		operations: make(map[string]*operation),
+	}
+	s.document = &Document{
+		OpenAPI: "3.1.0",
+		Info: Info{
+			Title:       "startup-auth-go API",
+			Version:     Version,
+			Description: "Errors use application/problem+json (RFC 7807) with a stable code; see the Problem schema.",
+		},
+		Paths: make(map[string]*PathItem),
+		Components: Components{
+			SecuritySchemes: securitySchemes(),
+		},
+	}
+
+	problem := s.registry.response(dto.Problem{})
+	oauthError := s.registry.response(OAuthError{})
+	for _, route := range Routes() {
+		s.addRoute(route, problem, oauthError)
+	}
+	s.document.Components.Schemas = s.registry.components
+
+	raw, err := json.MarshalIndent(s.document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	s.raw = raw
+	return s, nil
+}
+
+// JSON devolve o documento serializado.
+func (s *Spec) JSON() []byte {
+	return s.raw
+}
+
+// Document devolve o documento montado.
+func (s *Spec) Document() *Document {
+	return s.document
+}
+
+func (s *Spec) addRoute(route Route, problem, oauthError *Schema) {
+	path := openAPIPath(route.Path)
+	item, ok := s.document.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		s.document.Paths[path] = item
+	}
+
+	op := &OperationObject{
+		OperationID: operationID(route.Method, route.Path),
+		Summary:     route.Summary,
+		Description: route.Description,
+		Responses:   make(map[string]*Response),
+		Security:    security(route.Auth),
+	}
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+
+	for _, name := range pathParams(route.Path) {
+		op.Parameters = append(op.Parameters, &Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+
+	validation := &operation{}
+	if route.Query != nil {
+		validation.query = s.registry.parameters(route.Query)
+		op.Parameters = append(op.Parameters, validation.query...)
+	}
+	if route.Body != nil {
+		bodyType := route.BodyType
+		if bodyType == "" {
+			bodyType = contentJSON
+		}
+		schema := s.registry.request(route.Body)
+		validation.body = schema
+		validation.bodyType = bodyType
+		if bodyType == contentMultipart {
+			// Arquivos do multipart são binários, não base64
+			for _, prop := range s.registry.resolve(schema).Properties {
+				if prop.Format == "byte" {
+					prop.Format = "binary"
+				}
+			}
+		}
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{bodyType: {Schema: schema}},
+		}
+	}
+	s.operations[route.Method+" "+route.Path] = validation
+
+	for _, reply := range route.Responses {
+		op.Responses[strconv.Itoa(reply.Status)] = s.response(reply)
+	}
+
+	errorSchema, errorType := problem, problemContentType
+	if route.Auth == authClient {
+		errorSchema, errorType = oauthError, contentJSON
+	}
+	errorResponse := func(description string) *Response {
+		return &Response{
+			Description: description,
+			Content:     map[string]*MediaType{errorType: {Schema: errorSchema}},
+		}
+	}
+	for _, status := range route.Errors {
+		op.Responses[strconv.Itoa(status)] = errorResponse(http.StatusText(status))
+	}
+	op.Responses["default"] = errorResponse("Error")
+
+	(*item)[strings.ToLower(route.Method)] = op
+}
+
+func (s *Spec) response(reply Reply) *Response {
+	response := &Response{Description: reply.Description}
+	contentType := reply.ContentType
+	switch {
+	case reply.Body != nil:
+		if contentType == "" {
+			contentType = contentJSON
+		}
+		response.Content = map[string]*MediaType{contentType: {Schema: s.registry.response(reply.Body)}}
+	case contentType == contentHTML, contentType == contentText:
+		response.Content = map[string]*MediaType{contentType: {Schema: &Schema{Type: "string"}}}
+	case contentType != "":
+		response.Content = map[string]*MediaType{contentType: {Schema: &Schema{Type: "string", Format: "binary"}}}
+	}
+	return response
+}
+
+func securitySchemes() map[string]*SecurityScheme {
+	return map[string]*SecurityScheme{
+		"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+		"sessionCookie": {
+			Type: "apiKey", In: "cookie", Name: "startup_auth_session",
+			Description: "Cookie mode (AUTH_COOKIE_MODE); unsafe methods also need the X-CSRF-Token header.",
+		},
+		"apiKey": {
+			Type: "apiKey", In: "header", Name: "Authorization",
+			Description: `"ApiKey <key>"; the route lists the scope it needs.`,
+		},
+		"clientBasic": {Type: "http", Scheme: "basic", Description: "OAuth client credentials."},
+	}
+}
+
+// security devolve as alternativas aceitas pela rota.
+func security(a auth) []map[string][]string {
+	switch a {
+	case authJWT:
+		return []map[string][]string{{"bearerAuth": {}}, {"sessionCookie": {}}}
+	case authUser:
+		return []map[string][]string{{"bearerAuth": {}}, {"sessionCookie": {}}, {"apiKey": {}}}
+	case authClient:
+		return []map[string][]string{{"clientBasic": {}}}
+	}
+	return nil
+}
+
+// openAPIPath converte "/user/api-keys/:id" em "/user/api-keys/{id}".
+func openAPIPath(ginPath string) string {
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func pathParams(ginPath string) []string {
+	var names []string
+	for _, segment := range strings.Split(ginPath, "/") {
+		if strings.HasPrefix(segment, ":") {
+			names = append(names, segment[1:])
+		}
+	}
+	return names
+}
+
+// operationID gera identificadores estáveis, ex.: "deleteUserApiKeysId".
+func operationID(method, ginPath string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, part := range strings.FieldsFunc(ginPath, func(r rune) bool {
+		return r == '/' || r == '-' || r == ':' || r == '.' || r == '_'
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
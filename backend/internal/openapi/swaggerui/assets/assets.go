@@ -0,0 +1,17 @@
+// Package assets embute os arquivos do swagger-ui-dist gerados por
+// go generate ./internal/openapi/swaggerui. Sem eles o binário não compila:
+// go:embed falha com "no matching files found".
+package assets
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed swagger-ui.css swagger-ui-bundle.js LICENSE
+var files embed.FS
+
+// FS devolve os arquivos embutidos.
+func FS() fs.FS {
+	return files
+}
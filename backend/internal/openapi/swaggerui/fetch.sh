@@ -0,0 +1,17 @@
+#!/bin/sh
+# Baixa o swagger-ui-dist na versão fixada e copia para assets/ só os arquivos
+# servidos em /docs. O npm confere o tarball com o hash de integridade
+# publicado no registro.
+set -eu
+
+VERSION=5.17.14
+cd "$(dirname "$0")"
+tmp=$(mktemp -d)
+trap 'rm -rf "$tmp"' EXIT
+
+npm pack --silent --pack-destination "$tmp" "swagger-ui-dist@$VERSION" >/dev/null
+tar -xzf "$tmp"/swagger-ui-dist-"$VERSION".tgz -C "$tmp"
+for file in swagger-ui.css swagger-ui-bundle.js LICENSE; do
+	cp "$tmp/package/$file" "assets/$file"
+done
+echo "swagger-ui-dist $VERSION copied to assets/"
@@ -0,0 +1,16 @@
+// Package swaggerui descreve os arquivos do Swagger UI servidos pela própria
+// API: a página de documentação não executa scripts de terceiros. Os arquivos
+// ficam em assets/ e são embutidos pelo pacote swaggerui/assets.
+package swaggerui
+
+// Version é a versão exata do pacote npm swagger-ui-dist em assets/. Para
+// atualizar, troque aqui e em fetch.sh e rode go generate.
+const Version = "5.17.14"
+
+//go:generate sh fetch.sh
+
+// Arquivos usados pela página /docs
+const (
+	StylesheetFile = "swagger-ui.css"
+	BundleFile     = "swagger-ui-bundle.js"
+)
@@ -0,0 +1,192 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+// Corpos JSON maiores que isso nem chegam ao handler
+const maxValidatedBody = 1 << 20
+
+// ValidateRequest confere o corpo JSON e a query da requisição com a rota
+// (método e caminho no formato do gin). Rotas desconhecidas, corpos que não
+// são JSON e parâmetros de caminho ficam a cargo dos handlers. O corpo lido
+// é devolvido à requisição para o handler.
+func (s *Spec) ValidateRequest(method, ginPath string, r *http.Request) error {
+	op, ok := s.operations[method+" "+ginPath]
+	if !ok {
+		return nil
+	}
+
+	valErr := msgerror.NewValidationErrors()
+	s.validateQuery(op, r, valErr)
+
+	if op.body != nil && op.bodyType == contentJSON {
+		body, err := readBody(r)
+		if err != nil {
+			return err
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			return msgerror.AnErrInvalidRequestBody
+		}
+		if _, isObject := value.(map[string]any); !isObject {
+			return fmt.Errorf("%w: expected a JSON object", msgerror.AnErrInvalidRequestBody)
+		}
+		s.validateValue(op.body, value, "", valErr)
+	}
+
+	if valErr.HasErrors() {
+		return valErr
+	}
+	return nil
+}
+
+// readBody lê o corpo e o recoloca na requisição.
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, msgerror.AnErrInvalidRequestBody
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxValidatedBody+1))
+	if err != nil {
+		return nil, msgerror.AnErrInvalidRequestBody
+	}
+	if len(body) > maxValidatedBody {
+		return nil, fmt.Errorf("%w: body too large", msgerror.AnErrInvalidRequestBody)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func (s *Spec) validateQuery(op *operation, r *http.Request, valErr *msgerror.ValidationErrors) {
+	query := r.URL.Query()
+	for _, param := range op.query {
+		values, present := query[param.Name]
+		if !present || len(values) == 0 || values[0] == "" {
+			if param.Required {
+				valErr.AddError(param.Name, msgerror.AnErrRequired)
+			}
+			continue
+		}
+
+		// Os DTOs de query só têm escalares
+		raw := values[0]
+		var value any = raw
+		switch param.Schema.Type {
+		case "integer":
+			n, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil {
+				valErr.AddError(param.Name, msgerror.AnErrInvalidType)
+				continue
+			}
+			value = json.Number(strconv.FormatInt(n, 10))
+		case "boolean":
+			b, err := strconv.ParseBool(raw)
+			if err != nil {
+				valErr.AddError(param.Name, msgerror.AnErrInvalidType)
+				continue
+			}
+			value = b
+		}
+		s.validateValue(param.Schema, value, param.Name, valErr)
+	}
+}
+
+// validateValue registra no máximo um erro por campo.
+func (s *Spec) validateValue(schema *Schema, value any, field string, valErr *msgerror.ValidationErrors) {
+	schema = s.registry.resolve(schema)
+	if schema == nil {
+		return
+	}
+
+	types := schema.types()
+	if value == nil {
+		if len(types) > 0 && !slices.Contains(types, "null") {
+			valErr.AddError(field, msgerror.AnErrInvalidType)
+		}
+		return
+	}
+	if len(types) > 0 && !slices.Contains(types, jsonType(value, types)) {
+		valErr.AddError(field, msgerror.AnErrInvalidType)
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, v) {
+			valErr.AddError(field, msgerror.AnErrInvalidValue)
+			return
+		}
+		if schema.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, v); err != nil {
+				valErr.AddError(field, msgerror.AnErrInvalidValue)
+			}
+		}
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return
+		}
+		if (schema.Minimum != nil && n < int64(*schema.Minimum)) ||
+			(schema.Maximum != nil && n > int64(*schema.Maximum)) {
+			valErr.AddError(field, msgerror.AnErrInvalidValue)
+		}
+	case []any:
+		for i, item := range v {
+			s.validateValue(schema.Items, item, fmt.Sprintf("%s[%d]", field, i), valErr)
+		}
+	case map[string]any:
+		for _, name := range schema.Required {
+			if _, ok := v[name]; !ok {
+				valErr.AddError(join(field, name), msgerror.AnErrRequired)
+			}
+		}
+		for name, item := range v {
+			if prop, ok := schema.Properties[name]; ok {
+				s.validateValue(prop, item, join(field, name), valErr)
+			} else if schema.AdditionalProperties != nil {
+				s.validateValue(schema.AdditionalProperties, item, join(field, name), valErr)
+			}
+			// Campos desconhecidos são ignorados, como no encoding/json
+		}
+	}
+}
+
+// jsonType devolve o tipo JSON do valor; números inteiros também servem
+// onde se espera "number".
+func jsonType(value any, accepted []string) string {
+	switch v := value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if _, err := v.Int64(); err == nil && !slices.Contains(accepted, "number") {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return ""
+}
+
+func join(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
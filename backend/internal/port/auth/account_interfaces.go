@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+)
+
+// DeactivateAccountInterface devolve o instante a partir do qual a conta será apagada.
+type DeactivateAccountInterface interface {
+	Execute(ctx context.Context, userID vo.ID, password string) (time.Time, error)
+}
+
+type RestoreAccountInterface interface {
+	Execute(ctx context.Context, email string, password string) (vo.ID, error)
+}
+
+// PurgeAccountsInterface devolve quantas contas foram apagadas.
+type PurgeAccountsInterface interface {
+	Execute(ctx context.Context) (int, error)
+}
+
+type ExportUserDataInterface interface {
+	Execute(ctx context.Context, userID vo.ID, format string) (dto.UserDataExportResult, error)
+}
+
+type DownloadUserDataExportInterface interface {
+	Execute(ctx context.Context, fileName string, expiresAt time.Time, signature string) (dto.UserDataExportResult, error)
+}
@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+)
+
+type CreateAPIKeyInterface interface {
+	Execute(ctx context.Context, userID vo.ID, input dto.CreateAPIKeyParams) (dto.CreatedAPIKeyResult, error)
+}
+
+type ListAPIKeysInterface interface {
+	Execute(ctx context.Context, userID vo.ID) ([]dto.APIKeyResult, error)
+}
+
+type RevokeAPIKeyInterface interface {
+	Execute(ctx context.Context, userID vo.ID, keyID vo.ID) error
+}
+
+type AuthenticateAPIKeyInterface interface {
+	Execute(ctx context.Context, rawKey string) (dto.APIKeyPrincipal, error)
+}
@@ -0,0 +1,16 @@
+package port
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+)
+
+type UploadAvatarInterface interface {
+	Execute(ctx context.Context, userID vo.ID, data []byte) (string, error)
+}
+
+type GetAvatarInterface interface {
+	Execute(ctx context.Context, avatarID string, size int) (dto.AvatarImage, error)
+}
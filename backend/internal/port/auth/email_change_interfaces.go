@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+)
+
+type RequestEmailChangeInterface interface {
+	Execute(ctx context.Context, userID vo.ID, newEmail string, password string) error
+}
+
+type ConfirmEmailChangeInterface interface {
+	Execute(ctx context.Context, token string) error
+}
+
+type CancelEmailChangeInterface interface {
+	Execute(ctx context.Context, token string) error
+}
@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+)
+
+type IntrospectTokenInterface interface {
+	Execute(ctx context.Context, token string) (dto.IntrospectionResult, error)
+}
@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+)
+
+type ListAuditEventsInterface interface {
+	Execute(ctx context.Context, params dto.AuditQueryParams) ([]dto.AuditEventOutput, error)
+}
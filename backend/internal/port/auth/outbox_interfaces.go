@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+)
+
+type ListOutboxMessagesInterface interface {
+	Execute(ctx context.Context, params dto.OutboxQueryParams) ([]dto.OutboxMessageOutput, error)
+}
+
+// ReplayOutboxMessageInterface devolve uma mensagem da dead letter à fila.
+type ReplayOutboxMessageInterface interface {
+	Execute(ctx context.Context, id vo.ID) (dto.OutboxMessageOutput, error)
+}
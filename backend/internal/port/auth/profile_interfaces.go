@@ -0,0 +1,16 @@
+package port
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+)
+
+type GetProfileInterface interface {
+	Execute(ctx context.Context, userID vo.ID) (dto.UserOutput, error)
+}
+
+type UpdateProfileInterface interface {
+	Execute(ctx context.Context, userID vo.ID, input dto.UpdateProfileInput) (dto.UserOutput, error)
+}
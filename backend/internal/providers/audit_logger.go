@@ -0,0 +1,207 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+)
+
+type auditLine struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Action    string    `json:"action"`
+	Outcome   string    `json:"outcome"`
+	ActorID   string    `json:"actor_id,omitempty"`
+	Target    string    `json:"target,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+// JSONLinesAuditLogger grava um evento JSON por linha no writer informado.
+type JSONLinesAuditLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+	// path só é conhecido quando o logger foi aberto sobre um arquivo
+	path string
+}
+
+func NewJSONLinesAuditLogger(w io.Writer) *JSONLinesAuditLogger {
+	return &JSONLinesAuditLogger{w: w}
+}
+
+// OpenJSONLinesAuditFile abre (ou cria) o arquivo em modo append; Close o fecha.
+func OpenJSONLinesAuditFile(path string) (*JSONLinesAuditLogger, error) {
+	f, err := openAuditFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONLinesAuditLogger{w: f, path: path}, nil
+}
+
+func openAuditFile(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+}
+
+func (l *JSONLinesAuditLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if c, ok := l.w.(io.Closer); ok && l.path != "" {
+		return c.Close()
+	}
+	return nil
+}
+
+func (l *JSONLinesAuditLogger) Log(_ context.Context, event *entity.AuditEvent) error {
+	line, err := json.Marshal(auditLine{
+		ID:        event.ID.String(),
+		Timestamp: event.CreatedAt,
+		Action:    event.Action,
+		Outcome:   event.Outcome,
+		ActorID:   event.ActorID,
+		Target:    event.Target,
+		IP:        event.IP,
+		UserAgent: event.UserAgent,
+		Reason:    event.Reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.w.Write(append(line, '\n'))
+	return err
+}
+
+// Anonymize aplica a regra de repository.AuditRepository.Anonymize às linhas
+// já gravadas. O arquivo é reescrito num temporário que toma o lugar do
+// original; as demais linhas ficam como estavam. Sem arquivo não há o que
+// reescrever.
+func (l *JSONLinesAuditLogger) Anonymize(_ context.Context, subjects []string, pseudonym string) error {
+	if l.path == "" {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return err
+	}
+
+	matches := make(map[string]bool, len(subjects))
+	for _, subject := range subjects {
+		if subject != "" {
+			matches[subject] = true
+		}
+	}
+
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	events := make([]*auditLine, len(lines))
+	for i, line := range lines {
+		var event auditLine
+		if json.Unmarshal(line, &event) == nil {
+			events[i] = &event
+		}
+	}
+	// E-mails usados antes aparecem como alvo dos eventos que a pessoa fez
+	for _, event := range events {
+		if event != nil && matches[event.ActorID] && strings.Contains(event.Target, "@") {
+			matches[event.Target] = true
+		}
+	}
+
+	var out bytes.Buffer
+	changed := false
+	for i, line := range lines {
+		event := events[i]
+		if event == nil || (!matches[event.ActorID] && !matches[event.Target]) {
+			out.Write(line)
+			continue
+		}
+		if matches[event.ActorID] {
+			event.ActorID = pseudonym
+		}
+		if matches[event.Target] {
+			event.Target = pseudonym
+		}
+		event.IP, event.UserAgent = "", ""
+		encoded, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		out.Write(append(encoded, '\n'))
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := replaceFile(l.path, out.Bytes()); err != nil {
+		return err
+	}
+	// O arquivo aberto foi substituído; as próximas linhas vão para o novo
+	f, err := openAuditFile(l.path)
+	if err != nil {
+		return err
+	}
+	if c, ok := l.w.(io.Closer); ok {
+		_ = c.Close()
+	}
+	l.w = f
+	return nil
+}
+
+// replaceFile grava no mesmo diretório e renomeia, para o arquivo nunca ficar
+// pela metade.
+func replaceFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// MultiAuditLogger repassa cada evento para todos os destinos configurados.
+type MultiAuditLogger struct {
+	loggers []providers.AuditLogger
+}
+
+func NewMultiAuditLogger(loggers ...providers.AuditLogger) *MultiAuditLogger {
+	return &MultiAuditLogger{loggers: loggers}
+}
+
+func (m *MultiAuditLogger) Log(ctx context.Context, event *entity.AuditEvent) error {
+	var errs []error
+	for _, logger := range m.loggers {
+		if err := logger.Log(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -1,6 +1,8 @@
 package providers
 
 import (
+	"context"
+
 	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -13,7 +15,7 @@ func NewBcryptProvider(cost int) *BcryptProvider {
 	return &BcryptProvider{cost: cost}
 }
 
-func (b *BcryptProvider) Encrypt(password string) (string, error) {
+func (b *BcryptProvider) Encrypt(_ context.Context, password string) (string, error) {
 	if password == "" {
 		return "", msgerror.AnErrEmptyPassword
 	}
@@ -22,7 +24,7 @@ func (b *BcryptProvider) Encrypt(password string) (string, error) {
 	return string(hash), nil
 }
 
-func (b *BcryptProvider) Compare(password, hashedPassword string) (bool, error) {
+func (b *BcryptProvider) Compare(_ context.Context, password, hashedPassword string) (bool, error) {
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 	if err != nil {
 		return false, err
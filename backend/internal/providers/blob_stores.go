@@ -0,0 +1,130 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+const (
+	BlobStoreFile = "file"
+	BlobStoreS3   = "s3"
+)
+
+// Cada segmento começa por letra ou número, o que exclui "." e ".." e impede
+// path traversal tanto no disco quanto na URL do S3
+var blobKeyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*(/[A-Za-z0-9][A-Za-z0-9._-]*)*$`)
+
+func validBlobKey(key string) error {
+	if len(key) > 512 || !blobKeyPattern.MatchString(key) {
+		return fmt.Errorf("%w: %q", msgerror.AnErrInvalidBlobKey, key)
+	}
+	return nil
+}
+
+type BlobStoreConfig struct {
+	Store string
+	// Dir é a raiz do armazenamento "file"
+	Dir string
+	S3  S3Config
+}
+
+// NewBlobStore escolhe o armazenamento de arquivos conforme a configuração.
+func NewBlobStore(cfg BlobStoreConfig) (providers.BlobStore, error) {
+	switch cfg.Store {
+	case "", BlobStoreFile:
+		return NewFileBlobStore(cfg.Dir)
+	case BlobStoreS3:
+		return NewS3BlobStore(cfg.S3), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", msgerror.AnErrInvalidBlobStore, cfg.Store)
+	}
+}
+
+// FileBlobStore grava cada chave como um arquivo sob o diretório raiz. O tipo
+// do conteúdo não é guardado: Get o deduz pela extensão da chave.
+type FileBlobStore struct {
+	dir string
+}
+
+func NewFileBlobStore(dir string) (*FileBlobStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	return &FileBlobStore{dir: dir}, nil
+}
+
+func (s *FileBlobStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if err := validBlobKey(key); err != nil {
+		return err
+	}
+
+	target := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+		return err
+	}
+
+	// Arquivo temporário + rename: leitores nunca veem um arquivo pela metade
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+func (s *FileBlobStore) Get(ctx context.Context, key string) (providers.Blob, error) {
+	if err := validBlobKey(key); err != nil {
+		return providers.Blob{}, err
+	}
+
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return providers.Blob{}, msgerror.AnErrBlobNotFound
+	}
+	if err != nil {
+		return providers.Blob{}, err
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	return providers.Blob{Data: data, ContentType: contentType}, nil
+}
+
+func (s *FileBlobStore) Delete(ctx context.Context, key string) error {
+	if err := validBlobKey(key); err != nil {
+		return err
+	}
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *FileBlobStore) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
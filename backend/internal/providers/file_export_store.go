@@ -0,0 +1,84 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+// Só nomes gerados pela exportação são aceitos, o que impede path traversal;
+// os anteriores à inclusão do dono não têm o primeiro id
+var exportNamePattern = regexp.MustCompile(`^([0-9a-f-]{36}\.)?[0-9a-f-]{36}\.(json|zip)$`)
+
+// FileExportStore grava as exportações em um diretório local com permissão restrita.
+type FileExportStore struct {
+	dir string
+}
+
+func NewFileExportStore(dir string) (*FileExportStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	return &FileExportStore{dir: dir}, nil
+}
+
+func (s *FileExportStore) Save(ctx context.Context, name string, data []byte) error {
+	if !exportNamePattern.MatchString(name) {
+		return msgerror.AnErrExportNotFound
+	}
+	return os.WriteFile(filepath.Join(s.dir, name), data, 0o600)
+}
+
+func (s *FileExportStore) Load(ctx context.Context, name string) ([]byte, error) {
+	if !exportNamePattern.MatchString(name) {
+		return nil, msgerror.AnErrExportNotFound
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, msgerror.AnErrExportNotFound
+	}
+	return data, err
+}
+
+func (s *FileExportStore) DeleteOlderThan(ctx context.Context, before time.Time) error {
+	return s.deleteWhere(func(entry fs.DirEntry) bool {
+		info, err := entry.Info()
+		return err == nil && info.ModTime().Before(before)
+	})
+}
+
+func (s *FileExportStore) DeleteByOwner(ctx context.Context, ownerID string) error {
+	if ownerID == "" {
+		return nil
+	}
+	return s.deleteWhere(func(entry fs.DirEntry) bool {
+		return strings.HasPrefix(entry.Name(), ownerID+".")
+	})
+}
+
+func (s *FileExportStore) deleteWhere(match func(fs.DirEntry) bool) error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		if !exportNamePattern.MatchString(entry.Name()) || !match(entry) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, entry.Name())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,35 @@
+package providers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"time"
+)
+
+// HMACURLSigner deriva a própria chave do segredo informado, para que o mesmo
+// segredo do JWT possa ser usado sem que uma assinatura valha no outro contexto.
+type HMACURLSigner struct {
+	key []byte
+}
+
+func NewHMACURLSigner(secret string) *HMACURLSigner {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("startup-auth-go:url-signer"))
+	return &HMACURLSigner{key: mac.Sum(nil)}
+}
+
+func (s *HMACURLSigner) Sign(resource string, expiresAt time.Time) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(resource + "\n" + strconv.FormatInt(expiresAt.Unix(), 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *HMACURLSigner) Verify(resource string, expiresAt time.Time, signature string) bool {
+	if !time.Now().Before(expiresAt) {
+		return false
+	}
+	expected := s.Sign(resource, expiresAt)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
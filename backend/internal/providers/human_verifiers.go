@@ -0,0 +1,266 @@
+package providers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/bits"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/logging"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+const (
+	HumanVerifierNone = "none"
+
+	hCaptchaVerifyURL  = "https://api.hcaptcha.com/siteverify"
+	turnstileVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+)
+
+type HumanVerifierConfig struct {
+	// Kind: none, pow, hcaptcha ou turnstile
+	Kind          string
+	PowDifficulty int
+	PowTTL        time.Duration
+	Captcha       CaptchaConfig
+}
+
+// NewHumanVerifier escolhe a verificação conforme a configuração; com "none"
+// devolve nil e as rotas não exigem prova. A prova de trabalho assina os
+// desafios com signer e guarda os já usados em used.
+func NewHumanVerifier(cfg HumanVerifierConfig, signer providers.URLSigner, used providers.BlacklistProvider) (providers.HumanVerifier, error) {
+	switch cfg.Kind {
+	case "", HumanVerifierNone:
+		return nil, nil
+	case providers.HumanVerifierProofOfWork:
+		return NewProofOfWorkVerifier(signer, used, cfg.PowDifficulty, cfg.PowTTL), nil
+	case providers.HumanVerifierHCaptcha, providers.HumanVerifierTurnstile:
+		captcha := cfg.Captcha
+		captcha.Kind = cfg.Kind
+		return NewCaptchaVerifier(captcha), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", msgerror.AnErrInvalidHumanVerifier, cfg.Kind)
+	}
+}
+
+// ProofOfWorkVerifier emite desafios assinados e sem estado no servidor: o
+// cliente procura uma solução tal que sha256("<desafio>:<solução>") comece
+// com Difficulty bits zero e a envia como "<desafio>:<solução>". Cada desafio
+// vale uma única vez até expirar.
+type ProofOfWorkVerifier struct {
+	signer     providers.URLSigner
+	used       providers.BlacklistProvider
+	difficulty int
+	ttl        time.Duration
+}
+
+func NewProofOfWorkVerifier(signer providers.URLSigner, used providers.BlacklistProvider, difficulty int, ttl time.Duration) *ProofOfWorkVerifier {
+	return &ProofOfWorkVerifier{signer: signer, used: used, difficulty: difficulty, ttl: ttl}
+}
+
+// Soluções maiores que isso são recusadas sem calcular o hash
+const maxPowSolution = 64
+
+func (p *ProofOfWorkVerifier) Challenge(context.Context) (providers.HumanChallenge, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return providers.HumanChallenge{}, err
+	}
+	id := base64.RawURLEncoding.EncodeToString(nonce)
+	// A assinatura cobre segundos inteiros
+	expiresAt := time.Unix(time.Now().Add(p.ttl).Unix(), 0)
+	signature := p.signer.Sign(powResource(id, p.difficulty), expiresAt)
+
+	return providers.HumanChallenge{
+		Type:       providers.HumanVerifierProofOfWork,
+		Challenge:  fmt.Sprintf("%s.%d.%d.%s", id, p.difficulty, expiresAt.Unix(), signature),
+		Difficulty: p.difficulty,
+		ExpiresAt:  expiresAt,
+	}, nil
+}
+
+func (p *ProofOfWorkVerifier) Verify(ctx context.Context, proof string, _ providers.HumanSubject) error {
+	if proof == "" {
+		return msgerror.AnErrHumanVerificationRequired
+	}
+	challenge, solution, ok := strings.Cut(proof, ":")
+	if !ok || solution == "" || len(solution) > maxPowSolution {
+		return msgerror.AnErrHumanVerificationFailed
+	}
+
+	parts := strings.Split(challenge, ".")
+	if len(parts) != 4 {
+		return msgerror.AnErrHumanVerificationFailed
+	}
+	id := parts[0]
+	difficulty, errDifficulty := strconv.Atoi(parts[1])
+	expires, errExpires := strconv.ParseInt(parts[2], 10, 64)
+	if errDifficulty != nil || errExpires != nil {
+		return msgerror.AnErrHumanVerificationFailed
+	}
+	// Desafios emitidos com dificuldade menor que a atual não valem mais
+	expiresAt := time.Unix(expires, 0)
+	if difficulty < p.difficulty || !p.signer.Verify(powResource(id, difficulty), expiresAt, parts[3]) {
+		return msgerror.AnErrHumanVerificationFailed
+	}
+
+	sum := sha256.Sum256([]byte(proof))
+	if leadingZeroBits(sum[:]) < difficulty {
+		return msgerror.AnErrHumanVerificationFailed
+	}
+
+	// Registro atômico: entre envios simultâneos da mesma solução só um passa
+	added, err := p.used.AddIfAbsent(ctx, "human-challenge:"+id, time.Until(expiresAt))
+	if err != nil {
+		return err
+	}
+	if !added {
+		return msgerror.AnErrHumanVerificationFailed
+	}
+	return nil
+}
+
+func powResource(id string, difficulty int) string {
+	return "human-challenge:" + id + ":" + strconv.Itoa(difficulty)
+}
+
+func leadingZeroBits(sum []byte) int {
+	n := 0
+	for _, b := range sum {
+		if b != 0 {
+			return n + bits.LeadingZeros8(b)
+		}
+		n += 8
+	}
+	return n
+}
+
+// CaptchaConfig serve a serviços com a API siteverify do hCaptcha e do
+// Cloudflare Turnstile: POST com secret, response e remoteip, resposta JSON
+// com "success".
+type CaptchaConfig struct {
+	Kind    string
+	SiteKey string
+	Secret  string
+	// VerifyURL sobrepõe o endereço padrão do serviço
+	VerifyURL  string
+	HTTPClient *http.Client
+}
+
+// CaptchaVerifier confere o token gerado pelo widget do captcha no navegador.
+type CaptchaVerifier struct {
+	cfg CaptchaConfig
+}
+
+func NewCaptchaVerifier(cfg CaptchaConfig) *CaptchaVerifier {
+	if cfg.VerifyURL == "" {
+		cfg.VerifyURL = hCaptchaVerifyURL
+		if cfg.Kind == providers.HumanVerifierTurnstile {
+			cfg.VerifyURL = turnstileVerifyURL
+		}
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &CaptchaVerifier{cfg: cfg}
+}
+
+func (v *CaptchaVerifier) Challenge(context.Context) (providers.HumanChallenge, error) {
+	return providers.HumanChallenge{Type: v.cfg.Kind, SiteKey: v.cfg.SiteKey}, nil
+}
+
+func (v *CaptchaVerifier) Verify(ctx context.Context, proof string, subject providers.HumanSubject) error {
+	if proof == "" {
+		return msgerror.AnErrHumanVerificationRequired
+	}
+
+	form := url.Values{"secret": {v.cfg.Secret}, "response": {proof}, "sitekey": {v.cfg.SiteKey}}
+	if subject.IP != "" {
+		form.Set("remoteip", subject.IP)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.cfg.VerifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s siteverify: %w", v.cfg.Kind, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s siteverify: unexpected status %d", v.cfg.Kind, resp.StatusCode)
+	}
+
+	var result struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("%s siteverify: %w", v.cfg.Kind, err)
+	}
+	if !result.Success {
+		return fmt.Errorf("%w: %s", msgerror.AnErrHumanVerificationFailed, strings.Join(result.ErrorCodes, ", "))
+	}
+	return nil
+}
+
+// RiskBasedHumanVerifier só exige a prova depois que o IP ou o e-mail alvo
+// passa de threshold tentativas; provas enviadas antes disso são conferidas
+// mesmo assim. Contar o e-mail impede que trocar de IP a cada tentativa
+// dispense a prova.
+type RiskBasedHumanVerifier struct {
+	verifier  providers.HumanVerifier
+	attempts  providers.RateLimiter
+	threshold providers.RateLimit
+}
+
+func NewRiskBasedHumanVerifier(verifier providers.HumanVerifier, attempts providers.RateLimiter, threshold providers.RateLimit) *RiskBasedHumanVerifier {
+	return &RiskBasedHumanVerifier{verifier: verifier, attempts: attempts, threshold: threshold}
+}
+
+func (r *RiskBasedHumanVerifier) Challenge(ctx context.Context) (providers.HumanChallenge, error) {
+	return r.verifier.Challenge(ctx)
+}
+
+func (r *RiskBasedHumanVerifier) Verify(ctx context.Context, proof string, subject providers.HumanSubject) error {
+	if proof == "" && r.lowRisk(ctx, subject) {
+		return nil
+	}
+	return r.verifier.Verify(ctx, proof, subject)
+}
+
+// lowRisk consome uma tentativa de cada chave, mesmo quando a primeira já
+// esgotou, para que as duas contem todas as tentativas.
+func (r *RiskBasedHumanVerifier) lowRisk(ctx context.Context, subject providers.HumanSubject) bool {
+	keys := []string{"human-risk:ip:" + subject.IP}
+	if email := strings.ToLower(strings.TrimSpace(subject.Email)); email != "" {
+		sum := sha256.Sum256([]byte(email))
+		keys = append(keys, "human-risk:email:"+hex.EncodeToString(sum[:]))
+	}
+
+	low := true
+	for _, key := range keys {
+		result, err := r.attempts.Allow(ctx, key, r.threshold)
+		if err != nil {
+			// Sem como contar as tentativas, a prova passa a ser exigida
+			logging.FromContext(ctx).Warn("human verification risk check failed", slog.Any("error", err))
+			low = false
+		} else if !result.Allowed {
+			low = false
+		}
+	}
+	return low
+}
@@ -0,0 +1,232 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"net/http"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+// Formatos aceitos, identificados pelo conteúdo e não pela extensão ou pelo
+// Content-Type enviado pelo cliente
+var supportedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// StdImageProcessor usa só a biblioteca padrão. Decodificar e codificar de
+// novo em PNG descarta qualquer metadado do arquivo original.
+type StdImageProcessor struct {
+	maxPixels int
+}
+
+// NewStdImageProcessor recusa imagens com mais de maxPixels pixels, o que
+// protege contra arquivos pequenos que descomprimem para dimensões enormes.
+func NewStdImageProcessor(maxPixels int) *StdImageProcessor {
+	return &StdImageProcessor{maxPixels: maxPixels}
+}
+
+func (p *StdImageProcessor) Thumbnails(data []byte, sizes []int) (map[int][]byte, error) {
+	contentType := http.DetectContentType(data)
+	if !supportedImageTypes[contentType] {
+		return nil, msgerror.AnErrUnsupportedImage
+	}
+
+	// Confere as dimensões antes de alocar a imagem inteira
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width <= 0 || config.Height <= 0 {
+		return nil, msgerror.AnErrInvalidImage
+	}
+	if config.Width*config.Height > p.maxPixels {
+		return nil, msgerror.AnErrImageTooLarge
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, msgerror.AnErrInvalidImage
+	}
+
+	img := toRGBA(decoded)
+	if contentType == "image/jpeg" {
+		// O EXIF some na nova codificação, então a rotação é aplicada antes
+		img = orient(img, jpegOrientation(data))
+	}
+	img = cropSquare(img)
+
+	thumbnails := make(map[int][]byte, len(sizes))
+	for _, size := range sizes {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, resize(img, size)); err != nil {
+			return nil, err
+		}
+		thumbnails[size] = buf.Bytes()
+	}
+	return thumbnails, nil
+}
+
+func toRGBA(src image.Image) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, b.Min, draw.Src)
+	return dst
+}
+
+func cropSquare(src *image.RGBA) *image.RGBA {
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	side := min(w, h)
+	x0, y0 := (w-side)/2, (h-side)/2
+	return toRGBA(src.SubImage(image.Rect(x0, y0, x0+side, y0+side)))
+}
+
+// resize calcula cada pixel pela média da área correspondente na origem; ao
+// ampliar, a área tem um único pixel e o resultado é o vizinho mais próximo.
+func resize(src *image.RGBA, size int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+
+	for y := 0; y < size; y++ {
+		y0, y1 := sourceSpan(y, h, size)
+		for x := 0; x < size; x++ {
+			x0, x1 := sourceSpan(x, w, size)
+
+			var sum [4]uint64
+			for sy := y0; sy < y1; sy++ {
+				offset := src.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					for c := 0; c < 4; c++ {
+						sum[c] += uint64(src.Pix[offset+c])
+					}
+					offset += 4
+				}
+			}
+
+			n := uint64((x1 - x0) * (y1 - y0))
+			offset := dst.PixOffset(x, y)
+			for c := 0; c < 4; c++ {
+				dst.Pix[offset+c] = uint8((sum[c] + n/2) / n)
+			}
+		}
+	}
+	return dst
+}
+
+func sourceSpan(i, srcSize, dstSize int) (int, int) {
+	start := i * srcSize / dstSize
+	end := (i + 1) * srcSize / dstSize
+	if end <= start {
+		end = start + 1
+	}
+	return start, end
+}
+
+// orient aplica a orientação EXIF (1 a 8) girando ou espelhando os pixels.
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // espelhada na horizontal
+				sx, sy = w-1-x, y
+			case 3: // 180°
+				sx, sy = w-1-x, h-1-y
+			case 4: // espelhada na vertical
+				sx, sy = x, h-1-y
+			case 5: // transposta
+				sx, sy = y, x
+			case 6: // 90° no sentido horário
+				sx, sy = y, h-1-x
+			case 7: // transversa
+				sx, sy = w-1-y, h-1-x
+			case 8: // 90° no sentido anti-horário
+				sx, sy = w-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(sx, sy):src.PixOffset(sx, sy)+4])
+		}
+	}
+	return dst
+}
+
+// jpegOrientation procura a tag Orientation (0x0112) no segmento APP1 do
+// JPEG. Sem EXIF, ou com EXIF malformado, a imagem fica como está (1).
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		// Os metadados vêm antes dos dados da imagem (SOS) ou do fim (EOI)
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		if marker == 0xE1 {
+			if orientation := exifOrientation(data[i+4 : i+2+length]); orientation != 0 {
+				return orientation
+			}
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+func exifOrientation(segment []byte) int {
+	if len(segment) < 14 || string(segment[:6]) != "Exif\x00\x00" {
+		return 0
+	}
+	tiff := segment[6:]
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for n := 0; n < count; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8:]))
+			if orientation >= 1 && orientation <= 8 {
+				return orientation
+			}
+			return 0
+		}
+	}
+	return 0
+}
@@ -0,0 +1,137 @@
+package providers
+
+import (
+	"context"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/metrics"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+)
+
+// Os decorators abaixo alimentam as métricas do Prometheus sem alterar o
+// comportamento do provider decorado.
+
+// InstrumentedBlacklist mede a latência de cada operação no Redis.
+type InstrumentedBlacklist struct {
+	inner   providers.BlacklistProvider
+	metrics *metrics.Metrics
+}
+
+func NewInstrumentedBlacklist(inner providers.BlacklistProvider, m *metrics.Metrics) *InstrumentedBlacklist {
+	return &InstrumentedBlacklist{inner: inner, metrics: m}
+}
+
+func (b *InstrumentedBlacklist) observe(operation string, start time.Time, err error) {
+	b.metrics.BlacklistDuration.Observe(metrics.Since(start), operation, metrics.Result(err))
+}
+
+func (b *InstrumentedBlacklist) Add(ctx context.Context, token string, ttl time.Duration) error {
+	start := time.Now()
+	err := b.inner.Add(ctx, token, ttl)
+	b.observe("add", start, err)
+	return err
+}
+
+func (b *InstrumentedBlacklist) AddIfAbsent(ctx context.Context, token string, ttl time.Duration) (bool, error) {
+	start := time.Now()
+	added, err := b.inner.AddIfAbsent(ctx, token, ttl)
+	b.observe("add_if_absent", start, err)
+	return added, err
+}
+
+func (b *InstrumentedBlacklist) Exists(ctx context.Context, token string) (bool, error) {
+	start := time.Now()
+	exists, err := b.inner.Exists(ctx, token)
+	b.observe("exists", start, err)
+	return exists, err
+}
+
+func (b *InstrumentedBlacklist) ExistsKey(ctx context.Context, key string) (bool, error) {
+	start := time.Now()
+	exists, err := b.inner.ExistsKey(ctx, key)
+	b.observe("exists_key", start, err)
+	return exists, err
+}
+
+func (b *InstrumentedBlacklist) SetWithKey(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	start := time.Now()
+	err := b.inner.SetWithKey(ctx, key, value, ttl)
+	b.observe("set_with_key", start, err)
+	return err
+}
+
+func (b *InstrumentedBlacklist) Get(ctx context.Context, key string) (string, error) {
+	start := time.Now()
+	value, err := b.inner.Get(ctx, key)
+	b.observe("get", start, err)
+	return value, err
+}
+
+func (b *InstrumentedBlacklist) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
+	start := time.Now()
+	values, err := b.inner.MGet(ctx, keys...)
+	b.observe("mget", start, err)
+	return values, err
+}
+
+func (b *InstrumentedBlacklist) Del(ctx context.Context, keys ...string) error {
+	start := time.Now()
+	err := b.inner.Del(ctx, keys...)
+	b.observe("del", start, err)
+	return err
+}
+
+// InstrumentedTokenProvider conta as validações de JWT (assinatura e validade).
+type InstrumentedTokenProvider struct {
+	inner   providers.TokenProvider
+	metrics *metrics.Metrics
+}
+
+func NewInstrumentedTokenProvider(inner providers.TokenProvider, m *metrics.Metrics) *InstrumentedTokenProvider {
+	return &InstrumentedTokenProvider{inner: inner, metrics: m}
+}
+
+func (p *InstrumentedTokenProvider) Generate(ctx context.Context, claims interface{}) (string, error) {
+	return p.inner.Generate(ctx, claims)
+}
+
+func (p *InstrumentedTokenProvider) Validate(ctx context.Context, token string) (interface{}, error) {
+	claims, err := p.inner.Validate(ctx, token)
+	result := "valid"
+	if err != nil {
+		result = "invalid"
+	}
+	p.metrics.TokenValidations.Inc(result)
+	return claims, err
+}
+
+// InstrumentedTokenStore conta as revogações, de um token ou de todos os
+// tokens de um usuário.
+type InstrumentedTokenStore struct {
+	inner   providers.TokenStore
+	metrics *metrics.Metrics
+}
+
+func NewInstrumentedTokenStore(inner providers.TokenStore, m *metrics.Metrics) *InstrumentedTokenStore {
+	return &InstrumentedTokenStore{inner: inner, metrics: m}
+}
+
+func (s *InstrumentedTokenStore) Register(ctx context.Context, claims providers.Claims) error {
+	return s.inner.Register(ctx, claims)
+}
+
+func (s *InstrumentedTokenStore) Revoke(ctx context.Context, claims providers.Claims) error {
+	err := s.inner.Revoke(ctx, claims)
+	s.metrics.TokenRevocations.Inc("token", metrics.Result(err))
+	return err
+}
+
+func (s *InstrumentedTokenStore) IsActive(ctx context.Context, claims providers.Claims) (bool, error) {
+	return s.inner.IsActive(ctx, claims)
+}
+
+func (s *InstrumentedTokenStore) RevokeUser(ctx context.Context, userID string, ttl time.Duration) error {
+	err := s.inner.RevokeUser(ctx, userID, ttl)
+	s.metrics.TokenRevocations.Inc("user", metrics.Result(err))
+	return err
+}
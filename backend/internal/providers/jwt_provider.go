@@ -1,7 +1,9 @@
 package providers
 
 import (
+	"context"
 	"errors"
+	"math"
 	"time"
 
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
@@ -20,23 +22,35 @@ func NewJWTProvider(secret string, expiry time.Duration) *JWTProvider {
 	}
 }
 
-func (j *JWTProvider) Generate(claims interface{}) (string, error) {
+func (j *JWTProvider) Generate(_ context.Context, claims interface{}) (string, error) {
 	c, ok := claims.(providers.Claims)
 	if !ok {
 		return "", errors.New("tipo de claims inválido")
 	}
 
-	expirationTime := time.Now().Add(j.expiry)
+	now := time.Now()
+	expirationTime := now.Add(j.expiry)
+	if c.ExpiresAt != nil {
+		expirationTime = c.ExpiresAt.Time
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	// iat leva milissegundos (NumericDate aceita fração): a revogação por
+	// usuário compara nessa precisão
+	mapClaims := jwt.MapClaims{
 		"sub":     c.RegisteredClaims.Subject,
 		"exp":     expirationTime.Unix(),
+		"iat":     float64(now.UnixMilli()) / 1000,
 		"user_id": c.UserID,
-	})
+	}
+	if c.ID != "" {
+		mapClaims["jti"] = c.ID
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, mapClaims)
 	return token.SignedString(j.secretKey)
 }
 
-func (j *JWTProvider) Validate(token string) (interface{}, error) {
+func (j *JWTProvider) Validate(_ context.Context, token string) (interface{}, error) {
 	parsedToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
 		return j.secretKey, nil
 	})
@@ -62,11 +76,22 @@ func (j *JWTProvider) Validate(token string) (interface{}, error) {
 	}
 	expirationTime := time.Unix(int64(expFloat), 0)
 
+	// jti e iat são opcionais: tokens sem jti são rejeitados pelo TokenStore
+	jti, _ := claims["jti"].(string)
+
+	var issuedAt *jwt.NumericDate
+	if iatFloat, ok := claims["iat"].(float64); ok {
+		// NewNumericDate truncaria para segundos
+		issuedAt = &jwt.NumericDate{Time: time.UnixMilli(int64(math.Round(iatFloat * 1000)))}
+	}
+
 	return providers.Claims{
 		UserID: userID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
 			Subject:   subject,
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			IssuedAt:  issuedAt,
 		},
 	}, nil
 }
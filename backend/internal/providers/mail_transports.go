@@ -0,0 +1,287 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	service "github.com/eskokado/startup-auth-go/backend/pkg/domain/services"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"gopkg.in/gomail.v2"
+)
+
+type MailTransportConfig struct {
+	Transport    string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	// FileDir é o diretório maildir do transporte "file"
+	FileDir string
+	// MailboxSize limita as mensagens retidas pelo transporte "mailbox"
+	MailboxSize int
+	// Logger nil usa slog.Default()
+	Logger *slog.Logger
+}
+
+// NewMailSender escolhe o transporte de e-mail conforme a configuração.
+func NewMailSender(cfg MailTransportConfig) (service.MailSender, error) {
+	switch cfg.Transport {
+	case "", service.MailTransportSMTP:
+		return gomail.NewDialer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword), nil
+	case service.MailTransportMailbox:
+		return NewMemoryMailbox(cfg.MailboxSize), nil
+	case service.MailTransportFile:
+		return NewFileMailTransport(cfg.FileDir)
+	case service.MailTransportLog:
+		return NewLogMailTransport(cfg.Logger), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", msgerror.AnErrInvalidTransport, cfg.Transport)
+	}
+}
+
+// LogMailTransport não entrega nada; registra só destinatários e assunto,
+// já que o corpo leva links com tokens.
+type LogMailTransport struct {
+	logger *slog.Logger
+}
+
+func NewLogMailTransport(logger *slog.Logger) *LogMailTransport {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LogMailTransport{logger: logger}
+}
+
+func (t *LogMailTransport) DialAndSend(messages ...*gomail.Message) error {
+	for _, m := range messages {
+		t.logger.Info("email not sent (log transport)",
+			slog.String("to", strings.Join(m.GetHeader("To"), ",")),
+			slog.String("subject", decodeHeader(strings.Join(m.GetHeader("Subject"), " "))),
+		)
+	}
+	return nil
+}
+
+// FileMailTransport entrega cada mensagem como um arquivo .eml em formato
+// maildir: escreve em tmp/ e move para new/, então leitores nunca veem
+// arquivos pela metade.
+type FileMailTransport struct {
+	dir      string
+	hostname string
+}
+
+func NewFileMailTransport(dir string) (*FileMailTransport, error) {
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o700); err != nil {
+			return nil, fmt.Errorf("failed to create maildir: %w", err)
+		}
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "localhost"
+	}
+	// "/" e ":" têm significado especial em nomes maildir
+	hostname = strings.NewReplacer("/", "_", ":", "_").Replace(hostname)
+
+	return &FileMailTransport{dir: dir, hostname: hostname}, nil
+}
+
+func (t *FileMailTransport) DialAndSend(messages ...*gomail.Message) error {
+	for _, m := range messages {
+		name := fmt.Sprintf("%d.%s.%s.eml", time.Now().UnixNano(), vo.NewID(), t.hostname)
+		tmp := filepath.Join(t.dir, "tmp", name)
+
+		if err := writeMessageFile(tmp, m); err != nil {
+			return err
+		}
+		if err := os.Rename(tmp, filepath.Join(t.dir, "new", name)); err != nil {
+			_ = os.Remove(tmp)
+			return err
+		}
+	}
+	return nil
+}
+
+func writeMessageFile(path string, m *gomail.Message) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := m.WriteTo(f); err != nil {
+		f.Close()
+		_ = os.Remove(path)
+		return err
+	}
+	return f.Close()
+}
+
+// MemoryMailbox retém as últimas mensagens enviadas para inspeção em
+// desenvolvimento e testes ponta a ponta. Nada sai do processo.
+type MemoryMailbox struct {
+	mu       sync.Mutex
+	capacity int
+	messages []dto.MailboxMessage
+}
+
+func NewMemoryMailbox(capacity int) *MemoryMailbox {
+	if capacity <= 0 {
+		capacity = 100
+	}
+	return &MemoryMailbox{capacity: capacity}
+}
+
+func (b *MemoryMailbox) DialAndSend(messages ...*gomail.Message) error {
+	parsed := make([]dto.MailboxMessage, 0, len(messages))
+	for _, m := range messages {
+		message, err := parseMessage(m)
+		if err != nil {
+			return err
+		}
+		parsed = append(parsed, message)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.messages = append(b.messages, parsed...)
+	if extra := len(b.messages) - b.capacity; extra > 0 {
+		b.messages = append([]dto.MailboxMessage(nil), b.messages[extra:]...)
+	}
+	return nil
+}
+
+func (b *MemoryMailbox) Messages(to string) []dto.MailboxMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result := make([]dto.MailboxMessage, 0, len(b.messages))
+	for i := len(b.messages) - 1; i >= 0; i-- {
+		if to == "" || hasRecipient(b.messages[i], to) {
+			result = append(result, b.messages[i])
+		}
+	}
+	return result
+}
+
+func (b *MemoryMailbox) Clear() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.messages = nil
+}
+
+func hasRecipient(message dto.MailboxMessage, to string) bool {
+	for _, recipient := range message.To {
+		if strings.EqualFold(recipient, to) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMessage serializa a mensagem como seria enviada e lê de volta
+// cabeçalhos e partes, já decodificados.
+func parseMessage(m *gomail.Message) (dto.MailboxMessage, error) {
+	var raw bytes.Buffer
+	if _, err := m.WriteTo(&raw); err != nil {
+		return dto.MailboxMessage{}, err
+	}
+	msg, err := mail.ReadMessage(&raw)
+	if err != nil {
+		return dto.MailboxMessage{}, err
+	}
+
+	message := dto.MailboxMessage{
+		ID:         vo.NewID().String(),
+		From:       decodeHeader(msg.Header.Get("From")),
+		Subject:    decodeHeader(msg.Header.Get("Subject")),
+		ReceivedAt: time.Now(),
+	}
+	if to, err := msg.Header.AddressList("To"); err == nil {
+		for _, address := range to {
+			message.To = append(message.To, address.Address)
+		}
+	}
+
+	err = readParts(mimeHeader(msg.Header), msg.Body, &message)
+	return message, err
+}
+
+func readParts(header mimeHeader, body io.Reader, message *dto.MailboxMessage) error {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			// NextPart já decodifica quoted-printable e remove o cabeçalho
+			if err := readParts(mimeHeader(part.Header), part, message); err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := io.ReadAll(decodeTransfer(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return err
+	}
+	switch mediaType {
+	case "text/plain":
+		message.Text = string(content)
+	case "text/html":
+		message.HTML = string(content)
+	}
+	return nil
+}
+
+// decodeHeader desfaz a codificação RFC 2047 (=?UTF-8?q?...?=) que o gomail aplica.
+func decodeHeader(value string) string {
+	decoded, err := new(mime.WordDecoder).DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
+
+func decodeTransfer(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(encoding) {
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	default:
+		return body
+	}
+}
+
+// mimeHeader cobre os cabeçalhos de mail.Header e textproto.MIMEHeader.
+type mimeHeader map[string][]string
+
+func (h mimeHeader) Get(key string) string {
+	if values := h[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,118 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MemoryBlacklist guarda as chaves no processo com as mesmas regras do
+// RedisBlacklist; serve para testes e para uma única instância.
+type MemoryBlacklist struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	value string
+	// Zero: não expira
+	expiresAt time.Time
+}
+
+// now pode ser nil (time.Now).
+func NewMemoryBlacklist(now func() time.Time) *MemoryBlacklist {
+	if now == nil {
+		now = time.Now
+	}
+	return &MemoryBlacklist{now: now, entries: make(map[string]memoryEntry)}
+}
+
+func (m *MemoryBlacklist) Add(_ context.Context, token string, ttl time.Duration) error {
+	value := ""
+	if ttl > 0 {
+		value = token
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.set("startup-auth-go:"+token, value, ttl)
+	return nil
+}
+
+func (m *MemoryBlacklist) AddIfAbsent(_ context.Context, token string, ttl time.Duration) (bool, error) {
+	key := "startup-auth-go:" + token
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.lookup(key); ok {
+		return false, nil
+	}
+	m.set(key, token, ttl)
+	return true, nil
+}
+
+func (m *MemoryBlacklist) Exists(ctx context.Context, token string) (bool, error) {
+	return m.ExistsKey(ctx, "startup-auth-go:"+token)
+}
+
+func (m *MemoryBlacklist) ExistsKey(_ context.Context, key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.lookup(key)
+	return ok, nil
+}
+
+func (m *MemoryBlacklist) SetWithKey(_ context.Context, key string, value interface{}, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.set(key, fmt.Sprint(value), ttl)
+	return nil
+}
+
+func (m *MemoryBlacklist) Get(_ context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, _ := m.lookup(key)
+	return entry.value, nil
+}
+
+// MGet devolve nil nas chaves ausentes, como o Redis.
+func (m *MemoryBlacklist) MGet(_ context.Context, keys ...string) ([]interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		if entry, ok := m.lookup(key); ok {
+			values[i] = entry.value
+		}
+	}
+	return values, nil
+}
+
+func (m *MemoryBlacklist) Del(_ context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, key := range keys {
+		delete(m.entries, key)
+	}
+	return nil
+}
+
+// lookup e set exigem mu travado.
+func (m *MemoryBlacklist) lookup(key string) (memoryEntry, bool) {
+	entry, ok := m.entries[key]
+	if ok && !entry.expiresAt.IsZero() && !m.now().Before(entry.expiresAt) {
+		delete(m.entries, key)
+		return memoryEntry{}, false
+	}
+	return entry, ok
+}
+
+func (m *MemoryBlacklist) set(key, value string, ttl time.Duration) {
+	entry := memoryEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = m.now().Add(ttl)
+	}
+	m.entries[key] = entry
+}
@@ -0,0 +1,285 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+const rateLimitKeyPrefix = "startup-auth-go:ratelimit:"
+
+// NewRateLimiter escolhe o backend ("memory" ou "redis") e o algoritmo.
+// O backend em memória não é compartilhado entre instâncias.
+func NewRateLimiter(backend, algorithm string, client RedisCmdable) (providers.RateLimiter, error) {
+	switch algorithm {
+	case "":
+		algorithm = providers.RateLimitTokenBucket
+	case providers.RateLimitTokenBucket, providers.RateLimitSlidingWindow:
+	default:
+		return nil, fmt.Errorf("%w: algorithm %q", msgerror.AnErrInvalidRateLimit, algorithm)
+	}
+
+	switch backend {
+	case "memory":
+		return NewMemoryRateLimiter(algorithm, nil), nil
+	case "", "redis":
+		return NewRedisRateLimiter(algorithm, client), nil
+	default:
+		return nil, fmt.Errorf("%w: backend %q", msgerror.AnErrInvalidRateLimit, backend)
+	}
+}
+
+// rateLimitState é o estado de uma chave. No token bucket, A são os tokens e
+// Stamp o último reabastecimento; na janela deslizante, Stamp é o início da
+// janela atual, A o contador dela e B o da anterior.
+type rateLimitState struct {
+	A, B      float64
+	Stamp     time.Time
+	ExpiresAt time.Time
+}
+
+// tokenBucket consome um token; a cota enche por completo a cada Window.
+func tokenBucket(state *rateLimitState, limit providers.RateLimit, now time.Time) providers.RateLimitResult {
+	capacity := float64(limit.Limit)
+	perSecond := capacity / limit.Window.Seconds()
+
+	tokens := capacity
+	if !state.Stamp.IsZero() {
+		tokens = math.Min(capacity, state.A+now.Sub(state.Stamp).Seconds()*perSecond)
+	}
+
+	result := providers.RateLimitResult{}
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - tokens) / perSecond)
+	}
+
+	state.A, state.Stamp = tokens, now
+	state.ExpiresAt = now.Add(limit.Window)
+	result.Remaining = int(tokens)
+	result.ResetAfter = seconds((capacity - tokens) / perSecond)
+	return result
+}
+
+// slidingWindow conta a requisição na janela atual e pondera a anterior pela
+// fração dela que ainda cai na janela deslizante.
+func slidingWindow(state *rateLimitState, limit providers.RateLimit, now time.Time) providers.RateLimitResult {
+	window := limit.Window
+	start := now.Truncate(window)
+	switch {
+	case state.Stamp.Equal(start):
+	case state.Stamp.Equal(start.Add(-window)):
+		state.A, state.B = 0, state.A
+	default:
+		state.A, state.B = 0, 0
+	}
+	state.Stamp = start
+
+	elapsed := now.Sub(start).Seconds() / window.Seconds()
+	max := float64(limit.Limit)
+	used := state.B*(1-elapsed) + state.A
+
+	result := providers.RateLimitResult{}
+	if used+1 <= max {
+		state.A++
+		used++
+		result.Allowed = true
+	} else if state.A+1 > max || state.B == 0 {
+		// Só a próxima janela libera
+		result.RetryAfter = start.Add(window).Sub(now)
+	} else {
+		// A janela anterior perde peso até sobrar espaço para uma requisição
+		free := 1 - (max-1-state.A)/state.B
+		result.RetryAfter = start.Add(time.Duration(free * float64(window))).Sub(now)
+	}
+
+	state.ExpiresAt = start.Add(2 * window)
+	result.Remaining = int(math.Max(0, max-used))
+	result.ResetAfter = start.Add(window).Sub(now)
+	if state.A > 0 {
+		result.ResetAfter += window
+	}
+	return result
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
+
+// MemoryRateLimiter guarda as cotas no processo; serve para testes e para
+// uma única instância.
+type MemoryRateLimiter struct {
+	algorithm string
+	now       func() time.Time
+
+	mu     sync.Mutex
+	states map[string]*rateLimitState
+	calls  int
+}
+
+// now pode ser nil (time.Now).
+func NewMemoryRateLimiter(algorithm string, now func() time.Time) *MemoryRateLimiter {
+	if now == nil {
+		now = time.Now
+	}
+	return &MemoryRateLimiter{algorithm: algorithm, now: now, states: make(map[string]*rateLimitState)}
+}
+
+// Limpa as chaves vencidas a cada tantas chamadas
+const memorySweepEvery = 1000
+
+func (m *MemoryRateLimiter) Allow(_ context.Context, key string, limit providers.RateLimit) (providers.RateLimitResult, error) {
+	if limit.Limit <= 0 || limit.Window <= 0 {
+		return providers.RateLimitResult{}, msgerror.AnErrInvalidRateLimit
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.calls++
+	if m.calls%memorySweepEvery == 0 {
+		for k, state := range m.states {
+			if now.After(state.ExpiresAt) {
+				delete(m.states, k)
+			}
+		}
+	}
+
+	state, ok := m.states[key]
+	if !ok || now.After(state.ExpiresAt) {
+		state = &rateLimitState{}
+		m.states[key] = state
+	}
+
+	if m.algorithm == providers.RateLimitSlidingWindow {
+		return slidingWindow(state, limit, now), nil
+	}
+	return tokenBucket(state, limit, now), nil
+}
+
+// Os scripts repetem tokenBucket e slidingWindow para que a leitura e a
+// escrita da cota sejam atômicas entre instâncias. Os tempos vão em
+// milissegundos; o retorno é {permitido, restantes, reset_ms, retry_ms}.
+const tokenBucketScript = `
+local capacity = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local rate = capacity / window
+local state = redis.call("HMGET", KEYS[1], "tokens", "stamp")
+local tokens = capacity
+if state[1] then
+  tokens = math.min(capacity, tonumber(state[1]) + (now - tonumber(state[2])) * rate)
+end
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  retry = math.ceil((1 - tokens) / rate)
+end
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "stamp", now)
+redis.call("PEXPIRE", KEYS[1], window)
+return {allowed, math.floor(tokens), math.ceil((capacity - tokens) / rate), retry}
+`
+
+const slidingWindowScript = `
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local start = now - (now % window)
+local state = redis.call("HMGET", KEYS[1], "start", "current", "previous")
+local current, previous = 0, 0
+if state[1] then
+  local stored = tonumber(state[1])
+  if stored == start then
+    current, previous = tonumber(state[2]), tonumber(state[3])
+  elseif stored == start - window then
+    previous = tonumber(state[2])
+  end
+end
+local used = previous * (1 - (now - start) / window) + current
+local allowed = 0
+local retry = 0
+if used + 1 <= limit then
+  current = current + 1
+  used = used + 1
+  allowed = 1
+elseif current + 1 > limit or previous == 0 then
+  retry = start + window - now
+else
+  retry = math.ceil(start + (1 - (limit - 1 - current) / previous) * window - now)
+end
+redis.call("HSET", KEYS[1], "start", start, "current", current, "previous", previous)
+redis.call("PEXPIRE", KEYS[1], 2 * window)
+local reset = start + window - now
+if current > 0 then
+  reset = reset + window
+end
+return {allowed, math.max(0, math.floor(limit - used)), reset, retry}
+`
+
+// RedisRateLimiter compartilha as cotas entre as instâncias da API.
+type RedisRateLimiter struct {
+	script string
+	client RedisCmdable
+	now    func() time.Time
+}
+
+func NewRedisRateLimiter(algorithm string, client RedisCmdable) *RedisRateLimiter {
+	script := tokenBucketScript
+	if algorithm == providers.RateLimitSlidingWindow {
+		script = slidingWindowScript
+	}
+	return &RedisRateLimiter{script: script, client: client, now: time.Now}
+}
+
+func (r *RedisRateLimiter) Allow(ctx context.Context, key string, limit providers.RateLimit) (providers.RateLimitResult, error) {
+	if limit.Limit <= 0 || limit.Window <= 0 {
+		return providers.RateLimitResult{}, msgerror.AnErrInvalidRateLimit
+	}
+
+	raw, err := r.client.Eval(ctx, r.script, []string{rateLimitKeyPrefix + key},
+		limit.Limit, limit.Window.Milliseconds(), r.now().UnixMilli()).Result()
+	if err != nil {
+		return providers.RateLimitResult{}, err
+	}
+
+	values, ok := raw.([]interface{})
+	if !ok || len(values) != 4 {
+		return providers.RateLimitResult{}, fmt.Errorf("rate limit: unexpected reply %v", raw)
+	}
+	ints := make([]int64, len(values))
+	for i, value := range values {
+		if ints[i], err = toInt64(value); err != nil {
+			return providers.RateLimitResult{}, err
+		}
+	}
+
+	return providers.RateLimitResult{
+		Allowed:    ints[0] == 1,
+		Remaining:  int(ints[1]),
+		ResetAfter: time.Duration(ints[2]) * time.Millisecond,
+		RetryAfter: time.Duration(ints[3]) * time.Millisecond,
+	}, nil
+}
+
+// O Redis converte números do Lua em inteiros; strings aparecem em clientes de teste.
+func toInt64(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int64:
+		return v, nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	}
+	return 0, fmt.Errorf("rate limit: unexpected value %v", value)
+}
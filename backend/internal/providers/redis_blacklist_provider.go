@@ -7,13 +7,15 @@ import (
 	"github.com/go-redis/redis/v8"
 )
 
-// RedisCmdable define a interface mínima necessária para o blacklist
+// RedisCmdable define a interface mínima do Redis usada pelos providers
 type RedisCmdable interface {
 	Get(ctx context.Context, key string) *redis.StringCmd
 	MGet(ctx context.Context, keys ...string) *redis.SliceCmd
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd
 	Exists(ctx context.Context, keys ...string) *redis.IntCmd
 	Del(ctx context.Context, keys ...string) *redis.IntCmd
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) *redis.Cmd
 }
 
 type RedisBlacklist struct {
@@ -36,6 +38,11 @@ func (r *RedisBlacklist) Add(
 	return r.client.Set(ctx, "startup-auth-go:"+token, value, ttl).Err()
 }
 
+// AddIfAbsent usa SET NX: só uma de várias chamadas simultâneas grava.
+func (r *RedisBlacklist) AddIfAbsent(ctx context.Context, token string, ttl time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, "startup-auth-go:"+token, token, ttl).Result()
+}
+
 func (r *RedisBlacklist) Exists(ctx context.Context, token string) (bool, error) {
 	exists, err := r.client.Exists(ctx, "startup-auth-go:"+token).Result()
 	return exists > 0, err
@@ -0,0 +1,159 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+const (
+	sessionKeyPrefix = "startup-auth-go:session:"
+	// BlacklistProvider.Add/Exists já prefixam as chaves com "startup-auth-go:"
+	revokedKeyPrefix = "revoked:"
+	// Guarda o instante (unix em milissegundos) até o qual os tokens do
+	// usuário são inválidos
+	userRevokedKeyPrefix = "startup-auth-go:user-revoked:"
+)
+
+// NewTokenStore escolhe a implementação conforme o modo configurado.
+func NewTokenStore(mode string, store providers.BlacklistProvider) (providers.TokenStore, error) {
+	switch mode {
+	case "", providers.TokenStoreAllowlist:
+		return NewAllowlistTokenStore(store), nil
+	case providers.TokenStoreDenylist:
+		return NewDenylistTokenStore(store), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", msgerror.AnErrInvalidTokenStore, mode)
+	}
+}
+
+// AllowlistTokenStore mantém uma chave por sessão ativa; logout remove a chave.
+type AllowlistTokenStore struct {
+	store providers.BlacklistProvider
+}
+
+func NewAllowlistTokenStore(store providers.BlacklistProvider) *AllowlistTokenStore {
+	return &AllowlistTokenStore{store: store}
+}
+
+func (s *AllowlistTokenStore) Register(ctx context.Context, claims providers.Claims) error {
+	if claims.ID == "" {
+		return msgerror.AnErrMissingTokenID
+	}
+
+	ttl := remainingTTL(claims)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return s.store.SetWithKey(ctx, sessionKeyPrefix+claims.ID, claims.UserID, ttl)
+}
+
+func (s *AllowlistTokenStore) Revoke(ctx context.Context, claims providers.Claims) error {
+	if claims.ID == "" {
+		return nil
+	}
+	return s.store.Del(ctx, sessionKeyPrefix+claims.ID)
+}
+
+func (s *AllowlistTokenStore) IsActive(ctx context.Context, claims providers.Claims) (bool, error) {
+	if claims.ID == "" {
+		return false, nil
+	}
+	active, err := s.store.ExistsKey(ctx, sessionKeyPrefix+claims.ID)
+	if err != nil || !active {
+		return false, err
+	}
+	return notRevokedForUser(ctx, s.store, claims)
+}
+
+func (s *AllowlistTokenStore) RevokeUser(ctx context.Context, userID string, ttl time.Duration) error {
+	return revokeUser(ctx, s.store, userID, ttl)
+}
+
+// DenylistTokenStore não guarda estado no login; logout registra o jti
+// revogado até o token expirar naturalmente.
+type DenylistTokenStore struct {
+	store providers.BlacklistProvider
+}
+
+func NewDenylistTokenStore(store providers.BlacklistProvider) *DenylistTokenStore {
+	return &DenylistTokenStore{store: store}
+}
+
+func (s *DenylistTokenStore) Register(ctx context.Context, claims providers.Claims) error {
+	if claims.ID == "" {
+		return msgerror.AnErrMissingTokenID
+	}
+	return nil
+}
+
+func (s *DenylistTokenStore) Revoke(ctx context.Context, claims providers.Claims) error {
+	if claims.ID == "" {
+		return nil
+	}
+
+	ttl := remainingTTL(claims)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return s.store.Add(ctx, revokedKeyPrefix+claims.ID, ttl)
+}
+
+func (s *DenylistTokenStore) IsActive(ctx context.Context, claims providers.Claims) (bool, error) {
+	// Sem jti não há como revogar o token, então ele não é aceito
+	if claims.ID == "" {
+		return false, nil
+	}
+
+	revoked, err := s.store.Exists(ctx, revokedKeyPrefix+claims.ID)
+	if err != nil || revoked {
+		return false, err
+	}
+	return notRevokedForUser(ctx, s.store, claims)
+}
+
+func (s *DenylistTokenStore) RevokeUser(ctx context.Context, userID string, ttl time.Duration) error {
+	return revokeUser(ctx, s.store, userID, ttl)
+}
+
+// revokeUser funciona nos dois modos: tokens com iat até o instante gravado são recusados.
+func revokeUser(ctx context.Context, store providers.BlacklistProvider, userID string, ttl time.Duration) error {
+	if userID == "" || ttl <= 0 {
+		return nil
+	}
+	return store.SetWithKey(ctx, userRevokedKeyPrefix+userID, strconv.FormatInt(time.Now().UnixMilli(), 10), ttl)
+}
+
+func notRevokedForUser(ctx context.Context, store providers.BlacklistProvider, claims providers.Claims) (bool, error) {
+	if claims.UserID == "" {
+		return true, nil
+	}
+
+	value, err := store.Get(ctx, userRevokedKeyPrefix+claims.UserID)
+	if err != nil {
+		return false, err
+	}
+	if value == "" {
+		return true, nil
+	}
+
+	// Na dúvida (valor corrompido ou token sem iat), o token é recusado
+	cutoff, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || claims.IssuedAt == nil {
+		return false, nil
+	}
+	return claims.IssuedAt.UnixMilli() > cutoff, nil
+}
+
+func remainingTTL(claims providers.Claims) time.Duration {
+	if claims.ExpiresAt == nil {
+		return 0
+	}
+	return time.Until(claims.ExpiresAt.Time)
+}
@@ -0,0 +1,179 @@
+package providers
+
+import (
+	"context"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/tracing"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	service "github.com/eskokado/startup-auth-go/backend/pkg/domain/services"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+)
+
+// Os decorators abaixo abrem um span filho do span presente no contexto em
+// volta de cada chamada. Chaves, tokens e senhas nunca viram atributos.
+
+// TracedBlacklist rastreia as operações no Redis.
+type TracedBlacklist struct {
+	inner  providers.BlacklistProvider
+	tracer *tracing.Tracer
+}
+
+func NewTracedBlacklist(inner providers.BlacklistProvider, tracer *tracing.Tracer) *TracedBlacklist {
+	return &TracedBlacklist{inner: inner, tracer: tracer}
+}
+
+func (b *TracedBlacklist) start(ctx context.Context, operation string) (context.Context, *tracing.Span) {
+	return b.tracer.Start(ctx, "BlacklistProvider."+operation, tracing.KindClient,
+		tracing.String("db.system", "redis"))
+}
+
+func (b *TracedBlacklist) Add(ctx context.Context, token string, ttl time.Duration) error {
+	ctx, span := b.start(ctx, "Add")
+	err := b.inner.Add(ctx, token, ttl)
+	span.Finish(err)
+	return err
+}
+
+func (b *TracedBlacklist) AddIfAbsent(ctx context.Context, token string, ttl time.Duration) (bool, error) {
+	ctx, span := b.start(ctx, "AddIfAbsent")
+	added, err := b.inner.AddIfAbsent(ctx, token, ttl)
+	span.Finish(err)
+	return added, err
+}
+
+func (b *TracedBlacklist) Exists(ctx context.Context, token string) (bool, error) {
+	ctx, span := b.start(ctx, "Exists")
+	exists, err := b.inner.Exists(ctx, token)
+	span.Finish(err)
+	return exists, err
+}
+
+func (b *TracedBlacklist) ExistsKey(ctx context.Context, key string) (bool, error) {
+	ctx, span := b.start(ctx, "ExistsKey")
+	exists, err := b.inner.ExistsKey(ctx, key)
+	span.Finish(err)
+	return exists, err
+}
+
+func (b *TracedBlacklist) SetWithKey(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	ctx, span := b.start(ctx, "SetWithKey")
+	err := b.inner.SetWithKey(ctx, key, value, ttl)
+	span.Finish(err)
+	return err
+}
+
+func (b *TracedBlacklist) Get(ctx context.Context, key string) (string, error) {
+	ctx, span := b.start(ctx, "Get")
+	value, err := b.inner.Get(ctx, key)
+	span.Finish(err)
+	return value, err
+}
+
+func (b *TracedBlacklist) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
+	ctx, span := b.start(ctx, "MGet")
+	span.SetAttributes(tracing.Int("db.operation.batch.size", len(keys)))
+	values, err := b.inner.MGet(ctx, keys...)
+	span.Finish(err)
+	return values, err
+}
+
+func (b *TracedBlacklist) Del(ctx context.Context, keys ...string) error {
+	ctx, span := b.start(ctx, "Del")
+	span.SetAttributes(tracing.Int("db.operation.batch.size", len(keys)))
+	err := b.inner.Del(ctx, keys...)
+	span.Finish(err)
+	return err
+}
+
+// TracedCrypto rastreia o bcrypt, em geral a etapa mais lenta do login.
+type TracedCrypto struct {
+	inner  providers.CryptoProvider
+	tracer *tracing.Tracer
+}
+
+func NewTracedCrypto(inner providers.CryptoProvider, tracer *tracing.Tracer) *TracedCrypto {
+	return &TracedCrypto{inner: inner, tracer: tracer}
+}
+
+func (p *TracedCrypto) Encrypt(ctx context.Context, password string) (string, error) {
+	ctx, span := p.tracer.Start(ctx, "CryptoProvider.Encrypt", tracing.KindInternal)
+	hash, err := p.inner.Encrypt(ctx, password)
+	span.Finish(err)
+	return hash, err
+}
+
+func (p *TracedCrypto) Compare(ctx context.Context, password, hashedPassword string) (bool, error) {
+	ctx, span := p.tracer.Start(ctx, "CryptoProvider.Compare", tracing.KindInternal)
+	match, err := p.inner.Compare(ctx, password, hashedPassword)
+	span.SetAttributes(tracing.Bool("auth.password.match", match))
+	span.Finish(err)
+	return match, err
+}
+
+// TracedTokenProvider rastreia a emissão e a validação de JWT.
+type TracedTokenProvider struct {
+	inner  providers.TokenProvider
+	tracer *tracing.Tracer
+}
+
+func NewTracedTokenProvider(inner providers.TokenProvider, tracer *tracing.Tracer) *TracedTokenProvider {
+	return &TracedTokenProvider{inner: inner, tracer: tracer}
+}
+
+func (p *TracedTokenProvider) Generate(ctx context.Context, claims interface{}) (string, error) {
+	ctx, span := p.tracer.Start(ctx, "TokenProvider.Generate", tracing.KindInternal)
+	token, err := p.inner.Generate(ctx, claims)
+	span.Finish(err)
+	return token, err
+}
+
+func (p *TracedTokenProvider) Validate(ctx context.Context, token string) (interface{}, error) {
+	ctx, span := p.tracer.Start(ctx, "TokenProvider.Validate", tracing.KindInternal)
+	claims, err := p.inner.Validate(ctx, token)
+	span.Finish(err)
+	return claims, err
+}
+
+// TracedEmailService rastreia o envio dos e-mails transacionais.
+type TracedEmailService struct {
+	inner  service.EmailServiceInterface
+	tracer *tracing.Tracer
+}
+
+func NewTracedEmailService(inner service.EmailServiceInterface, tracer *tracing.Tracer) *TracedEmailService {
+	return &TracedEmailService{inner: inner, tracer: tracer}
+}
+
+func (s *TracedEmailService) start(ctx context.Context, operation, locale string) (context.Context, *tracing.Span) {
+	return s.tracer.Start(ctx, "EmailService."+operation, tracing.KindClient,
+		tracing.String("email.locale", locale))
+}
+
+func (s *TracedEmailService) SendResetPasswordEmail(ctx context.Context, email vo.Email, locale, token string) error {
+	ctx, span := s.start(ctx, "SendResetPasswordEmail", locale)
+	err := s.inner.SendResetPasswordEmail(ctx, email, locale, token)
+	span.Finish(err)
+	return err
+}
+
+func (s *TracedEmailService) SendDataExportEmail(ctx context.Context, email vo.Email, locale, downloadURL string) error {
+	ctx, span := s.start(ctx, "SendDataExportEmail", locale)
+	err := s.inner.SendDataExportEmail(ctx, email, locale, downloadURL)
+	span.Finish(err)
+	return err
+}
+
+func (s *TracedEmailService) SendEmailChangeConfirmation(ctx context.Context, email vo.Email, locale, token string) error {
+	ctx, span := s.start(ctx, "SendEmailChangeConfirmation", locale)
+	err := s.inner.SendEmailChangeConfirmation(ctx, email, locale, token)
+	span.Finish(err)
+	return err
+}
+
+func (s *TracedEmailService) SendEmailChangeNotice(ctx context.Context, email vo.Email, locale string, newEmail vo.Email, cancelToken string) error {
+	ctx, span := s.start(ctx, "SendEmailChangeNotice", locale)
+	err := s.inner.SendEmailChangeNotice(ctx, email, locale, newEmail, cancelToken)
+	span.Finish(err)
+	return err
+}
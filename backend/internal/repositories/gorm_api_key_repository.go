@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"gorm.io/gorm"
+)
+
+type GormAPIKey struct {
+	ID         string `gorm:"primaryKey;type:varchar(36)"`
+	UserID     string `gorm:"type:varchar(36);index;not null"`
+	Name       string `gorm:"type:varchar(100);not null"`
+	Prefix     string `gorm:"type:varchar(32);uniqueIndex;not null"`
+	KeyHash    string `gorm:"type:varchar(64);not null"`
+	Scopes     string `gorm:"type:varchar(255);not null"`
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	RevokedAt  time.Time
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+type GormAPIKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAPIKeyRepository(db *gorm.DB) *GormAPIKeyRepository {
+	return &GormAPIKeyRepository{db: db}
+}
+
+func (r *GormAPIKeyRepository) toDBModel(key *entity.APIKey) *GormAPIKey {
+	return &GormAPIKey{
+		ID:         key.ID.String(),
+		UserID:     key.UserID.String(),
+		Name:       key.Name.String(),
+		Prefix:     key.Prefix,
+		KeyHash:    key.KeyHash,
+		Scopes:     strings.Join(key.Scopes, " "),
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+func (r *GormAPIKeyRepository) fromDBModel(dbKey *GormAPIKey) (*entity.APIKey, error) {
+	id, err := vo.ParseID(dbKey.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := vo.ParseID(dbKey.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := vo.NewName(dbKey.Name, 3, 50)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.APIKey{
+		ID:         id,
+		UserID:     userID,
+		Name:       name,
+		Prefix:     dbKey.Prefix,
+		KeyHash:    dbKey.KeyHash,
+		Scopes:     strings.Fields(dbKey.Scopes),
+		ExpiresAt:  dbKey.ExpiresAt,
+		LastUsedAt: dbKey.LastUsedAt,
+		RevokedAt:  dbKey.RevokedAt,
+		CreatedAt:  dbKey.CreatedAt,
+	}, nil
+}
+
+func (r *GormAPIKeyRepository) Save(ctx context.Context, key *entity.APIKey) (*entity.APIKey, error) {
+	dbKey := r.toDBModel(key)
+
+	result := dbFromContext(ctx, r.db).Save(dbKey)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.fromDBModel(dbKey)
+}
+
+func (r *GormAPIKeyRepository) GetByID(ctx context.Context, id vo.ID) (*entity.APIKey, error) {
+	return r.first(ctx, "id = ?", id.String())
+}
+
+func (r *GormAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error) {
+	return r.first(ctx, "prefix = ?", prefix)
+}
+
+func (r *GormAPIKeyRepository) ListByUserID(ctx context.Context, userID vo.ID) ([]*entity.APIKey, error) {
+	var dbKeys []GormAPIKey
+	result := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Order("created_at DESC").
+		Find(&dbKeys)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	keys := make([]*entity.APIKey, 0, len(dbKeys))
+	for i := range dbKeys {
+		key, err := r.fromDBModel(&dbKeys[i])
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// TouchLastUsed atualiza apenas last_used_at para não sobrescrever uma revogação concorrente
+func (r *GormAPIKeyRepository) TouchLastUsed(ctx context.Context, id vo.ID, at time.Time) error {
+	return dbFromContext(ctx, r.db).
+		Model(&GormAPIKey{}).
+		Where("id = ?", id.String()).
+		Update("last_used_at", at).Error
+}
+
+// RevokeAllByUserID preserva a data de revogação de chaves já revogadas.
+// O filtro é feito em Go porque "tempo zero" não é comparável da mesma forma em todos os bancos.
+func (r *GormAPIKeyRepository) RevokeAllByUserID(ctx context.Context, userID vo.ID, at time.Time) error {
+	var dbKeys []GormAPIKey
+	if err := dbFromContext(ctx, r.db).Where("user_id = ?", userID.String()).Find(&dbKeys).Error; err != nil {
+		return err
+	}
+
+	for _, dbKey := range dbKeys {
+		if !dbKey.RevokedAt.IsZero() {
+			continue
+		}
+		err := dbFromContext(ctx, r.db).
+			Model(&GormAPIKey{}).
+			Where("id = ?", dbKey.ID).
+			Update("revoked_at", at).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *GormAPIKeyRepository) DeleteByUserID(ctx context.Context, userID vo.ID) error {
+	return dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Delete(&GormAPIKey{}).Error
+}
+
+func (r *GormAPIKeyRepository) first(ctx context.Context, query string, arg string) (*entity.APIKey, error) {
+	var dbKey GormAPIKey
+	result := dbFromContext(ctx, r.db).Where(query, arg).First(&dbKey)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return r.fromDBModel(&dbKey)
+}
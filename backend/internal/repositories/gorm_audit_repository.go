@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+)
+
+type GormAuditEvent struct {
+	ID        string    `gorm:"primaryKey;type:varchar(36)"`
+	Action    string    `gorm:"type:varchar(64);index;not null"`
+	Outcome   string    `gorm:"type:varchar(16);index;not null"`
+	ActorID   string    `gorm:"type:varchar(36);index"`
+	Target    string    `gorm:"type:varchar(255);index"`
+	IP        string    `gorm:"type:varchar(64)"`
+	UserAgent string    `gorm:"type:varchar(512)"`
+	Reason    string    `gorm:"type:varchar(255)"`
+	CreatedAt time.Time `gorm:"index"`
+}
+
+// GormAuditRepository persiste eventos de auditoria e atende às consultas administrativas.
+type GormAuditRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAuditRepository(db *gorm.DB) *GormAuditRepository {
+	return &GormAuditRepository{db: db}
+}
+
+func (r *GormAuditRepository) Log(ctx context.Context, event *entity.AuditEvent) error {
+	return dbFromContext(ctx, r.db).Create(&GormAuditEvent{
+		ID:        event.ID.String(),
+		Action:    event.Action,
+		Outcome:   event.Outcome,
+		ActorID:   event.ActorID,
+		Target:    event.Target,
+		IP:        event.IP,
+		UserAgent: event.UserAgent,
+		Reason:    event.Reason,
+		CreatedAt: event.CreatedAt,
+	}).Error
+}
+
+func (r *GormAuditRepository) List(ctx context.Context, filter repository.AuditFilter) ([]*entity.AuditEvent, error) {
+	query := r.filtered(ctx, filter)
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+	if limit > maxAuditLimit {
+		limit = maxAuditLimit
+	}
+
+	var dbEvents []GormAuditEvent
+	result := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(filter.Offset).
+		Find(&dbEvents)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	events := make([]*entity.AuditEvent, 0, len(dbEvents))
+	for i := range dbEvents {
+		id, err := vo.ParseID(dbEvents[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, &entity.AuditEvent{
+			ID:        id,
+			Action:    dbEvents[i].Action,
+			Outcome:   dbEvents[i].Outcome,
+			ActorID:   dbEvents[i].ActorID,
+			Target:    dbEvents[i].Target,
+			IP:        dbEvents[i].IP,
+			UserAgent: dbEvents[i].UserAgent,
+			Reason:    dbEvents[i].Reason,
+			CreatedAt: dbEvents[i].CreatedAt,
+		})
+	}
+	return events, nil
+}
+
+// Anonymize mantém os eventos, sem nada que leve de volta à pessoa. Os e-mails
+// que ela usou antes aparecem como alvo dos eventos que fez (ex.: login) e
+// também são trocados.
+func (r *GormAuditRepository) Anonymize(ctx context.Context, subjects []string, pseudonym string) error {
+	subjects = slices.DeleteFunc(slices.Clone(subjects), func(s string) bool { return s == "" })
+	if len(subjects) == 0 {
+		return nil
+	}
+	db := dbFromContext(ctx, r.db)
+
+	var addresses []string
+	err := db.Model(&GormAuditEvent{}).
+		Where("actor_id IN ? AND target LIKE ?", subjects, "%@%").
+		Distinct("target").
+		Pluck("target", &addresses).Error
+	if err != nil {
+		return err
+	}
+	subjects = append(subjects, addresses...)
+
+	err = db.Model(&GormAuditEvent{}).
+		Where("actor_id IN ? OR target IN ?", subjects, subjects).
+		Updates(map[string]any{"ip": "", "user_agent": ""}).Error
+	if err != nil {
+		return err
+	}
+	err = db.Model(&GormAuditEvent{}).
+		Where("actor_id IN ?", subjects).
+		Update("actor_id", pseudonym).Error
+	if err != nil {
+		return err
+	}
+	return db.Model(&GormAuditEvent{}).
+		Where("target IN ?", subjects).
+		Update("target", pseudonym).Error
+}
+
+// Count usa os mesmos filtros de List, ignorando paginação.
+func (r *GormAuditRepository) Count(ctx context.Context, filter repository.AuditFilter) (int64, error) {
+	var total int64
+	err := r.filtered(ctx, filter).Count(&total).Error
+	return total, err
+}
+
+func (r *GormAuditRepository) filtered(ctx context.Context, filter repository.AuditFilter) *gorm.DB {
+	query := dbFromContext(ctx, r.db).Model(&GormAuditEvent{})
+
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.Outcome != "" {
+		query = query.Where("outcome = ?", filter.Outcome)
+	}
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Target != "" {
+		query = query.Where("target = ?", filter.Target)
+	}
+	if filter.IP != "" {
+		query = query.Where("ip = ?", filter.IP)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+	return query
+}
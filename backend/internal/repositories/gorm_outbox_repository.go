@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultOutboxLimit = 50
+	maxOutboxLimit     = 500
+)
+
+type GormOutboxMessage struct {
+	ID            string    `gorm:"primaryKey;type:varchar(36)"`
+	UserID        string    `gorm:"type:varchar(36);index"`
+	Topic         string    `gorm:"type:varchar(64);index;not null"`
+	Payload       string    `gorm:"type:text;not null"`
+	Status        string    `gorm:"type:varchar(16);not null"`
+	Attempts      int       `gorm:"not null;default:0"`
+	NextAttemptAt time.Time `gorm:"not null"`
+	LastError     string    `gorm:"type:varchar(1024)"`
+	CreatedAt     time.Time `gorm:"index"`
+	SentAt        time.Time
+}
+
+type GormOutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewGormOutboxRepository(db *gorm.DB) *GormOutboxRepository {
+	return &GormOutboxRepository{db: db}
+}
+
+func (r *GormOutboxRepository) toDBModel(message *entity.OutboxMessage) *GormOutboxMessage {
+	userID := ""
+	if message.UserID != (vo.ID{}) {
+		userID = message.UserID.String()
+	}
+	return &GormOutboxMessage{
+		ID:            message.ID.String(),
+		UserID:        userID,
+		Topic:         message.Topic,
+		Payload:       string(message.Payload),
+		Status:        message.Status,
+		Attempts:      message.Attempts,
+		NextAttemptAt: message.NextAttemptAt,
+		LastError:     message.LastError,
+		CreatedAt:     message.CreatedAt,
+		SentAt:        message.SentAt,
+	}
+}
+
+func (r *GormOutboxRepository) fromDBModel(dbMessage *GormOutboxMessage) (*entity.OutboxMessage, error) {
+	id, err := vo.ParseID(dbMessage.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Mensagens anteriores à coluna user_id não têm destinatário registrado
+	var userID vo.ID
+	if dbMessage.UserID != "" {
+		if userID, err = vo.ParseID(dbMessage.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &entity.OutboxMessage{
+		ID:            id,
+		UserID:        userID,
+		Topic:         dbMessage.Topic,
+		Payload:       []byte(dbMessage.Payload),
+		Status:        dbMessage.Status,
+		Attempts:      dbMessage.Attempts,
+		NextAttemptAt: dbMessage.NextAttemptAt,
+		LastError:     dbMessage.LastError,
+		CreatedAt:     dbMessage.CreatedAt,
+		SentAt:        dbMessage.SentAt,
+	}, nil
+}
+
+func (r *GormOutboxRepository) Enqueue(ctx context.Context, message *entity.OutboxMessage) error {
+	return dbFromContext(ctx, r.db).Create(r.toDBModel(message)).Error
+}
+
+// ClaimDue só fica com as mensagens cujo adiamento condicional afetou a linha;
+// as demais foram reservadas por outra instância entre a leitura e a escrita.
+func (r *GormOutboxRepository) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+	limit int,
+) ([]*entity.OutboxMessage, error) {
+	var dbMessages []GormOutboxMessage
+	result := dbFromContext(ctx, r.db).
+		Where("status = ? AND next_attempt_at <= ?", entity.OutboxStatusPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&dbMessages)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	leaseUntil := now.Add(lease)
+	messages := make([]*entity.OutboxMessage, 0, len(dbMessages))
+	for i := range dbMessages {
+		claim := dbFromContext(ctx, r.db).
+			Model(&GormOutboxMessage{}).
+			Where("id = ? AND status = ? AND next_attempt_at <= ?", dbMessages[i].ID, entity.OutboxStatusPending, now).
+			Update("next_attempt_at", leaseUntil)
+		if claim.Error != nil {
+			return nil, claim.Error
+		}
+		if claim.RowsAffected == 0 {
+			continue
+		}
+
+		dbMessages[i].NextAttemptAt = leaseUntil
+		message, err := r.fromDBModel(&dbMessages[i])
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+func (r *GormOutboxRepository) Update(ctx context.Context, message *entity.OutboxMessage) error {
+	return dbFromContext(ctx, r.db).
+		Model(&GormOutboxMessage{}).
+		Where("id = ?", message.ID.String()).
+		Updates(map[string]interface{}{
+			"status":          message.Status,
+			"payload":         string(message.Payload),
+			"attempts":        message.Attempts,
+			"next_attempt_at": message.NextAttemptAt,
+			"last_error":      message.LastError,
+			"sent_at":         message.SentAt,
+		}).Error
+}
+
+func (r *GormOutboxRepository) GetByID(ctx context.Context, id vo.ID) (*entity.OutboxMessage, error) {
+	var dbMessage GormOutboxMessage
+	result := dbFromContext(ctx, r.db).Where("id = ?", id.String()).First(&dbMessage)
+
+	if result.Error != nil {
+		if r.IsErrNotFound(result.Error) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return r.fromDBModel(&dbMessage)
+}
+
+func (r *GormOutboxRepository) List(ctx context.Context, filter repository.OutboxFilter) ([]*entity.OutboxMessage, error) {
+	query := dbFromContext(ctx, r.db).Model(&GormOutboxMessage{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Topic != "" {
+		query = query.Where("topic = ?", filter.Topic)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultOutboxLimit
+	}
+	if limit > maxOutboxLimit {
+		limit = maxOutboxLimit
+	}
+
+	var dbMessages []GormOutboxMessage
+	result := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(filter.Offset).
+		Find(&dbMessages)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.fromDBModels(dbMessages)
+}
+
+func (r *GormOutboxRepository) ListByUserID(ctx context.Context, userID vo.ID) ([]*entity.OutboxMessage, error) {
+	var dbMessages []GormOutboxMessage
+	result := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Order("created_at ASC").
+		Find(&dbMessages)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.fromDBModels(dbMessages)
+}
+
+func (r *GormOutboxRepository) DeleteByUserID(ctx context.Context, userID vo.ID) error {
+	return dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID.String()).
+		Delete(&GormOutboxMessage{}).Error
+}
+
+func (r *GormOutboxRepository) fromDBModels(dbMessages []GormOutboxMessage) ([]*entity.OutboxMessage, error) {
+	messages := make([]*entity.OutboxMessage, 0, len(dbMessages))
+	for i := range dbMessages {
+		message, err := r.fromDBModel(&dbMessages[i])
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+func (r *GormOutboxRepository) IsErrNotFound(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound)
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// GormTxManager guarda a transação aberta no contexto; chamadas aninhadas
+// reaproveitam a transação externa por meio de savepoints.
+type GormTxManager struct {
+	db *gorm.DB
+}
+
+func NewGormTxManager(db *gorm.DB) *GormTxManager {
+	return &GormTxManager{db: db}
+}
+
+func (m *GormTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return dbFromContext(ctx, m.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// dbFromContext devolve a transação do contexto, se houver, ou a conexão padrão.
+func dbFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -2,23 +2,55 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
 	"gorm.io/gorm"
 )
 
 type GormUser struct {
-	ID                   string    `gorm:"primaryKey;type:varchar(36)"`
-	Name                 string    `gorm:"type:varchar(100);not null"`
-	Email                string    `gorm:"type:varchar(255);uniqueIndex;not null"`
-	PasswordHash         string    `gorm:"type:varchar(255);not null"`
-	ImageURL             string    `gorm:"type:varchar(255)"`
+	ID           string `gorm:"primaryKey;type:varchar(36)"`
+	Name         string `gorm:"type:varchar(100);not null"`
+	Email        string `gorm:"type:varchar(255);uniqueIndex;not null"`
+	PasswordHash string `gorm:"type:varchar(255);not null"`
+	ImageURL     string `gorm:"type:varchar(255)"`
+	Locale       string `gorm:"type:varchar(16)"`
+	Timezone     string `gorm:"type:varchar(64)"`
+	// Attributes guarda vo.ProfileAttributes como objeto JSON
+	Attributes           string    `gorm:"type:text"`
 	CreatedAt            time.Time `gorm:"autoCreateTime"`
 	PasswordResetToken   string    `gorm:"type:varchar(255)"`
 	PasswordResetExpires time.Time `gorm:"type:datetime"`
+	// Troca de e-mail pendente (entity.User.RequestEmailChange)
+	PendingEmail           string    `gorm:"type:varchar(255)"`
+	EmailChangeToken       string    `gorm:"type:varchar(255)"`
+	EmailChangeCancelToken string    `gorm:"type:varchar(255)"`
+	EmailChangeExpires     time.Time `gorm:"type:datetime"`
+	// Soft delete: consultas padrão ignoram contas desativadas
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	// Controle de concorrência otimista: incrementada a cada escrita
+	Version int `gorm:"not null;default:1"`
+}
+
+// userColumns traduz os campos de repository.UserRepository.Update para colunas
+var userColumns = map[string][]string{
+	repository.UserFieldName:          {"name"},
+	repository.UserFieldEmail:         {"email"},
+	repository.UserFieldPasswordHash:  {"password_hash"},
+	repository.UserFieldImageURL:      {"image_url"},
+	repository.UserFieldLocale:        {"locale"},
+	repository.UserFieldTimezone:      {"timezone"},
+	repository.UserFieldAttributes:    {"attributes"},
+	repository.UserFieldPasswordReset: {"password_reset_token", "password_reset_expires"},
+	repository.UserFieldEmailChange: {
+		"pending_email", "email_change_token", "email_change_cancel_token", "email_change_expires",
+	},
 }
 
 type GormUserRepository struct {
@@ -31,13 +63,23 @@ func NewGormUserRepository(db *gorm.DB) *GormUserRepository {
 
 func (r *GormUserRepository) toDBModel(user *entity.User) *GormUser {
 	return &GormUser{
-		ID:                   user.ID.String(),
-		Name:                 user.Name.String(),
-		Email:                user.Email.String(),
-		PasswordHash:         user.PasswordHash.String(),
-		ImageURL:             user.ImageURL.String(),
-		PasswordResetToken:   user.PasswordResetToken,
-		PasswordResetExpires: user.PasswordResetExpires,
+		ID:                     user.ID.String(),
+		Name:                   user.Name.String(),
+		Email:                  user.Email.String(),
+		PasswordHash:           user.PasswordHash.String(),
+		ImageURL:               user.ImageURL.String(),
+		Locale:                 user.Locale.String(),
+		Timezone:               user.Timezone.String(),
+		Attributes:             encodeAttributes(user.Attributes),
+		CreatedAt:              user.CreatedAt,
+		PasswordResetToken:     user.PasswordResetToken,
+		PasswordResetExpires:   user.PasswordResetExpires,
+		PendingEmail:           user.PendingEmail.String(),
+		EmailChangeToken:       user.EmailChangeToken,
+		EmailChangeCancelToken: user.EmailChangeCancelToken,
+		EmailChangeExpires:     user.EmailChangeExpires,
+		DeletedAt:              gorm.DeletedAt{Time: user.DeletedAt, Valid: user.IsDeactivated()},
+		Version:                user.Version,
 	}
 }
 
@@ -67,21 +109,82 @@ func (r *GormUserRepository) fromDBModel(dbUser *GormUser) (*entity.User, error)
 		return nil, err
 	}
 
+	locale, err := vo.NewLocale(dbUser.Locale)
+	if err != nil {
+		return nil, err
+	}
+
+	timezone, err := vo.NewTimezone(dbUser.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes, err := decodeAttributes(dbUser.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	var pendingEmail vo.Email
+	if dbUser.PendingEmail != "" {
+		if pendingEmail, err = vo.NewEmail(dbUser.PendingEmail); err != nil {
+			return nil, err
+		}
+	}
+
 	return &entity.User{
-		ID:                   id,
-		Name:                 name,
-		Email:                email,
-		PasswordHash:         passwordHash,
-		ImageURL:             imageURL,
-		PasswordResetToken:   dbUser.PasswordResetToken,
-		PasswordResetExpires: dbUser.PasswordResetExpires,
+		ID:                     id,
+		Name:                   name,
+		Email:                  email,
+		PasswordHash:           passwordHash,
+		ImageURL:               imageURL,
+		Locale:                 locale,
+		Timezone:               timezone,
+		Attributes:             attributes,
+		CreatedAt:              dbUser.CreatedAt,
+		PasswordResetToken:     dbUser.PasswordResetToken,
+		PasswordResetExpires:   dbUser.PasswordResetExpires,
+		PendingEmail:           pendingEmail,
+		EmailChangeToken:       dbUser.EmailChangeToken,
+		EmailChangeCancelToken: dbUser.EmailChangeCancelToken,
+		EmailChangeExpires:     dbUser.EmailChangeExpires,
+		DeletedAt:              dbUser.DeletedAt.Time,
+		Version:                dbUser.Version,
 	}, nil
 }
 
+func encodeAttributes(attributes vo.ProfileAttributes) string {
+	if attributes.IsEmpty() {
+		return ""
+	}
+	// Marshal de map[string]string não falha
+	data, _ := json.Marshal(attributes.Map())
+	return string(data)
+}
+
+func decodeAttributes(raw string) (vo.ProfileAttributes, error) {
+	if raw == "" {
+		return vo.ProfileAttributes{}, nil
+	}
+	var values map[string]string
+	if err := json.Unmarshal([]byte(raw), &values); err != nil {
+		return vo.ProfileAttributes{}, fmt.Errorf("invalid stored attributes: %w", err)
+	}
+	return vo.NewProfileAttributes(values)
+}
+
 func (r *GormUserRepository) Save(ctx context.Context, user *entity.User) (*entity.User, error) {
+	if user.Version != 0 {
+		fields := make([]string, 0, len(userColumns))
+		for field := range userColumns {
+			fields = append(fields, field)
+		}
+		return r.Update(ctx, user, fields...)
+	}
+
 	dbUser := r.toDBModel(user)
+	dbUser.Version = 1
 
-	result := r.db.WithContext(ctx).Save(dbUser)
+	result := dbFromContext(ctx, r.db).Create(dbUser)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -89,9 +192,67 @@ func (r *GormUserRepository) Save(ctx context.Context, user *entity.User) (*enti
 	return r.fromDBModel(dbUser)
 }
 
+// Update grava só as colunas dos campos informados, condicionada à versão lida.
+// Nenhuma linha afetada significa que o usuário sumiu ou foi alterado por outra requisição.
+func (r *GormUserRepository) Update(ctx context.Context, user *entity.User, fields ...string) (*entity.User, error) {
+	dbUser := r.toDBModel(user)
+	values := map[string]interface{}{
+		"name":                      dbUser.Name,
+		"email":                     dbUser.Email,
+		"password_hash":             dbUser.PasswordHash,
+		"image_url":                 dbUser.ImageURL,
+		"locale":                    dbUser.Locale,
+		"timezone":                  dbUser.Timezone,
+		"attributes":                dbUser.Attributes,
+		"password_reset_token":      dbUser.PasswordResetToken,
+		"password_reset_expires":    dbUser.PasswordResetExpires,
+		"pending_email":             dbUser.PendingEmail,
+		"email_change_token":        dbUser.EmailChangeToken,
+		"email_change_cancel_token": dbUser.EmailChangeCancelToken,
+		"email_change_expires":      dbUser.EmailChangeExpires,
+	}
+
+	updates := map[string]interface{}{"version": gorm.Expr("version + 1")}
+	for _, field := range fields {
+		columns, ok := userColumns[field]
+		if !ok {
+			return nil, fmt.Errorf("unknown user field %q", field)
+		}
+		for _, column := range columns {
+			updates[column] = values[column]
+		}
+	}
+
+	result := dbFromContext(ctx, r.db).
+		Model(&GormUser{}).
+		Where("id = ? AND version = ?", dbUser.ID, user.Version).
+		Updates(updates)
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		// Índice único de e-mail: outra conta ficou com o endereço nesse meio tempo
+		return nil, msgerror.AnErrUserExists
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		current, err := r.GetByID(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if current == nil {
+			return nil, msgerror.AnErrUserNotFound
+		}
+		return nil, msgerror.AnErrConflict
+	}
+
+	updated := *user
+	updated.Version = user.Version + 1
+	return &updated, nil
+}
+
 func (r *GormUserRepository) GetByEmail(ctx context.Context, email vo.Email) (*entity.User, error) {
 	var dbUser GormUser
-	result := r.db.WithContext(ctx).Where("email = ?", email.String()).First(&dbUser)
+	result := dbFromContext(ctx, r.db).Where("email = ?", email.String()).First(&dbUser)
 
 	if result.Error != nil {
 		if r.IsErrNotFound(result.Error) {
@@ -105,7 +266,34 @@ func (r *GormUserRepository) GetByEmail(ctx context.Context, email vo.Email) (*e
 
 func (r *GormUserRepository) GetByResetToken(ctx context.Context, token string) (*entity.User, error) {
 	var dbUser GormUser
-	result := r.db.WithContext(ctx).Where("password_reset_token = ?", token).First(&dbUser)
+	result := dbFromContext(ctx, r.db).Where("password_reset_token = ?", token).First(&dbUser)
+
+	if result.Error != nil {
+		if r.IsErrNotFound(result.Error) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return r.fromDBModel(&dbUser)
+}
+
+func (r *GormUserRepository) GetByEmailChangeToken(ctx context.Context, token string) (*entity.User, error) {
+	return r.getByToken(ctx, "email_change_token", token)
+}
+
+func (r *GormUserRepository) GetByEmailChangeCancelToken(ctx context.Context, token string) (*entity.User, error) {
+	return r.getByToken(ctx, "email_change_cancel_token", token)
+}
+
+func (r *GormUserRepository) getByToken(ctx context.Context, column, token string) (*entity.User, error) {
+	// Token vazio casaria com todas as contas sem troca pendente
+	if token == "" {
+		return nil, nil
+	}
+
+	var dbUser GormUser
+	result := dbFromContext(ctx, r.db).Where(column+" = ?", token).First(&dbUser)
 
 	if result.Error != nil {
 		if r.IsErrNotFound(result.Error) {
@@ -119,7 +307,53 @@ func (r *GormUserRepository) GetByResetToken(ctx context.Context, token string)
 
 func (r *GormUserRepository) GetByID(ctx context.Context, id vo.ID) (*entity.User, error) {
 	var dbUser GormUser
-	result := r.db.WithContext(ctx).Where("id = ?", id.String()).First(&dbUser)
+	result := dbFromContext(ctx, r.db).Where("id = ?", id.String()).First(&dbUser)
+
+	if result.Error != nil {
+		if r.IsErrNotFound(result.Error) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return r.fromDBModel(&dbUser)
+}
+
+// Deactivate descarta também tokens de reset e trocas de e-mail pendentes,
+// que não devem sobreviver à desativação.
+func (r *GormUserRepository) Deactivate(ctx context.Context, id vo.ID, at time.Time) error {
+	return dbFromContext(ctx, r.db).
+		Model(&GormUser{}).
+		Where("id = ?", id.String()).
+		Updates(map[string]interface{}{
+			"deleted_at":                at,
+			"password_reset_token":      "",
+			"password_reset_expires":    time.Time{},
+			"pending_email":             "",
+			"email_change_token":        "",
+			"email_change_cancel_token": "",
+			"email_change_expires":      time.Time{},
+			"version":                   gorm.Expr("version + 1"),
+		}).Error
+}
+
+func (r *GormUserRepository) Restore(ctx context.Context, id vo.ID) error {
+	return dbFromContext(ctx, r.db).
+		Unscoped().
+		Model(&GormUser{}).
+		Where("id = ?", id.String()).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		}).Error
+}
+
+func (r *GormUserRepository) GetDeactivatedByEmail(ctx context.Context, email vo.Email) (*entity.User, error) {
+	var dbUser GormUser
+	result := dbFromContext(ctx, r.db).
+		Unscoped().
+		Where("email = ? AND deleted_at IS NOT NULL", email.String()).
+		First(&dbUser)
 
 	if result.Error != nil {
 		if r.IsErrNotFound(result.Error) {
@@ -131,6 +365,37 @@ func (r *GormUserRepository) GetByID(ctx context.Context, id vo.ID) (*entity.Use
 	return r.fromDBModel(&dbUser)
 }
 
+// ListDeactivatedBefore devolve as contas desativadas até before, mais antigas primeiro.
+func (r *GormUserRepository) ListDeactivatedBefore(ctx context.Context, before time.Time, limit int) ([]*entity.User, error) {
+	var dbUsers []GormUser
+	result := dbFromContext(ctx, r.db).
+		Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at <= ?", before).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Find(&dbUsers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	users := make([]*entity.User, 0, len(dbUsers))
+	for i := range dbUsers {
+		user, err := r.fromDBModel(&dbUsers[i])
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func (r *GormUserRepository) Erase(ctx context.Context, id vo.ID) error {
+	return dbFromContext(ctx, r.db).
+		Unscoped().
+		Where("id = ?", id.String()).
+		Delete(&GormUser{}).Error
+}
+
 func (r *GormUserRepository) IsErrNotFound(err error) bool {
 	return errors.Is(err, gorm.ErrRecordNotFound)
 }
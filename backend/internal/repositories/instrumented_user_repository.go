@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/metrics"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+)
+
+// InstrumentedUserRepository mede a latência de cada consulta ao banco sem
+// alterar o comportamento do repositório decorado.
+type InstrumentedUserRepository struct {
+	inner   repository.UserRepository
+	metrics *metrics.Metrics
+}
+
+func NewInstrumentedUserRepository(inner repository.UserRepository, m *metrics.Metrics) *InstrumentedUserRepository {
+	return &InstrumentedUserRepository{inner: inner, metrics: m}
+}
+
+func (r *InstrumentedUserRepository) observe(operation string, start time.Time, err error) {
+	r.metrics.UserRepoDuration.Observe(metrics.Since(start), operation, metrics.Result(err))
+}
+
+func (r *InstrumentedUserRepository) Save(ctx context.Context, user *entity.User) (*entity.User, error) {
+	start := time.Now()
+	saved, err := r.inner.Save(ctx, user)
+	r.observe("save", start, err)
+	return saved, err
+}
+
+func (r *InstrumentedUserRepository) Update(ctx context.Context, user *entity.User, fields ...string) (*entity.User, error) {
+	start := time.Now()
+	updated, err := r.inner.Update(ctx, user, fields...)
+	r.observe("update", start, err)
+	return updated, err
+}
+
+func (r *InstrumentedUserRepository) GetByEmail(ctx context.Context, email vo.Email) (*entity.User, error) {
+	start := time.Now()
+	user, err := r.inner.GetByEmail(ctx, email)
+	r.observe("get_by_email", start, err)
+	return user, err
+}
+
+func (r *InstrumentedUserRepository) GetByID(ctx context.Context, userID vo.ID) (*entity.User, error) {
+	start := time.Now()
+	user, err := r.inner.GetByID(ctx, userID)
+	r.observe("get_by_id", start, err)
+	return user, err
+}
+
+func (r *InstrumentedUserRepository) GetByResetToken(ctx context.Context, token string) (*entity.User, error) {
+	start := time.Now()
+	user, err := r.inner.GetByResetToken(ctx, token)
+	r.observe("get_by_reset_token", start, err)
+	return user, err
+}
+
+func (r *InstrumentedUserRepository) GetByEmailChangeToken(ctx context.Context, token string) (*entity.User, error) {
+	start := time.Now()
+	user, err := r.inner.GetByEmailChangeToken(ctx, token)
+	r.observe("get_by_email_change_token", start, err)
+	return user, err
+}
+
+func (r *InstrumentedUserRepository) GetByEmailChangeCancelToken(ctx context.Context, token string) (*entity.User, error) {
+	start := time.Now()
+	user, err := r.inner.GetByEmailChangeCancelToken(ctx, token)
+	r.observe("get_by_email_change_cancel_token", start, err)
+	return user, err
+}
+
+func (r *InstrumentedUserRepository) Deactivate(ctx context.Context, userID vo.ID, at time.Time) error {
+	start := time.Now()
+	err := r.inner.Deactivate(ctx, userID, at)
+	r.observe("deactivate", start, err)
+	return err
+}
+
+func (r *InstrumentedUserRepository) Restore(ctx context.Context, userID vo.ID) error {
+	start := time.Now()
+	err := r.inner.Restore(ctx, userID)
+	r.observe("restore", start, err)
+	return err
+}
+
+func (r *InstrumentedUserRepository) GetDeactivatedByEmail(ctx context.Context, email vo.Email) (*entity.User, error) {
+	start := time.Now()
+	user, err := r.inner.GetDeactivatedByEmail(ctx, email)
+	r.observe("get_deactivated_by_email", start, err)
+	return user, err
+}
+
+func (r *InstrumentedUserRepository) ListDeactivatedBefore(ctx context.Context, before time.Time, limit int) ([]*entity.User, error) {
+	start := time.Now()
+	users, err := r.inner.ListDeactivatedBefore(ctx, before, limit)
+	r.observe("list_deactivated_before", start, err)
+	return users, err
+}
+
+func (r *InstrumentedUserRepository) Erase(ctx context.Context, userID vo.ID) error {
+	start := time.Now()
+	err := r.inner.Erase(ctx, userID)
+	r.observe("erase", start, err)
+	return err
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/tracing"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+)
+
+// TracedUserRepository abre um span filho do span presente no contexto em
+// volta de cada consulta ao banco.
+type TracedUserRepository struct {
+	inner  repository.UserRepository
+	tracer *tracing.Tracer
+}
+
+func NewTracedUserRepository(inner repository.UserRepository, tracer *tracing.Tracer) *TracedUserRepository {
+	return &TracedUserRepository{inner: inner, tracer: tracer}
+}
+
+func (r *TracedUserRepository) start(ctx context.Context, operation string) (context.Context, *tracing.Span) {
+	return r.tracer.Start(ctx, "UserRepository."+operation, tracing.KindClient,
+		tracing.String("db.operation.name", operation))
+}
+
+func (r *TracedUserRepository) Save(ctx context.Context, user *entity.User) (*entity.User, error) {
+	ctx, span := r.start(ctx, "Save")
+	saved, err := r.inner.Save(ctx, user)
+	span.Finish(err)
+	return saved, err
+}
+
+func (r *TracedUserRepository) Update(ctx context.Context, user *entity.User, fields ...string) (*entity.User, error) {
+	ctx, span := r.start(ctx, "Update")
+	updated, err := r.inner.Update(ctx, user, fields...)
+	span.Finish(err)
+	return updated, err
+}
+
+func (r *TracedUserRepository) GetByEmail(ctx context.Context, email vo.Email) (*entity.User, error) {
+	ctx, span := r.start(ctx, "GetByEmail")
+	user, err := r.inner.GetByEmail(ctx, email)
+	span.Finish(err)
+	return user, err
+}
+
+func (r *TracedUserRepository) GetByID(ctx context.Context, userID vo.ID) (*entity.User, error) {
+	ctx, span := r.start(ctx, "GetByID")
+	user, err := r.inner.GetByID(ctx, userID)
+	span.Finish(err)
+	return user, err
+}
+
+func (r *TracedUserRepository) GetByResetToken(ctx context.Context, token string) (*entity.User, error) {
+	ctx, span := r.start(ctx, "GetByResetToken")
+	user, err := r.inner.GetByResetToken(ctx, token)
+	span.Finish(err)
+	return user, err
+}
+
+func (r *TracedUserRepository) GetByEmailChangeToken(ctx context.Context, token string) (*entity.User, error) {
+	ctx, span := r.start(ctx, "GetByEmailChangeToken")
+	user, err := r.inner.GetByEmailChangeToken(ctx, token)
+	span.Finish(err)
+	return user, err
+}
+
+func (r *TracedUserRepository) GetByEmailChangeCancelToken(ctx context.Context, token string) (*entity.User, error) {
+	ctx, span := r.start(ctx, "GetByEmailChangeCancelToken")
+	user, err := r.inner.GetByEmailChangeCancelToken(ctx, token)
+	span.Finish(err)
+	return user, err
+}
+
+func (r *TracedUserRepository) Deactivate(ctx context.Context, userID vo.ID, at time.Time) error {
+	ctx, span := r.start(ctx, "Deactivate")
+	err := r.inner.Deactivate(ctx, userID, at)
+	span.Finish(err)
+	return err
+}
+
+func (r *TracedUserRepository) Restore(ctx context.Context, userID vo.ID) error {
+	ctx, span := r.start(ctx, "Restore")
+	err := r.inner.Restore(ctx, userID)
+	span.Finish(err)
+	return err
+}
+
+func (r *TracedUserRepository) GetDeactivatedByEmail(ctx context.Context, email vo.Email) (*entity.User, error) {
+	ctx, span := r.start(ctx, "GetDeactivatedByEmail")
+	user, err := r.inner.GetDeactivatedByEmail(ctx, email)
+	span.Finish(err)
+	return user, err
+}
+
+func (r *TracedUserRepository) ListDeactivatedBefore(ctx context.Context, before time.Time, limit int) ([]*entity.User, error) {
+	ctx, span := r.start(ctx, "ListDeactivatedBefore")
+	users, err := r.inner.ListDeactivatedBefore(ctx, before, limit)
+	span.Finish(err)
+	return users, err
+}
+
+func (r *TracedUserRepository) Erase(ctx context.Context, userID vo.ID) error {
+	ctx, span := r.start(ctx, "Erase")
+	err := r.inner.Erase(ctx, userID)
+	span.Finish(err)
+	return err
+}
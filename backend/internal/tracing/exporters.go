@@ -0,0 +1,253 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// NewExporter escolhe o exportador pela configuração; stdout escreve na saída
+// padrão do processo.
+func NewExporter(kind string, otlp OTLPConfig) (Exporter, error) {
+	switch kind {
+	case ExporterOTLP:
+		return NewOTLPExporter(otlp), nil
+	case ExporterStdout:
+		return NewStdoutExporter(os.Stdout), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", msgerror.AnErrInvalidTraceExporter, kind)
+	}
+}
+
+// StdoutExporter escreve um span por linha, em JSON, para depuração local.
+type StdoutExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewStdoutExporter(w io.Writer) *StdoutExporter {
+	return &StdoutExporter{w: w}
+}
+
+type stdoutSpan struct {
+	TraceID    string         `json:"trace_id"`
+	SpanID     string         `json:"span_id"`
+	ParentID   string         `json:"parent_span_id,omitempty"`
+	Name       string         `json:"name"`
+	Kind       string         `json:"kind"`
+	Start      time.Time      `json:"start"`
+	DurationMS float64        `json:"duration_ms"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+	Status     string         `json:"status,omitempty"`
+	Error      string         `json:"error,omitempty"`
+}
+
+func (e *StdoutExporter) Export(_ context.Context, spans []SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	encoder := json.NewEncoder(e.w)
+	for _, span := range spans {
+		out := stdoutSpan{
+			TraceID:    span.SpanContext.TraceID.String(),
+			SpanID:     span.SpanContext.SpanID.String(),
+			Name:       span.Name,
+			Kind:       kindNames[span.Kind],
+			Start:      span.Start,
+			DurationMS: float64(span.End.Sub(span.Start).Microseconds()) / 1000,
+		}
+		if span.ParentSpanID.IsValid() {
+			out.ParentID = span.ParentSpanID.String()
+		}
+		if len(span.Attributes) > 0 {
+			out.Attributes = make(map[string]any, len(span.Attributes))
+			for _, attr := range span.Attributes {
+				out.Attributes[attr.Key] = attr.Value
+			}
+		}
+		if span.Status == StatusError {
+			out.Status = "error"
+			out.Error = span.StatusMessage
+		}
+		if err := encoder.Encode(out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var kindNames = map[SpanKind]string{KindInternal: "internal", KindServer: "server", KindClient: "client"}
+
+// OTLPExporter envia os spans por OTLP/HTTP com corpo JSON, aceito pelo
+// OpenTelemetry Collector, Jaeger e Tempo em <endpoint>/v1/traces.
+type OTLPExporter struct {
+	url         string
+	serviceName string
+	headers     map[string]string
+	client      *http.Client
+}
+
+type OTLPConfig struct {
+	// Endpoint base do coletor, ex.: http://localhost:4318
+	Endpoint    string
+	ServiceName string
+	// Headers extras, ex.: autenticação do coletor
+	Headers    map[string]string
+	HTTPClient *http.Client
+}
+
+func NewOTLPExporter(cfg OTLPConfig) *OTLPExporter {
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &OTLPExporter{
+		url:         strings.TrimRight(cfg.Endpoint, "/") + "/v1/traces",
+		serviceName: cfg.ServiceName,
+		headers:     cfg.Headers,
+		client:      client,
+	}
+}
+
+func (e *OTLPExporter) Export(ctx context.Context, spans []SpanData) error {
+	body, err := json.Marshal(e.payload(spans))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("otlp export: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("otlp export: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Estruturas do ExportTraceServiceRequest na codificação JSON do OTLP: ids
+// em hexadecimal e inteiros de 64 bits como string.
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpSpan struct {
+		TraceID           string         `json:"traceId"`
+		SpanID            string         `json:"spanId"`
+		TraceState        string         `json:"traceState,omitempty"`
+		ParentSpanID      string         `json:"parentSpanId,omitempty"`
+		Name              string         `json:"name"`
+		Kind              SpanKind       `json:"kind"`
+		StartTimeUnixNano string         `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+		Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+		Status            otlpStatus     `json:"status"`
+	}
+	otlpStatus struct {
+		Code    StatusCode `json:"code,omitempty"`
+		Message string     `json:"message,omitempty"`
+	}
+	otlpKeyValue struct {
+		Key   string         `json:"key"`
+		Value map[string]any `json:"value"`
+	}
+)
+
+func (e *OTLPExporter) payload(spans []SpanData) otlpRequest {
+	out := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		s := otlpSpan{
+			TraceID:           span.SpanContext.TraceID.String(),
+			SpanID:            span.SpanContext.SpanID.String(),
+			TraceState:        span.SpanContext.TraceState,
+			Name:              span.Name,
+			Kind:              span.Kind,
+			StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+			Attributes:        otlpAttributes(span.Attributes),
+			Status:            otlpStatus{Code: span.Status, Message: span.StatusMessage},
+		}
+		if span.ParentSpanID.IsValid() {
+			s.ParentSpanID = span.ParentSpanID.String()
+		}
+		out = append(out, s)
+	}
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: otlpAttributes([]Attribute{String("service.name", e.serviceName)})},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: "startup-auth-go"}, Spans: out}},
+	}}}
+}
+
+// otlpDouble segue o mapeamento JSON do proto3: NaN e infinitos viram string,
+// que o JSON não tem como número.
+func otlpDouble(v float64) any {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "Infinity"
+	case math.IsInf(v, -1):
+		return "-Infinity"
+	}
+	return v
+}
+
+func otlpAttributes(attrs []Attribute) []otlpKeyValue {
+	out := make([]otlpKeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		var value map[string]any
+		switch v := attr.Value.(type) {
+		case string:
+			value = map[string]any{"stringValue": v}
+		case int64:
+			value = map[string]any{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			value = map[string]any{"doubleValue": otlpDouble(v)}
+		case bool:
+			value = map[string]any{"boolValue": v}
+		default:
+			value = map[string]any{"stringValue": fmt.Sprint(v)}
+		}
+		out = append(out, otlpKeyValue{Key: attr.Key, Value: value})
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// Cabeçalhos do W3C Trace Context
+const (
+	TraceparentHeader = "traceparent"
+	TracestateHeader  = "tracestate"
+)
+
+type TraceID [16]byte
+
+func (id TraceID) IsValid() bool  { return id != TraceID{} }
+func (id TraceID) String() string { return hex.EncodeToString(id[:]) }
+
+type SpanID [8]byte
+
+func (id SpanID) IsValid() bool  { return id != SpanID{} }
+func (id SpanID) String() string { return hex.EncodeToString(id[:]) }
+
+// SpanContext é a parte do span que atravessa processos.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+	// TraceState é repassado sem interpretação
+	TraceState string
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Traceparent monta o cabeçalho "00-<trace-id>-<span-id>-<flags>".
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+// ParseTraceparent aceita a versão 00 e, como pede a especificação, versões
+// futuras desde que os quatro primeiros campos tenham o formato conhecido.
+func ParseTraceparent(header string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return SpanContext{}, false
+	}
+	var sc SpanContext
+	var version, flags [1]byte
+	if !decodeHex(version[:], parts[0]) || !decodeHex(sc.TraceID[:], parts[1]) ||
+		!decodeHex(sc.SpanID[:], parts[2]) || !decodeHex(flags[:], parts[3]) || !sc.IsValid() {
+		return SpanContext{}, false
+	}
+	sc.Sampled = flags[0]&1 == 1
+	return sc, true
+}
+
+// decodeHex exige hexadecimal minúsculo com o tamanho exato de dst.
+func decodeHex(dst []byte, s string) bool {
+	if len(s) != 2*len(dst) || strings.ToLower(s) != s {
+		return false
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err == nil
+}
+
+// Extract lê traceparent e tracestate de uma requisição recebida. Mais de um
+// traceparent invalida o contexto; vários tracestate valem como um só, unidos
+// por vírgula.
+func Extract(header http.Header) (SpanContext, bool) {
+	parents := header.Values(TraceparentHeader)
+	if len(parents) != 1 {
+		return SpanContext{}, false
+	}
+	sc, ok := ParseTraceparent(parents[0])
+	if !ok {
+		return SpanContext{}, false
+	}
+	sc.TraceState = strings.Join(header.Values(TracestateHeader), ",")
+	return sc, true
+}
+
+// Inject grava o span corrente do contexto em uma requisição de saída.
+func Inject(ctx context.Context, header http.Header) {
+	sc := SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	header.Set(TraceparentHeader, sc.Traceparent())
+	if sc.TraceState != "" {
+		header.Set(TracestateHeader, sc.TraceState)
+	}
+}
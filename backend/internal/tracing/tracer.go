@@ -0,0 +1,347 @@
+package tracing
+
+import (
+	"context"
+	"encoding/binary"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// SpanKind segue a numeração do OTLP.
+type SpanKind int
+
+const (
+	KindInternal SpanKind = 1
+	KindServer   SpanKind = 2
+	KindClient   SpanKind = 3
+)
+
+// StatusCode segue a numeração do OTLP.
+type StatusCode int
+
+const (
+	StatusUnset StatusCode = 0
+	StatusOK    StatusCode = 1
+	StatusError StatusCode = 2
+)
+
+type Attribute struct {
+	Key   string
+	Value any // string, int64, float64 ou bool
+}
+
+func String(key, value string) Attribute    { return Attribute{Key: key, Value: value} }
+func Int(key string, value int) Attribute   { return Attribute{Key: key, Value: int64(value)} }
+func Bool(key string, value bool) Attribute { return Attribute{Key: key, Value: value} }
+
+// SpanData é o span encerrado, entregue aos exportadores.
+type SpanData struct {
+	Name          string
+	Kind          SpanKind
+	SpanContext   SpanContext
+	ParentSpanID  SpanID
+	Start         time.Time
+	End           time.Time
+	Attributes    []Attribute
+	Status        StatusCode
+	StatusMessage string
+}
+
+// Exporter envia spans a um destino; é chamado fora das requisições.
+type Exporter interface {
+	Export(ctx context.Context, spans []SpanData) error
+}
+
+// Span é uma operação em andamento. Os métodos aceitam receptor nil, o que
+// permite instrumentar código sem checar se há tracer.
+type Span struct {
+	tracer *Tracer
+	mu     sync.Mutex
+	data   SpanData
+	ended  bool
+}
+
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.data.SpanContext
+}
+
+func (s *Span) SetName(name string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Name = name
+}
+
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Attributes = append(s.data.Attributes, attrs...)
+}
+
+// SetError marca o span como falho; err nil não altera o status.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Status = StatusError
+	s.data.StatusMessage = err.Error()
+}
+
+// End encerra o span e o enfileira para exportação, se amostrado; chamadas
+// seguintes são ignoradas.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = s.tracer.now()
+	data := s.data
+	s.mu.Unlock()
+
+	if data.SpanContext.Sampled {
+		s.tracer.enqueue(data)
+	}
+}
+
+// Finish registra err, se houver, e encerra o span.
+func (s *Span) Finish(err error) {
+	s.SetError(err)
+	s.End()
+}
+
+type spanKey struct{}
+type remoteKey struct{}
+
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// ContextWithRemoteSpanContext guarda o pai vindo de outro processo
+// (traceparent); o próximo span iniciado será seu filho.
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// SpanContextFromContext devolve o span local ou, na falta dele, o remoto.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.SpanContext()
+	}
+	sc, _ := ctx.Value(remoteKey{}).(SpanContext)
+	return sc
+}
+
+type TracerConfig struct {
+	// Exporter nil só propaga os identificadores, sem exportar nada
+	Exporter Exporter
+	// SampleRatio vale para traces iniciados aqui; com pai, vale a decisão dele
+	SampleRatio   float64
+	BatchSize     int           // Padrão: 512
+	FlushInterval time.Duration // Padrão: 5s
+	QueueSize     int           // Padrão: 2048; acima disso os spans são descartados
+	Logger        *slog.Logger  // Padrão: slog.Default(); recebe as falhas de exportação
+}
+
+// Tracer cria spans e os exporta em lotes, em segundo plano.
+type Tracer struct {
+	cfg   TracerConfig
+	now   func() time.Time
+	queue chan SpanData
+	flush chan chan struct{}
+	done  chan struct{}
+	once  sync.Once
+}
+
+func NewTracer(cfg TracerConfig) *Tracer {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 512
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = 5 * time.Second
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 2048
+	}
+	if cfg.Exporter == nil {
+		cfg.SampleRatio = 0
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	t := &Tracer{
+		cfg:   cfg,
+		now:   time.Now,
+		queue: make(chan SpanData, cfg.QueueSize),
+		flush: make(chan chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go t.run()
+	return t
+}
+
+// Start abre um span filho do span (local ou remoto) presente em ctx.
+func (t *Tracer) Start(ctx context.Context, name string, kind SpanKind, attrs ...Attribute) (context.Context, *Span) {
+	parent := SpanContextFromContext(ctx)
+	sc := SpanContext{SpanID: newSpanID()}
+	if parent.IsValid() {
+		sc.TraceID = parent.TraceID
+		sc.Sampled = parent.Sampled && t.cfg.Exporter != nil
+		sc.TraceState = parent.TraceState
+	} else {
+		sc.TraceID = newTraceID()
+		sc.Sampled = t.sample(sc.TraceID)
+	}
+
+	span := &Span{tracer: t, data: SpanData{
+		Name:         name,
+		Kind:         kind,
+		SpanContext:  sc,
+		ParentSpanID: parent.SpanID,
+		Start:        t.now(),
+		Attributes:   attrs,
+	}}
+	return ContextWithSpan(ctx, span), span
+}
+
+// sample decide pelo próprio trace id, como o TraceIdRatioBased do OTel:
+// processos com a mesma taxa chegam à mesma decisão.
+func (t *Tracer) sample(id TraceID) bool {
+	switch {
+	case t.cfg.SampleRatio >= 1:
+		return true
+	case t.cfg.SampleRatio <= 0:
+		return false
+	}
+	bound := uint64(t.cfg.SampleRatio * (1 << 63))
+	return binary.BigEndian.Uint64(id[8:])>>1 < bound
+}
+
+func (t *Tracer) enqueue(data SpanData) {
+	select {
+	case t.queue <- data:
+	default:
+		// Fila cheia: perder spans é melhor que atrasar a requisição
+	}
+}
+
+func (t *Tracer) run() {
+	ticker := time.NewTicker(t.cfg.FlushInterval)
+	defer ticker.Stop()
+	batch := make([]SpanData, 0, t.cfg.BatchSize)
+	// Com o coletor fora do ar toda exportação falha: avisa no máximo uma vez
+	// por minuto, com o total de spans perdidos desde o último aviso
+	var lastWarning time.Time
+	lost := 0
+	export := func() {
+		if len(batch) > 0 && t.cfg.Exporter != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if err := t.cfg.Exporter.Export(ctx, batch); err != nil {
+				lost += len(batch)
+				if now := t.now(); now.Sub(lastWarning) >= time.Minute {
+					t.cfg.Logger.Warn("tracing export failed", slog.Any("error", err), slog.Int("spans", lost))
+					lastWarning, lost = now, 0
+				}
+			}
+			cancel()
+			batch = make([]SpanData, 0, t.cfg.BatchSize)
+		}
+	}
+	add := func(data SpanData) {
+		batch = append(batch, data)
+		if len(batch) >= t.cfg.BatchSize {
+			export()
+		}
+	}
+	drain := func() {
+		for {
+			select {
+			case data := <-t.queue:
+				add(data)
+			default:
+				export()
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case data := <-t.queue:
+			add(data)
+		case <-ticker.C:
+			export()
+		case reply := <-t.flush:
+			drain()
+			close(reply)
+		case <-t.done:
+			drain()
+			return
+		}
+	}
+}
+
+// ForceFlush exporta os spans já encerrados.
+func (t *Tracer) ForceFlush(ctx context.Context) error {
+	reply := make(chan struct{})
+	select {
+	case t.flush <- reply:
+	case <-t.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-reply:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown exporta o que estiver na fila e encerra o tracer.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	if err := t.ForceFlush(ctx); err != nil {
+		return err
+	}
+	t.once.Do(func() { close(t.done) })
+	return nil
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	for !id.IsValid() {
+		binary.BigEndian.PutUint64(id[:8], rand.Uint64())
+		binary.BigEndian.PutUint64(id[8:], rand.Uint64())
+	}
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	for !id.IsValid() {
+		binary.BigEndian.PutUint64(id[:], rand.Uint64())
+	}
+	return id
+}
@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Transport abre um span cliente para cada requisição de saída e propaga o
+// contexto no cabeçalho traceparent.
+type Transport struct {
+	base   http.RoundTripper
+	tracer *Tracer
+}
+
+// NewTransport usa http.DefaultTransport quando base é nil.
+func NewTransport(base http.RoundTripper, tracer *Tracer) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{base: base, tracer: tracer}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.Start(req.Context(), "HTTP "+req.Method, KindClient,
+		String("http.request.method", req.Method),
+		String("server.address", req.URL.Hostname()),
+		// Sem a query, que pode carregar credenciais
+		String("url.path", req.URL.Path),
+	)
+	defer span.End()
+
+	// RoundTrip não deve alterar a requisição recebida
+	req = req.Clone(ctx)
+	Inject(ctx, req.Header)
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	span.SetAttributes(Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= 500 {
+		span.SetError(httpStatusError(resp.StatusCode))
+	}
+	return resp, nil
+}
+
+type httpStatusError int
+
+func (e httpStatusError) Error() string { return "HTTP " + strconv.Itoa(int(e)) }
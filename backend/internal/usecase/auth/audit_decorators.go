@@ -0,0 +1,388 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/logging"
+	port "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+)
+
+// Os decorators abaixo registram eventos de auditoria em volta dos casos de uso
+// sem alterar seu comportamento. Falhas ao gravar o evento não interrompem o fluxo.
+
+func recordAudit(
+	ctx context.Context,
+	logger providers.AuditLogger,
+	action, actorID, target string,
+	err error,
+) {
+	info := providers.RequestInfoFromContext(ctx)
+
+	event := entity.NewAuditEvent(action, err)
+	event.ActorID = actorID
+	if event.ActorID == "" {
+		event.ActorID = info.ActorID
+	}
+	event.Target = target
+	event.IP = info.IP
+	event.UserAgent = info.UserAgent
+
+	// Falha na auditoria não desfaz a operação já concluída
+	if err := logger.Log(ctx, event); err != nil {
+		logging.FromContext(ctx).Warn("failed to record audit event",
+			slog.String("action", action), slog.Any("error", err))
+	}
+}
+
+type AuditedRegister struct {
+	inner  port.RegisterInterface
+	logger providers.AuditLogger
+}
+
+func NewAuditedRegister(inner port.RegisterInterface, logger providers.AuditLogger) *AuditedRegister {
+	return &AuditedRegister{inner: inner, logger: logger}
+}
+
+func (d *AuditedRegister) Execute(ctx context.Context, input dto.RegisterParams) error {
+	err := d.inner.Execute(ctx, input)
+	recordAudit(ctx, d.logger, entity.AuditActionRegister, "", input.Email, err)
+	return err
+}
+
+type AuditedLogin struct {
+	inner  port.LoginInterface
+	logger providers.AuditLogger
+}
+
+func NewAuditedLogin(inner port.LoginInterface, logger providers.AuditLogger) *AuditedLogin {
+	return &AuditedLogin{inner: inner, logger: logger}
+}
+
+func (d *AuditedLogin) Execute(ctx context.Context, email string, password string) (dto.LoginResult, error) {
+	result, err := d.inner.Execute(ctx, email, password)
+
+	actorID := ""
+	if err == nil {
+		actorID = result.UserID.String()
+	}
+	recordAudit(ctx, d.logger, entity.AuditActionLogin, actorID, email, err)
+	return result, err
+}
+
+type AuditedLogout struct {
+	inner  port.LogoutInterface
+	logger providers.AuditLogger
+}
+
+func NewAuditedLogout(inner port.LogoutInterface, logger providers.AuditLogger) *AuditedLogout {
+	return &AuditedLogout{inner: inner, logger: logger}
+}
+
+func (d *AuditedLogout) Execute(ctx context.Context, token string) error {
+	err := d.inner.Execute(ctx, token)
+	actorID := providers.RequestInfoFromContext(ctx).ActorID
+	recordAudit(ctx, d.logger, entity.AuditActionLogout, actorID, actorID, err)
+	return err
+}
+
+type AuditedRequestPasswordReset struct {
+	inner  port.RequestPasswordResetInterface
+	logger providers.AuditLogger
+}
+
+func NewAuditedRequestPasswordReset(
+	inner port.RequestPasswordResetInterface,
+	logger providers.AuditLogger,
+) *AuditedRequestPasswordReset {
+	return &AuditedRequestPasswordReset{inner: inner, logger: logger}
+}
+
+func (d *AuditedRequestPasswordReset) Execute(ctx context.Context, email vo.Email) error {
+	err := d.inner.Execute(ctx, email)
+	recordAudit(ctx, d.logger, entity.AuditActionPasswordResetRequested, "", email.String(), err)
+	return err
+}
+
+type AuditedResetPassword struct {
+	inner    port.ResetPasswordInterface
+	userRepo repository.UserRepository
+	logger   providers.AuditLogger
+}
+
+func NewAuditedResetPassword(
+	inner port.ResetPasswordInterface,
+	userRepo repository.UserRepository,
+	logger providers.AuditLogger,
+) *AuditedResetPassword {
+	return &AuditedResetPassword{inner: inner, userRepo: userRepo, logger: logger}
+}
+
+func (d *AuditedResetPassword) Execute(ctx context.Context, token, newPassword string) error {
+	// O token é descartado após o reset; o alvo precisa ser resolvido antes
+	target := ""
+	if user, err := d.userRepo.GetByResetToken(ctx, token); err == nil && user != nil {
+		target = user.ID.String()
+	}
+
+	err := d.inner.Execute(ctx, token, newPassword)
+	recordAudit(ctx, d.logger, entity.AuditActionPasswordResetCompleted, target, target, err)
+	return err
+}
+
+type AuditedUpdateName struct {
+	inner  port.UpdateNameInterface
+	logger providers.AuditLogger
+}
+
+func NewAuditedUpdateName(inner port.UpdateNameInterface, logger providers.AuditLogger) *AuditedUpdateName {
+	return &AuditedUpdateName{inner: inner, logger: logger}
+}
+
+func (d *AuditedUpdateName) Execute(ctx context.Context, userID vo.ID, newName string) error {
+	err := d.inner.Execute(ctx, userID, newName)
+	recordAudit(ctx, d.logger, entity.AuditActionNameChange, "", userID.String(), err)
+	return err
+}
+
+type AuditedUpdatePassword struct {
+	inner  port.UpdatePasswordInterface
+	logger providers.AuditLogger
+}
+
+func NewAuditedUpdatePassword(
+	inner port.UpdatePasswordInterface,
+	logger providers.AuditLogger,
+) *AuditedUpdatePassword {
+	return &AuditedUpdatePassword{inner: inner, logger: logger}
+}
+
+func (d *AuditedUpdatePassword) Execute(
+	ctx context.Context,
+	userID vo.ID,
+	currentPassword string,
+	newPassword string,
+) error {
+	err := d.inner.Execute(ctx, userID, currentPassword, newPassword)
+	recordAudit(ctx, d.logger, entity.AuditActionPasswordChange, "", userID.String(), err)
+	return err
+}
+
+type AuditedListAuditEvents struct {
+	inner  port.ListAuditEventsInterface
+	logger providers.AuditLogger
+}
+
+func NewAuditedListAuditEvents(
+	inner port.ListAuditEventsInterface,
+	logger providers.AuditLogger,
+) *AuditedListAuditEvents {
+	return &AuditedListAuditEvents{inner: inner, logger: logger}
+}
+
+func (d *AuditedListAuditEvents) Execute(
+	ctx context.Context,
+	params dto.AuditQueryParams,
+) ([]dto.AuditEventOutput, error) {
+	output, err := d.inner.Execute(ctx, params)
+	recordAudit(ctx, d.logger, entity.AuditActionAdminAuditQuery, "", "", err)
+	return output, err
+}
+
+type AuditedDeactivateAccount struct {
+	inner  port.DeactivateAccountInterface
+	logger providers.AuditLogger
+}
+
+func NewAuditedDeactivateAccount(
+	inner port.DeactivateAccountInterface,
+	logger providers.AuditLogger,
+) *AuditedDeactivateAccount {
+	return &AuditedDeactivateAccount{inner: inner, logger: logger}
+}
+
+func (d *AuditedDeactivateAccount) Execute(ctx context.Context, userID vo.ID, password string) (time.Time, error) {
+	purgeAfter, err := d.inner.Execute(ctx, userID, password)
+	recordAudit(ctx, d.logger, entity.AuditActionAccountDeactivate, "", userID.String(), err)
+	return purgeAfter, err
+}
+
+type AuditedRestoreAccount struct {
+	inner  port.RestoreAccountInterface
+	logger providers.AuditLogger
+}
+
+func NewAuditedRestoreAccount(inner port.RestoreAccountInterface, logger providers.AuditLogger) *AuditedRestoreAccount {
+	return &AuditedRestoreAccount{inner: inner, logger: logger}
+}
+
+func (d *AuditedRestoreAccount) Execute(ctx context.Context, email string, password string) (vo.ID, error) {
+	userID, err := d.inner.Execute(ctx, email, password)
+
+	actorID := ""
+	if err == nil {
+		actorID = userID.String()
+	}
+	recordAudit(ctx, d.logger, entity.AuditActionAccountRestore, actorID, email, err)
+	return userID, err
+}
+
+type AuditedExportUserData struct {
+	inner  port.ExportUserDataInterface
+	logger providers.AuditLogger
+}
+
+func NewAuditedExportUserData(inner port.ExportUserDataInterface, logger providers.AuditLogger) *AuditedExportUserData {
+	return &AuditedExportUserData{inner: inner, logger: logger}
+}
+
+func (d *AuditedExportUserData) Execute(ctx context.Context, userID vo.ID, format string) (dto.UserDataExportResult, error) {
+	result, err := d.inner.Execute(ctx, userID, format)
+	recordAudit(ctx, d.logger, entity.AuditActionDataExport, "", userID.String(), err)
+	return result, err
+}
+
+type AuditedListOutboxMessages struct {
+	inner  port.ListOutboxMessagesInterface
+	logger providers.AuditLogger
+}
+
+func NewAuditedListOutboxMessages(
+	inner port.ListOutboxMessagesInterface,
+	logger providers.AuditLogger,
+) *AuditedListOutboxMessages {
+	return &AuditedListOutboxMessages{inner: inner, logger: logger}
+}
+
+func (d *AuditedListOutboxMessages) Execute(
+	ctx context.Context,
+	params dto.OutboxQueryParams,
+) ([]dto.OutboxMessageOutput, error) {
+	output, err := d.inner.Execute(ctx, params)
+	recordAudit(ctx, d.logger, entity.AuditActionAdminOutboxQuery, "", "", err)
+	return output, err
+}
+
+type AuditedReplayOutboxMessage struct {
+	inner  port.ReplayOutboxMessageInterface
+	logger providers.AuditLogger
+}
+
+func NewAuditedReplayOutboxMessage(
+	inner port.ReplayOutboxMessageInterface,
+	logger providers.AuditLogger,
+) *AuditedReplayOutboxMessage {
+	return &AuditedReplayOutboxMessage{inner: inner, logger: logger}
+}
+
+func (d *AuditedReplayOutboxMessage) Execute(ctx context.Context, id vo.ID) (dto.OutboxMessageOutput, error) {
+	output, err := d.inner.Execute(ctx, id)
+	recordAudit(ctx, d.logger, entity.AuditActionAdminOutboxReplay, "", id.String(), err)
+	return output, err
+}
+
+type AuditedRequestEmailChange struct {
+	inner  port.RequestEmailChangeInterface
+	logger providers.AuditLogger
+}
+
+func NewAuditedRequestEmailChange(inner port.RequestEmailChangeInterface, logger providers.AuditLogger) *AuditedRequestEmailChange {
+	return &AuditedRequestEmailChange{inner: inner, logger: logger}
+}
+
+func (d *AuditedRequestEmailChange) Execute(ctx context.Context, userID vo.ID, newEmail string, password string) error {
+	err := d.inner.Execute(ctx, userID, newEmail, password)
+	recordAudit(ctx, d.logger, entity.AuditActionEmailChangeRequested, "", userID.String(), err)
+	return err
+}
+
+type AuditedConfirmEmailChange struct {
+	inner    port.ConfirmEmailChangeInterface
+	userRepo repository.UserRepository
+	logger   providers.AuditLogger
+}
+
+func NewAuditedConfirmEmailChange(
+	inner port.ConfirmEmailChangeInterface,
+	userRepo repository.UserRepository,
+	logger providers.AuditLogger,
+) *AuditedConfirmEmailChange {
+	return &AuditedConfirmEmailChange{inner: inner, userRepo: userRepo, logger: logger}
+}
+
+func (d *AuditedConfirmEmailChange) Execute(ctx context.Context, token string) error {
+	// Como no reset de senha, o token some após a confirmação
+	target := ""
+	if user, err := d.userRepo.GetByEmailChangeToken(ctx, token); err == nil && user != nil {
+		target = user.ID.String()
+	}
+
+	err := d.inner.Execute(ctx, token)
+	recordAudit(ctx, d.logger, entity.AuditActionEmailChangeConfirmed, target, target, err)
+	return err
+}
+
+type AuditedCancelEmailChange struct {
+	inner    port.CancelEmailChangeInterface
+	userRepo repository.UserRepository
+	logger   providers.AuditLogger
+}
+
+func NewAuditedCancelEmailChange(
+	inner port.CancelEmailChangeInterface,
+	userRepo repository.UserRepository,
+	logger providers.AuditLogger,
+) *AuditedCancelEmailChange {
+	return &AuditedCancelEmailChange{inner: inner, userRepo: userRepo, logger: logger}
+}
+
+func (d *AuditedCancelEmailChange) Execute(ctx context.Context, token string) error {
+	target := ""
+	if user, err := d.userRepo.GetByEmailChangeCancelToken(ctx, token); err == nil && user != nil {
+		target = user.ID.String()
+	}
+
+	err := d.inner.Execute(ctx, token)
+	recordAudit(ctx, d.logger, entity.AuditActionEmailChangeCancelled, target, target, err)
+	return err
+}
+
+type AuditedUploadAvatar struct {
+	inner  port.UploadAvatarInterface
+	logger providers.AuditLogger
+}
+
+func NewAuditedUploadAvatar(inner port.UploadAvatarInterface, logger providers.AuditLogger) *AuditedUploadAvatar {
+	return &AuditedUploadAvatar{inner: inner, logger: logger}
+}
+
+func (d *AuditedUploadAvatar) Execute(ctx context.Context, userID vo.ID, data []byte) (string, error) {
+	imageURL, err := d.inner.Execute(ctx, userID, data)
+	recordAudit(ctx, d.logger, entity.AuditActionAvatarChange, "", userID.String(), err)
+	return imageURL, err
+}
+
+type AuditedUpdateProfile struct {
+	inner  port.UpdateProfileInterface
+	logger providers.AuditLogger
+}
+
+func NewAuditedUpdateProfile(inner port.UpdateProfileInterface, logger providers.AuditLogger) *AuditedUpdateProfile {
+	return &AuditedUpdateProfile{inner: inner, logger: logger}
+}
+
+func (d *AuditedUpdateProfile) Execute(
+	ctx context.Context,
+	userID vo.ID,
+	input dto.UpdateProfileInput,
+) (dto.UserOutput, error) {
+	output, err := d.inner.Execute(ctx, userID, input)
+	recordAudit(ctx, d.logger, entity.AuditActionProfileUpdate, "", userID.String(), err)
+	return output, err
+}
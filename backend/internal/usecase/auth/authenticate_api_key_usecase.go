@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+type AuthenticateAPIKeyUsecase struct {
+	apiKeyRepo repository.APIKeyRepository
+}
+
+func NewAuthenticateAPIKeyUsecase(apiKeyRepo repository.APIKeyRepository) *AuthenticateAPIKeyUsecase {
+	return &AuthenticateAPIKeyUsecase{apiKeyRepo: apiKeyRepo}
+}
+
+func (uc *AuthenticateAPIKeyUsecase) Execute(ctx context.Context, rawKey string) (dto.APIKeyPrincipal, error) {
+	prefix, err := entity.ParseAPIKeyPrefix(rawKey)
+	if err != nil {
+		return dto.APIKeyPrincipal{}, msgerror.AnErrInvalidAPIKey
+	}
+
+	key, err := uc.apiKeyRepo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		return dto.APIKeyPrincipal{}, msgerror.Wrap("failed to get api key", err)
+	}
+	if key == nil {
+		return dto.APIKeyPrincipal{}, msgerror.AnErrInvalidAPIKey
+	}
+
+	hash := entity.HashAPIKey(rawKey)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(key.KeyHash)) != 1 {
+		return dto.APIKeyPrincipal{}, msgerror.AnErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if !key.IsActive(now) {
+		return dto.APIKeyPrincipal{}, msgerror.AnErrInvalidAPIKey
+	}
+
+	if err := uc.apiKeyRepo.TouchLastUsed(ctx, key.ID, now); err != nil {
+		return dto.APIKeyPrincipal{}, msgerror.Wrap("failed to update api key usage", err)
+	}
+
+	return dto.APIKeyPrincipal{
+		UserID:   key.UserID.String(),
+		APIKeyID: key.ID.String(),
+		Scopes:   key.Scopes,
+	}, nil
+}
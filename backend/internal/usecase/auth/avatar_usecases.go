@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/logging"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+// AvatarSizes são os lados, em pixels, das miniaturas geradas a cada envio;
+// ImageURL aponta para DefaultAvatarSize e ?size= escolhe as demais.
+var AvatarSizes = []int{64, 128, 256}
+
+const DefaultAvatarSize = 256
+
+type AvatarOptions struct {
+	// MaxBytes limita o tamanho do arquivo enviado
+	MaxBytes int64
+	// PublicURL é a base das URLs servidas por GET /avatars/:id
+	PublicURL string
+}
+
+// UploadAvatarUsecase gera as miniaturas, grava-as sob um id novo a cada envio
+// (a URL muda e pode ser mantida em cache indefinidamente) e remove as do
+// avatar anterior.
+type UploadAvatarUsecase struct {
+	userRepo repository.UserRepository
+	store    providers.BlobStore
+	images   providers.ImageProcessor
+	opts     AvatarOptions
+}
+
+func NewUploadAvatarUsecase(
+	userRepo repository.UserRepository,
+	store providers.BlobStore,
+	images providers.ImageProcessor,
+	opts AvatarOptions,
+) *UploadAvatarUsecase {
+	opts.PublicURL = strings.TrimRight(opts.PublicURL, "/")
+	return &UploadAvatarUsecase{userRepo: userRepo, store: store, images: images, opts: opts}
+}
+
+func (uc *UploadAvatarUsecase) Execute(ctx context.Context, userID vo.ID, data []byte) (string, error) {
+	if int64(len(data)) > uc.opts.MaxBytes {
+		return "", msgerror.AnErrImageTooLarge
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", msgerror.Wrap("failed to get user", err)
+	}
+	if user == nil {
+		return "", msgerror.AnErrUserNotFound
+	}
+
+	thumbnails, err := uc.images.Thumbnails(data, AvatarSizes)
+	if errors.Is(err, msgerror.AnErrUnsupportedImage) ||
+		errors.Is(err, msgerror.AnErrInvalidImage) ||
+		errors.Is(err, msgerror.AnErrImageTooLarge) {
+		return "", err
+	}
+	if err != nil {
+		return "", msgerror.Wrap("failed to process image", err)
+	}
+
+	avatarID := vo.NewID().String()
+	for _, size := range AvatarSizes {
+		if err := uc.store.Put(ctx, avatarKey(avatarID, size), thumbnails[size], "image/png"); err != nil {
+			uc.deleteAvatar(ctx, avatarID)
+			return "", msgerror.Wrap("failed to store avatar", err)
+		}
+	}
+
+	imageURL, err := vo.NewURL(uc.opts.PublicURL + "/" + avatarID)
+	if err != nil {
+		uc.deleteAvatar(ctx, avatarID)
+		return "", msgerror.Wrap("failed to build avatar URL", err)
+	}
+
+	previous := user.ImageURL
+	if _, err := uc.userRepo.Update(ctx, user.WithImageURL(imageURL), repository.UserFieldImageURL); err != nil {
+		uc.deleteAvatar(ctx, avatarID)
+		if errors.Is(err, msgerror.AnErrConflict) {
+			return "", err
+		}
+		return "", msgerror.Wrap("failed to save user", err)
+	}
+
+	if oldID, ok := avatarIDFromURL(uc.opts.PublicURL, previous); ok {
+		uc.deleteAvatar(ctx, oldID)
+	}
+
+	return imageURL.String(), nil
+}
+
+// deleteAvatar é o melhor esforço: um arquivo órfão não afeta o usuário.
+func (uc *UploadAvatarUsecase) deleteAvatar(ctx context.Context, avatarID string) {
+	if err := deleteAvatarBlobs(ctx, uc.store, avatarID); err != nil {
+		logging.FromContext(ctx).Warn("failed to delete old avatar",
+			slog.String("avatar_id", avatarID), slog.Any("error", err))
+	}
+}
+
+// avatarIDFromURL só reconhece os avatares servidos por aqui; URLs externas
+// não têm arquivos a remover.
+func avatarIDFromURL(publicURL string, imageURL vo.URL) (string, bool) {
+	id, ok := strings.CutPrefix(imageURL.String(), strings.TrimRight(publicURL, "/")+"/")
+	if !ok {
+		return "", false
+	}
+	if _, err := vo.ParseID(id); err != nil {
+		return "", false
+	}
+	return id, true
+}
+
+// deleteAvatarBlobs remove todos os tamanhos, mesmo que algum falhe.
+func deleteAvatarBlobs(ctx context.Context, store providers.BlobStore, avatarID string) error {
+	var errs []error
+	for _, size := range AvatarSizes {
+		if err := store.Delete(ctx, avatarKey(avatarID, size)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type GetAvatarUsecase struct {
+	store providers.BlobStore
+}
+
+func NewGetAvatarUsecase(store providers.BlobStore) *GetAvatarUsecase {
+	return &GetAvatarUsecase{store: store}
+}
+
+func (uc *GetAvatarUsecase) Execute(ctx context.Context, avatarID string, size int) (dto.AvatarImage, error) {
+	if size == 0 {
+		size = DefaultAvatarSize
+	}
+	if !slices.Contains(AvatarSizes, size) {
+		return dto.AvatarImage{}, msgerror.AnErrInvalidImageSize
+	}
+	// O id compõe a chave no armazenamento, então só ids válidos passam
+	if _, err := vo.ParseID(avatarID); err != nil {
+		return dto.AvatarImage{}, msgerror.AnErrAvatarNotFound
+	}
+
+	blob, err := uc.store.Get(ctx, avatarKey(avatarID, size))
+	if errors.Is(err, msgerror.AnErrBlobNotFound) {
+		return dto.AvatarImage{}, msgerror.AnErrAvatarNotFound
+	}
+	if err != nil {
+		return dto.AvatarImage{}, msgerror.Wrap("failed to load avatar", err)
+	}
+	return dto.AvatarImage{ContentType: blob.ContentType, Data: blob.Data}, nil
+}
+
+func avatarKey(avatarID string, size int) string {
+	return fmt.Sprintf("avatars/%s/%d.png", avatarID, size)
+}
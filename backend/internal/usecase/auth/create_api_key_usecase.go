@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+type CreateAPIKeyUsecase struct {
+	apiKeyRepo repository.APIKeyRepository
+}
+
+func NewCreateAPIKeyUsecase(apiKeyRepo repository.APIKeyRepository) *CreateAPIKeyUsecase {
+	return &CreateAPIKeyUsecase{apiKeyRepo: apiKeyRepo}
+}
+
+func (uc *CreateAPIKeyUsecase) Execute(
+	ctx context.Context,
+	userID vo.ID,
+	input dto.CreateAPIKeyParams,
+) (dto.CreatedAPIKeyResult, error) {
+	validationErrs := msgerror.NewValidationErrors()
+
+	name, err := vo.NewName(input.Name, 3, 50)
+	if err != nil {
+		validationErrs.AddError("name", err)
+	}
+
+	if validationErrs.HasErrors() {
+		return dto.CreatedAPIKeyResult{}, validationErrs
+	}
+
+	key, rawKey, err := entity.NewAPIKey(userID, name, input.Scopes, input.ExpiresAt)
+	if err != nil {
+		switch err {
+		case msgerror.AnErrInvalidScope:
+			validationErrs.AddError("scopes", err)
+			return dto.CreatedAPIKeyResult{}, validationErrs
+		case msgerror.AnErrInvalidExpiration:
+			validationErrs.AddError("expires_at", err)
+			return dto.CreatedAPIKeyResult{}, validationErrs
+		}
+		return dto.CreatedAPIKeyResult{}, msgerror.Wrap("failed to generate api key", err)
+	}
+
+	saved, err := uc.apiKeyRepo.Save(ctx, key)
+	if err != nil {
+		return dto.CreatedAPIKeyResult{}, msgerror.Wrap("failed to save api key", err)
+	}
+
+	return dto.CreatedAPIKeyResult{
+		APIKeyResult: toAPIKeyResult(saved),
+		Key:          rawKey,
+	}, nil
+}
+
+func toAPIKeyResult(key *entity.APIKey) dto.APIKeyResult {
+	return dto.APIKeyResult{
+		ID:         key.ID.String(),
+		Name:       key.Name.String(),
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
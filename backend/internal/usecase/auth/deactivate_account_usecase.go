@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+// DeactivateAccountUsecase desativa a conta após reconfirmar a senha. A conta
+// pode ser restaurada durante o período de carência; depois disso o purger
+// apaga os dados pessoais.
+type DeactivateAccountUsecase struct {
+	userRepo       repository.UserRepository
+	apiKeyRepo     repository.APIKeyRepository
+	txManager      repository.TxManager
+	cryptoProvider providers.CryptoProvider
+	tokenStore     providers.TokenStore
+	tokenTTL       time.Duration
+	grace          time.Duration
+}
+
+func NewDeactivateAccountUsecase(
+	userRepo repository.UserRepository,
+	apiKeyRepo repository.APIKeyRepository,
+	txManager repository.TxManager,
+	cryptoProvider providers.CryptoProvider,
+	tokenStore providers.TokenStore,
+	tokenTTL time.Duration,
+	grace time.Duration,
+) *DeactivateAccountUsecase {
+	return &DeactivateAccountUsecase{
+		userRepo:       userRepo,
+		apiKeyRepo:     apiKeyRepo,
+		txManager:      txManager,
+		cryptoProvider: cryptoProvider,
+		tokenStore:     tokenStore,
+		tokenTTL:       tokenTTL,
+		grace:          grace,
+	}
+}
+
+func (uc *DeactivateAccountUsecase) Execute(ctx context.Context, userID vo.ID, password string) (time.Time, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return time.Time{}, msgerror.Wrap("failed to get user", err)
+	}
+	if user == nil {
+		return time.Time{}, msgerror.AnErrUserNotFound
+	}
+
+	match, err := uc.cryptoProvider.Compare(ctx, password, user.PasswordHash.String())
+	if err != nil {
+		return time.Time{}, msgerror.Wrap("failed to compare passwords", err)
+	}
+	if !match {
+		return time.Time{}, msgerror.AnErrInvalidCredentials
+	}
+
+	now := time.Now()
+	user.Deactivate(now)
+
+	// Sessões e chaves de API não sobrevivem à desativação, nem a uma restauração
+	err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.userRepo.Deactivate(ctx, user.ID, now); err != nil {
+			return msgerror.Wrap("failed to deactivate user", err)
+		}
+		if err := uc.apiKeyRepo.RevokeAllByUserID(ctx, user.ID, now); err != nil {
+			return msgerror.Wrap("failed to revoke api keys", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := uc.tokenStore.RevokeUser(ctx, user.ID.String(), uc.tokenTTL); err != nil {
+		return time.Time{}, msgerror.Wrap("failed to revoke sessions", err)
+	}
+
+	return user.PurgeAfter(uc.grace), nil
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"path"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+// DownloadUserDataExportUsecase entrega o arquivo de um link assinado; a
+// assinatura substitui a autenticação, pois o link é aberto a partir do e-mail.
+type DownloadUserDataExportUsecase struct {
+	store  providers.ExportStore
+	signer providers.URLSigner
+}
+
+func NewDownloadUserDataExportUsecase(
+	store providers.ExportStore,
+	signer providers.URLSigner,
+) *DownloadUserDataExportUsecase {
+	return &DownloadUserDataExportUsecase{store: store, signer: signer}
+}
+
+func (uc *DownloadUserDataExportUsecase) Execute(
+	ctx context.Context,
+	fileName string,
+	expiresAt time.Time,
+	signature string,
+) (dto.UserDataExportResult, error) {
+	if !uc.signer.Verify(fileName, expiresAt, signature) {
+		return dto.UserDataExportResult{}, msgerror.AnErrInvalidSignature
+	}
+
+	data, err := uc.store.Load(ctx, fileName)
+	if errors.Is(err, msgerror.AnErrExportNotFound) {
+		return dto.UserDataExportResult{}, err
+	}
+	if err != nil {
+		return dto.UserDataExportResult{}, msgerror.Wrap("failed to load export", err)
+	}
+
+	contentType := "application/json"
+	if path.Ext(fileName) == ".zip" {
+		contentType = "application/zip"
+	}
+	return dto.UserDataExportResult{FileName: fileName, ContentType: contentType, Data: data}, nil
+}
@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+// RequestEmailChangeUsecase reconfirma a senha e grava a troca pendente junto
+// com os dois e-mails: confirmação para o novo endereço e aviso com link de
+// cancelamento para o atual.
+type RequestEmailChangeUsecase struct {
+	userRepo       repository.UserRepository
+	outboxRepo     repository.OutboxRepository
+	txManager      repository.TxManager
+	cryptoProvider providers.CryptoProvider
+	ttl            time.Duration
+}
+
+func NewRequestEmailChangeUsecase(
+	userRepo repository.UserRepository,
+	outboxRepo repository.OutboxRepository,
+	txManager repository.TxManager,
+	cryptoProvider providers.CryptoProvider,
+	ttl time.Duration,
+) *RequestEmailChangeUsecase {
+	return &RequestEmailChangeUsecase{
+		userRepo:       userRepo,
+		outboxRepo:     outboxRepo,
+		txManager:      txManager,
+		cryptoProvider: cryptoProvider,
+		ttl:            ttl,
+	}
+}
+
+func (uc *RequestEmailChangeUsecase) Execute(ctx context.Context, userID vo.ID, newEmail string, password string) error {
+	email, err := vo.NewEmail(newEmail)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return msgerror.Wrap("failed to get user", err)
+	}
+	if user == nil {
+		return msgerror.AnErrUserNotFound
+	}
+
+	match, err := uc.cryptoProvider.Compare(ctx, password, user.PasswordHash.String())
+	if err != nil {
+		return msgerror.Wrap("failed to compare passwords", err)
+	}
+	if !match {
+		return msgerror.AnErrInvalidCredentials
+	}
+
+	if err := user.RequestEmailChange(email, uc.ttl); err != nil {
+		if errors.Is(err, msgerror.AnErrEmailUnchanged) {
+			return err
+		}
+		return msgerror.Wrap("failed to generate email change token", err)
+	}
+	if err := ensureEmailAvailable(ctx, uc.userRepo, email); err != nil {
+		return err
+	}
+
+	confirmation, err := entity.NewOutboxMessage(entity.OutboxTopicEmailChangeConfirmationEmail, entity.EmailChangeConfirmationPayload{
+		Email:  email.String(),
+		Token:  user.EmailChangeToken,
+		Locale: user.Locale.String(),
+	})
+	if err != nil {
+		return msgerror.Wrap("failed to build confirmation email", err)
+	}
+	notice, err := entity.NewOutboxMessage(entity.OutboxTopicEmailChangeNoticeEmail, entity.EmailChangeNoticePayload{
+		Email:       user.Email.String(),
+		NewEmail:    email.String(),
+		CancelToken: user.EmailChangeCancelToken,
+		Locale:      user.Locale.String(),
+	})
+	if err != nil {
+		return msgerror.Wrap("failed to build notice email", err)
+	}
+	confirmation.UserID, notice.UserID = user.ID, user.ID
+
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := uc.userRepo.Update(ctx, user, repository.UserFieldEmailChange); err != nil {
+			if errors.Is(err, msgerror.AnErrConflict) {
+				return err
+			}
+			return msgerror.Wrap("failed to save user", err)
+		}
+		for _, message := range []*entity.OutboxMessage{confirmation, notice} {
+			if err := uc.outboxRepo.Enqueue(ctx, message); err != nil {
+				return msgerror.Wrap("failed to enqueue email change emails", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ConfirmEmailChangeUsecase efetiva a troca e encerra as sessões abertas com
+// o endereço antigo.
+type ConfirmEmailChangeUsecase struct {
+	userRepo   repository.UserRepository
+	tokenStore providers.TokenStore
+	tokenTTL   time.Duration
+}
+
+func NewConfirmEmailChangeUsecase(
+	userRepo repository.UserRepository,
+	tokenStore providers.TokenStore,
+	tokenTTL time.Duration,
+) *ConfirmEmailChangeUsecase {
+	return &ConfirmEmailChangeUsecase{
+		userRepo:   userRepo,
+		tokenStore: tokenStore,
+		tokenTTL:   tokenTTL,
+	}
+}
+
+func (uc *ConfirmEmailChangeUsecase) Execute(ctx context.Context, token string) error {
+	user, err := uc.userRepo.GetByEmailChangeToken(ctx, token)
+	if err != nil {
+		return msgerror.Wrap("failed to get user by token", err)
+	}
+	if user == nil {
+		return msgerror.AnErrInvalidToken
+	}
+
+	newEmail := user.PendingEmail
+	if err := user.ConfirmEmailChange(time.Now()); err != nil {
+		return err
+	}
+
+	// O endereço pode ter sido ocupado depois do pedido
+	if err := ensureEmailAvailable(ctx, uc.userRepo, newEmail); err != nil {
+		return err
+	}
+
+	_, err = uc.userRepo.Update(ctx, user, repository.UserFieldEmail, repository.UserFieldEmailChange)
+	if errors.Is(err, msgerror.AnErrConflict) || errors.Is(err, msgerror.AnErrUserExists) {
+		return err
+	}
+	if err != nil {
+		return msgerror.Wrap("failed to save user", err)
+	}
+
+	if err := uc.tokenStore.RevokeUser(ctx, user.ID.String(), uc.tokenTTL); err != nil {
+		return msgerror.Wrap("failed to revoke sessions", err)
+	}
+	return nil
+}
+
+// CancelEmailChangeUsecase descarta a troca pendente pelo link enviado ao
+// endereço atual.
+type CancelEmailChangeUsecase struct {
+	userRepo repository.UserRepository
+}
+
+func NewCancelEmailChangeUsecase(userRepo repository.UserRepository) *CancelEmailChangeUsecase {
+	return &CancelEmailChangeUsecase{userRepo: userRepo}
+}
+
+func (uc *CancelEmailChangeUsecase) Execute(ctx context.Context, token string) error {
+	user, err := uc.userRepo.GetByEmailChangeCancelToken(ctx, token)
+	if err != nil {
+		return msgerror.Wrap("failed to get user by token", err)
+	}
+	if user == nil {
+		return msgerror.AnErrInvalidToken
+	}
+
+	user.ClearEmailChange()
+	_, err = uc.userRepo.Update(ctx, user, repository.UserFieldEmailChange)
+	if errors.Is(err, msgerror.AnErrConflict) {
+		return err
+	}
+	if err != nil {
+		return msgerror.Wrap("failed to save user", err)
+	}
+	return nil
+}
+
+// ensureEmailAvailable considera também contas desativadas, cujo e-mail segue
+// reservado até o apagamento.
+func ensureEmailAvailable(ctx context.Context, userRepo repository.UserRepository, email vo.Email) error {
+	existing, err := userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return msgerror.Wrap("failed to check email existence", err)
+	}
+	if existing != nil {
+		return msgerror.AnErrUserExists
+	}
+
+	deactivated, err := userRepo.GetDeactivatedByEmail(ctx, email)
+	if err != nil {
+		return msgerror.Wrap("failed to check email existence", err)
+	}
+	if deactivated != nil {
+		return msgerror.AnErrUserExists
+	}
+	return nil
+}
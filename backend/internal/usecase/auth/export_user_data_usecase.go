@@ -0,0 +1,334 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/logging"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	service "github.com/eskokado/startup-auth-go/backend/pkg/domain/services"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+const auditExportPageSize = 500
+
+// RunInBackground executa as exportações assíncronas; substituível nos testes.
+var RunInBackground = func(task func()) { go task() }
+
+type ExportOptions struct {
+	// Acima deste número de eventos de auditoria o arquivo é enviado por e-mail
+	AsyncThreshold int
+	LinkTTL        time.Duration
+	// DownloadURL é a base do link assinado, ex.: https://api.exemplo.com/exports
+	DownloadURL string
+}
+
+type ExportUserDataUsecase struct {
+	userRepo    repository.UserRepository
+	apiKeyRepo  repository.APIKeyRepository
+	auditRepo   repository.AuditRepository
+	outboxRepo  repository.OutboxRepository
+	store       providers.ExportStore
+	signer      providers.URLSigner
+	emailSender service.EmailServiceInterface
+	options     ExportOptions
+}
+
+func NewExportUserDataUsecase(
+	userRepo repository.UserRepository,
+	apiKeyRepo repository.APIKeyRepository,
+	auditRepo repository.AuditRepository,
+	outboxRepo repository.OutboxRepository,
+	store providers.ExportStore,
+	signer providers.URLSigner,
+	emailSender service.EmailServiceInterface,
+	options ExportOptions,
+) *ExportUserDataUsecase {
+	return &ExportUserDataUsecase{
+		userRepo:    userRepo,
+		apiKeyRepo:  apiKeyRepo,
+		auditRepo:   auditRepo,
+		outboxRepo:  outboxRepo,
+		store:       store,
+		signer:      signer,
+		emailSender: emailSender,
+		options:     options,
+	}
+}
+
+func (uc *ExportUserDataUsecase) Execute(ctx context.Context, userID vo.ID, format string) (dto.UserDataExportResult, error) {
+	if format == "" {
+		format = dto.ExportFormatJSON
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return dto.UserDataExportResult{}, msgerror.Wrap("failed to get user", err)
+	}
+	if user == nil {
+		return dto.UserDataExportResult{}, msgerror.AnErrUserNotFound
+	}
+
+	total, err := uc.countAuditEvents(ctx, user)
+	if err != nil {
+		return dto.UserDataExportResult{}, err
+	}
+
+	if total > int64(uc.options.AsyncThreshold) {
+		// A requisição termina antes da exportação; o contexto não pode ser cancelado junto
+		bgCtx := context.WithoutCancel(ctx)
+		RunInBackground(func() {
+			if err := uc.deliver(bgCtx, user, format); err != nil {
+				logging.FromContext(bgCtx).Error("data export failed",
+					slog.String("user_id", user.ID.String()), slog.Any("error", err))
+			}
+		})
+		return dto.UserDataExportResult{Pending: true}, nil
+	}
+
+	return uc.build(ctx, user, format)
+}
+
+// deliver grava o arquivo e envia o link assinado para o e-mail do usuário.
+// Arquivos vencidos são removidos pelo jobs.ExportCleaner; os do usuário, no
+// apagamento da conta.
+func (uc *ExportUserDataUsecase) deliver(ctx context.Context, user *entity.User, format string) error {
+	result, err := uc.build(ctx, user, format)
+	if err != nil {
+		return err
+	}
+
+	// O prefixo identifica o dono no armazenamento
+	name := user.ID.String() + "." + result.FileName
+	if err := uc.store.Save(ctx, name, result.Data); err != nil {
+		return msgerror.Wrap("failed to store export", err)
+	}
+
+	expiresAt := time.Now().Add(uc.options.LinkTTL)
+	query := url.Values{}
+	query.Set("expires", fmt.Sprint(expiresAt.Unix()))
+	query.Set("signature", uc.signer.Sign(name, expiresAt))
+	link := fmt.Sprintf("%s/%s?%s", uc.options.DownloadURL, name, query.Encode())
+
+	if err := uc.emailSender.SendDataExportEmail(ctx, user.Email, user.Locale.String(), link); err != nil {
+		return msgerror.Wrap("failed to send export email", err)
+	}
+	return nil
+}
+
+func (uc *ExportUserDataUsecase) build(ctx context.Context, user *entity.User, format string) (dto.UserDataExportResult, error) {
+	export, err := uc.collect(ctx, user)
+	if err != nil {
+		return dto.UserDataExportResult{}, err
+	}
+
+	name := vo.NewID().String()
+	if format == dto.ExportFormatZIP {
+		data, err := zipExport(export)
+		if err != nil {
+			return dto.UserDataExportResult{}, msgerror.Wrap("failed to build export archive", err)
+		}
+		return dto.UserDataExportResult{FileName: name + ".zip", ContentType: "application/zip", Data: data}, nil
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return dto.UserDataExportResult{}, msgerror.Wrap("failed to encode export", err)
+	}
+	return dto.UserDataExportResult{FileName: name + ".json", ContentType: "application/json", Data: data}, nil
+}
+
+func (uc *ExportUserDataUsecase) collect(ctx context.Context, user *entity.User) (dto.UserDataExport, error) {
+	keys, err := uc.apiKeyRepo.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return dto.UserDataExport{}, msgerror.Wrap("failed to list api keys", err)
+	}
+
+	apiKeys := make([]dto.APIKeyOutput, 0, len(keys))
+	for _, key := range keys {
+		apiKeys = append(apiKeys, dto.APIKeyOutput{
+			ID:         key.ID.String(),
+			Name:       key.Name.String(),
+			Prefix:     key.Prefix,
+			Scopes:     key.Scopes,
+			ExpiresAt:  exportTime(key.ExpiresAt),
+			LastUsedAt: exportTime(key.LastUsedAt),
+			RevokedAt:  exportTime(key.RevokedAt),
+			CreatedAt:  key.CreatedAt,
+		})
+	}
+
+	events, err := uc.auditEvents(ctx, user)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	messages, err := uc.messages(ctx, user)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	return dto.UserDataExport{
+		ExportedAt: time.Now().UTC(),
+		Profile: dto.UserProfileExport{
+			ID:         user.ID.String(),
+			Name:       user.Name.String(),
+			Email:      user.Email.String(),
+			ImageURL:   user.ImageURL.String(),
+			Locale:     user.Locale.String(),
+			Timezone:   user.Timezone.String(),
+			Attributes: user.Attributes.Map(),
+			CreatedAt:  user.CreatedAt,
+		},
+		EmailChange: emailChangeExport(user),
+		APIKeys:     apiKeys,
+		AuditEvents: events,
+		Messages:    messages,
+	}, nil
+}
+
+// emailChangeExport omite os tokens: valem como senha enquanto a troca está pendente.
+func emailChangeExport(user *entity.User) *dto.EmailChangeExport {
+	if user.PendingEmail.IsEmpty() {
+		return nil
+	}
+	return &dto.EmailChangeExport{
+		PendingEmail: user.PendingEmail.String(),
+		ExpiresAt:    user.EmailChangeExpires,
+	}
+}
+
+// messages lista os e-mails enviados ao usuário, sem o conteúdo (tokens).
+func (uc *ExportUserDataUsecase) messages(ctx context.Context, user *entity.User) ([]dto.OutboxMessageExport, error) {
+	messages, err := uc.outboxRepo.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, msgerror.Wrap("failed to list outbox messages", err)
+	}
+
+	output := make([]dto.OutboxMessageExport, 0, len(messages))
+	for _, message := range messages {
+		// Mensagens enviadas não guardam mais o conteúdo nem o destinatário
+		var recipient struct {
+			Email string `json:"email"`
+		}
+		_ = json.Unmarshal(message.Payload, &recipient)
+		output = append(output, dto.OutboxMessageExport{
+			ID:        message.ID.String(),
+			Topic:     message.Topic,
+			Status:    message.Status,
+			To:        recipient.Email,
+			CreatedAt: message.CreatedAt,
+			SentAt:    exportTime(message.SentAt),
+		})
+	}
+	return output, nil
+}
+
+// auditFilters cobre eventos em que o usuário é autor ou alvo (pelo id ou e-mail).
+func auditFilters(user *entity.User) []repository.AuditFilter {
+	return []repository.AuditFilter{
+		{ActorID: user.ID.String()},
+		{Target: user.ID.String()},
+		{Target: user.Email.String()},
+	}
+}
+
+func (uc *ExportUserDataUsecase) countAuditEvents(ctx context.Context, user *entity.User) (int64, error) {
+	var total int64
+	for _, filter := range auditFilters(user) {
+		count, err := uc.auditRepo.Count(ctx, filter)
+		if err != nil {
+			return 0, msgerror.Wrap("failed to count audit events", err)
+		}
+		total += count
+	}
+	return total, nil
+}
+
+func (uc *ExportUserDataUsecase) auditEvents(ctx context.Context, user *entity.User) ([]dto.AuditEventOutput, error) {
+	seen := make(map[string]bool)
+	output := make([]dto.AuditEventOutput, 0)
+
+	for _, filter := range auditFilters(user) {
+		filter.Limit = auditExportPageSize
+		for {
+			events, err := uc.auditRepo.List(ctx, filter)
+			if err != nil {
+				return nil, msgerror.Wrap("failed to list audit events", err)
+			}
+
+			for _, event := range events {
+				if seen[event.ID.String()] {
+					continue
+				}
+				seen[event.ID.String()] = true
+				output = append(output, dto.AuditEventOutput{
+					ID:        event.ID.String(),
+					Action:    event.Action,
+					Outcome:   event.Outcome,
+					ActorID:   event.ActorID,
+					Target:    event.Target,
+					IP:        event.IP,
+					UserAgent: event.UserAgent,
+					Reason:    event.Reason,
+					CreatedAt: event.CreatedAt,
+				})
+			}
+
+			if len(events) < auditExportPageSize {
+				break
+			}
+			filter.Offset += auditExportPageSize
+		}
+	}
+	return output, nil
+}
+
+// zipExport separa cada seção em um arquivo JSON próprio.
+func zipExport(export dto.UserDataExport) ([]byte, error) {
+	files := []struct {
+		name    string
+		content any
+	}{
+		{"profile.json", export.Profile},
+		{"email_change.json", export.EmailChange},
+		{"api_keys.json", export.APIKeys},
+		{"audit_events.json", export.AuditEvents},
+		{"messages.json", export.Messages},
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range files {
+		w, err := archive.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func exportTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+type IntrospectTokenUsecase struct {
+	tokenProvider providers.TokenProvider
+	tokenStore    providers.TokenStore
+}
+
+func NewIntrospectTokenUsecase(
+	tokenProvider providers.TokenProvider,
+	tokenStore providers.TokenStore,
+) *IntrospectTokenUsecase {
+	return &IntrospectTokenUsecase{
+		tokenProvider: tokenProvider,
+		tokenStore:    tokenStore,
+	}
+}
+
+// Execute segue a RFC 7662: tokens inválidos, expirados ou revogados
+// resultam em {"active": false} e não em erro.
+func (uc *IntrospectTokenUsecase) Execute(ctx context.Context, token string) (dto.IntrospectionResult, error) {
+	inactive := dto.IntrospectionResult{Active: false}
+
+	if token == "" {
+		return inactive, nil
+	}
+
+	rawClaims, err := uc.tokenProvider.Validate(ctx, token)
+	if err != nil {
+		return inactive, nil
+	}
+
+	claims, ok := rawClaims.(providers.Claims)
+	if !ok || claims.UserID == "" {
+		return inactive, nil
+	}
+
+	active, err := uc.tokenStore.IsActive(ctx, claims)
+	if err != nil {
+		return dto.IntrospectionResult{}, msgerror.Wrap("failed to check token session", err)
+	}
+	if !active {
+		return inactive, nil
+	}
+
+	var expiresAt int64
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Unix()
+	}
+
+	return dto.IntrospectionResult{
+		Active:    true,
+		UserID:    claims.UserID,
+		Subject:   claims.Subject,
+		ExpiresAt: expiresAt,
+		TokenType: "Bearer",
+	}, nil
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+type ListAPIKeysUsecase struct {
+	apiKeyRepo repository.APIKeyRepository
+}
+
+func NewListAPIKeysUsecase(apiKeyRepo repository.APIKeyRepository) *ListAPIKeysUsecase {
+	return &ListAPIKeysUsecase{apiKeyRepo: apiKeyRepo}
+}
+
+func (uc *ListAPIKeysUsecase) Execute(ctx context.Context, userID vo.ID) ([]dto.APIKeyResult, error) {
+	keys, err := uc.apiKeyRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, msgerror.Wrap("failed to list api keys", err)
+	}
+
+	results := make([]dto.APIKeyResult, 0, len(keys))
+	for _, key := range keys {
+		results = append(results, toAPIKeyResult(key))
+	}
+	return results, nil
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+type ListAuditEventsUsecase struct {
+	auditRepo repository.AuditRepository
+}
+
+func NewListAuditEventsUsecase(auditRepo repository.AuditRepository) *ListAuditEventsUsecase {
+	return &ListAuditEventsUsecase{auditRepo: auditRepo}
+}
+
+func (uc *ListAuditEventsUsecase) Execute(
+	ctx context.Context,
+	params dto.AuditQueryParams,
+) ([]dto.AuditEventOutput, error) {
+	events, err := uc.auditRepo.List(ctx, repository.AuditFilter{
+		Action:  params.Action,
+		Outcome: params.Outcome,
+		ActorID: params.ActorID,
+		Target:  params.Target,
+		IP:      params.IP,
+		From:    params.From,
+		To:      params.To,
+		Limit:   params.Limit,
+		Offset:  params.Offset,
+	})
+	if err != nil {
+		return nil, msgerror.Wrap("failed to list audit events", err)
+	}
+
+	output := make([]dto.AuditEventOutput, 0, len(events))
+	for _, event := range events {
+		output = append(output, dto.AuditEventOutput{
+			ID:        event.ID.String(),
+			Action:    event.Action,
+			Outcome:   event.Outcome,
+			ActorID:   event.ActorID,
+			Target:    event.Target,
+			IP:        event.IP,
+			UserAgent: event.UserAgent,
+			Reason:    event.Reason,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+	return output, nil
+}
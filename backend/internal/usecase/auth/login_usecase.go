@@ -14,39 +14,43 @@ import (
 )
 
 type LoginUsecase struct {
-	userRepo          repository.UserRepository
-	cryptoProvider    providers.CryptoProvider
-	tokenProvider     providers.TokenProvider
-	blacklistProvider providers.BlacklistProvider
+	userRepo       repository.UserRepository
+	cryptoProvider providers.CryptoProvider
+	tokenProvider  providers.TokenProvider
+	tokenStore     providers.TokenStore
+	tokenTTL       time.Duration
 }
 
 func NewLoginUsecase(
 	userRepo repository.UserRepository,
 	cryptoProvider providers.CryptoProvider,
 	tokenProvider providers.TokenProvider,
-	blacklistProvider providers.BlacklistProvider,
+	tokenStore providers.TokenStore,
+	tokenTTL time.Duration,
 ) *LoginUsecase {
 	return &LoginUsecase{
-		userRepo:          userRepo,
-		cryptoProvider:    cryptoProvider,
-		tokenProvider:     tokenProvider,
-		blacklistProvider: blacklistProvider,
+		userRepo:       userRepo,
+		cryptoProvider: cryptoProvider,
+		tokenProvider:  tokenProvider,
+		tokenStore:     tokenStore,
+		tokenTTL:       tokenTTL,
 	}
 }
+
 func (h *LoginUsecase) Execute(ctx context.Context, email string, password string) (dto.LoginResult, error) {
 	validationErrs := msgerror.NewValidationErrors()
 
 	// Validação básica de campos
 	if email == "" {
-		validationErrs.Add("email", "cannot be empty")
+		validationErrs.AddError("email", msgerror.AnErrRequired)
 	} else if _, err := vo.NewEmail(email); err != nil {
-		validationErrs.Add("email", err.Error())
+		validationErrs.AddError("email", err)
 	}
 
 	if password == "" {
-		validationErrs.Add("password", "cannot be empty")
+		validationErrs.AddError("password", msgerror.AnErrRequired)
 	} else if len(password) < 8 {
-		validationErrs.Add("password", "must be at least 8 characters")
+		validationErrs.AddError("password", msgerror.AnErrPasswordTooShort)
 	}
 
 	// Se houver erros de validação básicos, retorne imediatamente
@@ -65,8 +69,11 @@ func (h *LoginUsecase) Execute(ctx context.Context, email string, password strin
 	if err != nil {
 		return dto.LoginResult{}, msgerror.Wrap("failed to get user", err)
 	}
+	if user == nil {
+		return dto.LoginResult{}, msgerror.AnErrInvalidCredentials
+	}
 
-	match, err := h.cryptoProvider.Compare(password, user.PasswordHash.String())
+	match, err := h.cryptoProvider.Compare(ctx, password, user.PasswordHash.String())
 	if err != nil {
 		return dto.LoginResult{}, msgerror.Wrap("failed to verify password", err)
 	}
@@ -74,60 +81,36 @@ func (h *LoginUsecase) Execute(ctx context.Context, email string, password strin
 		return dto.LoginResult{}, msgerror.AnErrInvalidCredentials
 	}
 
-	// Gerar token JWT
+	// Gerar token JWT com jti único para controle de revogação
+	now := time.Now()
 	claims := providers.Claims{
 		UserID: user.ID.String(),
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        vo.NewID().String(),
 			Subject:   user.Email.String(),
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(h.tokenTTL)),
 		},
 	}
 
-	token, err := h.tokenProvider.Generate(claims)
+	token, err := h.tokenProvider.Generate(ctx, claims)
 	if err != nil {
 		return dto.LoginResult{}, msgerror.Wrap("failed to generate token", err)
 	}
 
-	// Salvar dados no Redis com prefixo
-	prefix := "startup-auth-go"
-	ttl := 24 * time.Hour
-
-	// UserID
-	keyUserID := prefix + ":" + token + ":UserID"
-	if err := h.blacklistProvider.SetWithKey(ctx, keyUserID, user.ID.String(), ttl); err != nil {
-		return dto.LoginResult{}, msgerror.Wrap("failed to save UserID", err)
-	}
-
-	// Name
-	keyName := prefix + ":" + token + ":Name"
-	if err := h.blacklistProvider.SetWithKey(ctx, keyName, user.Name.String(), ttl); err != nil {
-		return dto.LoginResult{}, msgerror.Wrap("failed to save Name", err)
-	}
-
-	// Email
-	keyEmail := prefix + ":" + token + ":Email"
-	if err := h.blacklistProvider.SetWithKey(ctx, keyEmail, user.Email.String(), ttl); err != nil {
-		return dto.LoginResult{}, msgerror.Wrap("failed to save Email", err)
-	}
-
-	// Token
-	keyToken := prefix + ":" + token + ":Token"
-	if err := h.blacklistProvider.SetWithKey(ctx, keyToken, token, ttl); err != nil {
-		return dto.LoginResult{}, msgerror.Wrap("failed to save Token", err)
-	}
-
-	// CreatedAt (convertido para string)
-	keyCreatedAt := prefix + ":" + token + ":CreatedAt"
-	createdAtStr := user.CreatedAt.Format(time.RFC3339)
-	if err := h.blacklistProvider.SetWithKey(ctx, keyCreatedAt, createdAtStr, ttl); err != nil {
-		return dto.LoginResult{}, msgerror.Wrap("failed to save CreatedAt", err)
+	if err := h.tokenStore.Register(ctx, claims); err != nil {
+		return dto.LoginResult{}, msgerror.Wrap("failed to register token", err)
 	}
 
 	return dto.LoginResult{
-		UserID:    user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		Token:     token,
+		UserID:     user.ID,
+		Name:       user.Name,
+		Email:      user.Email,
+		ImageURL:   user.ImageURL,
+		Locale:     user.Locale,
+		Timezone:   user.Timezone,
+		Attributes: user.Attributes,
+		CreatedAt:  user.CreatedAt,
+		Token:      token,
 	}, nil
 }
@@ -8,12 +8,17 @@ import (
 )
 
 type LogoutUsecase struct {
-	blacklistProvider providers.BlacklistProvider
+	tokenProvider providers.TokenProvider
+	tokenStore    providers.TokenStore
 }
 
-func NewLogoutUsecase(blacklistProvider providers.BlacklistProvider) *LogoutUsecase {
+func NewLogoutUsecase(
+	tokenProvider providers.TokenProvider,
+	tokenStore providers.TokenStore,
+) *LogoutUsecase {
 	return &LogoutUsecase{
-		blacklistProvider: blacklistProvider,
+		tokenProvider: tokenProvider,
+		tokenStore:    tokenStore,
 	}
 }
 
@@ -23,18 +28,19 @@ func (uc *LogoutUsecase) Execute(ctx context.Context, token string) error {
 		return msgerror.AnErrTokenIsRequired
 	}
 
-	prefix := "startup-auth-go"
-	keys := []string{
-		prefix + ":" + token + ":UserID",
-		prefix + ":" + token + ":Name",
-		prefix + ":" + token + ":Email",
-		prefix + ":" + token + ":Token",
-		prefix + ":" + token + ":CreatedAt",
+	// Tokens inválidos ou expirados já não são aceitos: nada a revogar
+	rawClaims, err := uc.tokenProvider.Validate(ctx, token)
+	if err != nil {
+		return nil
 	}
 
-	err := uc.blacklistProvider.Del(ctx, keys...)
-	if err != nil {
-		return msgerror.Wrap("failed to remove user session data", err)
+	claims, ok := rawClaims.(providers.Claims)
+	if !ok {
+		return nil
+	}
+
+	if err := uc.tokenStore.Revoke(ctx, claims); err != nil {
+		return msgerror.Wrap("failed to revoke token", err)
 	}
 
 	return nil
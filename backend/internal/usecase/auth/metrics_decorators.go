@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/metrics"
+	port "github.com/eskokado/startup-auth-go/backend/internal/port/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+)
+
+// Os decorators abaixo contam os fluxos de autenticação para o Prometheus;
+// falhas são rotuladas pelo código estável do erro (msgerror.Code).
+
+type InstrumentedRegister struct {
+	inner   port.RegisterInterface
+	metrics *metrics.Metrics
+}
+
+func NewInstrumentedRegister(inner port.RegisterInterface, m *metrics.Metrics) *InstrumentedRegister {
+	return &InstrumentedRegister{inner: inner, metrics: m}
+}
+
+func (d *InstrumentedRegister) Execute(ctx context.Context, input dto.RegisterParams) error {
+	err := d.inner.Execute(ctx, input)
+	d.metrics.Registrations.Inc(metrics.Result(err), metrics.Reason(err))
+	return err
+}
+
+type InstrumentedLogin struct {
+	inner   port.LoginInterface
+	metrics *metrics.Metrics
+}
+
+func NewInstrumentedLogin(inner port.LoginInterface, m *metrics.Metrics) *InstrumentedLogin {
+	return &InstrumentedLogin{inner: inner, metrics: m}
+}
+
+func (d *InstrumentedLogin) Execute(ctx context.Context, email string, password string) (dto.LoginResult, error) {
+	result, err := d.inner.Execute(ctx, email, password)
+	d.metrics.Logins.Inc(metrics.Result(err), metrics.Reason(err))
+	return result, err
+}
+
+type InstrumentedRequestPasswordReset struct {
+	inner   port.RequestPasswordResetInterface
+	metrics *metrics.Metrics
+}
+
+func NewInstrumentedRequestPasswordReset(
+	inner port.RequestPasswordResetInterface,
+	m *metrics.Metrics,
+) *InstrumentedRequestPasswordReset {
+	return &InstrumentedRequestPasswordReset{inner: inner, metrics: m}
+}
+
+func (d *InstrumentedRequestPasswordReset) Execute(ctx context.Context, email vo.Email) error {
+	err := d.inner.Execute(ctx, email)
+	d.metrics.PasswordResets.Inc("requested", metrics.Result(err), metrics.Reason(err))
+	return err
+}
+
+type InstrumentedResetPassword struct {
+	inner   port.ResetPasswordInterface
+	metrics *metrics.Metrics
+}
+
+func NewInstrumentedResetPassword(inner port.ResetPasswordInterface, m *metrics.Metrics) *InstrumentedResetPassword {
+	return &InstrumentedResetPassword{inner: inner, metrics: m}
+}
+
+func (d *InstrumentedResetPassword) Execute(ctx context.Context, token, newPassword string) error {
+	err := d.inner.Execute(ctx, token, newPassword)
+	d.metrics.PasswordResets.Inc("completed", metrics.Result(err), metrics.Reason(err))
+	return err
+}
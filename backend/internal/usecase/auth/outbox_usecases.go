@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+type ListOutboxMessagesUsecase struct {
+	outboxRepo repository.OutboxRepository
+}
+
+func NewListOutboxMessagesUsecase(outboxRepo repository.OutboxRepository) *ListOutboxMessagesUsecase {
+	return &ListOutboxMessagesUsecase{outboxRepo: outboxRepo}
+}
+
+func (uc *ListOutboxMessagesUsecase) Execute(
+	ctx context.Context,
+	params dto.OutboxQueryParams,
+) ([]dto.OutboxMessageOutput, error) {
+	messages, err := uc.outboxRepo.List(ctx, repository.OutboxFilter{
+		Status: params.Status,
+		Topic:  params.Topic,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	})
+	if err != nil {
+		return nil, msgerror.Wrap("failed to list outbox messages", err)
+	}
+
+	output := make([]dto.OutboxMessageOutput, 0, len(messages))
+	for _, message := range messages {
+		output = append(output, toOutboxMessageOutput(message))
+	}
+	return output, nil
+}
+
+type ReplayOutboxMessageUsecase struct {
+	outboxRepo repository.OutboxRepository
+}
+
+func NewReplayOutboxMessageUsecase(outboxRepo repository.OutboxRepository) *ReplayOutboxMessageUsecase {
+	return &ReplayOutboxMessageUsecase{outboxRepo: outboxRepo}
+}
+
+func (uc *ReplayOutboxMessageUsecase) Execute(ctx context.Context, id vo.ID) (dto.OutboxMessageOutput, error) {
+	message, err := uc.outboxRepo.GetByID(ctx, id)
+	if err != nil {
+		return dto.OutboxMessageOutput{}, msgerror.Wrap("failed to get outbox message", err)
+	}
+	if message == nil {
+		return dto.OutboxMessageOutput{}, msgerror.AnErrOutboxNotFound
+	}
+	if !message.IsDead() {
+		return dto.OutboxMessageOutput{}, msgerror.AnErrOutboxNotDead
+	}
+
+	message.Replay(time.Now())
+	if err := uc.outboxRepo.Update(ctx, message); err != nil {
+		return dto.OutboxMessageOutput{}, msgerror.Wrap("failed to replay outbox message", err)
+	}
+	return toOutboxMessageOutput(message), nil
+}
+
+func toOutboxMessageOutput(message *entity.OutboxMessage) dto.OutboxMessageOutput {
+	output := dto.OutboxMessageOutput{
+		ID:            message.ID.String(),
+		Topic:         message.Topic,
+		Status:        message.Status,
+		Attempts:      message.Attempts,
+		NextAttemptAt: message.NextAttemptAt,
+		LastError:     message.LastError,
+		CreatedAt:     message.CreatedAt,
+	}
+	if !message.SentAt.IsZero() {
+		sentAt := message.SentAt
+		output.SentAt = &sentAt
+	}
+	return output
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+type GetProfileUsecase struct {
+	userRepo repository.UserRepository
+}
+
+func NewGetProfileUsecase(userRepo repository.UserRepository) *GetProfileUsecase {
+	return &GetProfileUsecase{userRepo: userRepo}
+}
+
+func (uc *GetProfileUsecase) Execute(ctx context.Context, userID vo.ID) (dto.UserOutput, error) {
+	user, err := getProfileUser(ctx, uc.userRepo, userID)
+	if err != nil {
+		return dto.UserOutput{}, err
+	}
+	return ToUserOutput(user), nil
+}
+
+// UpdateProfileUsecase aplica só os campos enviados e grava apenas as colunas
+// que de fato mudaram.
+type UpdateProfileUsecase struct {
+	userRepo repository.UserRepository
+}
+
+func NewUpdateProfileUsecase(userRepo repository.UserRepository) *UpdateProfileUsecase {
+	return &UpdateProfileUsecase{userRepo: userRepo}
+}
+
+func (uc *UpdateProfileUsecase) Execute(
+	ctx context.Context,
+	userID vo.ID,
+	input dto.UpdateProfileInput,
+) (dto.UserOutput, error) {
+	user, err := getProfileUser(ctx, uc.userRepo, userID)
+	if err != nil {
+		return dto.UserOutput{}, err
+	}
+
+	updated := *user
+	var fields []string
+	validationErrs := msgerror.NewValidationErrors()
+
+	if input.Name.Set {
+		name, err := vo.NewName(input.Name.Value, 3, 50)
+		if err != nil {
+			validationErrs.AddError("name", err)
+		} else if !name.Equal(user.Name) {
+			updated.Name = name
+			fields = append(fields, repository.UserFieldName)
+		}
+	}
+
+	if input.ImageURL.Set {
+		imageURL, err := vo.NewURL(input.ImageURL.Value)
+		if err != nil {
+			validationErrs.AddError("image_url", err)
+		} else if !imageURL.Equal(user.ImageURL) {
+			updated.ImageURL = imageURL
+			fields = append(fields, repository.UserFieldImageURL)
+		}
+	}
+
+	if input.Locale.Set {
+		locale, err := vo.NewLocale(input.Locale.Value)
+		if err != nil {
+			validationErrs.AddError("locale", err)
+		} else if !locale.Equal(user.Locale) {
+			updated.Locale = locale
+			fields = append(fields, repository.UserFieldLocale)
+		}
+	}
+
+	if input.Timezone.Set {
+		timezone, err := vo.NewTimezone(input.Timezone.Value)
+		if err != nil {
+			validationErrs.AddError("timezone", err)
+		} else if !timezone.Equal(user.Timezone) {
+			updated.Timezone = timezone
+			fields = append(fields, repository.UserFieldTimezone)
+		}
+	}
+
+	if input.Attributes != nil {
+		merged := user.Attributes.Map()
+		for key, value := range input.Attributes {
+			if value == nil {
+				delete(merged, key)
+				continue
+			}
+			merged[key] = *value
+		}
+		attributes, err := vo.NewProfileAttributes(merged)
+		if err != nil {
+			validationErrs.AddError("attributes", err)
+		} else if !attributes.Equal(user.Attributes) {
+			updated.Attributes = attributes
+			fields = append(fields, repository.UserFieldAttributes)
+		}
+	}
+
+	if validationErrs.HasErrors() {
+		return dto.UserOutput{}, validationErrs
+	}
+
+	// Nada mudou: evita uma escrita que só incrementaria a versão
+	if len(fields) == 0 {
+		return ToUserOutput(user), nil
+	}
+
+	saved, err := uc.userRepo.Update(ctx, &updated, fields...)
+	if errors.Is(err, msgerror.AnErrConflict) || errors.Is(err, msgerror.AnErrUserNotFound) {
+		return dto.UserOutput{}, err
+	}
+	if err != nil {
+		return dto.UserOutput{}, msgerror.Wrap("failed to save user", err)
+	}
+	return ToUserOutput(saved), nil
+}
+
+func getProfileUser(ctx context.Context, userRepo repository.UserRepository, userID vo.ID) (*entity.User, error) {
+	user, err := userRepo.GetByID(ctx, userID)
+	if errors.Is(err, msgerror.AnErrNotFound) {
+		return nil, msgerror.AnErrUserNotFound
+	}
+	if err != nil {
+		return nil, msgerror.Wrap("failed to get user", err)
+	}
+	if user == nil {
+		return nil, msgerror.AnErrUserNotFound
+	}
+	return user, nil
+}
+
+// ToUserOutput monta a representação pública do usuário.
+func ToUserOutput(user *entity.User) dto.UserOutput {
+	return dto.UserOutput{
+		Id:           user.ID.String(),
+		Name:         user.Name.String(),
+		Email:        user.Email.String(),
+		ImageURL:     user.ImageURL.String(),
+		Locale:       user.Locale.String(),
+		Timezone:     user.Timezone.String(),
+		Attributes:   user.Attributes.Map(),
+		PendingEmail: user.PendingEmail.String(),
+		CreatedAt:    user.CreatedAt,
+	}
+}
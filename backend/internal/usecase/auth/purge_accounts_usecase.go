@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+const purgeBatchSize = 100
+
+type PurgeOptions struct {
+	// Contas desativadas há mais que Grace são apagadas
+	Grace time.Duration
+	// AvatarURL é a base das URLs de GET /avatars/:id (AvatarOptions.PublicURL)
+	AvatarURL string
+}
+
+// PurgeAccountsUsecase apaga definitivamente as contas cuja carência venceu:
+// usuário, chaves de API, mensagens da outbox e os arquivos de avatar e de
+// exportação. Os eventos de auditoria, no banco e no arquivo, são mantidos sob
+// um pseudônimo aleatório, sem IP nem user agent. Fora daqui só restam chaves
+// no Redis (revogação de sessões, contadores de risco com o e-mail em hash),
+// que expiram sozinhas.
+type PurgeAccountsUsecase struct {
+	userRepo    repository.UserRepository
+	apiKeyRepo  repository.APIKeyRepository
+	auditRepo   repository.AuditRepository
+	outboxRepo  repository.OutboxRepository
+	txManager   repository.TxManager
+	blobStore   providers.BlobStore
+	exportStore providers.ExportStore
+	// auditEraser é nil quando a auditoria só vai para o banco
+	auditEraser providers.AuditEraser
+	auditLogger providers.AuditLogger
+	opts        PurgeOptions
+}
+
+func NewPurgeAccountsUsecase(
+	userRepo repository.UserRepository,
+	apiKeyRepo repository.APIKeyRepository,
+	auditRepo repository.AuditRepository,
+	outboxRepo repository.OutboxRepository,
+	txManager repository.TxManager,
+	blobStore providers.BlobStore,
+	exportStore providers.ExportStore,
+	auditEraser providers.AuditEraser,
+	auditLogger providers.AuditLogger,
+	opts PurgeOptions,
+) *PurgeAccountsUsecase {
+	return &PurgeAccountsUsecase{
+		userRepo:    userRepo,
+		apiKeyRepo:  apiKeyRepo,
+		auditRepo:   auditRepo,
+		outboxRepo:  outboxRepo,
+		txManager:   txManager,
+		blobStore:   blobStore,
+		exportStore: exportStore,
+		auditEraser: auditEraser,
+		auditLogger: auditLogger,
+		opts:        opts,
+	}
+}
+
+// Execute processa um lote por chamada; uma conta com falha não impede as
+// demais e volta a ser tentada na próxima execução.
+func (uc *PurgeAccountsUsecase) Execute(ctx context.Context) (int, error) {
+	users, err := uc.userRepo.ListDeactivatedBefore(ctx, time.Now().Add(-uc.opts.Grace), purgeBatchSize)
+	if err != nil {
+		return 0, msgerror.Wrap("failed to list deactivated users", err)
+	}
+
+	var errs []error
+	erased := 0
+	for _, user := range users {
+		// O id também identifica a pessoa (logs, backups); a auditoria fica com outro
+		pseudonym := vo.NewID().String()
+		err := uc.erase(ctx, user, pseudonym)
+		if err != nil {
+			recordAudit(ctx, uc.auditLogger, entity.AuditActionAccountErase, "", user.ID.String(), err)
+			errs = append(errs, msgerror.Wrap("failed to erase user "+user.ID.String(), err))
+			continue
+		}
+		recordAudit(ctx, uc.auditLogger, entity.AuditActionAccountErase, "", pseudonym, nil)
+		erased++
+	}
+
+	return erased, errors.Join(errs...)
+}
+
+// erase apaga tudo ou nada no banco; numa falha a conta volta para o próximo
+// lote. Os arquivos vão antes: depois do banco não haveria mais como achá-los,
+// e apagá-los de novo numa nova tentativa não falha.
+func (uc *PurgeAccountsUsecase) erase(ctx context.Context, user *entity.User, pseudonym string) error {
+	if avatarID, ok := avatarIDFromURL(uc.opts.AvatarURL, user.ImageURL); ok {
+		if err := deleteAvatarBlobs(ctx, uc.blobStore, avatarID); err != nil {
+			return msgerror.Wrap("failed to delete avatar", err)
+		}
+	}
+	if err := uc.exportStore.DeleteByOwner(ctx, user.ID.String()); err != nil {
+		return msgerror.Wrap("failed to delete data exports", err)
+	}
+
+	subjects := []string{user.ID.String(), user.Email.String(), user.PendingEmail.String()}
+	if uc.auditEraser != nil {
+		if err := uc.auditEraser.Anonymize(ctx, subjects, pseudonym); err != nil {
+			return msgerror.Wrap("failed to anonymize audit log file", err)
+		}
+	}
+
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.auditRepo.Anonymize(ctx, subjects, pseudonym); err != nil {
+			return msgerror.Wrap("failed to anonymize audit events", err)
+		}
+		if err := uc.outboxRepo.DeleteByUserID(ctx, user.ID); err != nil {
+			return msgerror.Wrap("failed to delete outbox messages", err)
+		}
+		if err := uc.apiKeyRepo.DeleteByUserID(ctx, user.ID); err != nil {
+			return msgerror.Wrap("failed to delete api keys", err)
+		}
+		if err := uc.userRepo.Erase(ctx, user.ID); err != nil {
+			return msgerror.Wrap("failed to erase user", err)
+		}
+		return nil
+	})
+}
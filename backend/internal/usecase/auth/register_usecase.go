@@ -33,28 +33,28 @@ func (h *RegisterUsecase) Execute(ctx context.Context, input dto.RegisterParams)
 
 	// Validação básica de campos
 	if input.Password != input.PasswordConfirmation {
-		validationErrs.Add("password_confirmation", "passwords do not match")
+		validationErrs.AddError("password_confirmation", msgerror.AnErrPasswordMismatch)
 	}
 	if len(input.Password) < 8 {
-		validationErrs.Add("password", "must be at least 8 characters")
+		validationErrs.AddError("password", msgerror.AnErrPasswordTooShort)
 	}
 
 	// Validação de objetos de valor
 	name, nameErr := vo.NewName(input.Name, 3, 100)
 	if nameErr != nil {
-		validationErrs.Add("name", nameErr.Error())
+		validationErrs.AddError("name", nameErr)
 	}
 
 	email, emailErr := vo.NewEmail(input.Email)
 	if emailErr != nil {
-		validationErrs.Add("email", emailErr.Error())
+		validationErrs.AddError("email", emailErr)
 	}
 
 	var imageURL vo.URL
 	if input.ImageURL != "" {
 		url, urlErr := vo.NewURL(input.ImageURL)
 		if urlErr != nil {
-			validationErrs.Add("image_url", urlErr.Error())
+			validationErrs.AddError("image_url", urlErr)
 		} else {
 			imageURL = url
 		}
@@ -73,13 +73,23 @@ func (h *RegisterUsecase) Execute(ctx context.Context, input dto.RegisterParams)
 			return msgerror.Wrap("failed to check email existence", err)
 		}
 		if existingUser != nil {
-			validationErrs.Add("email", msgerror.AnErrUserExists.Error())
+			validationErrs.AddError("email", msgerror.AnErrUserExists)
+			return validationErrs
+		}
+
+		// O e-mail de uma conta desativada continua reservado até o apagamento
+		deactivatedUser, err := h.userRepo.GetDeactivatedByEmail(ctx, email)
+		if err != nil {
+			return msgerror.Wrap("failed to check email existence", err)
+		}
+		if deactivatedUser != nil {
+			validationErrs.AddError("email", msgerror.AnErrUserExists)
 			return validationErrs
 		}
 	}
 
 	// Criptografia de senha
-	hashedPassword, err := h.cryptoProvider.Encrypt(input.Password)
+	hashedPassword, err := h.cryptoProvider.Encrypt(ctx, input.Password)
 	if err != nil {
 		return msgerror.Wrap("failed to secure password", err)
 	}
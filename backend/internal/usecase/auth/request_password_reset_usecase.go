@@ -3,23 +3,30 @@ package usecase
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
-	service "github.com/eskokado/startup-auth-go/backend/pkg/domain/services"
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
 	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
 )
 
+// RequestPasswordResetUsecase grava o token e o e-mail a enviar na mesma
+// transação; o envio em si fica com o dispatcher da outbox.
 type RequestPasswordResetUsecase struct {
-	userRepo    repository.UserRepository
-	emailSender service.EmailServiceInterface
+	userRepo   repository.UserRepository
+	outboxRepo repository.OutboxRepository
+	txManager  repository.TxManager
+	resetTTL   time.Duration
 }
 
 func NewRequestPasswordReset(
 	repo repository.UserRepository,
-	emailSender service.EmailServiceInterface,
+	outboxRepo repository.OutboxRepository,
+	txManager repository.TxManager,
+	resetTTL time.Duration,
 ) *RequestPasswordResetUsecase {
-	return &RequestPasswordResetUsecase{userRepo: repo, emailSender: emailSender}
+	return &RequestPasswordResetUsecase{userRepo: repo, outboxRepo: outboxRepo, txManager: txManager, resetTTL: resetTTL}
 }
 
 func (uc *RequestPasswordResetUsecase) Execute(
@@ -35,18 +42,36 @@ func (uc *RequestPasswordResetUsecase) Execute(
 		}
 		return msgerror.Wrap("failed to get user", err)
 	}
-
-	if err := user.GeneratePasswordResetToken(); err != nil {
-		return msgerror.Wrap("failed to generate reset token", err)
+	if user == nil {
+		// Inexistente ou desativado: mesma resposta silenciosa
+		return nil
 	}
 
-	if _, err := uc.userRepo.Save(ctx, user); err != nil {
-		return msgerror.Wrap("failed to save user", err)
+	if err := user.GeneratePasswordResetToken(uc.resetTTL); err != nil {
+		return msgerror.Wrap("failed to generate reset token", err)
 	}
 
-	err = uc.emailSender.SendResetPasswordEmail(user.Email, user.PasswordResetToken)
+	message, err := entity.NewOutboxMessage(entity.OutboxTopicPasswordResetEmail, entity.PasswordResetEmailPayload{
+		Email:  user.Email.String(),
+		Token:  user.PasswordResetToken,
+		Locale: user.Locale.String(),
+	})
 	if err != nil {
-		return msgerror.Wrap("failed to send reset email", err)
+		return msgerror.Wrap("failed to build reset email", err)
 	}
-	return nil
+	message.UserID = user.ID
+
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := uc.userRepo.Update(ctx, user, repository.UserFieldPasswordReset); err != nil {
+			if errors.Is(err, msgerror.AnErrConflict) {
+				return err
+			}
+			return msgerror.Wrap("failed to save user", err)
+		}
+
+		if err := uc.outboxRepo.Enqueue(ctx, message); err != nil {
+			return msgerror.Wrap("failed to enqueue reset email", err)
+		}
+		return nil
+	})
 }
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
@@ -23,7 +24,7 @@ func (uc *ResetPasswordUsecase) Execute(
 ) error {
 	user, err := uc.userRepo.GetByResetToken(ctx, token)
 	if err != nil {
-		return msgerror.Wrap("falha ao buscar usuário pelo token", err)
+		return msgerror.Wrap("failed to get user by token", err)
 	}
 
 	if user == nil {
@@ -36,14 +37,18 @@ func (uc *ResetPasswordUsecase) Execute(
 
 	newHash, err := vo.NewPasswordHash(newPassword)
 	if err != nil {
-		return msgerror.Wrap("falha ao gerar hash da senha", err)
+		return msgerror.Wrap("failed to hash password", err)
 	}
 
 	user.PasswordHash = newHash
 	user.ClearResetToken()
 
-	if _, err := uc.userRepo.Save(ctx, user); err != nil {
-		return msgerror.Wrap("falha ao salvar usuário", err)
+	_, err = uc.userRepo.Update(ctx, user, repository.UserFieldPasswordHash, repository.UserFieldPasswordReset)
+	if errors.Is(err, msgerror.AnErrConflict) {
+		return err
+	}
+	if err != nil {
+		return msgerror.Wrap("failed to save user", err)
 	}
 
 	return nil
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+type RestoreAccountUsecase struct {
+	userRepo       repository.UserRepository
+	cryptoProvider providers.CryptoProvider
+	grace          time.Duration
+}
+
+func NewRestoreAccountUsecase(
+	userRepo repository.UserRepository,
+	cryptoProvider providers.CryptoProvider,
+	grace time.Duration,
+) *RestoreAccountUsecase {
+	return &RestoreAccountUsecase{
+		userRepo:       userRepo,
+		cryptoProvider: cryptoProvider,
+		grace:          grace,
+	}
+}
+
+// Execute responde sempre com AnErrInvalidCredentials quando não há conta
+// restaurável, para não revelar quais e-mails estão desativados.
+func (uc *RestoreAccountUsecase) Execute(ctx context.Context, email string, password string) (vo.ID, error) {
+	emailVO, err := vo.NewEmail(email)
+	if err != nil {
+		return vo.ID{}, msgerror.AnErrInvalidCredentials
+	}
+
+	user, err := uc.userRepo.GetDeactivatedByEmail(ctx, emailVO)
+	if err != nil {
+		return vo.ID{}, msgerror.Wrap("failed to get user", err)
+	}
+	if user == nil {
+		return vo.ID{}, msgerror.AnErrInvalidCredentials
+	}
+
+	match, err := uc.cryptoProvider.Compare(ctx, password, user.PasswordHash.String())
+	if err != nil {
+		return vo.ID{}, msgerror.Wrap("failed to compare passwords", err)
+	}
+	if !match {
+		return vo.ID{}, msgerror.AnErrInvalidCredentials
+	}
+
+	// Carência vencida: a conta aguarda apenas o purger
+	if !time.Now().Before(user.PurgeAfter(uc.grace)) {
+		return vo.ID{}, msgerror.AnErrInvalidCredentials
+	}
+
+	if err := uc.userRepo.Restore(ctx, user.ID); err != nil {
+		return vo.ID{}, msgerror.Wrap("failed to restore user", err)
+	}
+
+	return user.ID, nil
+}
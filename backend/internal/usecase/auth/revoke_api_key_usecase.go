@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+type RevokeAPIKeyUsecase struct {
+	apiKeyRepo repository.APIKeyRepository
+}
+
+func NewRevokeAPIKeyUsecase(apiKeyRepo repository.APIKeyRepository) *RevokeAPIKeyUsecase {
+	return &RevokeAPIKeyUsecase{apiKeyRepo: apiKeyRepo}
+}
+
+func (uc *RevokeAPIKeyUsecase) Execute(ctx context.Context, userID vo.ID, keyID vo.ID) error {
+	key, err := uc.apiKeyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return msgerror.Wrap("failed to get api key", err)
+	}
+
+	// Chaves de outros usuários são tratadas como inexistentes
+	if key == nil || !key.UserID.Equal(userID) {
+		return msgerror.AnErrAPIKeyNotFound
+	}
+
+	if !key.RevokedAt.IsZero() {
+		return nil
+	}
+
+	key.Revoke()
+
+	if _, err := uc.apiKeyRepo.Save(ctx, key); err != nil {
+		return msgerror.Wrap("failed to save api key", err)
+	}
+
+	return nil
+}
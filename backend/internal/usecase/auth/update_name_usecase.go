@@ -44,7 +44,10 @@ func (uc *UpdateNameUseCase) Execute(ctx context.Context, userID vo.ID, newName
 		return msgerror.Wrap("failed to update name", err)
 	}
 
-	_, err = uc.userRepo.Save(ctx, updatedUser)
+	_, err = uc.userRepo.Update(ctx, updatedUser, repository.UserFieldName)
+	if errors.Is(err, msgerror.AnErrConflict) {
+		return err
+	}
 	if err != nil {
 		return msgerror.Wrap("failed to save user", err)
 	}
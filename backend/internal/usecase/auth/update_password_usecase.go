@@ -47,7 +47,7 @@ func (uc *UpdatePasswordUseCase) Execute(
 	}
 
 	// Verify current password
-	match, err := uc.cryptoProvider.Compare(currentPassword, user.PasswordHash.String())
+	match, err := uc.cryptoProvider.Compare(ctx, currentPassword, user.PasswordHash.String())
 	if err != nil {
 		return msgerror.Wrap("failed to compare passwords", err)
 	}
@@ -56,7 +56,7 @@ func (uc *UpdatePasswordUseCase) Execute(
 	}
 
 	// Check if new password is different
-	same, err := uc.cryptoProvider.Compare(newPassword, user.PasswordHash.String())
+	same, err := uc.cryptoProvider.Compare(ctx, newPassword, user.PasswordHash.String())
 	if err != nil {
 		return msgerror.Wrap("failed to verify password difference", err)
 	}
@@ -65,7 +65,7 @@ func (uc *UpdatePasswordUseCase) Execute(
 	}
 
 	// Encrypt new password
-	newHash, err := uc.cryptoProvider.Encrypt(newPassword)
+	newHash, err := uc.cryptoProvider.Encrypt(ctx, newPassword)
 	if err != nil {
 		return msgerror.Wrap("failed to encrypt password", err)
 	}
@@ -80,7 +80,10 @@ func (uc *UpdatePasswordUseCase) Execute(
 		return msgerror.Wrap("failed to update password", err)
 	}
 
-	_, err = uc.userRepo.Save(ctx, updatedUser)
+	_, err = uc.userRepo.Update(ctx, updatedUser, repository.UserFieldPasswordHash)
+	if errors.Is(err, msgerror.AnErrConflict) {
+		return err
+	}
 	if err != nil {
 		return msgerror.Wrap("failed to save user", err)
 	}
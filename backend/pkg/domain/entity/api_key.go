@@ -0,0 +1,138 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+const (
+	APIKeyPrefix = "sag"
+
+	ScopeUserRead  = "user:read"
+	ScopeUserWrite = "user:write"
+)
+
+var AvailableScopes = []string{ScopeUserRead, ScopeUserWrite}
+
+type APIKey struct {
+	ID         vo.ID
+	UserID     vo.ID
+	Name       vo.Name
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	RevokedAt  time.Time
+	CreatedAt  time.Time
+}
+
+// NewAPIKey gera uma chave no formato "sag_<prefixo>_<segredo>".
+// A chave em texto puro é retornada apenas aqui; só o hash é persistido.
+func NewAPIKey(
+	userID vo.ID,
+	name vo.Name,
+	scopes []string,
+	expiresAt time.Time,
+) (*APIKey, string, error) {
+	if name.IsEmpty() {
+		return nil, "", msgerror.AnErrInvalidName
+	}
+
+	if len(scopes) == 0 {
+		return nil, "", msgerror.AnErrInvalidScope
+	}
+	for _, scope := range scopes {
+		if !isKnownScope(scope) {
+			return nil, "", msgerror.AnErrInvalidScope
+		}
+	}
+
+	if !expiresAt.IsZero() && !expiresAt.After(time.Now()) {
+		return nil, "", msgerror.AnErrInvalidExpiration
+	}
+
+	prefix, err := randomBytes(6)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := randomBytes(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	lookup := hex.EncodeToString(prefix)
+	rawKey := APIKeyPrefix + "_" + lookup + "_" + base64.RawURLEncoding.EncodeToString(secret)
+
+	return &APIKey{
+		ID:        vo.NewID(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    lookup,
+		KeyHash:   HashAPIKey(rawKey),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}, rawKey, nil
+}
+
+// ParseAPIKeyPrefix extrai o prefixo de busca de uma chave em texto puro.
+func ParseAPIKeyPrefix(rawKey string) (string, error) {
+	parts := strings.SplitN(rawKey, "_", 3)
+	if len(parts) != 3 || parts[0] != APIKeyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", msgerror.AnErrInvalidAPIKey
+	}
+	return parts[1], nil
+}
+
+func HashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func (k *APIKey) IsActive(now time.Time) bool {
+	if !k.RevokedAt.IsZero() {
+		return false
+	}
+	return k.ExpiresAt.IsZero() || now.Before(k.ExpiresAt)
+}
+
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (k *APIKey) Revoke() {
+	if k.RevokedAt.IsZero() {
+		k.RevokedAt = time.Now()
+	}
+}
+
+func isKnownScope(scope string) bool {
+	for _, s := range AvailableScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
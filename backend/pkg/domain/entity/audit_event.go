@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+)
+
+// Ações registradas no log de auditoria
+const (
+	AuditActionRegister               = "user.register"
+	AuditActionLogin                  = "auth.login"
+	AuditActionLogout                 = "auth.logout"
+	AuditActionPasswordResetRequested = "auth.password_reset.requested"
+	AuditActionPasswordResetCompleted = "auth.password_reset.completed"
+	AuditActionPasswordChange         = "user.password.change"
+	AuditActionNameChange             = "user.name.change"
+	AuditActionAdminAuditQuery        = "admin.audit.query"
+	AuditActionAccountDeactivate      = "user.account.deactivate"
+	AuditActionAccountRestore         = "user.account.restore"
+	AuditActionAccountErase           = "user.account.erase"
+	AuditActionDataExport             = "user.data.export"
+	AuditActionAdminOutboxQuery       = "admin.outbox.query"
+	AuditActionAdminOutboxReplay      = "admin.outbox.replay"
+	AuditActionEmailChangeRequested   = "user.email_change.requested"
+	AuditActionEmailChangeConfirmed   = "user.email_change.confirmed"
+	AuditActionEmailChangeCancelled   = "user.email_change.cancelled"
+	AuditActionAvatarChange           = "user.avatar.change"
+	AuditActionProfileUpdate          = "user.profile.update"
+)
+
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+)
+
+type AuditEvent struct {
+	ID        vo.ID
+	Action    string
+	Outcome   string
+	ActorID   string
+	Target    string
+	IP        string
+	UserAgent string
+	Reason    string
+	CreatedAt time.Time
+}
+
+func NewAuditEvent(action string, err error) *AuditEvent {
+	event := &AuditEvent{
+		ID:        vo.NewID(),
+		Action:    action,
+		Outcome:   AuditOutcomeSuccess,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err != nil {
+		event.Outcome = AuditOutcomeFailure
+		event.Reason = err.Error()
+	}
+	return event
+}
@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+)
+
+// Situações de uma mensagem da outbox
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSent    = "sent"
+	OutboxStatusDead    = "dead"
+)
+
+// Tópicos publicados na outbox
+const (
+	OutboxTopicPasswordResetEmail           = "email.password_reset"
+	OutboxTopicEmailChangeConfirmationEmail = "email.email_change_confirmation"
+	OutboxTopicEmailChangeNoticeEmail       = "email.email_change_notice"
+)
+
+// maxOutboxErrorLength acompanha o tamanho da coluna last_error
+const maxOutboxErrorLength = 1024
+
+// OutboxMessage é gravada na mesma transação da alteração que a originou e
+// entregue depois pelo dispatcher.
+type OutboxMessage struct {
+	ID vo.ID
+	// UserID é o usuário a quem a mensagem se destina; entra na exportação
+	// dos dados dele e é apagada com a conta
+	UserID        vo.ID
+	Topic         string
+	Payload       []byte
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	SentAt        time.Time
+}
+
+// PasswordResetEmailPayload é o conteúdo de OutboxTopicPasswordResetEmail.
+type PasswordResetEmailPayload struct {
+	Email string `json:"email"`
+	Token string `json:"token"`
+	// Locale vazio usa o idioma padrão dos e-mails
+	Locale string `json:"locale,omitempty"`
+}
+
+// EmailChangeConfirmationPayload vai para o novo endereço.
+type EmailChangeConfirmationPayload struct {
+	Email  string `json:"email"`
+	Token  string `json:"token"`
+	Locale string `json:"locale,omitempty"`
+}
+
+// EmailChangeNoticePayload vai para o endereço atual, com o link de cancelamento.
+type EmailChangeNoticePayload struct {
+	Email       string `json:"email"`
+	NewEmail    string `json:"new_email"`
+	CancelToken string `json:"cancel_token"`
+	Locale      string `json:"locale,omitempty"`
+}
+
+func NewOutboxMessage(topic string, payload any) (*OutboxMessage, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	return &OutboxMessage{
+		ID:            vo.NewID(),
+		Topic:         topic,
+		Payload:       data,
+		Status:        OutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+// MarkSent descarta o conteúdo: tokens e endereços não ficam guardados
+// depois da entrega.
+func (m *OutboxMessage) MarkSent(at time.Time) {
+	m.Status = OutboxStatusSent
+	m.Payload = nil
+	m.Attempts++
+	m.SentAt = at
+	m.LastError = ""
+}
+
+// MarkFailed agenda nova tentativa em retryAt ou, esgotadas as tentativas,
+// move a mensagem para a dead letter.
+func (m *OutboxMessage) MarkFailed(err error, retryAt time.Time, maxAttempts int) {
+	m.Attempts++
+	m.LastError = err.Error()
+	if len(m.LastError) > maxOutboxErrorLength {
+		m.LastError = m.LastError[:maxOutboxErrorLength]
+	}
+	if m.Attempts >= maxAttempts {
+		m.Status = OutboxStatusDead
+		return
+	}
+	m.Status = OutboxStatusPending
+	m.NextAttemptAt = retryAt
+}
+
+func (m *OutboxMessage) IsDead() bool {
+	return m.Status == OutboxStatusDead
+}
+
+// Replay devolve uma mensagem morta à fila, com as tentativas zeradas.
+func (m *OutboxMessage) Replay(now time.Time) {
+	m.Status = OutboxStatusPending
+	m.Attempts = 0
+	m.NextAttemptAt = now
+	m.LastError = ""
+}
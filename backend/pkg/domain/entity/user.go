@@ -13,14 +13,30 @@ import (
 var GenerateSecureToken = generateSecureToken
 
 type User struct {
-	ID                   vo.ID
-	Name                 vo.Name
-	Email                vo.Email
-	PasswordHash         vo.PasswordHash
-	ImageURL             vo.URL
+	ID           vo.ID
+	Name         vo.Name
+	Email        vo.Email
+	PasswordHash vo.PasswordHash
+	ImageURL     vo.URL
+	// Locale escolhe o idioma dos e-mails; vazio usa o idioma padrão
+	Locale   vo.Locale
+	Timezone vo.Timezone
+	// Attributes são campos livres do perfil, como cargo ou empresa
+	Attributes           vo.ProfileAttributes
 	CreatedAt            time.Time
 	PasswordResetToken   string
 	PasswordResetExpires time.Time
+	// Troca de e-mail aguardando confirmação pelo novo endereço; o token de
+	// cancelamento vai para o endereço atual
+	PendingEmail           vo.Email
+	EmailChangeToken       string
+	EmailChangeCancelToken string
+	EmailChangeExpires     time.Time
+	// DeletedAt marca a conta como desativada; zero indica conta ativa
+	DeletedAt time.Time
+	// Version é a versão lida do banco, usada no controle de concorrência otimista;
+	// zero indica um usuário ainda não persistido
+	Version int
 }
 
 func NewUser(
@@ -64,24 +80,24 @@ func CreateUser(
 
 	validName, err := vo.NewName(name, 3, 50)
 	if err != nil {
-		validationErrs.Add("name", err.Error())
+		validationErrs.AddError("name", err)
 	}
 
 	validEmail, err := vo.NewEmail(email)
 	if err != nil {
-		validationErrs.Add("email", err.Error())
+		validationErrs.AddError("email", err)
 	}
 
 	passwordHash, err := vo.NewPasswordHash(password)
 	if err != nil {
-		validationErrs.Add("password", err.Error())
+		validationErrs.AddError("password", err)
 	}
 
 	var url *vo.URL
 	if imageURL != "" {
 		u, err := vo.NewURL(imageURL)
 		if err != nil {
-			validationErrs.Add("image_url", err.Error())
+			validationErrs.AddError("image_url", err)
 		}
 		url = &u
 	}
@@ -102,28 +118,25 @@ func (u *User) WithName(newName vo.Name) (*User, error) {
 		return nil, msgerror.AnErrNameDifferent
 	}
 
-	return &User{
-		ID:           u.ID,
-		Name:         newName,
-		Email:        u.Email,
-		PasswordHash: u.PasswordHash,
-		ImageURL:     u.ImageURL,
-		CreatedAt:    u.CreatedAt,
-	}, nil
+	updated := *u
+	updated.Name = newName
+	return &updated, nil
+}
+
+func (u *User) WithImageURL(imageURL vo.URL) *User {
+	updated := *u
+	updated.ImageURL = imageURL
+	return &updated
 }
 
 func (u *User) WithPasswordHash(newHash vo.PasswordHash) (*User, error) {
 	if newHash.IsEmpty() {
 		return nil, msgerror.AnErrWeakPassword
 	}
-	return &User{
-		ID:           u.ID,
-		Name:         u.Name,
-		Email:        u.Email,
-		PasswordHash: newHash,
-		ImageURL:     u.ImageURL,
-		CreatedAt:    u.CreatedAt,
-	}, nil
+
+	updated := *u
+	updated.PasswordHash = newHash
+	return &updated, nil
 }
 
 func (u *User) Equal(other *User) bool {
@@ -133,13 +146,13 @@ func (u *User) Equal(other *User) bool {
 func (u *User) VerifyPassword(password string) bool {
 	return u.PasswordHash.Verify(password)
 }
-func (u *User) GeneratePasswordResetToken() error {
+func (u *User) GeneratePasswordResetToken(ttl time.Duration) error {
 	token, err := GenerateSecureToken()
 	if err != nil {
 		return err
 	}
 	u.PasswordResetToken = token
-	u.PasswordResetExpires = time.Now().Add(1 * time.Hour)
+	u.PasswordResetExpires = time.Now().Add(ttl)
 	return nil
 }
 
@@ -148,6 +161,76 @@ func (u *User) ClearResetToken() {
 	u.PasswordResetExpires = time.Time{}
 }
 
+// RequestEmailChange guarda o novo endereço até a confirmação e gera os
+// tokens de confirmação e de cancelamento.
+func (u *User) RequestEmailChange(newEmail vo.Email, ttl time.Duration) error {
+	if newEmail.IsEmpty() {
+		return msgerror.AnErrEmptyEmail
+	}
+	if newEmail.Equal(u.Email) {
+		return msgerror.AnErrEmailUnchanged
+	}
+
+	confirmToken, err := GenerateSecureToken()
+	if err != nil {
+		return err
+	}
+	cancelToken, err := GenerateSecureToken()
+	if err != nil {
+		return err
+	}
+
+	u.PendingEmail = newEmail
+	u.EmailChangeToken = confirmToken
+	u.EmailChangeCancelToken = cancelToken
+	u.EmailChangeExpires = time.Now().Add(ttl)
+	return nil
+}
+
+// ConfirmEmailChange troca o e-mail pelo pendente, se ainda dentro da validade.
+func (u *User) ConfirmEmailChange(now time.Time) error {
+	if u.PendingEmail.IsEmpty() {
+		return msgerror.AnErrInvalidToken
+	}
+	if u.EmailChangeExpires.Before(now) {
+		return msgerror.AnErrExpiredToken
+	}
+
+	u.Email = u.PendingEmail
+	u.ClearEmailChange()
+	return nil
+}
+
+func (u *User) ClearEmailChange() {
+	u.PendingEmail = vo.Email{}
+	u.EmailChangeToken = ""
+	u.EmailChangeCancelToken = ""
+	u.EmailChangeExpires = time.Time{}
+}
+
+// Deactivate inicia o período de carência antes do apagamento definitivo.
+func (u *User) Deactivate(at time.Time) {
+	u.DeletedAt = at
+	u.ClearResetToken()
+	u.ClearEmailChange()
+}
+
+func (u *User) Restore() {
+	u.DeletedAt = time.Time{}
+}
+
+func (u *User) IsDeactivated() bool {
+	return !u.DeletedAt.IsZero()
+}
+
+// PurgeAfter devolve o instante a partir do qual a conta desativada pode ser apagada.
+func (u *User) PurgeAfter(grace time.Duration) time.Time {
+	if !u.IsDeactivated() {
+		return time.Time{}
+	}
+	return u.DeletedAt.Add(grace)
+}
+
 func generateSecureToken() (string, error) {
 	bytes := make([]byte, 32)
 	if _, err := io.ReadFull(rand.Reader, bytes); err != nil {
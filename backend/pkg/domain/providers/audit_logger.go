@@ -0,0 +1,42 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+)
+
+type AuditLogger interface {
+	Log(ctx context.Context, event *entity.AuditEvent) error
+}
+
+// AuditEraser anonimiza os eventos gravados fora do banco (ex.: arquivo), com a
+// mesma regra de repository.AuditRepository.Anonymize.
+type AuditEraser interface {
+	Anonymize(ctx context.Context, subjects []string, pseudonym string) error
+}
+
+// RequestInfo carrega os dados da requisição HTTP usados na auditoria.
+type RequestInfo struct {
+	IP        string
+	UserAgent string
+	ActorID   string
+}
+
+type requestInfoKey struct{}
+
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+func RequestInfoFromContext(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info
+}
+
+// WithActorID registra o usuário autenticado preservando IP e user agent.
+func WithActorID(ctx context.Context, actorID string) context.Context {
+	info := RequestInfoFromContext(ctx)
+	info.ActorID = actorID
+	return WithRequestInfo(ctx, info)
+}
@@ -7,6 +7,9 @@ import (
 
 type BlacklistProvider interface {
 	Add(ctx context.Context, token string, ttl time.Duration) error
+	// AddIfAbsent registra token numa única operação atômica; devolve false
+	// quando ele já existia
+	AddIfAbsent(ctx context.Context, token string, ttl time.Duration) (bool, error)
 	Exists(ctx context.Context, token string) (bool, error)
 	ExistsKey(ctx context.Context, key string) (bool, error)
 	SetWithKey(ctx context.Context, key string, value interface{}, ttl time.Duration) error
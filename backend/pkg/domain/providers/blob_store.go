@@ -0,0 +1,18 @@
+package providers
+
+import "context"
+
+// BlobStore guarda arquivos binários por chave, como "avatars/<id>/128.png".
+// Chaves aceitas: segmentos de letras, números, ".", "_" e "-" separados por "/".
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get devolve msgerror.AnErrBlobNotFound quando a chave não existe
+	Get(ctx context.Context, key string) (Blob, error)
+	// Delete não falha para chaves inexistentes
+	Delete(ctx context.Context, key string) error
+}
+
+type Blob struct {
+	Data        []byte
+	ContentType string
+}
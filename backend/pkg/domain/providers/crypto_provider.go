@@ -1,6 +1,8 @@
 package providers
 
+import "context"
+
 type CryptoProvider interface {
-	Encrypt(password string) (string, error)
-	Compare(password, hashedPassword string) (bool, error)
+	Encrypt(ctx context.Context, password string) (string, error)
+	Compare(ctx context.Context, password, hashedPassword string) (bool, error)
 }
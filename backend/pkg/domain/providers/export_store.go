@@ -0,0 +1,16 @@
+package providers
+
+import (
+	"context"
+	"time"
+)
+
+// ExportStore guarda os arquivos de exportação de dados até o link expirar.
+// Os nomes começam pelo id do dono: "<id do usuário>.<id do arquivo>.zip".
+type ExportStore interface {
+	Save(ctx context.Context, name string, data []byte) error
+	Load(ctx context.Context, name string) ([]byte, error)
+	DeleteOlderThan(ctx context.Context, before time.Time) error
+	// DeleteByOwner remove todos os arquivos do usuário
+	DeleteByOwner(ctx context.Context, ownerID string) error
+}
@@ -0,0 +1,39 @@
+package providers
+
+import (
+	"context"
+	"time"
+)
+
+// Tipos de verificação informados ao cliente no desafio
+const (
+	HumanVerifierProofOfWork = "pow"
+	HumanVerifierHCaptcha    = "hcaptcha"
+	HumanVerifierTurnstile   = "turnstile"
+)
+
+// HumanChallenge diz ao cliente como provar que é uma pessoa: resolver a
+// prova de trabalho (Challenge e Difficulty) ou exibir o captcha (SiteKey).
+type HumanChallenge struct {
+	Type       string
+	Challenge  string
+	Difficulty int
+	ExpiresAt  time.Time
+	SiteKey    string
+}
+
+// HumanSubject identifica quem pede a rota protegida.
+type HumanSubject struct {
+	// IP do cliente conforme TRUSTED_PROXIES; sem proxies confiáveis, o da conexão
+	IP string
+	// Email alvo da operação (cadastro ou redefinição), quando houver
+	Email string
+}
+
+// HumanVerifier barra robôs em rotas públicas sensíveis.
+type HumanVerifier interface {
+	Challenge(ctx context.Context) (HumanChallenge, error)
+	// Verify devolve msgerror.AnErrHumanVerificationRequired quando a prova
+	// falta e msgerror.AnErrHumanVerificationFailed quando ela não vale.
+	Verify(ctx context.Context, proof string, subject HumanSubject) error
+}
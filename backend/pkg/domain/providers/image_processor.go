@@ -0,0 +1,8 @@
+package providers
+
+// ImageProcessor valida imagens enviadas pelos usuários e gera as miniaturas.
+type ImageProcessor interface {
+	// Thumbnails recorta a imagem no centro e devolve um PNG quadrado por
+	// tamanho, já na orientação correta e sem metadados (EXIF, GPS...)
+	Thumbnails(data []byte, sizes []int) (map[int][]byte, error)
+}
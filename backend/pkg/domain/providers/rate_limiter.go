@@ -0,0 +1,35 @@
+package providers
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// RateLimitTokenBucket: até Limit requisições em rajada, repostas aos
+	// poucos ao longo de Window.
+	RateLimitTokenBucket = "token_bucket"
+	// RateLimitSlidingWindow: até Limit requisições em qualquer janela de
+	// duração Window (contador da janela anterior ponderado).
+	RateLimitSlidingWindow = "sliding_window"
+)
+
+// RateLimit é a cota de uma chave: Limit requisições a cada Window.
+type RateLimit struct {
+	Limit  int
+	Window time.Duration
+}
+
+// RateLimitResult descreve a cota depois da requisição; RetryAfter só é
+// preenchido quando a requisição é recusada.
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int
+	ResetAfter time.Duration // Até a cota estar cheia de novo
+	RetryAfter time.Duration
+}
+
+// RateLimiter consome uma unidade da cota da chave.
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error)
+}
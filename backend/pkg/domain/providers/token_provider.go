@@ -1,10 +1,14 @@
 package providers
 
-import "github.com/golang-jwt/jwt/v5"
+import (
+	"context"
+
+	"github.com/golang-jwt/jwt/v5"
+)
 
 type TokenProvider interface {
-	Generate(claims interface{}) (string, error)
-	Validate(token string) (interface{}, error)
+	Generate(ctx context.Context, claims interface{}) (string, error)
+	Validate(ctx context.Context, token string) (interface{}, error)
 }
 
 type Claims struct {
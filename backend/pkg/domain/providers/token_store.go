@@ -0,0 +1,24 @@
+package providers
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// TokenStoreAllowlist (stateful): só são aceitos tokens registrados no login.
+	TokenStoreAllowlist = "allowlist"
+	// TokenStoreDenylist (stateless): todo token válido é aceito até ter o jti revogado.
+	TokenStoreDenylist = "denylist"
+)
+
+// TokenStore é o único ponto de decisão sobre revogação de tokens.
+// As chaves são derivadas do jti (claims.ID), nunca do JWT em si.
+type TokenStore interface {
+	Register(ctx context.Context, claims Claims) error
+	Revoke(ctx context.Context, claims Claims) error
+	IsActive(ctx context.Context, claims Claims) (bool, error)
+	// RevokeUser invalida todos os tokens do usuário emitidos até agora;
+	// ttl deve cobrir a validade máxima de um token.
+	RevokeUser(ctx context.Context, userID string, ttl time.Duration) error
+}
@@ -0,0 +1,10 @@
+package providers
+
+import "time"
+
+// URLSigner assina links temporários enviados por e-mail, que funcionam sem
+// autenticação até expirar.
+type URLSigner interface {
+	Sign(resource string, expiresAt time.Time) string
+	Verify(resource string, expiresAt time.Time, signature string) bool
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+)
+
+type APIKeyRepository interface {
+	Save(ctx context.Context, key *entity.APIKey) (*entity.APIKey, error)
+	GetByID(ctx context.Context, id vo.ID) (*entity.APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error)
+	ListByUserID(ctx context.Context, userID vo.ID) ([]*entity.APIKey, error)
+	TouchLastUsed(ctx context.Context, id vo.ID, at time.Time) error
+	RevokeAllByUserID(ctx context.Context, userID vo.ID, at time.Time) error
+	DeleteByUserID(ctx context.Context, userID vo.ID) error
+}
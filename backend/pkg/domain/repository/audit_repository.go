@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+)
+
+type AuditFilter struct {
+	Action  string
+	Outcome string
+	ActorID string
+	Target  string
+	IP      string
+	From    time.Time
+	To      time.Time
+	Limit   int
+	Offset  int
+}
+
+type AuditRepository interface {
+	List(ctx context.Context, filter AuditFilter) ([]*entity.AuditEvent, error)
+	Count(ctx context.Context, filter AuditFilter) (int64, error)
+	// Anonymize troca os identificadores de uma pessoa (id, e-mails) por um
+	// pseudônimo e apaga IP e user agent dos eventos dela
+	Anonymize(ctx context.Context, subjects []string, pseudonym string) error
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+)
+
+type OutboxFilter struct {
+	Status string
+	Topic  string
+	Limit  int
+	Offset int
+}
+
+type OutboxRepository interface {
+	// Enqueue participa da transação do contexto, se houver
+	Enqueue(ctx context.Context, message *entity.OutboxMessage) error
+	// ClaimDue reserva até limit mensagens vencidas, adiando a próxima tentativa
+	// por lease para que outra instância do dispatcher não as processe em paralelo.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.OutboxMessage, error)
+	// Update grava situação, tentativas e agendamento da mensagem
+	Update(ctx context.Context, message *entity.OutboxMessage) error
+	GetByID(ctx context.Context, id vo.ID) (*entity.OutboxMessage, error)
+	List(ctx context.Context, filter OutboxFilter) ([]*entity.OutboxMessage, error)
+	ListByUserID(ctx context.Context, userID vo.ID) ([]*entity.OutboxMessage, error)
+	// DeleteByUserID participa da transação do contexto, se houver
+	DeleteByUserID(ctx context.Context, userID vo.ID) error
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "context"
+
+// TxManager executa fn numa unidade de trabalho. A transação viaja no ctx
+// recebido por fn: repositórios chamados com ele participam dela, e um erro
+// devolvido (ou panic) desfaz todas as escritas.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
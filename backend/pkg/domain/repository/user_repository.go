@@ -2,14 +2,43 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
 )
 
+// Campos aceitos por UserRepository.Update
+const (
+	UserFieldName          = "name"
+	UserFieldEmail         = "email"
+	UserFieldPasswordHash  = "password_hash"
+	UserFieldImageURL      = "image_url"
+	UserFieldLocale        = "locale"
+	UserFieldTimezone      = "timezone"
+	UserFieldAttributes    = "attributes"
+	UserFieldPasswordReset = "password_reset"
+	UserFieldEmailChange   = "email_change"
+)
+
 type UserRepository interface {
+	// Save insere usuários novos (Version zero) e atualiza os demais por completo,
+	// com a mesma verificação de versão de Update.
 	Save(ctx context.Context, user *entity.User) (*entity.User, error)
+	// Update grava apenas os campos informados se a versão no banco ainda for
+	// user.Version; caso contrário devolve msgerror.AnErrConflict.
+	Update(ctx context.Context, user *entity.User, fields ...string) (*entity.User, error)
 	GetByEmail(ctx context.Context, email vo.Email) (*entity.User, error)
 	GetByID(ctx context.Context, userID vo.ID) (*entity.User, error)
 	GetByResetToken(ctx context.Context, token string) (*entity.User, error)
+	GetByEmailChangeToken(ctx context.Context, token string) (*entity.User, error)
+	GetByEmailChangeCancelToken(ctx context.Context, token string) (*entity.User, error)
+
+	// Contas desativadas não são devolvidas pelas consultas acima
+	Deactivate(ctx context.Context, userID vo.ID, at time.Time) error
+	Restore(ctx context.Context, userID vo.ID) error
+	GetDeactivatedByEmail(ctx context.Context, email vo.Email) (*entity.User, error)
+	ListDeactivatedBefore(ctx context.Context, before time.Time, limit int) ([]*entity.User, error)
+	// Erase remove definitivamente o registro e todos os dados pessoais
+	Erase(ctx context.Context, userID vo.ID) error
 }
@@ -1,9 +1,14 @@
 package service
 
 import (
+	"context"
+
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
 )
 
 type EmailServiceInterface interface {
-	SendResetPasswordEmail(email vo.Email, token string) error
+	SendResetPasswordEmail(ctx context.Context, email vo.Email, locale, token string) error
+	SendDataExportEmail(ctx context.Context, email vo.Email, locale, downloadURL string) error
+	SendEmailChangeConfirmation(ctx context.Context, email vo.Email, locale, token string) error
+	SendEmailChangeNotice(ctx context.Context, email vo.Email, locale string, newEmail vo.Email, cancelToken string) error
 }
@@ -0,0 +1,338 @@
+package service
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// Modelos padrão; um diretório de overrides pode substituir qualquer arquivo
+// ou acrescentar idiomas com a mesma estrutura:
+//
+//	layout.html.tmpl, layout.txt.tmpl     layout compartilhado
+//	<locale>/layout.html.tmpl             layout próprio do idioma (opcional)
+//	<locale>/partials.tmpl                trechos comuns do idioma, ex.: "expiry" (opcional)
+//	<locale>/<email>.subject.tmpl         assunto
+//	<locale>/<email>.html.tmpl            define "content"
+//	<locale>/<email>.txt.tmpl             define "content" (opcional, senão derivado do HTML)
+//
+//go:embed templates
+var embeddedTemplates embed.FS
+
+const DefaultEmailLocale = "pt-BR"
+
+// Tipos de e-mail
+const (
+	EmailResetPassword      = "reset_password"
+	EmailDataExport         = "data_export"
+	EmailChangeConfirmation = "email_change_confirmation"
+	EmailChangeNotice       = "email_change_notice"
+)
+
+// EmailData é o contexto dos modelos; cada tipo de e-mail usa os campos de que precisa.
+type EmailData struct {
+	Locale    string
+	Link      string
+	ExpiresIn Expiry
+	// NewEmail é o endereço pedido em uma troca de e-mail
+	NewEmail string
+}
+
+// Expiry separa a validade em horas inteiras ou minutos, para que cada idioma
+// faça a própria concordância.
+type Expiry struct {
+	Hours   int
+	Minutes int
+}
+
+func NewExpiry(ttl time.Duration) Expiry {
+	if ttl%time.Hour == 0 {
+		return Expiry{Hours: int(ttl / time.Hour)}
+	}
+	return Expiry{Minutes: int(ttl / time.Minute)}
+}
+
+type RenderedEmail struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+type emailTemplate struct {
+	subject *texttemplate.Template
+	html    *htmltemplate.Template
+	text    *texttemplate.Template
+}
+
+// EmailTemplates guarda os modelos já compilados, por idioma e tipo de e-mail.
+type EmailTemplates struct {
+	defaultLocale string
+	locales       map[string]map[string]*emailTemplate
+}
+
+// NewEmailTemplates compila os modelos embutidos sobrepostos pelos de
+// overrideDir (vazio para nenhum). O idioma padrão precisa existir.
+func NewEmailTemplates(overrideDir, defaultLocale string) (*EmailTemplates, error) {
+	embedded, err := fs.Sub(embeddedTemplates, "templates")
+	if err != nil {
+		return nil, err
+	}
+	src := &templateSource{embedded: embedded}
+	if overrideDir != "" {
+		info, err := os.Stat(overrideDir)
+		if err != nil {
+			return nil, fmt.Errorf("email templates dir: %w", err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("email templates dir %s is not a directory", overrideDir)
+		}
+		src.override = os.DirFS(overrideDir)
+	}
+
+	if defaultLocale == "" {
+		defaultLocale = DefaultEmailLocale
+	}
+	t := &EmailTemplates{locales: make(map[string]map[string]*emailTemplate)}
+
+	locales, err := src.dirs()
+	if err != nil {
+		return nil, err
+	}
+	for _, locale := range locales {
+		names, err := src.emailNames(locale)
+		if err != nil {
+			return nil, err
+		}
+		templates := make(map[string]*emailTemplate, len(names))
+		for _, name := range names {
+			tmpl, err := src.compile(locale, name)
+			if err != nil {
+				return nil, fmt.Errorf("email template %s/%s: %w", locale, name, err)
+			}
+			templates[name] = tmpl
+		}
+		t.locales[locale] = templates
+	}
+
+	resolved, ok := t.lookupLocale(defaultLocale)
+	if !ok {
+		return nil, fmt.Errorf("email templates: default locale %q not found", defaultLocale)
+	}
+	t.defaultLocale = resolved
+	return t, nil
+}
+
+// Locales devolve os idiomas disponíveis, em ordem alfabética.
+func (t *EmailTemplates) Locales() []string {
+	locales := make([]string, 0, len(t.locales))
+	for locale := range t.locales {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Render usa o idioma pedido, o mesmo idioma de outra região ("en" ou "en-GB"
+// para "en-US") ou, por fim, o idioma padrão.
+func (t *EmailTemplates) Render(locale, name string, data EmailData) (RenderedEmail, error) {
+	resolved, ok := t.lookupLocale(locale)
+	if !ok {
+		resolved = t.defaultLocale
+	}
+	tmpl, ok := t.locales[resolved][name]
+	if !ok {
+		resolved = t.defaultLocale
+		if tmpl, ok = t.locales[resolved][name]; !ok {
+			return RenderedEmail{}, fmt.Errorf("unknown email template %q", name)
+		}
+	}
+	data.Locale = resolved
+
+	var subject, html bytes.Buffer
+	if err := tmpl.subject.Execute(&subject, data); err != nil {
+		return RenderedEmail{}, err
+	}
+	if err := tmpl.html.ExecuteTemplate(&html, "layout", data); err != nil {
+		return RenderedEmail{}, err
+	}
+
+	rendered := RenderedEmail{
+		Subject: strings.TrimSpace(subject.String()),
+		HTML:    html.String(),
+	}
+	if tmpl.text != nil {
+		var text bytes.Buffer
+		if err := tmpl.text.ExecuteTemplate(&text, "layout", data); err != nil {
+			return RenderedEmail{}, err
+		}
+		rendered.Text = strings.TrimSpace(text.String()) + "\n"
+	} else {
+		rendered.Text = htmlToText(rendered.HTML)
+	}
+	return rendered, nil
+}
+
+func (t *EmailTemplates) lookupLocale(locale string) (string, bool) {
+	if locale == "" {
+		return "", false
+	}
+	for candidate := range t.locales {
+		if strings.EqualFold(candidate, locale) {
+			return candidate, true
+		}
+	}
+
+	lang, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	for _, candidate := range t.Locales() {
+		candidateLang, _, _ := strings.Cut(strings.ToLower(candidate), "-")
+		if candidateLang == lang {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// templateSource lê cada arquivo do diretório de overrides, se existir lá,
+// ou dos modelos embutidos.
+type templateSource struct {
+	embedded fs.FS
+	override fs.FS
+}
+
+func (s *templateSource) read(name string) ([]byte, error) {
+	if s.override != nil {
+		data, err := fs.ReadFile(s.override, name)
+		if err == nil {
+			return data, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return fs.ReadFile(s.embedded, name)
+}
+
+func (s *templateSource) exists(name string) bool {
+	_, err := s.read(name)
+	return err == nil
+}
+
+func (s *templateSource) entries(dir string) ([]fs.DirEntry, error) {
+	entries, err := fs.ReadDir(s.embedded, dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if s.override != nil {
+		extra, err := fs.ReadDir(s.override, dir)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		entries = append(entries, extra...)
+	}
+	return entries, nil
+}
+
+// dirs devolve os idiomas: cada subdiretório da raiz
+func (s *templateSource) dirs() ([]string, error) {
+	entries, err := s.entries(".")
+	if err != nil {
+		return nil, err
+	}
+	return uniqueNames(entries, func(e fs.DirEntry) (string, bool) {
+		return e.Name(), e.IsDir()
+	}), nil
+}
+
+func (s *templateSource) emailNames(locale string) ([]string, error) {
+	entries, err := s.entries(locale)
+	if err != nil {
+		return nil, err
+	}
+	return uniqueNames(entries, func(e fs.DirEntry) (string, bool) {
+		name, ok := strings.CutSuffix(e.Name(), ".html.tmpl")
+		return name, ok && !e.IsDir() && name != "layout"
+	}), nil
+}
+
+func (s *templateSource) layout(locale, ext string) string {
+	if localized := path.Join(locale, "layout"+ext); s.exists(localized) {
+		return localized
+	}
+	return "layout" + ext
+}
+
+// files lista, na ordem de parse, o layout, os trechos comuns e o conteúdo.
+func (s *templateSource) files(locale, name, ext string) []string {
+	files := []string{s.layout(locale, ext)}
+	if partials := path.Join(locale, "partials.tmpl"); s.exists(partials) {
+		files = append(files, partials)
+	}
+	return append(files, path.Join(locale, name+ext))
+}
+
+func (s *templateSource) compile(locale, name string) (*emailTemplate, error) {
+	base := path.Join(locale, name)
+
+	subjectSrc, err := s.read(base + ".subject.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	subject, err := texttemplate.New("subject").Parse(string(subjectSrc))
+	if err != nil {
+		return nil, err
+	}
+
+	html := htmltemplate.New("layout")
+	for _, file := range s.files(locale, name, ".html.tmpl") {
+		src, err := s.read(file)
+		if err != nil {
+			return nil, err
+		}
+		if html, err = html.Parse(string(src)); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(file), err)
+		}
+	}
+
+	tmpl := &emailTemplate{subject: subject, html: html}
+	if !s.exists(base + ".txt.tmpl") {
+		return tmpl, nil
+	}
+
+	text := texttemplate.New("layout")
+	for _, file := range s.files(locale, name, ".txt.tmpl") {
+		src, err := s.read(file)
+		if err != nil {
+			return nil, err
+		}
+		if text, err = text.Parse(string(src)); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(file), err)
+		}
+	}
+	tmpl.text = text
+	return tmpl, nil
+}
+
+func uniqueNames(entries []fs.DirEntry, accept func(fs.DirEntry) (string, bool)) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, entry := range entries {
+		name, ok := accept(entry)
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -1,10 +1,12 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"os"
+	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
 	"gopkg.in/gomail.v2"
@@ -14,62 +16,141 @@ type MailSender interface {
 	DialAndSend(...*gomail.Message) error
 }
 
+type EmailConfig struct {
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	From         string
+	FrontendURL  string
+	// EmailChangeURL é a página do frontend que confirma ou cancela a troca de e-mail
+	EmailChangeURL string
+	ResetTTL       time.Duration
+	ExportTTL      time.Duration
+	EmailChangeTTL time.Duration
+	// Templates nil usa apenas os modelos embutidos
+	Templates *EmailTemplates
+}
+
 type EmailService struct {
-	sender      MailSender
-	from        string
-	frontendURL string
+	sender         MailSender
+	templates      *EmailTemplates
+	from           string
+	frontendURL    string
+	resetTTL       time.Duration
+	exportTTL      time.Duration
+	emailChangeURL string
+	emailChangeTTL time.Duration
 }
 
-func NewEmailService(sender MailSender) *EmailService {
+func NewEmailService(sender MailSender, cfg EmailConfig) *EmailService {
 	if sender == nil {
-		host := os.Getenv("SMTP_HOST")
-		portStr := os.Getenv("SMTP_PORT")
-		username := os.Getenv("SMTP_USERNAME")
-		password := os.Getenv("SMTP_PASSWORD")
-
-		port := parsePort(portStr)
+		port := cfg.SMTPPort
 		if port == 0 {
 			port = 587
 		}
 
-		sender = gomail.NewDialer(host, port, username, password)
+		sender = gomail.NewDialer(cfg.SMTPHost, port, cfg.SMTPUsername, cfg.SMTPPassword)
+	}
+
+	templates := cfg.Templates
+	if templates == nil {
+		var err error
+		if templates, err = NewEmailTemplates("", DefaultEmailLocale); err != nil {
+			// Os modelos embutidos são validados pelos testes; falhar aqui é bug
+			panic(fmt.Sprintf("embedded email templates: %v", err))
+		}
+	}
+
+	resetTTL := cfg.ResetTTL
+	if resetTTL <= 0 {
+		resetTTL = time.Hour
+	}
+
+	exportTTL := cfg.ExportTTL
+	if exportTTL <= 0 {
+		exportTTL = 15 * time.Minute
+	}
+
+	emailChangeTTL := cfg.EmailChangeTTL
+	if emailChangeTTL <= 0 {
+		emailChangeTTL = 24 * time.Hour
 	}
 
 	return &EmailService{
-		sender:      sender,
-		from:        os.Getenv("FROM_EMAIL"),
-		frontendURL: os.Getenv("FRONTEND_RESET_URL"),
+		sender:         sender,
+		templates:      templates,
+		from:           cfg.From,
+		frontendURL:    cfg.FrontendURL,
+		resetTTL:       resetTTL,
+		exportTTL:      exportTTL,
+		emailChangeURL: cfg.EmailChangeURL,
+		emailChangeTTL: emailChangeTTL,
+	}
+}
+
+func (s *EmailService) SendResetPasswordEmail(_ context.Context, email vo.Email, locale, token string) error {
+	if s.frontendURL == "" {
+		return errors.New("FRONTEND_RESET_URL não está definido")
+	}
+
+	return s.send(email, locale, EmailResetPassword, EmailData{
+		Link:      fmt.Sprintf("%s?reset_password_token=%s", s.frontendURL, url.QueryEscape(token)),
+		ExpiresIn: NewExpiry(s.resetTTL),
+	})
+}
+
+// SendDataExportEmail envia o link assinado para download dos dados do usuário
+func (s *EmailService) SendDataExportEmail(_ context.Context, email vo.Email, locale, downloadURL string) error {
+	return s.send(email, locale, EmailDataExport, EmailData{
+		Link:      downloadURL,
+		ExpiresIn: NewExpiry(s.exportTTL),
+	})
+}
+
+// SendEmailChangeConfirmation envia ao novo endereço o link que efetiva a troca
+func (s *EmailService) SendEmailChangeConfirmation(_ context.Context, email vo.Email, locale, token string) error {
+	if s.emailChangeURL == "" {
+		return errors.New("FRONTEND_EMAIL_CHANGE_URL não está definido")
+	}
+
+	return s.send(email, locale, EmailChangeConfirmation, EmailData{
+		Link:      fmt.Sprintf("%s?email_change_token=%s", s.emailChangeURL, url.QueryEscape(token)),
+		ExpiresIn: NewExpiry(s.emailChangeTTL),
+	})
+}
+
+// SendEmailChangeNotice avisa o endereço atual, com o link de cancelamento
+func (s *EmailService) SendEmailChangeNotice(_ context.Context, email vo.Email, locale string, newEmail vo.Email, cancelToken string) error {
+	if s.emailChangeURL == "" {
+		return errors.New("FRONTEND_EMAIL_CHANGE_URL não está definido")
 	}
+
+	return s.send(email, locale, EmailChangeNotice, EmailData{
+		Link:      fmt.Sprintf("%s?email_change_cancel_token=%s", s.emailChangeURL, url.QueryEscape(cancelToken)),
+		ExpiresIn: NewExpiry(s.emailChangeTTL),
+		NewEmail:  newEmail.String(),
+	})
 }
 
-func (s *EmailService) SendResetPasswordEmail(email vo.Email, token string) error {
+// send monta a mensagem multipart/alternative: text/plain primeiro, HTML como
+// alternativa preferida.
+func (s *EmailService) send(email vo.Email, locale, name string, data EmailData) error {
 	if s.from == "" {
 		return errors.New("FROM_EMAIL não está definido")
 	}
 
-	if s.frontendURL == "" {
-		return errors.New("FRONTEND_RESET_URL não está definido")
+	rendered, err := s.templates.Render(locale, name, data)
+	if err != nil {
+		return err
 	}
 
 	m := gomail.NewMessage()
 	m.SetHeader("From", s.from)
 	m.SetHeader("To", email.String())
-	m.SetHeader("Subject", "Redefinição de Senha")
-
-	resetLink := fmt.Sprintf("%s?reset_password_token=%s", s.frontendURL, token)
-
-	htmlBody := fmt.Sprintf(`
-		<html>
-		<body>
-			<h2>Redefinição de Senha</h2>
-			<p>Clique no link abaixo para redefinir sua senha:</p>
-			<a href="%s">%s</a>
-			<p>Este link expira em 1 hora.</p>
-		</body>
-		</html>
-	`, resetLink, resetLink)
-
-	m.SetBody("text/html", htmlBody)
+	m.SetHeader("Subject", rendered.Subject)
+	m.SetBody("text/plain", rendered.Text)
+	m.AddAlternative("text/html", rendered.HTML)
 
 	return s.sender.DialAndSend(m)
 }
@@ -84,8 +165,3 @@ func ParsePort(port string) (int, error) {
 	}
 	return p, nil
 }
-
-func parsePort(port string) int {
-	p, _ := ParsePort(port)
-	return p
-}
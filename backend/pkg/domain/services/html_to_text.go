@@ -0,0 +1,117 @@
+package service
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Elementos que quebram linha no texto puro
+var blockElements = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "tr": true, "table": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ul": true, "ol": true, "hr": true,
+}
+
+// Elementos cujo conteúdo não aparece no texto puro
+var skippedElements = map[string]bool{
+	"head": true, "title": true, "style": true, "script": true,
+}
+
+// htmlToText gera a alternativa text/plain de um e-mail HTML: mantém o texto,
+// quebra linhas nos blocos e acrescenta o endereço dos links entre parênteses.
+func htmlToText(source string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(source))
+
+	var out strings.Builder
+	var hrefs []string
+	skipDepth := 0
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return tidyText(out.String())
+
+		case html.TextToken:
+			if skipDepth == 0 {
+				raw := tokenizer.Raw()
+				if len(raw) > 0 && isSpace(raw[0]) {
+					out.WriteByte(' ')
+				}
+				out.WriteString(strings.Join(strings.Fields(string(tokenizer.Text())), " "))
+				if len(raw) > 0 && isSpace(raw[len(raw)-1]) {
+					out.WriteByte(' ')
+				}
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			tag := string(name)
+			if skippedElements[tag] {
+				skipDepth++
+				continue
+			}
+			if blockElements[tag] {
+				out.WriteByte('\n')
+			}
+			if tag == "a" {
+				href := ""
+				for hasAttr {
+					var key, val []byte
+					key, val, hasAttr = tokenizer.TagAttr()
+					if string(key) == "href" {
+						href = string(val)
+					}
+				}
+				hrefs = append(hrefs, href)
+				if href != "" {
+					out.WriteByte(' ')
+				}
+			}
+
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			tag := string(name)
+			if skippedElements[tag] {
+				if skipDepth > 0 {
+					skipDepth--
+				}
+				continue
+			}
+			if tag == "a" && len(hrefs) > 0 {
+				href := hrefs[len(hrefs)-1]
+				hrefs = hrefs[:len(hrefs)-1]
+				// Links cujo texto já é o próprio endereço não se repetem
+				if href != "" && !strings.HasSuffix(strings.TrimSpace(out.String()), href) {
+					out.WriteString(" (" + href + ")")
+				}
+			}
+			if blockElements[tag] {
+				out.WriteByte('\n')
+			}
+		}
+	}
+}
+
+// tidyText apara as linhas e limita as linhas em branco consecutivas a uma.
+func tidyText(text string) string {
+	var lines []string
+	blank := true
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			if !blank {
+				lines = append(lines, "")
+			}
+			blank = true
+			continue
+		}
+		lines = append(lines, line)
+		blank = false
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")) + "\n"
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\n' || b == '\t' || b == '\r'
+}
@@ -0,0 +1,22 @@
+package service
+
+import "github.com/eskokado/startup-auth-go/backend/pkg/dto"
+
+// Transportes aceitos em EMAIL_TRANSPORT
+const (
+	// MailTransportSMTP entrega pelo servidor SMTP configurado.
+	MailTransportSMTP = "smtp"
+	// MailTransportMailbox guarda as mensagens em memória, expostas em GET /dev/mailbox.
+	MailTransportMailbox = "mailbox"
+	// MailTransportFile grava cada mensagem como .eml em um diretório maildir.
+	MailTransportFile = "file"
+	// MailTransportLog só registra destinatário e assunto no log.
+	MailTransportLog = "log"
+)
+
+// Mailbox dá acesso às mensagens retidas pelo transporte em memória.
+type Mailbox interface {
+	// Messages devolve as mensagens mais recentes primeiro; to vazio devolve todas.
+	Messages(to string) []dto.MailboxMessage
+	Clear()
+}
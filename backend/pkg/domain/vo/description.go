@@ -27,9 +27,9 @@ func NewDescription(value string, min, max int) (Description, error) {
 
 	switch {
 	case len(trimmed) < min:
-		return Description{}, fmt.Errorf("%w: mínimo %d caracteres", msgerror.AnErrTooShort, min)
+		return Description{}, msgerror.WithParam(fmt.Errorf("%w: at least %d characters", msgerror.AnErrTooShort, min), "min", min)
 	case len(trimmed) > max:
-		return Description{}, fmt.Errorf("%w: máximo %d caracteres", msgerror.AnErrTooLong, max)
+		return Description{}, msgerror.WithParam(fmt.Errorf("%w: at most %d characters", msgerror.AnErrTooLong, max), "max", max)
 	}
 
 	return Description{value: trimmed}, nil
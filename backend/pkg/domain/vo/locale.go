@@ -0,0 +1,39 @@
+package vo
+
+import (
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"golang.org/x/text/language"
+)
+
+// Tamanho da coluna users.locale
+const maxLocaleLength = 16
+
+// Locale é uma etiqueta de idioma BCP 47 na forma canônica ("pt-BR");
+// vazio indica o idioma padrão.
+type Locale struct {
+	value string
+}
+
+func NewLocale(value string) (Locale, error) {
+	if value == "" {
+		return Locale{}, nil
+	}
+
+	tag, err := language.Parse(value)
+	if err != nil || len(tag.String()) > maxLocaleLength {
+		return Locale{}, msgerror.AnErrInvalidLocale
+	}
+	return Locale{value: tag.String()}, nil
+}
+
+func (l Locale) String() string {
+	return l.value
+}
+
+func (l Locale) Equal(other Locale) bool {
+	return l.value == other.value
+}
+
+func (l Locale) IsEmpty() bool {
+	return l.value == ""
+}
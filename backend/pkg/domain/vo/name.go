@@ -28,9 +28,9 @@ func NewName(value string, min, max int) (Name, error) {
 
 	switch {
 	case len(trimmed) < min:
-		return Name{}, fmt.Errorf("%w: mínimo %d caracteres", msgerror.AnErrNameTooShort, min)
+		return Name{}, msgerror.WithParam(fmt.Errorf("%w: at least %d characters", msgerror.AnErrNameTooShort, min), "min", min)
 	case len(trimmed) > max:
-		return Name{}, fmt.Errorf("%w: máximo %d caracteres", msgerror.AnErrNameTooLong, max)
+		return Name{}, msgerror.WithParam(fmt.Errorf("%w: at most %d characters", msgerror.AnErrNameTooLong, max), "max", max)
 	}
 
 	return Name{value: trimmed}, nil
@@ -0,0 +1,67 @@
+package vo
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+const (
+	MaxProfileAttributes       = 20
+	MaxProfileAttributeLength  = 255
+	profileAttributeKeyPattern = `^[a-z][a-z0-9_]{0,31}$`
+)
+
+var attributeKeyRegexp = regexp.MustCompile(profileAttributeKeyPattern)
+
+// ProfileAttributes guarda atributos livres do perfil (cargo, empresa,
+// pronomes...) sem exigir uma coluna para cada um.
+type ProfileAttributes struct {
+	values map[string]string
+}
+
+func NewProfileAttributes(values map[string]string) (ProfileAttributes, error) {
+	if len(values) > MaxProfileAttributes {
+		return ProfileAttributes{}, fmt.Errorf("%w: at most %d attributes", msgerror.AnErrInvalidAttributes, MaxProfileAttributes)
+	}
+	for key, value := range values {
+		if !attributeKeyRegexp.MatchString(key) {
+			return ProfileAttributes{}, fmt.Errorf("%w: invalid key %q", msgerror.AnErrInvalidAttributes, key)
+		}
+		if !utf8.ValidString(value) || utf8.RuneCountInString(value) > MaxProfileAttributeLength {
+			return ProfileAttributes{}, fmt.Errorf("%w: value of %q is too long", msgerror.AnErrInvalidAttributes, key)
+		}
+	}
+
+	if len(values) == 0 {
+		return ProfileAttributes{}, nil
+	}
+	return ProfileAttributes{values: maps.Clone(values)}, nil
+}
+
+// Map devolve uma cópia, nunca nil; alterá-la não muda o valor.
+func (a ProfileAttributes) Map() map[string]string {
+	values := make(map[string]string, len(a.values))
+	maps.Copy(values, a.values)
+	return values
+}
+
+func (a ProfileAttributes) Get(key string) (string, bool) {
+	value, ok := a.values[key]
+	return value, ok
+}
+
+func (a ProfileAttributes) Len() int {
+	return len(a.values)
+}
+
+func (a ProfileAttributes) Equal(other ProfileAttributes) bool {
+	return maps.Equal(a.values, other.values)
+}
+
+func (a ProfileAttributes) IsEmpty() bool {
+	return len(a.values) == 0
+}
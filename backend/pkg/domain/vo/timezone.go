@@ -0,0 +1,57 @@
+package vo
+
+import (
+	"time"
+	// Base de fusos embutida: a validação não depende do sistema operacional
+	_ "time/tzdata"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+// Tamanho da coluna users.timezone
+const maxTimezoneLength = 64
+
+// Timezone é um nome da base IANA, como "America/Sao_Paulo"; vazio indica
+// que o usuário não escolheu um fuso.
+type Timezone struct {
+	value string
+}
+
+func NewTimezone(value string) (Timezone, error) {
+	if value == "" {
+		return Timezone{}, nil
+	}
+
+	// "Local" dependeria do fuso do servidor
+	if value == "Local" || len(value) > maxTimezoneLength {
+		return Timezone{}, msgerror.AnErrInvalidTimezone
+	}
+	if _, err := time.LoadLocation(value); err != nil {
+		return Timezone{}, msgerror.AnErrInvalidTimezone
+	}
+	return Timezone{value: value}, nil
+}
+
+// Location devolve UTC quando o fuso não foi definido.
+func (t Timezone) Location() *time.Location {
+	if t.value == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(t.value)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+func (t Timezone) String() string {
+	return t.value
+}
+
+func (t Timezone) Equal(other Timezone) bool {
+	return t.value == other.value
+}
+
+func (t Timezone) IsEmpty() bool {
+	return t.value == ""
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+type DeleteAccountInput struct {
+	Password string `json:"password" binding:"required"`
+}
+
+type DeleteAccountOutput struct {
+	PurgeAfter time.Time `json:"purge_after"`
+}
+
+type RestoreAccountInput struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type ChangeEmailInput struct {
+	NewEmail string `json:"new_email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// EmailChangeTokenInput serve à confirmação e ao cancelamento da troca de e-mail.
+type EmailChangeTokenInput struct {
+	Token string `json:"token" binding:"required"`
+}
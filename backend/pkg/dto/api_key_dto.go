@@ -0,0 +1,53 @@
+package dto
+
+import "time"
+
+type CreateAPIKeyParams struct {
+	Name      string
+	Scopes    []string
+	ExpiresAt time.Time
+}
+
+type APIKeyResult struct {
+	ID         string
+	Name       string
+	Prefix     string
+	Scopes     []string
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	RevokedAt  time.Time
+	CreatedAt  time.Time
+}
+
+type CreatedAPIKeyResult struct {
+	APIKeyResult
+	Key string
+}
+
+type APIKeyPrincipal struct {
+	UserID   string
+	APIKeyID string
+	Scopes   []string
+}
+
+type CreateAPIKeyInput struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type APIKeyOutput struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreateAPIKeyOutput struct {
+	APIKeyOutput
+	Key string `json:"key"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+type AuditQueryParams struct {
+	Action  string
+	Outcome string
+	ActorID string
+	Target  string
+	IP      string
+	From    time.Time
+	To      time.Time
+	Limit   int
+	Offset  int
+}
+
+type AuditEventOutput struct {
+	ID        string    `json:"id"`
+	Action    string    `json:"action"`
+	Outcome   string    `json:"outcome"`
+	ActorID   string    `json:"actor_id,omitempty"`
+	Target    string    `json:"target,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type AuditQueryInput struct {
+	Action  string    `form:"action"`
+	Outcome string    `form:"outcome" binding:"omitempty,oneof=success failure"`
+	ActorID string    `form:"actor_id"`
+	Target  string    `form:"target"`
+	IP      string    `form:"ip"`
+	From    time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To      time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit   int       `form:"limit" binding:"omitempty,min=1,max=500"`
+	Offset  int       `form:"offset" binding:"omitempty,min=0"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+type AvatarImage struct {
+	ContentType string
+	Data        []byte
+}
+
+type UploadAvatarOutput struct {
+	ImageURL string `json:"image_url"`
+}
+
+type AvatarQuery struct {
+	Size int `form:"size"`
+}
@@ -0,0 +1,64 @@
+package dto
+
+import "time"
+
+const (
+	ExportFormatJSON = "json"
+	ExportFormatZIP  = "zip"
+)
+
+// UserDataExport reúne tudo o que o sistema guarda sobre o usuário.
+type UserDataExport struct {
+	ExportedAt  time.Time          `json:"exported_at"`
+	Profile     UserProfileExport  `json:"profile"`
+	EmailChange *EmailChangeExport `json:"email_change,omitempty"`
+	APIKeys     []APIKeyOutput     `json:"api_keys"`
+	AuditEvents []AuditEventOutput `json:"audit_events"`
+	// Messages são os e-mails da outbox destinados ao usuário
+	Messages []OutboxMessageExport `json:"messages"`
+}
+
+// EmailChangeExport é a troca de e-mail aguardando confirmação.
+type EmailChangeExport struct {
+	PendingEmail string    `json:"pending_email"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+type OutboxMessageExport struct {
+	ID     string `json:"id"`
+	Topic  string `json:"topic"`
+	Status string `json:"status"`
+	// To fica vazio depois do envio, quando o conteúdo é descartado
+	To        string     `json:"to,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	SentAt    *time.Time `json:"sent_at,omitempty"`
+}
+
+type UserProfileExport struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Email      string            `json:"email"`
+	ImageURL   string            `json:"image_url,omitempty"`
+	Locale     string            `json:"locale,omitempty"`
+	Timezone   string            `json:"timezone,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// UserDataExportResult traz o arquivo pronto ou, com Pending, indica que ele
+// será enviado por e-mail.
+type UserDataExportResult struct {
+	Pending     bool
+	FileName    string
+	ContentType string
+	Data        []byte
+}
+
+type ExportInput struct {
+	Format string `form:"format" binding:"omitempty,oneof=json zip"`
+}
+
+type ExportDownloadInput struct {
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required"`
+}
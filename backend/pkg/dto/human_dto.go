@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// HumanChallengeOutput traz a prova de trabalho a resolver (type "pow") ou a
+// site key do widget de captcha (type "hcaptcha" ou "turnstile"). A resposta
+// volta no header X-Human-Proof.
+type HumanChallengeOutput struct {
+	Type       string     `json:"type"`
+	Challenge  string     `json:"challenge,omitempty"`
+	Difficulty int        `json:"difficulty,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	SiteKey    string     `json:"site_key,omitempty"`
+}
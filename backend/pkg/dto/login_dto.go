@@ -7,12 +7,15 @@ import (
 )
 
 type LoginResult struct {
-	UserID    vo.ID
-	Name      vo.Name
-	Email     vo.Email
-	ImageURL  vo.URL
-	CreatedAt time.Time
-	Token     string
+	UserID     vo.ID
+	Name       vo.Name
+	Email      vo.Email
+	ImageURL   vo.URL
+	Locale     vo.Locale
+	Timezone   vo.Timezone
+	Attributes vo.ProfileAttributes
+	CreatedAt  time.Time
+	Token      string
 }
 
 type LoginInput struct {
@@ -21,12 +24,21 @@ type LoginInput struct {
 }
 
 type LoginOutput struct {
-	AccessToken string     `json:"access_token"`
+	AccessToken string     `json:"access_token,omitempty"`
+	CSRFToken   string     `json:"csrf_token,omitempty"`
 	User        UserOutput `json:"user"`
 }
 
+// UserOutput é a representação do usuário em todas as respostas da API.
 type UserOutput struct {
-	Id    string `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	Id         string            `json:"id"`
+	Name       string            `json:"name"`
+	Email      string            `json:"email"`
+	ImageURL   string            `json:"image_url"`
+	Locale     string            `json:"locale"`
+	Timezone   string            `json:"timezone"`
+	Attributes map[string]string `json:"attributes"`
+	// PendingEmail só aparece durante uma troca de e-mail não confirmada
+	PendingEmail string    `json:"pending_email,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
 }
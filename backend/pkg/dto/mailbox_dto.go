@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// MailboxMessage é uma mensagem retida pelo transporte de e-mail em memória.
+type MailboxMessage struct {
+	ID         string    `json:"id"`
+	From       string    `json:"from"`
+	To         []string  `json:"to"`
+	Subject    string    `json:"subject"`
+	Text       string    `json:"text,omitempty"`
+	HTML       string    `json:"html,omitempty"`
+	ReceivedAt time.Time `json:"received_at"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+type IntrospectionResult struct {
+	Active    bool
+	UserID    string
+	Subject   string
+	ExpiresAt int64
+	TokenType string
+}
+
+type IntrospectOutput struct {
+	Active    bool   `json:"active"`
+	UserID    string `json:"user_id,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	Username  string `json:"username,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+type OutboxQueryParams struct {
+	Status string
+	Topic  string
+	Limit  int
+	Offset int
+}
+
+// OutboxMessageOutput omite o payload, que pode conter tokens.
+type OutboxMessageOutput struct {
+	ID            string     `json:"id"`
+	Topic         string     `json:"topic"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+}
+
+type OutboxQueryInput struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending sent dead"`
+	Topic  string `form:"topic"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=500"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+}
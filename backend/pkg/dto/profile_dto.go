@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// OptionalString distingue o campo ausente do enviado: Set indica que veio no
+// corpo, e null equivale a "" (limpa o valor).
+type OptionalString struct {
+	Set   bool
+	Value string
+}
+
+func (o *OptionalString) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if bytes.Equal(data, []byte("null")) {
+		o.Value = ""
+		return nil
+	}
+	return json.Unmarshal(data, &o.Value)
+}
+
+// UpdateProfileInput segue a semântica de merge patch: só os campos enviados
+// mudam, e em Attributes um valor null remove a chave.
+type UpdateProfileInput struct {
+	Name       OptionalString     `json:"name"`
+	ImageURL   OptionalString     `json:"image_url"`
+	Locale     OptionalString     `json:"locale"`
+	Timezone   OptionalString     `json:"timezone"`
+	Attributes map[string]*string `json:"attributes"`
+}
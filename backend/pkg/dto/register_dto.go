@@ -17,10 +17,8 @@ type RegisterUserInput struct {
 }
 
 type RegisterUserOutput struct {
-	ID                   string `json:"id"`
-	Name                 string `json:"name"`
-	Email                string `json:"email"`
-	ImageURL             string `json:"image_url"`
-	PasswordResetToken   string `json:"password_reset_token"`
-	PasswordResetExpires string `json:"password_reset_expires"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	ImageURL string `json:"image_url"`
 }
@@ -0,0 +1,144 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Catálogos de mensagens por idioma, um arquivo por etiqueta BCP 47
+// (catalogs/pt-BR.json). As chaves são os códigos de msgerror.
+//
+//go:embed catalogs/*.json
+var embeddedCatalogs embed.FS
+
+const DefaultLocale = "pt-BR"
+
+// Translator escolhe o idioma da resposta e traduz os códigos de erro.
+type Translator struct {
+	defaultLocale string
+	locales       []string // Mesma ordem das etiquetas do matcher
+	matcher       language.Matcher
+	catalogs      map[string]map[string]string
+}
+
+// NewTranslator carrega os catálogos embutidos; o idioma padrão precisa
+// existir e é usado quando nenhum outro combina.
+func NewTranslator(defaultLocale string) (*Translator, error) {
+	if defaultLocale == "" {
+		defaultLocale = DefaultLocale
+	}
+
+	entries, err := embeddedCatalogs.ReadDir("catalogs")
+	if err != nil {
+		return nil, err
+	}
+
+	catalogs := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		locale := strings.TrimSuffix(entry.Name(), ".json")
+		data, err := embeddedCatalogs.ReadFile(path.Join("catalogs", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		catalog := make(map[string]string)
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			return nil, fmt.Errorf("i18n catalog %s: %w", locale, err)
+		}
+		catalogs[locale] = catalog
+	}
+
+	t := &Translator{catalogs: catalogs}
+	for locale := range catalogs {
+		t.locales = append(t.locales, locale)
+	}
+	sort.Strings(t.locales)
+
+	// O padrão vai primeiro: o matcher o devolve quando nada combina
+	resolved, ok := t.lookup(defaultLocale)
+	if !ok {
+		return nil, fmt.Errorf("i18n: default locale %q not found", defaultLocale)
+	}
+	t.defaultLocale = resolved
+
+	ordered := []string{resolved}
+	for _, locale := range t.locales {
+		if locale != resolved {
+			ordered = append(ordered, locale)
+		}
+	}
+	t.locales = ordered
+
+	tags := make([]language.Tag, len(t.locales))
+	for i, locale := range t.locales {
+		tags[i] = language.MustParse(locale)
+	}
+	t.matcher = language.NewMatcher(tags)
+	return t, nil
+}
+
+// DefaultLocale devolve o idioma usado quando nenhuma preferência combina.
+func (t *Translator) DefaultLocale() string {
+	return t.defaultLocale
+}
+
+// Locales devolve os idiomas disponíveis, com o padrão primeiro.
+func (t *Translator) Locales() []string {
+	return append([]string(nil), t.locales...)
+}
+
+// Match negocia o idioma a partir das preferências, em ordem de prioridade.
+// Cada preferência pode ser uma etiqueta ("pt-BR") ou um cabeçalho
+// Accept-Language completo ("en-GB,en;q=0.8"); vazias e inválidas são
+// ignoradas.
+func (t *Translator) Match(preferences ...string) string {
+	for _, preference := range preferences {
+		if strings.TrimSpace(preference) == "" {
+			continue
+		}
+		tags, _, err := language.ParseAcceptLanguage(preference)
+		if err != nil || len(tags) == 0 {
+			continue
+		}
+		_, index, confidence := t.matcher.Match(tags...)
+		if confidence != language.No {
+			return t.locales[index]
+		}
+	}
+	return t.defaultLocale
+}
+
+// Message traduz o código no idioma pedido, caindo para o idioma padrão.
+// Os parâmetros substituem "{nome}"; se algum ficar sem valor a mensagem
+// não é usada e ok é false.
+func (t *Translator) Message(locale, code string, params map[string]string) (string, bool) {
+	message, ok := t.catalogs[locale][code]
+	if !ok {
+		if message, ok = t.catalogs[t.defaultLocale][code]; !ok {
+			return "", false
+		}
+	}
+
+	for name, value := range params {
+		message = strings.ReplaceAll(message, "{"+name+"}", value)
+	}
+	if strings.Contains(message, "{") {
+		return "", false
+	}
+	return message, true
+}
+
+// lookup aceita a etiqueta sem diferenciar maiúsculas ("pt-br").
+func (t *Translator) lookup(locale string) (string, bool) {
+	for _, candidate := range t.locales {
+		if strings.EqualFold(candidate, locale) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,108 @@
+package msgerror
+
+import "errors"
+
+// Códigos estáveis expostos aos clientes da API; o texto das mensagens pode
+// mudar, os códigos não.
+const (
+	CodeInternal         = "internal_error"
+	CodeValidationFailed = "validation_failed"
+	CodeInvalid          = "invalid"
+)
+
+// codes segue a ordem de precedência: um erro que envolve mais de um
+// sentinela (ex.: unauthorized + invalid_token) recebe o primeiro código.
+// Sentinelas de configuração e de infraestrutura ficam de fora e saem como
+// CodeInternal.
+var codes = []struct {
+	err  error
+	code string
+}{
+	{AnErrUnauthorized, "unauthorized"},
+	{AnErrForbidden, "forbidden"},
+	{AnErrInsufficientScope, "insufficient_scope"},
+	{AnErrInvalidCSRFToken, "invalid_csrf_token"},
+	{AnErrTokenRevoked, "token_revoked"},
+	{AnErrRateLimited, "rate_limited"},
+	{AnErrHumanVerificationRequired, "human_verification_required"},
+	{AnErrHumanVerificationFailed, "human_verification_failed"},
+	{AnErrInvalidRequestBody, "invalid_request_body"},
+	{AnErrInvalidQuery, "invalid_query"},
+	{AnErrInvalidType, "invalid_type"},
+	{AnErrInvalidValue, "invalid_value"},
+	{AnErrInvalidCredentials, "invalid_credentials"},
+	{AnErrUserNotFound, "user_not_found"},
+	{AnErrUserExists, "user_exists"},
+	{AnErrWeakPassword, "weak_password"},
+	{AnErrInvalidUser, "invalid_user"},
+	{AnErrEmptyEmail, "empty_email"},
+	{AnErrEmptyDescription, "empty_description"},
+	{AnErrTooShort, "description_too_short"},
+	{AnErrTooLong, "description_too_long"},
+	{AnErrInvalidEmail, "invalid_email"},
+	{AnErrEmptyID, "empty_id"},
+	{AnErrInvalidID, "invalid_id"},
+	{AnErrInvalidName, "invalid_name"},
+	{AnErrEmptyName, "empty_name"},
+	{AnErrNameDifferent, "name_unchanged"},
+	{AnErrPasswordInvalid, "password_too_short"},
+	{AnErrPasswordTooShort, "password_too_short"},
+	{AnErrEmptyPassword, "empty_password"},
+	{AnErrPasswordMismatch, "password_mismatch"},
+	{AnErrRequired, "required"},
+	{AnErrInvalidURL, "invalid_url"},
+	{AnErrEmptyURL, "empty_url"},
+	{AnErrNameTooShort, "name_too_short"},
+	{AnErrNameTooLong, "name_too_long"},
+	{AnErrInvalidPassword, "invalid_password"},
+	{AnErrInvalidToken, "invalid_token"},
+	{AnErrExpiredToken, "expired_token"},
+	{AnErrTokenIsRequired, "token_required"},
+	{AnErrInvalidAPIKey, "invalid_api_key"},
+	{AnErrInvalidScope, "invalid_scope"},
+	{AnErrAPIKeyNotFound, "api_key_not_found"},
+	{AnErrInvalidExpiration, "invalid_expiration"},
+	{AnErrExportNotFound, "export_not_found"},
+	{AnErrInvalidSignature, "invalid_signature"},
+	{AnErrConflict, "conflict"},
+	{AnErrOutboxNotFound, "outbox_not_found"},
+	{AnErrOutboxNotDead, "outbox_not_dead"},
+	{AnErrEmailUnchanged, "email_unchanged"},
+	{AnErrUnsupportedImage, "unsupported_image"},
+	{AnErrInvalidImage, "invalid_image"},
+	{AnErrImageTooLarge, "image_too_large"},
+	{AnErrInvalidImageSize, "invalid_image_size"},
+	{AnErrAvatarNotFound, "avatar_not_found"},
+	{AnErrDocsNotFound, "docs_not_found"},
+	{AnErrInvalidLocale, "invalid_locale"},
+	{AnErrInvalidTimezone, "invalid_timezone"},
+	{AnErrInvalidAttributes, "invalid_attributes"},
+}
+
+// Codes devolve todos os códigos estáveis, sem repetição, na ordem de
+// precedência; os catálogos de mensagens devem cobrir cada um deles.
+func Codes() []string {
+	all := []string{CodeInternal, CodeValidationFailed, CodeInvalid}
+	seen := map[string]bool{CodeInternal: true, CodeValidationFailed: true, CodeInvalid: true}
+	for _, entry := range codes {
+		if !seen[entry.code] {
+			seen[entry.code] = true
+			all = append(all, entry.code)
+		}
+	}
+	return all
+}
+
+// Code identifica o erro pelo sentinela que ele envolve.
+func Code(err error) string {
+	var valErr *ValidationErrors
+	if errors.As(err, &valErr) {
+		return CodeValidationFailed
+	}
+	for _, entry := range codes {
+		if errors.Is(err, entry.err) {
+			return entry.code
+		}
+	}
+	return CodeInternal
+}
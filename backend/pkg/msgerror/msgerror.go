@@ -6,7 +6,9 @@ import (
 )
 
 type ValidationErrors struct {
-	FieldErrors map[string]string // Campo -> mensagem
+	FieldErrors map[string]string            // Campo -> mensagem
+	FieldCodes  map[string]string            // Campo -> código estável (ver Code)
+	FieldParams map[string]map[string]string // Campo -> parâmetros da mensagem (ver Params)
 }
 
 var (
@@ -39,6 +41,56 @@ var (
 	AnErrExpiredToken       = errors.New("expired token")
 	AnErrSendMessageByEmail = errors.New("error send message by email")
 	AnErrTokenIsRequired    = errors.New("token is required")
+	AnErrInvalidAPIKey      = errors.New("invalid api key")
+	AnErrInvalidScope       = errors.New("invalid scope")
+	AnErrAPIKeyNotFound     = errors.New("api key not found")
+	AnErrInvalidExpiration  = errors.New("expiration must be in the future")
+	AnErrMissingTokenID     = errors.New("token has no jti claim")
+	AnErrInvalidTokenStore  = errors.New("invalid token store mode")
+	AnErrInvalidDBDriver    = errors.New("unsupported database driver")
+	AnErrInvalidMigration   = errors.New("invalid migration")
+	AnErrExportNotFound     = errors.New("export not found")
+	AnErrInvalidSignature   = errors.New("invalid or expired link")
+	AnErrConflict           = errors.New("resource was modified by another request")
+	AnErrOutboxNotFound     = errors.New("outbox message not found")
+	AnErrOutboxNotDead      = errors.New("only dead outbox messages can be replayed")
+	AnErrInvalidTransport   = errors.New("invalid email transport")
+	AnErrEmailUnchanged     = errors.New("new email must be different")
+	AnErrBlobNotFound       = errors.New("blob not found")
+	AnErrInvalidBlobKey     = errors.New("invalid blob key")
+	AnErrInvalidBlobStore   = errors.New("invalid blob store")
+	AnErrUnsupportedImage   = errors.New("unsupported image type")
+	AnErrInvalidImage       = errors.New("invalid image")
+	AnErrImageTooLarge      = errors.New("image too large")
+	AnErrInvalidImageSize   = errors.New("invalid image size")
+	AnErrAvatarNotFound     = errors.New("avatar not found")
+	AnErrDocsNotFound       = errors.New("documentation not found")
+	AnErrInvalidLocale      = errors.New("invalid locale")
+	AnErrInvalidTimezone    = errors.New("invalid timezone")
+	AnErrInvalidAttributes  = errors.New("invalid profile attributes")
+	AnErrRequired           = errors.New("cannot be empty")
+	AnErrPasswordTooShort   = errors.New("must be at least 8 characters")
+	AnErrPasswordMismatch   = errors.New("passwords do not match")
+	AnErrUnauthorized       = errors.New("unauthorized")
+	AnErrForbidden          = errors.New("forbidden")
+	AnErrInsufficientScope  = errors.New("insufficient scope")
+	AnErrInvalidCSRFToken   = errors.New("invalid csrf token")
+	AnErrTokenRevoked       = errors.New("token revoked")
+	AnErrInvalidRequestBody = errors.New("invalid request body")
+	AnErrInvalidQuery       = errors.New("invalid query parameters")
+	AnErrInvalidType        = errors.New("invalid type")
+	AnErrInvalidValue       = errors.New("value not allowed")
+	AnErrRateLimited        = errors.New("too many requests")
+	AnErrInvalidRateLimit   = errors.New("invalid rate limit")
+
+	AnErrHumanVerificationRequired = errors.New("human verification required")
+	AnErrHumanVerificationFailed   = errors.New("human verification failed")
+	AnErrInvalidHumanVerifier      = errors.New("invalid human verifier")
+
+	AnErrInvalidTraceExporter = errors.New("invalid trace exporter")
+
+	AnErrInvalidLogFormat = errors.New("invalid log format")
+	AnErrInvalidLogLevel  = errors.New("invalid log level")
 )
 
 func Wrap(msg string, err error) error {
@@ -59,6 +111,7 @@ func (v ValidationErrors) Error() string {
 func NewValidationErrors() *ValidationErrors {
 	return &ValidationErrors{
 		FieldErrors: make(map[string]string),
+		FieldCodes:  make(map[string]string),
 	}
 }
 
@@ -66,6 +119,34 @@ func (v *ValidationErrors) Add(field, message string) {
 	v.FieldErrors[field] = message
 }
 
+// AddError registra a mensagem e o código do erro, que permitem ao cliente
+// tratar (e traduzir) cada campo sem depender do texto.
+func (v *ValidationErrors) AddError(field string, err error) {
+	if v.FieldErrors == nil {
+		v.FieldErrors = make(map[string]string)
+	}
+	if v.FieldCodes == nil {
+		v.FieldCodes = make(map[string]string)
+	}
+	v.FieldErrors[field] = err.Error()
+	v.FieldCodes[field] = Code(err)
+	if params := Params(err); len(params) > 0 {
+		if v.FieldParams == nil {
+			v.FieldParams = make(map[string]map[string]string)
+		}
+		v.FieldParams[field] = params
+	}
+}
+
+// FieldCode devolve o código do campo; campos registrados só com Add ficam
+// com CodeInvalid.
+func (v *ValidationErrors) FieldCode(field string) string {
+	if code, ok := v.FieldCodes[field]; ok && code != CodeInternal {
+		return code
+	}
+	return CodeInvalid
+}
+
 func (v *ValidationErrors) HasErrors() bool {
 	return len(v.FieldErrors) > 0
 }
@@ -0,0 +1,47 @@
+package msgerror
+
+import (
+	"errors"
+	"fmt"
+)
+
+// paramError anexa um parâmetro nomeado ao erro; as mensagens traduzidas
+// usam o parâmetro como "{nome}" (ex.: "no mínimo {min} caracteres").
+type paramError struct {
+	err   error
+	name  string
+	value string
+}
+
+// WithParam mantém a mensagem e a cadeia de erros de err.
+func WithParam(err error, name string, value any) error {
+	if err == nil {
+		return nil
+	}
+	return &paramError{err: err, name: name, value: fmt.Sprint(value)}
+}
+
+func (e *paramError) Error() string {
+	return e.err.Error()
+}
+
+func (e *paramError) Unwrap() error {
+	return e.err
+}
+
+// Params reúne os parâmetros anexados com WithParam; o mais externo vence.
+func Params(err error) map[string]string {
+	var params map[string]string
+	for err != nil {
+		if p, ok := err.(*paramError); ok {
+			if params == nil {
+				params = make(map[string]string)
+			}
+			if _, exists := params[p.name]; !exists {
+				params[p.name] = p.value
+			}
+		}
+		err = errors.Unwrap(err)
+	}
+	return params
+}
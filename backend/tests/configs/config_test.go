@@ -0,0 +1,490 @@
+package configs_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/configs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const strongSecret = "6f1c1f0b8a0e4d5cb0b5e3a1c2d4e6f8a9b0c1d2"
+
+func setRequiredEnv(t *testing.T) {
+	t.Setenv("JWT_SECRET", strongSecret)
+	t.Setenv("FROM_EMAIL", "no-reply@example.com")
+	t.Setenv("FRONTEND_RESET_URL", "https://app.example.com/reset-password")
+	t.Setenv("FRONTEND_EMAIL_CHANGE_URL", "https://app.example.com/email-change")
+}
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	setRequiredEnv(t)
+
+	cfg, err := configs.LoadConfig("")
+	require.NoError(t, err)
+
+	assert.Equal(t, "production", cfg.Server.Environment)
+	assert.False(t, cfg.DevEndpoints())
+	assert.Equal(t, "8080", cfg.Server.Port)
+	assert.Equal(t, []string{"http://localhost:3000"}, cfg.Server.CORSOrigins)
+	assert.Equal(t, 12*time.Hour, cfg.Server.CORSMaxAge)
+	assert.Equal(t, 15*time.Second, cfg.Server.ShutdownTimeout)
+	assert.Equal(t, "sqlite", cfg.Database.Driver)
+	assert.Equal(t, "localhost:6379", cfg.Redis.Addr)
+	assert.Equal(t, 24*time.Hour, cfg.JWT.TTL)
+	assert.Equal(t, "allowlist", cfg.Session.TokenStore)
+	assert.True(t, cfg.Session.CookieSecure)
+	assert.Equal(t, 587, cfg.SMTP.Port)
+	assert.Equal(t, "pt-BR", cfg.SMTP.DefaultLocale)
+	assert.Empty(t, cfg.SMTP.TemplatesDir)
+	assert.Equal(t, "smtp", cfg.SMTP.Transport)
+	assert.Equal(t, 100, cfg.SMTP.MailboxSize)
+	assert.Equal(t, time.Hour, cfg.PasswordReset.TTL)
+	assert.Equal(t, 24*time.Hour, cfg.EmailChange.TTL)
+	assert.Equal(t, 720*time.Hour, cfg.Account.DeletionGrace)
+	assert.Equal(t, time.Hour, cfg.Account.PurgeInterval)
+	assert.Equal(t, 15*time.Minute, cfg.Export.LinkTTL)
+	assert.Equal(t, "http://localhost:8080", cfg.Export.PublicURL)
+	assert.Equal(t, 5*time.Second, cfg.Outbox.PollInterval)
+	assert.Equal(t, 8, cfg.Outbox.MaxAttempts)
+	assert.Equal(t, time.Hour, cfg.Outbox.BackoffMax)
+	assert.Equal(t, "file", cfg.Blob.Store)
+	assert.Equal(t, "blobs", cfg.Blob.Dir)
+	assert.Equal(t, 5<<20, cfg.Avatar.MaxBytes)
+}
+
+func TestLoadConfig_EnvOverrides(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("WEB_SERVER_PORT", "9090")
+	t.Setenv("JWT_TTL", "15m")
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://a.example.com, https://b.example.com")
+	t.Setenv("OAUTH_CLIENTS", "billing:"+strongSecret)
+	t.Setenv("ADMIN_USER_IDS", "admin-1,admin-2")
+	t.Setenv("REDIS_DB", "2")
+
+	cfg, err := configs.LoadConfig("")
+	require.NoError(t, err)
+
+	assert.Equal(t, "9090", cfg.Server.Port)
+	assert.Equal(t, 15*time.Minute, cfg.JWT.TTL)
+	assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, cfg.Server.CORSOrigins)
+	assert.Equal(t, map[string]string{"billing": strongSecret}, cfg.OAuthClients())
+	assert.Equal(t, map[string]bool{"admin-1": true, "admin-2": true}, cfg.AdminUserIDs())
+	assert.Equal(t, 2, cfg.Redis.DB)
+}
+
+func TestLoadConfig_YAMLWithEnvPrecedence(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("WEB_SERVER_PORT", "7070")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+server:
+  port: "6060"
+  cors_origins:
+    - https://yaml.example.com
+database:
+  driver: postgres
+  user: app
+  name: auth
+jwt:
+  ttl: 2h
+`), 0o600))
+
+	cfg, err := configs.LoadConfig(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "7070", cfg.Server.Port, "variável de ambiente tem prioridade sobre o YAML")
+	assert.Equal(t, []string{"https://yaml.example.com"}, cfg.Server.CORSOrigins)
+	assert.Equal(t, "postgres", cfg.Database.Driver)
+	assert.Equal(t, 2*time.Hour, cfg.JWT.TTL)
+}
+
+func TestLoadConfig_ReportsEveryBadKey(t *testing.T) {
+	t.Setenv("JWT_SECRET", "secret")
+	t.Setenv("FROM_EMAIL", "")
+	t.Setenv("FRONTEND_RESET_URL", "https://app.example.com/reset-password")
+	t.Setenv("FRONTEND_EMAIL_CHANGE_URL", "https://app.example.com/email-change")
+	t.Setenv("DB_DRIVER", "oracle")
+	t.Setenv("TOKEN_STORE_MODE", "bogus")
+	t.Setenv("AUTH_COOKIE_MODE", "true")
+	t.Setenv("CSRF_SECRET", "short")
+	t.Setenv("OAUTH_CLIENTS", "no-secret")
+
+	_, err := configs.LoadConfig("")
+
+	var cfgErr *configs.ConfigError
+	require.True(t, errors.As(err, &cfgErr))
+	assert.ElementsMatch(t, []string{
+		"DB_DRIVER", "JWT_SECRET", "TOKEN_STORE_MODE", "CSRF_SECRET", "OAUTH_CLIENTS", "FROM_EMAIL",
+	}, cfgErr.Keys())
+	assert.Contains(t, err.Error(), "JWT_SECRET (jwt.secret): uses a well-known placeholder value")
+	assert.Contains(t, err.Error(), "CSRF_SECRET (session.csrf_secret): must be at least 32 characters")
+}
+
+func TestLoadConfig_InvalidTypedValue(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("JWT_TTL", "forever")
+	t.Setenv("SMTP_PORT", "abc")
+	t.Setenv("TRACING_SAMPLE_RATIO", "abc")
+
+	_, err := configs.LoadConfig("")
+
+	var cfgErr *configs.ConfigError
+	require.True(t, errors.As(err, &cfgErr))
+	assert.ElementsMatch(t, []string{"JWT_TTL", "SMTP_PORT", "TRACING_SAMPLE_RATIO"}, cfgErr.Keys())
+	assert.Contains(t, err.Error(), `TRACING_SAMPLE_RATIO (tracing.sample_ratio): invalid value "abc"`)
+}
+
+func TestLoadConfig_SameSiteNoneRequiresSecure(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("AUTH_COOKIE_SAMESITE", "None")
+	t.Setenv("AUTH_COOKIE_SECURE", "false")
+
+	_, err := configs.LoadConfig("")
+
+	var cfgErr *configs.ConfigError
+	require.True(t, errors.As(err, &cfgErr))
+	assert.Equal(t, []string{"AUTH_COOKIE_SAMESITE"}, cfgErr.Keys())
+}
+
+func TestLoadConfig_OutboxBackoffBounds(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("OUTBOX_BACKOFF_BASE", "10m")
+	t.Setenv("OUTBOX_BACKOFF_MAX", "1m")
+	t.Setenv("OUTBOX_MAX_ATTEMPTS", "0")
+
+	_, err := configs.LoadConfig("")
+
+	var cfgErr *configs.ConfigError
+	require.True(t, errors.As(err, &cfgErr))
+	assert.ElementsMatch(t, []string{"OUTBOX_BACKOFF_MAX", "OUTBOX_MAX_ATTEMPTS"}, cfgErr.Keys())
+}
+
+func TestLoadConfig_ShutdownTimeout(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("SERVER_SHUTDOWN_TIMEOUT", "0s")
+
+	_, err := configs.LoadConfig("")
+
+	var cfgErr *configs.ConfigError
+	require.True(t, errors.As(err, &cfgErr))
+	assert.Equal(t, []string{"SERVER_SHUTDOWN_TIMEOUT"}, cfgErr.Keys())
+}
+
+func TestLoadConfig_EmailTransport(t *testing.T) {
+	t.Run("Transporte desconhecido", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("EMAIL_TRANSPORT", "pigeon")
+		t.Setenv("EMAIL_MAILBOX_SIZE", "0")
+
+		_, err := configs.LoadConfig("")
+
+		var cfgErr *configs.ConfigError
+		require.True(t, errors.As(err, &cfgErr))
+		assert.ElementsMatch(t, []string{"EMAIL_TRANSPORT", "EMAIL_MAILBOX_SIZE"}, cfgErr.Keys())
+	})
+
+	t.Run("Nome sem diferenciar maiúsculas", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("APP_ENV", "Development")
+		t.Setenv("EMAIL_TRANSPORT", "Mailbox")
+
+		cfg, err := configs.LoadConfig("")
+		require.NoError(t, err)
+		assert.Equal(t, "mailbox", cfg.SMTP.Transport)
+		assert.True(t, cfg.DevEndpoints())
+	})
+
+	t.Run("Mailbox recusada em produção", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("EMAIL_TRANSPORT", "mailbox")
+
+		_, err := configs.LoadConfig("")
+
+		var cfgErr *configs.ConfigError
+		require.True(t, errors.As(err, &cfgErr))
+		assert.Equal(t, []string{"EMAIL_TRANSPORT"}, cfgErr.Keys())
+	})
+
+	t.Run("Ambiente desconhecido", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("APP_ENV", "staging")
+
+		_, err := configs.LoadConfig("")
+
+		var cfgErr *configs.ConfigError
+		require.True(t, errors.As(err, &cfgErr))
+		assert.Equal(t, []string{"APP_ENV"}, cfgErr.Keys())
+	})
+}
+
+func TestLoadConfig_BlobStore(t *testing.T) {
+	t.Run("S3 exige endpoint, bucket e credenciais", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("BLOB_STORE", "S3")
+		t.Setenv("S3_ENDPOINT", "localhost:9000")
+
+		_, err := configs.LoadConfig("")
+
+		var cfgErr *configs.ConfigError
+		require.True(t, errors.As(err, &cfgErr))
+		assert.Equal(t, []string{"S3_ENDPOINT", "S3_BUCKET", "S3_ACCESS_KEY", "S3_SECRET_KEY"}, cfgErr.Keys())
+	})
+
+	t.Run("S3 completo", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("BLOB_STORE", "s3")
+		t.Setenv("S3_ENDPOINT", "http://localhost:9000")
+		t.Setenv("S3_BUCKET", "avatars")
+		t.Setenv("S3_ACCESS_KEY", "minio")
+		t.Setenv("S3_SECRET_KEY", "minio-secret")
+
+		cfg, err := configs.LoadConfig("")
+		require.NoError(t, err)
+		assert.Equal(t, "us-east-1", cfg.Blob.S3Region)
+	})
+
+	t.Run("Armazenamento e limites inválidos", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("BLOB_STORE", "ftp")
+		t.Setenv("AVATAR_MAX_BYTES", "0")
+
+		_, err := configs.LoadConfig("")
+
+		var cfgErr *configs.ConfigError
+		require.True(t, errors.As(err, &cfgErr))
+		assert.Equal(t, []string{"BLOB_STORE", "AVATAR_MAX_BYTES"}, cfgErr.Keys())
+	})
+}
+
+func TestLoadDatabaseConfig_IgnoresOtherSubsystems(t *testing.T) {
+	t.Setenv("JWT_SECRET", "")
+	t.Setenv("DB_DRIVER", "postgres")
+	t.Setenv("DB_USER", "app")
+	t.Setenv("DB_NAME", "auth")
+
+	cfg, err := configs.LoadDatabaseConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, "postgres", cfg.Driver)
+
+	t.Setenv("DB_USER", "")
+	_, err = configs.LoadDatabaseConfig("")
+
+	var cfgErr *configs.ConfigError
+	require.True(t, errors.As(err, &cfgErr))
+	assert.Equal(t, []string{"DB_USER"}, cfgErr.Keys())
+}
+
+func TestLoadConfig_RateLimit(t *testing.T) {
+	t.Run("Regras padrão", func(t *testing.T) {
+		setRequiredEnv(t)
+
+		cfg, err := configs.LoadConfig("")
+		require.NoError(t, err)
+		assert.True(t, cfg.RateLimit.Enabled)
+		assert.Equal(t, "redis", cfg.RateLimit.Backend)
+		assert.Contains(t, cfg.RateLimitRules(), configs.RateLimitRule{
+			Method: "POST", Path: "/auth/login", Limit: 5, Window: 15 * time.Minute, Key: "email",
+		})
+	})
+
+	t.Run("Regras e allowlist do ambiente", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("RATE_LIMIT_BACKEND", "Memory")
+		t.Setenv("RATE_LIMIT_RULES", "post /auth/login 3/1m ip, GET /user/me 100/1h user")
+		t.Setenv("RATE_LIMIT_ALLOWLIST", "10.0.0.0/8,127.0.0.1")
+
+		cfg, err := configs.LoadConfig("")
+		require.NoError(t, err)
+		assert.Equal(t, "memory", cfg.RateLimit.Backend)
+		assert.Equal(t, []configs.RateLimitRule{
+			{Method: "POST", Path: "/auth/login", Limit: 3, Window: time.Minute, Key: "ip"},
+			{Method: "GET", Path: "/user/me", Limit: 100, Window: time.Hour, Key: "user"},
+		}, cfg.RateLimitRules())
+
+		allowlist := cfg.RateLimitAllowlist()
+		require.Len(t, allowlist, 2)
+		assert.Equal(t, "10.0.0.0/8", allowlist[0].String())
+		assert.Equal(t, "127.0.0.1/32", allowlist[1].String())
+	})
+
+	t.Run("Valores inválidos", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("RATE_LIMIT_ALGORITHM", "leaky_bucket")
+		t.Setenv("RATE_LIMIT_RULES", "POST /auth/login 5/15m cookie")
+		t.Setenv("RATE_LIMIT_ALLOWLIST", "intranet")
+
+		_, err := configs.LoadConfig("")
+
+		var cfgErr *configs.ConfigError
+		require.True(t, errors.As(err, &cfgErr))
+		assert.Equal(t, []string{"RATE_LIMIT_ALGORITHM", "RATE_LIMIT_RULES", "RATE_LIMIT_ALLOWLIST"}, cfgErr.Keys())
+	})
+}
+
+func TestParseRateLimitRule(t *testing.T) {
+	for _, raw := range []string{
+		"POST /auth/login 5/15m",
+		"POST auth/login 5/15m ip",
+		"POST /auth/login five/15m ip",
+		"POST /auth/login 5/15 ip",
+		"POST /auth/login 0/1m ip",
+	} {
+		_, err := configs.ParseRateLimitRule(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestLoadConfig_HumanVerifier(t *testing.T) {
+	t.Run("Prova de trabalho por padrão", func(t *testing.T) {
+		setRequiredEnv(t)
+
+		cfg, err := configs.LoadConfig("")
+		require.NoError(t, err)
+		assert.Equal(t, "pow", cfg.Human.Verifier)
+		assert.Equal(t, 16, cfg.Human.PowDifficulty)
+		assert.Equal(t, 5, cfg.Human.RiskThreshold)
+	})
+
+	t.Run("Captcha exige site key e segredo", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("HUMAN_VERIFIER", "Turnstile")
+
+		_, err := configs.LoadConfig("")
+
+		var cfgErr *configs.ConfigError
+		require.True(t, errors.As(err, &cfgErr))
+		assert.Equal(t, []string{"HUMAN_CAPTCHA_SITE_KEY", "HUMAN_CAPTCHA_SECRET"}, cfgErr.Keys())
+	})
+
+	t.Run("Valores inválidos", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("HUMAN_POW_DIFFICULTY", "40")
+		t.Setenv("HUMAN_RISK_THRESHOLD", "-1")
+
+		_, err := configs.LoadConfig("")
+
+		var cfgErr *configs.ConfigError
+		require.True(t, errors.As(err, &cfgErr))
+		assert.Equal(t, []string{"HUMAN_POW_DIFFICULTY", "HUMAN_RISK_THRESHOLD"}, cfgErr.Keys())
+	})
+}
+
+func TestLoadConfig_Metrics(t *testing.T) {
+	setRequiredEnv(t)
+
+	cfg, err := configs.LoadConfig("")
+	require.NoError(t, err)
+	assert.False(t, cfg.Metrics.Enabled)
+	assert.Empty(t, cfg.Metrics.Token)
+
+	t.Setenv("METRICS_ENABLED", "true")
+	t.Setenv("METRICS_TOKEN", " scrape-secret ")
+
+	cfg, err = configs.LoadConfig("")
+	require.NoError(t, err)
+	assert.True(t, cfg.Metrics.Enabled)
+	assert.Equal(t, "scrape-secret", cfg.Metrics.Token)
+
+	// Sem token o endpoint aberto só é aceito em development e test
+	t.Setenv("METRICS_TOKEN", "")
+	_, err = configs.LoadConfig("")
+	var cfgErr *configs.ConfigError
+	require.True(t, errors.As(err, &cfgErr))
+	assert.Equal(t, []string{"METRICS_TOKEN"}, cfgErr.Keys())
+
+	t.Setenv("APP_ENV", "development")
+	cfg, err = configs.LoadConfig("")
+	require.NoError(t, err)
+	assert.True(t, cfg.Metrics.Enabled)
+}
+
+func TestLoadConfig_Tracing(t *testing.T) {
+	setRequiredEnv(t)
+
+	cfg, err := configs.LoadConfig("")
+	require.NoError(t, err)
+	assert.False(t, cfg.Tracing.Enabled)
+	assert.Equal(t, "otlp", cfg.Tracing.Exporter)
+	assert.Equal(t, "http://localhost:4318", cfg.Tracing.OTLPEndpoint)
+	assert.Equal(t, 1.0, cfg.Tracing.SampleRatio)
+
+	t.Setenv("TRACING_ENABLED", "true")
+	t.Setenv("TRACING_EXPORTER", "STDOUT")
+	t.Setenv("TRACING_SAMPLE_RATIO", "0.25")
+
+	cfg, err = configs.LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, "stdout", cfg.Tracing.Exporter)
+	assert.Equal(t, 0.25, cfg.Tracing.SampleRatio)
+}
+
+func TestLoadConfig_InvalidTracing(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("TRACING_ENABLED", "true")
+	t.Setenv("TRACING_OTLP_ENDPOINT", "localhost:4318")
+	t.Setenv("TRACING_SAMPLE_RATIO", "2")
+
+	_, err := configs.LoadConfig("")
+
+	var cfgErr *configs.ConfigError
+	require.True(t, errors.As(err, &cfgErr))
+	assert.ElementsMatch(t, []string{"TRACING_OTLP_ENDPOINT", "TRACING_SAMPLE_RATIO"}, cfgErr.Keys())
+
+	t.Setenv("TRACING_OTLP_ENDPOINT", "http://collector:4318/")
+	t.Setenv("TRACING_SAMPLE_RATIO", "1")
+	t.Setenv("TRACING_EXPORTER", "zipkin")
+
+	_, err = configs.LoadConfig("")
+	require.True(t, errors.As(err, &cfgErr))
+	assert.Equal(t, []string{"TRACING_EXPORTER"}, cfgErr.Keys())
+}
+
+func TestLoadConfig_Log(t *testing.T) {
+	setRequiredEnv(t)
+
+	cfg, err := configs.LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, "json", cfg.Log.Format)
+	assert.Equal(t, "info", cfg.Log.Level)
+
+	t.Setenv("LOG_FORMAT", "TEXT")
+	t.Setenv("LOG_LEVEL", "Debug")
+
+	cfg, err = configs.LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, "text", cfg.Log.Format)
+	assert.Equal(t, "debug", cfg.Log.Level)
+
+	t.Setenv("LOG_FORMAT", "xml")
+	t.Setenv("LOG_LEVEL", "verbose")
+
+	_, err = configs.LoadConfig("")
+	var cfgErr *configs.ConfigError
+	require.True(t, errors.As(err, &cfgErr))
+	assert.ElementsMatch(t, []string{"LOG_FORMAT", "LOG_LEVEL"}, cfgErr.Keys())
+}
+
+func TestLoadConfig_TrustedProxies(t *testing.T) {
+	setRequiredEnv(t)
+
+	cfg, err := configs.LoadConfig("")
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Server.TrustedProxies)
+
+	t.Setenv("TRUSTED_PROXIES", " 10.0.0.0/8, 192.0.2.1 ")
+	cfg, err = configs.LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.0/8", "192.0.2.1"}, cfg.Server.TrustedProxies)
+
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8,proxy.internal")
+	_, err = configs.LoadConfig("")
+	var cfgErr *configs.ConfigError
+	require.True(t, errors.As(err, &cfgErr))
+	assert.Equal(t, []string{"TRUSTED_PROXIES"}, cfgErr.Keys())
+}
@@ -0,0 +1,40 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/database"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialector_SelectsDriver(t *testing.T) {
+	cases := map[string]string{
+		"":         "sqlite",
+		"sqlite":   "sqlite",
+		"postgres": "postgres",
+		"mysql":    "mysql",
+	}
+
+	for driver, expected := range cases {
+		dialector, err := database.Dialector(database.Config{Driver: driver, Name: "app"})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, dialector.Name())
+	}
+}
+
+func TestDialector_UnknownDriver(t *testing.T) {
+	_, err := database.Dialector(database.Config{Driver: "oracle"})
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidDBDriver)
+}
+
+func TestDSNs(t *testing.T) {
+	cfg := database.Config{User: "app", Password: "secret", Name: "auth"}
+
+	assert.Equal(t,
+		"host=localhost port=5432 user=app password=secret dbname=auth sslmode=disable",
+		database.PostgresDSN(cfg))
+	assert.Equal(t,
+		"app:secret@tcp(localhost:3306)/auth?charset=utf8mb4&parseTime=True&loc=UTC",
+		database.MySQLDSN(cfg))
+}
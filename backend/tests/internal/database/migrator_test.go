@@ -0,0 +1,357 @@
+package database_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/database"
+	repository "github.com/eskokado/startup-auth-go/backend/internal/repositories"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	repo "github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// testDatabases devolve SQLite sempre e PostgreSQL quando TEST_POSTGRES_DSN estiver definido.
+func testDatabases(t *testing.T) map[string]*gorm.DB {
+	t.Helper()
+
+	dbs := make(map[string]*gorm.DB)
+
+	sqliteDB, err := database.Open(database.Config{
+		Driver: database.DriverSQLite,
+		Name:   filepath.Join(t.TempDir(), "test.db"),
+	})
+	require.NoError(t, err)
+	dbs["sqlite"] = sqliteDB
+
+	if dsn := os.Getenv("TEST_POSTGRES_DSN"); dsn != "" {
+		pgDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+		require.NoError(t, err)
+		dbs["postgres"] = pgDB
+	}
+
+	return dbs
+}
+
+func TestMigrator_UpDownStatus(t *testing.T) {
+	for name, db := range testDatabases(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			migrator, err := database.NewMigrator(db)
+			require.NoError(t, err)
+
+			done, err := migrator.Up(ctx)
+			require.NoError(t, err)
+			t.Cleanup(func() { _, _ = migrator.Down(ctx, len(done)) })
+
+			assert.True(t, db.Migrator().HasTable("gorm_users"))
+			assert.True(t, db.Migrator().HasTable("gorm_api_keys"))
+			assert.True(t, db.Migrator().HasTable("gorm_audit_events"))
+
+			// Reexecutar não aplica nada
+			again, err := migrator.Up(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, again)
+
+			statuses, err := migrator.Status(ctx)
+			require.NoError(t, err)
+			for _, s := range statuses {
+				assert.True(t, s.Applied, "%04d_%s", s.Version, s.Name)
+			}
+
+			reverted, err := migrator.Down(ctx, 1)
+			require.NoError(t, err)
+			require.Len(t, reverted, 1)
+			assert.Equal(t, statuses[len(statuses)-1].Version, reverted[0].Version)
+			// Só a última é desfeita
+			assert.False(t, db.Migrator().HasColumn(&repository.GormOutboxMessage{}, "user_id"))
+			assert.True(t, db.Migrator().HasColumn(&repository.GormOutboxMessage{}, "payload"))
+
+			statuses, err = migrator.Status(ctx)
+			require.NoError(t, err)
+			assert.False(t, statuses[len(statuses)-1].Applied)
+		})
+	}
+}
+
+func TestMigrator_SchemaMatchesRepositories(t *testing.T) {
+	for name, db := range testDatabases(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			migrator, err := database.NewMigrator(db)
+			require.NoError(t, err)
+			done, err := migrator.Up(ctx)
+			require.NoError(t, err)
+			t.Cleanup(func() { _, _ = migrator.Down(ctx, len(done)) })
+
+			userName, _ := vo.NewName("Maria Silva", 3, 50)
+			email, _ := vo.NewEmail("maria@test.com")
+			hash, _ := vo.NewPasswordHash("$2a$10$0MwrQkGO0Bw6dYpVfiX4mefEVgTdgtCYCJ7LxltXfzj5qscr4sive")
+			imageURL, _ := vo.NewURL("https://example.com/maria.png")
+
+			users := repository.NewGormUserRepository(db)
+			_, err = users.Save(ctx, &entity.User{
+				ID:           vo.NewID(),
+				Name:         userName,
+				Email:        email,
+				PasswordHash: hash,
+				ImageURL:     imageURL,
+			})
+			require.NoError(t, err)
+
+			found, err := users.GetByEmail(ctx, email)
+			require.NoError(t, err)
+			require.NotNil(t, found)
+			assert.Equal(t, userName, found.Name)
+
+			audit := repository.NewGormAuditRepository(db)
+			require.NoError(t, audit.Log(ctx, entity.NewAuditEvent(entity.AuditActionLogin, nil)))
+		})
+	}
+}
+
+func TestMigrator_RejectsIncompleteMigration(t *testing.T) {
+	db, err := database.Open(database.Config{Name: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+
+	_, err = database.NewMigratorFromFS(db, fstest.MapFS{
+		"0001_create_things.up.sql": {Data: []byte("CREATE TABLE things (id INTEGER)")},
+	})
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidMigration)
+
+	_, err = database.NewMigratorFromFS(db, fstest.MapFS{
+		"create_things.up.sql": {Data: []byte("CREATE TABLE things (id INTEGER)")},
+	})
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidMigration)
+}
+
+func TestMigrator_FailedMigrationIsNotRecorded(t *testing.T) {
+	db, err := database.Open(database.Config{Name: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+
+	migrator, err := database.NewMigratorFromFS(db, fstest.MapFS{
+		"0001_ok.up.sql":     {Data: []byte("CREATE TABLE ok_table (id INTEGER)")},
+		"0001_ok.down.sql":   {Data: []byte("DROP TABLE ok_table")},
+		"0002_fail.up.sql":   {Data: []byte("CREATE TABLE broken (")},
+		"0002_fail.down.sql": {Data: []byte("SELECT 1")},
+	})
+	require.NoError(t, err)
+
+	done, err := migrator.Up(context.Background())
+	assert.Error(t, err)
+	assert.Len(t, done, 1)
+
+	statuses, err := migrator.Status(context.Background())
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[1].Applied)
+}
+
+func TestMigrator_UserSoftDeleteLifecycle(t *testing.T) {
+	for name, db := range testDatabases(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			migrator, err := database.NewMigrator(db)
+			require.NoError(t, err)
+			done, err := migrator.Up(ctx)
+			require.NoError(t, err)
+			t.Cleanup(func() { _, _ = migrator.Down(ctx, len(done)) })
+
+			userName, _ := vo.NewName("Maria Silva", 3, 50)
+			email, _ := vo.NewEmail("maria@test.com")
+			hash, _ := vo.NewPasswordHash("$2a$10$0MwrQkGO0Bw6dYpVfiX4mefEVgTdgtCYCJ7LxltXfzj5qscr4sive")
+			imageURL, _ := vo.NewURL("https://example.com/maria.png")
+
+			users := repository.NewGormUserRepository(db)
+			saved, err := users.Save(ctx, &entity.User{
+				ID:           vo.NewID(),
+				Name:         userName,
+				Email:        email,
+				PasswordHash: hash,
+				ImageURL:     imageURL,
+			})
+			require.NoError(t, err)
+
+			// Desativada: invisível às consultas normais, mas restaurável
+			deactivatedAt := time.Now().Add(-2 * time.Hour)
+			require.NoError(t, users.Deactivate(ctx, saved.ID, deactivatedAt))
+
+			found, err := users.GetByEmail(ctx, email)
+			require.NoError(t, err)
+			assert.Nil(t, found)
+
+			deactivated, err := users.GetDeactivatedByEmail(ctx, email)
+			require.NoError(t, err)
+			require.NotNil(t, deactivated)
+			assert.True(t, deactivated.IsDeactivated())
+
+			pending, err := users.ListDeactivatedBefore(ctx, time.Now().Add(-time.Hour), 10)
+			require.NoError(t, err)
+			assert.Len(t, pending, 1)
+			pending, err = users.ListDeactivatedBefore(ctx, time.Now().Add(-3*time.Hour), 10)
+			require.NoError(t, err)
+			assert.Empty(t, pending)
+
+			require.NoError(t, users.Restore(ctx, saved.ID))
+			found, err = users.GetByID(ctx, saved.ID)
+			require.NoError(t, err)
+			require.NotNil(t, found)
+			assert.False(t, found.IsDeactivated())
+
+			// Apagamento definitivo mantém a auditoria sob um pseudônimo, sem e-mail nem IP
+			audit := repository.NewGormAuditRepository(db)
+			event := entity.NewAuditEvent(entity.AuditActionLogin, nil)
+			event.ActorID, event.Target, event.IP = saved.ID.String(), email.String(), "198.51.100.1"
+			require.NoError(t, audit.Log(ctx, event))
+			pseudonym := vo.NewID().String()
+
+			require.NoError(t, audit.Anonymize(ctx, []string{saved.ID.String(), email.String()}, pseudonym))
+			require.NoError(t, users.Erase(ctx, saved.ID))
+
+			found, err = users.GetByID(ctx, saved.ID)
+			require.NoError(t, err)
+			assert.Nil(t, found)
+			deactivated, err = users.GetDeactivatedByEmail(ctx, email)
+			require.NoError(t, err)
+			assert.Nil(t, deactivated)
+
+			events, err := audit.List(ctx, repo.AuditFilter{Target: pseudonym})
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.Equal(t, pseudonym, events[0].ActorID)
+			assert.Empty(t, events[0].IP)
+			total, err := audit.Count(ctx, repo.AuditFilter{ActorID: saved.ID.String()})
+			require.NoError(t, err)
+			assert.Zero(t, total)
+			events, err = audit.List(ctx, repo.AuditFilter{Target: email.String()})
+			require.NoError(t, err)
+			assert.Empty(t, events)
+		})
+	}
+}
+
+func TestMigrator_UserOptimisticLocking(t *testing.T) {
+	for name, db := range testDatabases(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			migrator, err := database.NewMigrator(db)
+			require.NoError(t, err)
+			done, err := migrator.Up(ctx)
+			require.NoError(t, err)
+			t.Cleanup(func() { _, _ = migrator.Down(ctx, len(done)) })
+
+			userName, _ := vo.NewName("Maria Silva", 3, 50)
+			email, _ := vo.NewEmail("maria@test.com")
+			hash, _ := vo.NewPasswordHash("$2a$10$0MwrQkGO0Bw6dYpVfiX4mefEVgTdgtCYCJ7LxltXfzj5qscr4sive")
+
+			users := repository.NewGormUserRepository(db)
+			saved, err := users.Save(ctx, &entity.User{
+				ID:           vo.NewID(),
+				Name:         userName,
+				Email:        email,
+				PasswordHash: hash,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 1, saved.Version)
+
+			// Duas requisições leem a mesma versão
+			first, err := users.GetByID(ctx, saved.ID)
+			require.NoError(t, err)
+			second, err := users.GetByID(ctx, saved.ID)
+			require.NoError(t, err)
+
+			require.NoError(t, first.GeneratePasswordResetToken(time.Hour))
+			updated, err := users.Update(ctx, first, repo.UserFieldPasswordReset)
+			require.NoError(t, err)
+			assert.Equal(t, 2, updated.Version)
+
+			newName, _ := vo.NewName("Maria Souza", 3, 50)
+			renamed, err := second.WithName(newName)
+			require.NoError(t, err)
+			_, err = users.Update(ctx, renamed, repo.UserFieldName)
+			assert.ErrorIs(t, err, msgerror.AnErrConflict)
+
+			// Relendo, a escrita parcial não apaga o token gravado antes
+			current, err := users.GetByID(ctx, saved.ID)
+			require.NoError(t, err)
+			renamed, err = current.WithName(newName)
+			require.NoError(t, err)
+			_, err = users.Update(ctx, renamed, repo.UserFieldName)
+			require.NoError(t, err)
+
+			current, err = users.GetByID(ctx, saved.ID)
+			require.NoError(t, err)
+			assert.Equal(t, newName, current.Name)
+			assert.Equal(t, first.PasswordResetToken, current.PasswordResetToken)
+			assert.Equal(t, 3, current.Version)
+
+			current.Locale, _ = vo.NewLocale("en-US")
+			current.Timezone, _ = vo.NewTimezone("America/Sao_Paulo")
+			current.Attributes, _ = vo.NewProfileAttributes(map[string]string{"company": "Acme", "title": "Dev"})
+			_, err = users.Update(ctx, current, repo.UserFieldLocale, repo.UserFieldTimezone, repo.UserFieldAttributes)
+			require.NoError(t, err)
+			current, err = users.GetByID(ctx, saved.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "en-US", current.Locale.String())
+			assert.Equal(t, "America/Sao_Paulo", current.Timezone.String())
+			assert.Equal(t, map[string]string{"company": "Acme", "title": "Dev"}, current.Attributes.Map())
+
+			// Sem atributos a coluna volta a ficar vazia
+			current.Attributes = vo.ProfileAttributes{}
+			_, err = users.Update(ctx, current, repo.UserFieldAttributes)
+			require.NoError(t, err)
+			current, err = users.GetByID(ctx, saved.ID)
+			require.NoError(t, err)
+			assert.True(t, current.Attributes.IsEmpty())
+
+			// Troca de e-mail: tokens localizam o usuário e o índice único vale
+			other, _ := vo.NewEmail("joao@test.com")
+			_, err = users.Save(ctx, &entity.User{
+				ID:           vo.NewID(),
+				Name:         userName,
+				Email:        other,
+				PasswordHash: hash,
+			})
+			require.NoError(t, err)
+
+			pending, _ := vo.NewEmail("maria.nova@test.com")
+			require.NoError(t, current.RequestEmailChange(pending, time.Hour))
+			current, err = users.Update(ctx, current, repo.UserFieldEmailChange)
+			require.NoError(t, err)
+
+			byToken, err := users.GetByEmailChangeToken(ctx, current.EmailChangeToken)
+			require.NoError(t, err)
+			require.NotNil(t, byToken)
+			assert.Equal(t, pending, byToken.PendingEmail)
+			byCancel, err := users.GetByEmailChangeCancelToken(ctx, current.EmailChangeCancelToken)
+			require.NoError(t, err)
+			require.NotNil(t, byCancel)
+			assert.Equal(t, current.ID, byCancel.ID)
+			none, err := users.GetByEmailChangeToken(ctx, "")
+			require.NoError(t, err)
+			assert.Nil(t, none)
+
+			taken := *current
+			taken.Email = other
+			_, err = users.Update(ctx, &taken, repo.UserFieldEmail)
+			assert.ErrorIs(t, err, msgerror.AnErrUserExists)
+
+			_, err = users.Update(ctx, current, "unknown")
+			assert.Error(t, err)
+
+			missing := *current
+			missing.ID = vo.NewID()
+			_, err = users.Update(ctx, &missing, repo.UserFieldName)
+			assert.ErrorIs(t, err, msgerror.AnErrUserNotFound)
+		})
+	}
+}
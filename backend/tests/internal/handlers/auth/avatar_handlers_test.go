@@ -0,0 +1,166 @@
+package handlers_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func avatarRequest(t *testing.T, field string, content []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(field, "me.png")
+	require.NoError(t, err)
+	_, _ = part.Write(content)
+	require.NoError(t, writer.Close())
+
+	req, _ := http.NewRequest(http.MethodPut, "/user/avatar", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestUploadAvatarHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := vo.NewID()
+	content := []byte("image-bytes")
+
+	serve := func(uc *mocks.MockUploadAvatarUseCase, maxBytes int64, req *http.Request) *httptest.ResponseRecorder {
+		handler := handlers.NewUploadAvatarHandler(uc, maxBytes)
+		router := newTestRouter()
+		router.PUT("/user/avatar", func(c *gin.Context) {
+			c.Set("userID", userID.String())
+			handler.Handle(c)
+		})
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	t.Run("Success - Returns the new image URL", func(t *testing.T) {
+		uc := new(mocks.MockUploadAvatarUseCase)
+		uc.On("Execute", mock.Anything, userID, content).Return("https://api.example.com/avatars/abc", nil)
+
+		resp := serve(uc, 1024, avatarRequest(t, "avatar", content))
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.JSONEq(t, `{"image_url": "https://api.example.com/avatars/abc"}`, resp.Body.String())
+		uc.AssertExpectations(t)
+	})
+
+	t.Run("Error - Missing file field", func(t *testing.T) {
+		resp := serve(new(mocks.MockUploadAvatarUseCase), 1024, avatarRequest(t, "photo", content))
+
+		assertProblem(t, resp, http.StatusBadRequest, "invalid_request_body")
+	})
+
+	t.Run("Error - File above the limit", func(t *testing.T) {
+		uc := new(mocks.MockUploadAvatarUseCase)
+
+		resp := serve(uc, 4, avatarRequest(t, "avatar", content))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+		uc.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Body far above the limit", func(t *testing.T) {
+		resp := serve(new(mocks.MockUploadAvatarUseCase), 4, avatarRequest(t, "avatar", make([]byte, 200<<10)))
+
+		assertProblem(t, resp, http.StatusRequestEntityTooLarge, "image_too_large")
+	})
+
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"Unsupported type", msgerror.AnErrUnsupportedImage, http.StatusUnsupportedMediaType},
+		{"Corrupted image", msgerror.AnErrInvalidImage, http.StatusBadRequest},
+		{"Dimensions too large", msgerror.AnErrImageTooLarge, http.StatusRequestEntityTooLarge},
+		{"User not found", msgerror.AnErrUserNotFound, http.StatusNotFound},
+		{"Concurrent modification", msgerror.AnErrConflict, http.StatusConflict},
+		{"Internal error", assert.AnError, http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		t.Run("Error - "+tc.name, func(t *testing.T) {
+			uc := new(mocks.MockUploadAvatarUseCase)
+			uc.On("Execute", mock.Anything, userID, content).Return("", tc.err)
+
+			resp := serve(uc, 1024, avatarRequest(t, "avatar", content))
+
+			assert.Equal(t, tc.code, resp.Code)
+		})
+	}
+
+	t.Run("Error - Missing user in context", func(t *testing.T) {
+		router := newTestRouter()
+		router.PUT("/user/avatar", handlers.NewUploadAvatarHandler(nil, 1024).Handle)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, avatarRequest(t, "avatar", content))
+
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+}
+
+func TestGetAvatarHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(uc *mocks.MockGetAvatarUseCase, target string) *httptest.ResponseRecorder {
+		router := newTestRouter()
+		router.GET("/avatars/:id", handlers.NewGetAvatarHandler(uc).Handle)
+		req, _ := http.NewRequest(http.MethodGet, target, nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	t.Run("Success - Immutable cache headers", func(t *testing.T) {
+		uc := new(mocks.MockGetAvatarUseCase)
+		uc.On("Execute", mock.Anything, "abc", 64).Return(dto.AvatarImage{ContentType: "image/png", Data: []byte("png")}, nil)
+
+		resp := serve(uc, "/avatars/abc?size=64")
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "image/png", resp.Header().Get("Content-Type"))
+		assert.Equal(t, "public, max-age=31536000, immutable", resp.Header().Get("Cache-Control"))
+		assert.Equal(t, "nosniff", resp.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, "png", resp.Body.String())
+	})
+
+	t.Run("Error - Non numeric size", func(t *testing.T) {
+		resp := serve(new(mocks.MockGetAvatarUseCase), "/avatars/abc?size=big")
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"Size not generated", msgerror.AnErrInvalidImageSize, http.StatusBadRequest},
+		{"Not found", msgerror.AnErrAvatarNotFound, http.StatusNotFound},
+		{"Internal error", assert.AnError, http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		t.Run("Error - "+tc.name, func(t *testing.T) {
+			uc := new(mocks.MockGetAvatarUseCase)
+			uc.On("Execute", mock.Anything, "abc", 0).Return(dto.AvatarImage{}, tc.err)
+
+			resp := serve(uc, "/avatars/abc")
+
+			assert.Equal(t, tc.code, resp.Code)
+		})
+	}
+}
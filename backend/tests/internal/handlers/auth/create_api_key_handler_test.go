@@ -0,0 +1,119 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateAPIKeyHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID, _ := vo.ParseID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	newRouter := func(handler *handlers.CreateAPIKeyHandler) *gin.Engine {
+		router := newTestRouter()
+		router.POST("/user/api-keys", func(c *gin.Context) {
+			c.Set("userID", userID.String())
+			handler.Handle(c)
+		})
+		return router
+	}
+
+	t.Run("Sucesso - Chave criada e exibida uma única vez", func(t *testing.T) {
+		mockUseCase := new(mocks.MockCreateAPIKeyUseCase)
+		handler := handlers.NewCreateAPIKeyHandler(mockUseCase)
+
+		mockUseCase.On("Execute", mock.Anything, userID, dto.CreateAPIKeyParams{
+			Name:   "CI script",
+			Scopes: []string{"user:read"},
+		}).Return(dto.CreatedAPIKeyResult{
+			APIKeyResult: dto.APIKeyResult{ID: "key-1", Name: "CI script", Prefix: "abc123", Scopes: []string{"user:read"}},
+			Key:          "sag_abc123_secret",
+		}, nil)
+
+		reqBody := `{"name": "CI script", "scopes": ["user:read"]}`
+		req, _ := http.NewRequest(http.MethodPost, "/user/api-keys", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+
+		newRouter(handler).ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusCreated, resp.Code)
+
+		var output dto.CreateAPIKeyOutput
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &output))
+		assert.Equal(t, "sag_abc123_secret", output.Key)
+		assert.Equal(t, "abc123", output.Prefix)
+		assert.Nil(t, output.ExpiresAt)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Erro - Validação", func(t *testing.T) {
+		mockUseCase := new(mocks.MockCreateAPIKeyUseCase)
+		handler := handlers.NewCreateAPIKeyHandler(mockUseCase)
+
+		valErr := msgerror.NewValidationErrors()
+		valErr.Add("scopes", msgerror.AnErrInvalidScope.Error())
+		mockUseCase.On("Execute", mock.Anything, userID, mock.Anything).
+			Return(dto.CreatedAPIKeyResult{}, valErr)
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/api-keys", bytes.NewBufferString(`{"name": "CI script", "scopes": ["root"]}`))
+		resp := httptest.NewRecorder()
+
+		newRouter(handler).ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, resp.Body.String(), "invalid scope")
+	})
+
+	t.Run("Erro - Body inválido", func(t *testing.T) {
+		handler := handlers.NewCreateAPIKeyHandler(nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/api-keys", bytes.NewBufferString(`{invalid`))
+		resp := httptest.NewRecorder()
+
+		newRouter(handler).ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("Erro - Não autenticado", func(t *testing.T) {
+		handler := handlers.NewCreateAPIKeyHandler(nil)
+
+		router := newTestRouter()
+		router.POST("/user/api-keys", handler.Handle)
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/api-keys", bytes.NewBufferString(`{}`))
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+
+	t.Run("Erro - Falha interna", func(t *testing.T) {
+		mockUseCase := new(mocks.MockCreateAPIKeyUseCase)
+		handler := handlers.NewCreateAPIKeyHandler(mockUseCase)
+
+		mockUseCase.On("Execute", mock.Anything, userID, mock.Anything).
+			Return(dto.CreatedAPIKeyResult{}, errors.New("db error"))
+
+		req, _ := http.NewRequest(http.MethodPost, "/user/api-keys", bytes.NewBufferString(`{"name": "CI script", "scopes": ["user:read"]}`))
+		resp := httptest.NewRecorder()
+
+		newRouter(handler).ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
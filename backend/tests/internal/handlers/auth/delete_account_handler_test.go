@@ -0,0 +1,92 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteAccountHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := vo.NewID()
+
+	setup := func(uc *mocks.MockDeactivateAccountUseCase, authenticated bool) *gin.Engine {
+		handler := handlers.NewDeleteAccountHandler(uc, nil)
+		router := newTestRouter()
+		router.DELETE("/user/me", func(c *gin.Context) {
+			if authenticated {
+				c.Set("userID", userID.String())
+			}
+			handler.Handle(c)
+		})
+		return router
+	}
+	request := func(body string) *http.Request {
+		req, _ := http.NewRequest(http.MethodDelete, "/user/me", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("Accepted", func(t *testing.T) {
+		uc := new(mocks.MockDeactivateAccountUseCase)
+		purgeAfter := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+		uc.On("Execute", mock.Anything, userID, "secret123").Return(purgeAfter, nil)
+
+		resp := httptest.NewRecorder()
+		setup(uc, true).ServeHTTP(resp, request(`{"password":"secret123"}`))
+
+		assert.Equal(t, http.StatusAccepted, resp.Code)
+		var output dto.DeleteAccountOutput
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &output))
+		assert.True(t, purgeAfter.Equal(output.PurgeAfter))
+		uc.AssertExpectations(t)
+	})
+
+	t.Run("MissingPassword", func(t *testing.T) {
+		uc := new(mocks.MockDeactivateAccountUseCase)
+
+		resp := httptest.NewRecorder()
+		setup(uc, true).ServeHTTP(resp, request(`{}`))
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		uc.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		setup(new(mocks.MockDeactivateAccountUseCase), false).ServeHTTP(resp, request(`{"password":"x"}`))
+
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+
+	t.Run("ErrorMapping", func(t *testing.T) {
+		cases := map[error]int{
+			msgerror.AnErrInvalidCredentials: http.StatusUnauthorized,
+			msgerror.AnErrUserNotFound:       http.StatusNotFound,
+			errors.New("db down"):            http.StatusInternalServerError,
+		}
+		for err, status := range cases {
+			uc := new(mocks.MockDeactivateAccountUseCase)
+			uc.On("Execute", mock.Anything, userID, "secret123").Return(time.Time{}, err)
+
+			resp := httptest.NewRecorder()
+			setup(uc, true).ServeHTTP(resp, request(`{"password":"secret123"}`))
+
+			assert.Equal(t, status, resp.Code, err.Error())
+		}
+	})
+}
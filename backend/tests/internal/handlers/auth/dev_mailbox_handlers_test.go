@@ -0,0 +1,70 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListDevMailboxHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(mailbox *mocks.MockMailbox) *gin.Engine {
+		router := newTestRouter()
+		router.GET("/dev/mailbox", handlers.NewListDevMailboxHandler(mailbox).Handle)
+		return router
+	}
+
+	t.Run("Sucesso - Filtra por destinatário", func(t *testing.T) {
+		mailbox := new(mocks.MockMailbox)
+		mailbox.On("Messages", "ana@test.com").
+			Return([]dto.MailboxMessage{{ID: "m1", To: []string{"ana@test.com"}, Subject: "Password Reset"}})
+
+		req, _ := http.NewRequest(http.MethodGet, "/dev/mailbox?to=ana@test.com", nil)
+		resp := httptest.NewRecorder()
+		newRouter(mailbox).ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		var output []dto.MailboxMessage
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &output))
+		assert.Len(t, output, 1)
+		assert.Equal(t, "Password Reset", output[0].Subject)
+		mailbox.AssertExpectations(t)
+	})
+
+	t.Run("Sucesso - Caixa vazia devolve lista vazia", func(t *testing.T) {
+		mailbox := new(mocks.MockMailbox)
+		mailbox.On("Messages", "").Return([]dto.MailboxMessage{})
+
+		req, _ := http.NewRequest(http.MethodGet, "/dev/mailbox", nil)
+		resp := httptest.NewRecorder()
+		newRouter(mailbox).ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.JSONEq(t, `[]`, resp.Body.String())
+	})
+}
+
+func TestClearDevMailboxHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mailbox := new(mocks.MockMailbox)
+	mailbox.On("Clear").Return()
+
+	router := newTestRouter()
+	router.DELETE("/dev/mailbox", handlers.NewClearDevMailboxHandler(mailbox).Handle)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/dev/mailbox", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	mailbox.AssertExpectations(t)
+}
@@ -0,0 +1,160 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestChangeEmailHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := vo.NewID()
+	body := `{"new_email": "new@test.com", "password": "secret123"}`
+
+	serve := func(uc *mocks.MockRequestEmailChangeUseCase, withUser bool, reqBody string) *httptest.ResponseRecorder {
+		handler := handlers.NewChangeEmailHandler(uc)
+		router := newTestRouter()
+		router.PUT("/user/email", func(c *gin.Context) {
+			if withUser {
+				c.Set("userID", userID.String())
+			}
+			handler.Handle(c)
+		})
+
+		req, _ := http.NewRequest(http.MethodPut, "/user/email", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	t.Run("Success - Confirmation sent", func(t *testing.T) {
+		uc := new(mocks.MockRequestEmailChangeUseCase)
+		uc.On("Execute", mock.Anything, userID, "new@test.com", "secret123").Return(nil)
+
+		resp := serve(uc, true, body)
+
+		assert.Equal(t, http.StatusAccepted, resp.Code)
+		assert.JSONEq(t, `{"message": "confirmation sent to the new email"}`, resp.Body.String())
+		uc.AssertExpectations(t)
+	})
+
+	t.Run("Error - Missing user in context", func(t *testing.T) {
+		resp := serve(new(mocks.MockRequestEmailChangeUseCase), false, body)
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+
+	t.Run("Error - Missing password", func(t *testing.T) {
+		resp := serve(new(mocks.MockRequestEmailChangeUseCase), true, `{"new_email": "new@test.com"}`)
+		assertProblem(t, resp, http.StatusBadRequest, "invalid_request_body")
+	})
+
+	cases := []struct {
+		name string
+		err  error
+		code int
+		want string
+	}{
+		{"Wrong password", msgerror.AnErrInvalidCredentials, http.StatusUnauthorized, "invalid_credentials"},
+		{"Same email", msgerror.AnErrEmailUnchanged, http.StatusBadRequest, "email_unchanged"},
+		{"Email taken", msgerror.AnErrUserExists, http.StatusConflict, "user_exists"},
+		{"User not found", msgerror.AnErrUserNotFound, http.StatusNotFound, "user_not_found"},
+		{"Internal error", assert.AnError, http.StatusInternalServerError, "internal_error"},
+	}
+	for _, tc := range cases {
+		t.Run("Error - "+tc.name, func(t *testing.T) {
+			uc := new(mocks.MockRequestEmailChangeUseCase)
+			uc.On("Execute", mock.Anything, userID, "new@test.com", "secret123").Return(tc.err)
+
+			resp := serve(uc, true, body)
+
+			assertProblem(t, resp, tc.code, tc.want)
+		})
+	}
+}
+
+func TestConfirmEmailChangeHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"Success", nil, http.StatusNoContent},
+		{"Expired token", msgerror.AnErrExpiredToken, http.StatusBadRequest},
+		{"Invalid token", msgerror.AnErrInvalidToken, http.StatusBadRequest},
+		{"Email taken meanwhile", msgerror.AnErrUserExists, http.StatusConflict},
+		{"Internal error", assert.AnError, http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := new(mocks.MockConfirmEmailChangeUseCase)
+			uc.On("Execute", mock.Anything, "tok").Return(tc.err)
+
+			router := newTestRouter()
+			router.POST("/auth/email-change/confirm", handlers.NewConfirmEmailChangeHandler(uc).Handle)
+
+			req, _ := http.NewRequest(http.MethodPost, "/auth/email-change/confirm", bytes.NewBufferString(`{"token": "tok"}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tc.code, resp.Code)
+			uc.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Missing token", func(t *testing.T) {
+		router := newTestRouter()
+		router.POST("/auth/email-change/confirm", handlers.NewConfirmEmailChangeHandler(nil).Handle)
+
+		req, _ := http.NewRequest(http.MethodPost, "/auth/email-change/confirm", bytes.NewBufferString(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+}
+
+func TestCancelEmailChangeHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"Success", nil, http.StatusNoContent},
+		{"Invalid token", msgerror.AnErrInvalidToken, http.StatusBadRequest},
+		{"Concurrent modification", msgerror.AnErrConflict, http.StatusConflict},
+		{"Internal error", assert.AnError, http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := new(mocks.MockCancelEmailChangeUseCase)
+			uc.On("Execute", mock.Anything, "cancel-tok").Return(tc.err)
+
+			router := newTestRouter()
+			router.POST("/auth/email-change/cancel", handlers.NewCancelEmailChangeHandler(uc).Handle)
+
+			req, _ := http.NewRequest(http.MethodPost, "/auth/email-change/cancel", bytes.NewBufferString(`{"token": "cancel-tok"}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tc.code, resp.Code)
+			uc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportUserDataHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := vo.NewID()
+
+	serve := func(uc *mocks.MockExportUserDataUseCase, target string) *httptest.ResponseRecorder {
+		handler := handlers.NewExportUserDataHandler(uc)
+		router := newTestRouter()
+		router.GET("/user/me/export", func(c *gin.Context) {
+			c.Set("userID", userID.String())
+			handler.Handle(c)
+		})
+		req, _ := http.NewRequest(http.MethodGet, target, nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	t.Run("ReturnsFile", func(t *testing.T) {
+		uc := new(mocks.MockExportUserDataUseCase)
+		uc.On("Execute", mock.Anything, userID, "zip").Return(dto.UserDataExportResult{
+			FileName: "abc.zip", ContentType: "application/zip", Data: []byte("PK"),
+		}, nil)
+
+		resp := serve(uc, "/user/me/export?format=zip")
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "application/zip", resp.Header().Get("Content-Type"))
+		assert.Contains(t, resp.Header().Get("Content-Disposition"), "user-data-abc.zip")
+		assert.Equal(t, "no-store", resp.Header().Get("Cache-Control"))
+		assert.Equal(t, "PK", resp.Body.String())
+	})
+
+	t.Run("PendingIsAccepted", func(t *testing.T) {
+		uc := new(mocks.MockExportUserDataUseCase)
+		uc.On("Execute", mock.Anything, userID, "").Return(dto.UserDataExportResult{Pending: true}, nil)
+
+		resp := serve(uc, "/user/me/export")
+
+		assert.Equal(t, http.StatusAccepted, resp.Code)
+	})
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		resp := serve(new(mocks.MockExportUserDataUseCase), "/user/me/export?format=xml")
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("UseCaseError", func(t *testing.T) {
+		uc := new(mocks.MockExportUserDataUseCase)
+		uc.On("Execute", mock.Anything, userID, "").Return(dto.UserDataExportResult{}, errors.New("db down"))
+
+		resp := serve(uc, "/user/me/export")
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestDownloadUserDataExportHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	expires := time.Now().Add(time.Minute).Unix()
+
+	serve := func(uc *mocks.MockDownloadUserDataExportUseCase, target string) *httptest.ResponseRecorder {
+		router := newTestRouter()
+		router.GET("/exports/:file", handlers.NewDownloadUserDataExportHandler(uc).Handle)
+		req, _ := http.NewRequest(http.MethodGet, target, nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+	target := "/exports/abc.json?signature=sig&expires=" + strconv.FormatInt(expires, 10)
+
+	t.Run("Downloads", func(t *testing.T) {
+		uc := new(mocks.MockDownloadUserDataExportUseCase)
+		uc.On("Execute", mock.Anything, "abc.json", time.Unix(expires, 0), "sig").Return(dto.UserDataExportResult{
+			FileName: "abc.json", ContentType: "application/json", Data: []byte("{}"),
+		}, nil)
+
+		resp := serve(uc, target)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "{}", resp.Body.String())
+	})
+
+	t.Run("MissingSignature", func(t *testing.T) {
+		resp := serve(new(mocks.MockDownloadUserDataExportUseCase), "/exports/abc.json")
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("ErrorMapping", func(t *testing.T) {
+		cases := map[error]int{
+			msgerror.AnErrInvalidSignature: http.StatusForbidden,
+			msgerror.AnErrExportNotFound:   http.StatusNotFound,
+			errors.New("disk"):             http.StatusInternalServerError,
+		}
+		for err, status := range cases {
+			uc := new(mocks.MockDownloadUserDataExportUseCase)
+			uc.On("Execute", mock.Anything, "abc.json", time.Unix(expires, 0), "sig").
+				Return(dto.UserDataExportResult{}, err)
+
+			assert.Equal(t, status, serve(uc, target).Code, err.Error())
+		}
+	})
+}
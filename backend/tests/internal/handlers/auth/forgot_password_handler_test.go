@@ -1,4 +1,4 @@
-package handlers
+package handlers_test
 
 import (
 	"bytes"
@@ -10,7 +10,6 @@ import (
 	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
 	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
 	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
-	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -18,52 +17,52 @@ import (
 func TestForgotPasswordHandler_InvalidRequestBody(t *testing.T) {
 	// Setup
 	uc := new(mocks.MockForgotPasswordUseCase)
-	handler := handlers.NewForgotPasswordHandler(uc)
+	handler := handlers.NewForgotPasswordHandler(uc, nil)
 	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
+	router := newTestRouter()
+	router.POST("/forgot-password", handler.Handle)
 
 	// Request com corpo inválido
 	body := []byte("{invalid}")
 	req := httptest.NewRequest(http.MethodPost, "/forgot-password", bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
-	c.Request = req
 
 	// Execute
-	handler.Handle(c)
+	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Equal(t, `{"error":"invalid character 'i' looking for beginning of object key string"}`, w.Body.String())
+	assertProblem(t, w, http.StatusBadRequest, "invalid_request_body")
 }
 
 func TestForgotPasswordHandler_InvalidEmailFormat(t *testing.T) {
 	// Setup
 	uc := new(mocks.MockForgotPasswordUseCase)
-	handler := handlers.NewForgotPasswordHandler(uc)
+	handler := handlers.NewForgotPasswordHandler(uc, nil)
 	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
+	router := newTestRouter()
+	router.POST("/forgot-password", handler.Handle)
 
 	// Request com email inválido
 	input := dto.ForgotPasswordInput{Email: "invalid"}
 	body, _ := json.Marshal(input)
 	req := httptest.NewRequest(http.MethodPost, "/forgot-password", bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
-	c.Request = req
 
 	// Execute
-	handler.Handle(c)
+	router.ServeHTTP(w, req)
 
 	// Assert - Mantém a mensagem para formato inválido
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Equal(t, `{"error":"invalid email format"}`, w.Body.String())
+	problem := assertProblem(t, w, http.StatusBadRequest, "invalid_email")
+	assert.Equal(t, "invalid email format", problem.Detail)
 }
 
 func TestForgotPasswordHandler_InternalServerError(t *testing.T) {
 	// Setup
 	uc := new(mocks.MockForgotPasswordUseCase)
-	handler := handlers.NewForgotPasswordHandler(uc)
+	handler := handlers.NewForgotPasswordHandler(uc, nil)
 	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
+	router := newTestRouter()
+	router.POST("/forgot-password", handler.Handle)
 
 	// Configura o mock para retornar erro
 	uc.On("Execute", mock.Anything, mock.Anything).Return(assert.AnError)
@@ -73,23 +72,24 @@ func TestForgotPasswordHandler_InternalServerError(t *testing.T) {
 	body, _ := json.Marshal(input)
 	req := httptest.NewRequest(http.MethodPost, "/forgot-password", bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
-	c.Request = req
 
 	// Execute
-	handler.Handle(c)
+	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
-	assert.Equal(t, `{"error":"assert.AnError general error for testing"}`, w.Body.String())
+	// O erro interno não vaza para o cliente
+	problem := assertProblem(t, w, http.StatusInternalServerError, "internal_error")
+	assert.NotContains(t, problem.Detail, "assert.AnError")
 	uc.AssertExpectations(t)
 }
 
 func TestForgotPasswordHandler_Success(t *testing.T) {
 	// Setup
 	uc := new(mocks.MockForgotPasswordUseCase)
-	handler := handlers.NewForgotPasswordHandler(uc)
+	handler := handlers.NewForgotPasswordHandler(uc, nil)
 	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
+	router := newTestRouter()
+	router.POST("/forgot-password", handler.Handle)
 
 	// Configura o mock para sucesso
 	uc.On("Execute", mock.Anything, mock.Anything).Return(nil)
@@ -99,10 +99,9 @@ func TestForgotPasswordHandler_Success(t *testing.T) {
 	body, _ := json.Marshal(input)
 	req := httptest.NewRequest(http.MethodPost, "/forgot-password", bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
-	c.Request = req
 
 	// Execute
-	handler.Handle(c)
+	router.ServeHTTP(w, req)
 
 	// Assert
 	assert.Equal(t, http.StatusNoContent, w.Code)
@@ -113,40 +112,39 @@ func TestForgotPasswordHandler_Success(t *testing.T) {
 func TestForgotPasswordHandler_EmptyEmail(t *testing.T) {
 	// Setup
 	uc := new(mocks.MockForgotPasswordUseCase)
-	handler := handlers.NewForgotPasswordHandler(uc)
+	handler := handlers.NewForgotPasswordHandler(uc, nil)
 	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
+	router := newTestRouter()
+	router.POST("/forgot-password", handler.Handle)
 
 	// Request com email vazio
 	input := dto.ForgotPasswordInput{Email: ""}
 	body, _ := json.Marshal(input)
 	req := httptest.NewRequest(http.MethodPost, "/forgot-password", bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
-	c.Request = req
 
 	// Execute
-	handler.Handle(c)
+	router.ServeHTTP(w, req)
 
 	// Assert - Agora espera a mensagem específica para email vazio
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Equal(t, `{"error":"email cannot be empty"}`, w.Body.String())
+	problem := assertProblem(t, w, http.StatusBadRequest, "empty_email")
+	assert.Equal(t, "email cannot be empty", problem.Detail)
 }
 func TestForgotPasswordHandler_EmptyBody(t *testing.T) {
 	// Setup
 	uc := new(mocks.MockForgotPasswordUseCase)
-	handler := handlers.NewForgotPasswordHandler(uc)
+	handler := handlers.NewForgotPasswordHandler(uc, nil)
 	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
+	router := newTestRouter()
+	router.POST("/forgot-password", handler.Handle)
 
 	// Request sem corpo
 	req := httptest.NewRequest(http.MethodPost, "/forgot-password", nil)
 	req.Header.Set("Content-Type", "application/json")
-	c.Request = req
 
 	// Execute
-	handler.Handle(c)
+	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Equal(t, `{"error":"EOF"}`, w.Body.String())
+	assertProblem(t, w, http.StatusBadRequest, "invalid_request_body")
 }
@@ -0,0 +1,90 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHumanChallengeHandler(t *testing.T) {
+	expiresAt := time.Date(2025, 1, 1, 12, 5, 0, 0, time.UTC)
+	verifier := new(mocks.MockHumanVerifier)
+	verifier.On("Challenge", mock.Anything).Return(providers.HumanChallenge{
+		Type: providers.HumanVerifierProofOfWork, Challenge: "abc.16.1735733100.sig", Difficulty: 16, ExpiresAt: expiresAt,
+	}, nil)
+
+	router := newTestRouter()
+	router.GET("/auth/human-challenge", handlers.NewHumanChallengeHandler(verifier).Handle)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/auth/human-challenge", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"type": "pow", "challenge": "abc.16.1735733100.sig", "difficulty": 16, "expires_at": "2025-01-01T12:05:00Z"}`, w.Body.String())
+}
+
+func TestHumanVerification_RunsBeforeUseCases(t *testing.T) {
+	t.Run("Forgot password without proof", func(t *testing.T) {
+		uc := new(mocks.MockForgotPasswordUseCase)
+		verifier := new(mocks.MockHumanVerifier)
+		verifier.On("Verify", mock.Anything, "", providers.HumanSubject{IP: "192.0.2.1", Email: "user@example.com"}).Return(msgerror.AnErrHumanVerificationRequired)
+
+		router := newTestRouter()
+		router.POST("/auth/forgot-password", handlers.NewForgotPasswordHandler(uc, verifier).Handle)
+		body, _ := json.Marshal(dto.ForgotPasswordInput{Email: "user@example.com"})
+		req := httptest.NewRequest(http.MethodPost, "/auth/forgot-password", bytes.NewReader(body))
+		req.RemoteAddr = "192.0.2.1:1000"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assertProblem(t, w, http.StatusForbidden, "human_verification_required")
+		uc.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Register with a rejected proof", func(t *testing.T) {
+		uc := new(mocks.MockRegisterUseCase)
+		verifier := new(mocks.MockHumanVerifier)
+		verifier.On("Verify", mock.Anything, "token", mock.Anything).Return(msgerror.AnErrHumanVerificationFailed)
+
+		router := newTestRouter()
+		router.POST("/auth/register", handlers.NewRegisterHandler(uc, nil, verifier).Handle)
+		body, _ := json.Marshal(dto.RegisterUserInput{Name: "Maria", Email: "maria@example.com", Password: "secret123", PasswordConfirmation: "secret123"})
+		req := httptest.NewRequest(http.MethodPost, "/auth/register", bytes.NewReader(body))
+		req.Header.Set(handlers.HumanProofHeader, "token")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assertProblem(t, w, http.StatusForbidden, "human_verification_failed")
+		uc.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Accepted proof reaches the use case", func(t *testing.T) {
+		uc := new(mocks.MockForgotPasswordUseCase)
+		uc.On("Execute", mock.Anything, mock.Anything).Return(nil)
+		verifier := new(mocks.MockHumanVerifier)
+		verifier.On("Verify", mock.Anything, "proof", mock.Anything).Return(nil)
+
+		router := newTestRouter()
+		router.POST("/auth/forgot-password", handlers.NewForgotPasswordHandler(uc, verifier).Handle)
+		body, _ := json.Marshal(dto.ForgotPasswordInput{Email: "user@example.com"})
+		req := httptest.NewRequest(http.MethodPost, "/auth/forgot-password", bytes.NewReader(body))
+		req.Header.Set(handlers.HumanProofHeader, "proof")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNoContent, w.Code)
+		uc.AssertExpectations(t)
+	})
+}
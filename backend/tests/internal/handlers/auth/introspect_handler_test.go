@@ -0,0 +1,130 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/internal/middleware"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newIntrospectRequest(token string) *http.Request {
+	form := url.Values{}
+	if token != "" {
+		form.Set("token", token)
+	}
+	req, _ := http.NewRequest(http.MethodPost, "/oauth/introspect", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth("billing", "s3cret")
+	return req
+}
+
+func TestIntrospectHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	clients := map[string]string{"billing": "s3cret"}
+
+	t.Run("Sucesso - Token ativo", func(t *testing.T) {
+		mockUseCase := new(mocks.MockIntrospectTokenUseCase)
+		handler := handlers.NewIntrospectHandler(mockUseCase)
+
+		mockUseCase.On("Execute", mock.Anything, "valid_token").Return(dto.IntrospectionResult{
+			Active:    true,
+			UserID:    "user-123",
+			Subject:   "user@test.com",
+			ExpiresAt: 1700000000,
+			TokenType: "Bearer",
+		}, nil)
+
+		router := newTestRouter()
+		router.POST("/oauth/introspect", middleware.ClientAuthMiddleware(clients), handler.Handle)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, newIntrospectRequest("valid_token"))
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		var output dto.IntrospectOutput
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &output))
+		assert.True(t, output.Active)
+		assert.Equal(t, "user-123", output.UserID)
+		assert.Equal(t, "user@test.com", output.Subject)
+		assert.Equal(t, int64(1700000000), output.ExpiresAt)
+		// O cliente que consulta não é o dono do token
+		assert.NotContains(t, resp.Body.String(), "client_id")
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Sucesso - Token inativo retorna apenas active=false", func(t *testing.T) {
+		mockUseCase := new(mocks.MockIntrospectTokenUseCase)
+		handler := handlers.NewIntrospectHandler(mockUseCase)
+
+		mockUseCase.On("Execute", mock.Anything, "revoked_token").
+			Return(dto.IntrospectionResult{Active: false}, nil)
+
+		router := newTestRouter()
+		router.POST("/oauth/introspect", middleware.ClientAuthMiddleware(clients), handler.Handle)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, newIntrospectRequest("revoked_token"))
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.JSONEq(t, `{"active": false}`, resp.Body.String())
+	})
+
+	t.Run("Erro - Cliente não autenticado", func(t *testing.T) {
+		mockUseCase := new(mocks.MockIntrospectTokenUseCase)
+		handler := handlers.NewIntrospectHandler(mockUseCase)
+
+		router := newTestRouter()
+		router.POST("/oauth/introspect", middleware.ClientAuthMiddleware(clients), handler.Handle)
+
+		req := newIntrospectRequest("valid_token")
+		req.SetBasicAuth("billing", "wrong")
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		assert.Contains(t, resp.Body.String(), "invalid_client")
+		assert.NotEmpty(t, resp.Header().Get("WWW-Authenticate"))
+		mockUseCase.AssertNotCalled(t, "Execute")
+	})
+
+	t.Run("Erro - Token ausente", func(t *testing.T) {
+		handler := handlers.NewIntrospectHandler(nil)
+
+		router := newTestRouter()
+		router.POST("/oauth/introspect", handler.Handle)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, newIntrospectRequest(""))
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, resp.Body.String(), "invalid_request")
+	})
+
+	t.Run("Erro - Falha no caso de uso", func(t *testing.T) {
+		mockUseCase := new(mocks.MockIntrospectTokenUseCase)
+		handler := handlers.NewIntrospectHandler(mockUseCase)
+
+		mockUseCase.On("Execute", mock.Anything, "valid_token").
+			Return(dto.IntrospectionResult{}, errors.New("redis down"))
+
+		router := newTestRouter()
+		router.POST("/oauth/introspect", handler.Handle)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, newIntrospectRequest("valid_token"))
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
@@ -0,0 +1,68 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListAPIKeysHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID, _ := vo.ParseID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	newRouter := func(handler *handlers.ListAPIKeysHandler) *gin.Engine {
+		router := newTestRouter()
+		router.GET("/user/api-keys", func(c *gin.Context) {
+			c.Set("userID", userID.String())
+			handler.Handle(c)
+		})
+		return router
+	}
+
+	t.Run("Sucesso - Lista sem expor a chave", func(t *testing.T) {
+		mockUseCase := new(mocks.MockListAPIKeysUseCase)
+		handler := handlers.NewListAPIKeysHandler(mockUseCase)
+
+		lastUsed := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		mockUseCase.On("Execute", mock.Anything, userID).Return([]dto.APIKeyResult{
+			{ID: "key-1", Name: "CI script", Prefix: "abc123", Scopes: []string{"user:read"}, LastUsedAt: lastUsed},
+		}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/user/api-keys", nil)
+		resp := httptest.NewRecorder()
+		newRouter(handler).ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.NotContains(t, resp.Body.String(), `"key"`)
+
+		var output []dto.APIKeyOutput
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &output))
+		assert.Len(t, output, 1)
+		assert.Equal(t, lastUsed, *output[0].LastUsedAt)
+		assert.Nil(t, output[0].RevokedAt)
+	})
+
+	t.Run("Erro - Falha interna", func(t *testing.T) {
+		mockUseCase := new(mocks.MockListAPIKeysUseCase)
+		handler := handlers.NewListAPIKeysHandler(mockUseCase)
+
+		mockUseCase.On("Execute", mock.Anything, userID).Return(nil, errors.New("db error"))
+
+		req, _ := http.NewRequest(http.MethodGet, "/user/api-keys", nil)
+		resp := httptest.NewRecorder()
+		newRouter(handler).ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
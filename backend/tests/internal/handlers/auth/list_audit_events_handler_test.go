@@ -0,0 +1,85 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListAuditEventsHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(handler *handlers.ListAuditEventsHandler) *gin.Engine {
+		router := newTestRouter()
+		router.GET("/admin/audit", handler.Handle)
+		return router
+	}
+
+	t.Run("Sucesso - Repassa filtros", func(t *testing.T) {
+		mockUseCase := new(mocks.MockListAuditEventsUseCase)
+		handler := handlers.NewListAuditEventsHandler(mockUseCase)
+
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockUseCase.On("Execute", mock.Anything, mock.MatchedBy(func(p dto.AuditQueryParams) bool {
+			return p.Action == "auth.login" && p.Outcome == "failure" &&
+				p.ActorID == "user-1" && p.From.Equal(from) && p.Limit == 20
+		})).Return([]dto.AuditEventOutput{{ID: "evt-1", Action: "auth.login", Outcome: "failure"}}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet,
+			"/admin/audit?action=auth.login&outcome=failure&actor_id=user-1&from=2024-01-01T00:00:00Z&limit=20", nil)
+		resp := httptest.NewRecorder()
+		newRouter(handler).ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		var output []dto.AuditEventOutput
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &output))
+		assert.Len(t, output, 1)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Erro - Filtro inválido", func(t *testing.T) {
+		mockUseCase := new(mocks.MockListAuditEventsUseCase)
+		handler := handlers.NewListAuditEventsHandler(mockUseCase)
+
+		req, _ := http.NewRequest(http.MethodGet, "/admin/audit?outcome=maybe", nil)
+		resp := httptest.NewRecorder()
+		newRouter(handler).ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		mockUseCase.AssertNotCalled(t, "Execute")
+	})
+
+	t.Run("Erro - Data inválida", func(t *testing.T) {
+		mockUseCase := new(mocks.MockListAuditEventsUseCase)
+		handler := handlers.NewListAuditEventsHandler(mockUseCase)
+
+		req, _ := http.NewRequest(http.MethodGet, "/admin/audit?from=ontem", nil)
+		resp := httptest.NewRecorder()
+		newRouter(handler).ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("Erro - Falha interna", func(t *testing.T) {
+		mockUseCase := new(mocks.MockListAuditEventsUseCase)
+		handler := handlers.NewListAuditEventsHandler(mockUseCase)
+
+		mockUseCase.On("Execute", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+
+		req, _ := http.NewRequest(http.MethodGet, "/admin/audit", nil)
+		resp := httptest.NewRecorder()
+		newRouter(handler).ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
@@ -2,14 +2,17 @@ package handlers_test
 
 import (
 	"bytes"
-	"errors"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/internal/middleware"
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
 	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
 	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -21,15 +24,19 @@ func TestLoginHandler_Handle(t *testing.T) {
 
 	t.Run("Sucesso - Login válido", func(t *testing.T) {
 		mockUseCase := new(mocks.MockLoginUseCase)
-		handler := handlers.NewLoginHandler(mockUseCase)
+		handler := handlers.NewLoginHandler(mockUseCase, nil)
 
 		fixedID, _ := vo.ParseID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
 		email, _ := vo.NewEmail("test@example.com")
 
+		imageURL, _ := vo.NewURL("https://cdn.example.com/me.png")
+
 		expectedLoginResult := dto.LoginResult{
-			UserID: fixedID,
-			Email:  email,
-			Token:  "token_jwt_gerado_pelo_use_case",
+			UserID:    fixedID,
+			Email:     email,
+			ImageURL:  imageURL,
+			CreatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			Token:     "token_jwt_gerado_pelo_use_case",
 		}
 
 		mockUseCase.On("Execute", mock.Anything, "test@example.com", "senha123").
@@ -40,21 +47,24 @@ func TestLoginHandler_Handle(t *testing.T) {
 		req.Header.Set("Content-Type", "application/json")
 		resp := httptest.NewRecorder()
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.POST("/login", handler.Handle)
 		router.ServeHTTP(resp, req)
 
 		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Body.String(), `"image_url":"https://cdn.example.com/me.png"`)
+		assert.Contains(t, resp.Body.String(), `"created_at":"2024-05-01T12:00:00Z"`)
+		assert.Contains(t, resp.Body.String(), `"attributes":{}`)
 		mockUseCase.AssertExpectations(t)
 	})
 
 	t.Run("Erro - Body inválido", func(t *testing.T) {
-		handler := handlers.NewLoginHandler(nil)
+		handler := handlers.NewLoginHandler(nil, nil)
 
 		req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(`{invalid`))
 		resp := httptest.NewRecorder()
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.POST("/login", handler.Handle)
 		router.ServeHTTP(resp, req)
 
@@ -64,18 +74,67 @@ func TestLoginHandler_Handle(t *testing.T) {
 	t.Run("Erro - Credenciais inválidas", func(t *testing.T) {
 		mockUseCase := new(mocks.MockLoginUseCase)
 		mockUseCase.On("Execute", mock.Anything, "invalid@example.com", "wrong").
-			Return(dto.LoginResult{}, errors.New("credenciais inválidas"))
+			Return(dto.LoginResult{}, msgerror.AnErrInvalidCredentials)
 
-		handler := handlers.NewLoginHandler(mockUseCase)
+		handler := handlers.NewLoginHandler(mockUseCase, nil)
 
 		reqBody := `{"email": "invalid@example.com", "password": "wrong"}`
 		req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(reqBody))
 		resp := httptest.NewRecorder()
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.POST("/login", handler.Handle)
 		router.ServeHTTP(resp, req)
 
-		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		assertProblem(t, resp, http.StatusUnauthorized, "invalid_credentials")
+	})
+
+	t.Run("Sucesso - Modo cookie não expõe o token no corpo", func(t *testing.T) {
+		mockUseCase := new(mocks.MockLoginUseCase)
+		sessionCookie := middleware.NewSessionCookie(middleware.SessionCookieConfig{
+			Secure:     true,
+			SameSite:   "strict",
+			MaxAge:     time.Hour,
+			CSRFSecret: "csrf-secret",
+		})
+		handler := handlers.NewLoginHandler(mockUseCase, sessionCookie)
+
+		fixedID, _ := vo.ParseID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+		mockUseCase.On("Execute", mock.Anything, "test@example.com", "senha123").
+			Return(dto.LoginResult{UserID: fixedID, Token: "jwt_token"}, nil)
+
+		reqBody := `{"email": "test@example.com", "password": "senha123"}`
+		req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(reqBody))
+		resp := httptest.NewRecorder()
+
+		router := newTestRouter()
+		router.POST("/login", handler.Handle)
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		var output dto.LoginOutput
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &output))
+		assert.Empty(t, output.AccessToken)
+		assert.Equal(t, sessionCookie.CSRFToken("jwt_token"), output.CSRFToken)
+
+		cookies := map[string]*http.Cookie{}
+		for _, cookie := range resp.Result().Cookies() {
+			cookies[cookie.Name] = cookie
+		}
+
+		session := cookies[middleware.SessionCookieName]
+		if assert.NotNil(t, session) {
+			assert.Equal(t, "jwt_token", session.Value)
+			assert.True(t, session.HttpOnly)
+			assert.True(t, session.Secure)
+			assert.Equal(t, http.SameSiteStrictMode, session.SameSite)
+		}
+
+		csrf := cookies[middleware.CSRFCookieName]
+		if assert.NotNil(t, csrf) {
+			assert.Equal(t, output.CSRFToken, csrf.Value)
+			assert.False(t, csrf.HttpOnly)
+		}
 	})
 }
@@ -5,8 +5,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/internal/middleware"
 	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -20,13 +22,13 @@ func TestLogoutHandler_Handle(t *testing.T) {
 		mockUseCase := new(mocks.MockLogoutUseCase)
 		mockUseCase.On("Execute", mock.Anything, "valid_token").Return(nil)
 
-		handler := handlers.NewLogoutHandler(mockUseCase)
+		handler := handlers.NewLogoutHandler(mockUseCase, nil)
 
 		req, _ := http.NewRequest(http.MethodPost, "/logout", nil)
 		req.Header.Set("Authorization", "Bearer valid_token")
 		resp := httptest.NewRecorder()
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.POST("/logout", handler.Handle)
 		router.ServeHTTP(resp, req)
 
@@ -35,49 +37,72 @@ func TestLogoutHandler_Handle(t *testing.T) {
 	})
 
 	t.Run("Erro - Sem header de autorização", func(t *testing.T) {
-		handler := handlers.NewLogoutHandler(nil)
+		handler := handlers.NewLogoutHandler(nil, nil)
 
 		req, _ := http.NewRequest(http.MethodPost, "/logout", nil)
 		resp := httptest.NewRecorder()
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.POST("/logout", handler.Handle)
 		router.ServeHTTP(resp, req)
 
-		assert.Equal(t, http.StatusBadRequest, resp.Code)
-		assert.JSONEq(t, `{"error":"Authorization header is required"}`, resp.Body.String())
+		assertProblem(t, resp, http.StatusBadRequest, "token_required")
 	})
 
 	t.Run("Erro - Formato de autorização inválido", func(t *testing.T) {
-		handler := handlers.NewLogoutHandler(nil)
+		handler := handlers.NewLogoutHandler(nil, nil)
 
 		req, _ := http.NewRequest(http.MethodPost, "/logout", nil)
 		req.Header.Set("Authorization", "InvalidFormat")
 		resp := httptest.NewRecorder()
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.POST("/logout", handler.Handle)
 		router.ServeHTTP(resp, req)
 
-		assert.Equal(t, http.StatusBadRequest, resp.Code)
-		assert.JSONEq(t, `{"error":"Invalid authorization format"}`, resp.Body.String())
+		assertProblem(t, resp, http.StatusBadRequest, "invalid_token")
 	})
 
 	t.Run("Erro - Falha no caso de uso", func(t *testing.T) {
 		mockUseCase := new(mocks.MockLogoutUseCase)
 		mockUseCase.On("Execute", mock.Anything, "valid_token").Return(errors.New("erro"))
 
-		handler := handlers.NewLogoutHandler(mockUseCase)
+		handler := handlers.NewLogoutHandler(mockUseCase, nil)
 
 		req, _ := http.NewRequest(http.MethodPost, "/logout", nil)
 		req.Header.Set("Authorization", "Bearer valid_token")
 		resp := httptest.NewRecorder()
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.POST("/logout", handler.Handle)
 		router.ServeHTTP(resp, req)
 
-		assert.Equal(t, http.StatusInternalServerError, resp.Code)
-		assert.JSONEq(t, `{"error":"failed to logout"}`, resp.Body.String())
+		assertProblem(t, resp, http.StatusInternalServerError, "internal_error")
+	})
+
+	t.Run("Sucesso - Logout via cookie limpa a sessão", func(t *testing.T) {
+		mockUseCase := new(mocks.MockLogoutUseCase)
+		mockUseCase.On("Execute", mock.Anything, "cookie_token").Return(nil)
+
+		sessionCookie := middleware.NewSessionCookie(middleware.SessionCookieConfig{Secure: true, MaxAge: time.Hour})
+		handler := handlers.NewLogoutHandler(mockUseCase, sessionCookie)
+
+		req, _ := http.NewRequest(http.MethodPost, "/logout", nil)
+		req.AddCookie(&http.Cookie{Name: middleware.SessionCookieName, Value: "cookie_token"})
+		resp := httptest.NewRecorder()
+
+		router := newTestRouter()
+		router.POST("/logout", handler.Handle)
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		mockUseCase.AssertExpectations(t)
+
+		cleared := map[string]bool{}
+		for _, cookie := range resp.Result().Cookies() {
+			cleared[cookie.Name] = cookie.MaxAge < 0 && cookie.Value == ""
+		}
+		assert.True(t, cleared[middleware.SessionCookieName])
+		assert.True(t, cleared[middleware.CSRFCookieName])
 	})
 }
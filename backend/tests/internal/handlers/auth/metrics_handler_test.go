@@ -0,0 +1,42 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/internal/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsHandler(t *testing.T) {
+	m := metrics.New()
+	m.Logins.Inc("failure", "invalid_credentials")
+
+	serve := func(token, authorization string) *httptest.ResponseRecorder {
+		router := newTestRouter()
+		router.GET("/metrics", handlers.NewMetricsHandler(m.Registry, token).Handle)
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Public", func(t *testing.T) {
+		w := serve("", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, metrics.ContentType, w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), `startup_auth_logins_total{result="failure",reason="invalid_credentials"} 1`)
+	})
+
+	t.Run("Token required", func(t *testing.T) {
+		assertProblem(t, serve("scrape-secret", ""), http.StatusUnauthorized, "unauthorized")
+		assertProblem(t, serve("scrape-secret", "Bearer wrong"), http.StatusUnauthorized, "unauthorized")
+		assert.Equal(t, http.StatusOK, serve("scrape-secret", "Bearer scrape-secret").Code)
+	})
+}
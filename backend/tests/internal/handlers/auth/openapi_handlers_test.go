@@ -0,0 +1,84 @@
+package handlers_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/internal/openapi/swaggerui"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := newTestRouter()
+	router.GET("/openapi.json", handlers.NewOpenAPIHandler([]byte(`{"openapi":"3.1.0"}`)).Handle)
+	assets := fstest.MapFS{
+		swaggerui.StylesheetFile: {Data: []byte("body{}")},
+		swaggerui.BundleFile:     {Data: []byte("var SwaggerUIBundle;")},
+		"LICENSE":                {Data: []byte("Apache-2.0")},
+	}
+	swaggerUI := handlers.NewSwaggerUIHandler("/openapi.json", "/docs/assets", assets)
+	router.GET("/docs", swaggerUI.Handle)
+	router.GET("/docs/assets/:file", swaggerUI.HandleAsset)
+
+	t.Run("Document", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Header().Get("Content-Type"), "application/json")
+		assert.JSONEq(t, `{"openapi":"3.1.0"}`, resp.Body.String())
+	})
+
+	t.Run("Swagger UI", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Header().Get("Content-Type"), "text/html")
+		assert.Contains(t, resp.Body.String(), `url: "/openapi.json"`)
+		// Nada é carregado de outra origem
+		assert.NotContains(t, resp.Body.String(), "https://")
+		assert.Contains(t, resp.Body.String(), `src="/docs/assets/swagger-ui-bundle.js"`)
+
+		// A CSP libera só o script de inicialização da página, pelo hash
+		script := regexp.MustCompile(`<script>(.*)</script>`).FindStringSubmatch(resp.Body.String())
+		require.Len(t, script, 2)
+		hash := sha256.Sum256([]byte(script[1]))
+		policy := resp.Header().Get("Content-Security-Policy")
+		assert.Contains(t, policy, "script-src 'self' 'sha256-"+base64.StdEncoding.EncodeToString(hash[:])+"'")
+	})
+
+	t.Run("Assets", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/docs/assets/swagger-ui-bundle.js", nil))
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Header().Get("Content-Type"), "text/javascript")
+		assert.Equal(t, "var SwaggerUIBundle;", resp.Body.String())
+
+		// Só os arquivos usados pela página são servidos
+		for _, path := range []string{"/docs/assets/LICENSE", "/docs/assets/..%2Fswaggerui.go"} {
+			resp = httptest.NewRecorder()
+			router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, path, nil))
+			assert.Equal(t, http.StatusNotFound, resp.Code, path)
+		}
+	})
+
+	t.Run("Without assets", func(t *testing.T) {
+		// Falta de arquivo é erro de build, não uma página 404
+		assert.Panics(t, func() {
+			handlers.NewSwaggerUIHandler("/openapi.json", "/docs/assets", fstest.MapFS{
+				swaggerui.StylesheetFile: {Data: []byte("body{}")},
+			})
+		})
+	})
+}
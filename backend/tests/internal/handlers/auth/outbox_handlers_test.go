@@ -0,0 +1,104 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListOutboxMessagesHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(handler *handlers.ListOutboxMessagesHandler) *gin.Engine {
+		router := newTestRouter()
+		router.GET("/admin/outbox", handler.Handle)
+		return router
+	}
+
+	t.Run("Sucesso - Repassa filtros", func(t *testing.T) {
+		mockUseCase := new(mocks.MockListOutboxMessagesUseCase)
+		mockUseCase.On("Execute", mock.Anything, dto.OutboxQueryParams{Status: "dead", Topic: "email.password_reset", Limit: 20}).
+			Return([]dto.OutboxMessageOutput{{ID: "msg-1", Status: "dead"}}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/admin/outbox?status=dead&topic=email.password_reset&limit=20", nil)
+		resp := httptest.NewRecorder()
+		newRouter(handlers.NewListOutboxMessagesHandler(mockUseCase)).ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		var output []dto.OutboxMessageOutput
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &output))
+		assert.Len(t, output, 1)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Erro - Status inválido", func(t *testing.T) {
+		mockUseCase := new(mocks.MockListOutboxMessagesUseCase)
+
+		req, _ := http.NewRequest(http.MethodGet, "/admin/outbox?status=lost", nil)
+		resp := httptest.NewRecorder()
+		newRouter(handlers.NewListOutboxMessagesHandler(mockUseCase)).ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		mockUseCase.AssertNotCalled(t, "Execute")
+	})
+
+	t.Run("Erro - Falha interna", func(t *testing.T) {
+		mockUseCase := new(mocks.MockListOutboxMessagesUseCase)
+		mockUseCase.On("Execute", mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+		req, _ := http.NewRequest(http.MethodGet, "/admin/outbox", nil)
+		resp := httptest.NewRecorder()
+		newRouter(handlers.NewListOutboxMessagesHandler(mockUseCase)).ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestReplayOutboxMessageHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(handler *handlers.ReplayOutboxMessageHandler) *gin.Engine {
+		router := newTestRouter()
+		router.POST("/admin/outbox/:id/replay", handler.Handle)
+		return router
+	}
+	id := vo.NewID()
+
+	tests := []struct {
+		name         string
+		path         string
+		err          error
+		expectedCode int
+	}{
+		{"Sucesso", "/admin/outbox/" + id.String() + "/replay", nil, http.StatusOK},
+		{"Erro - ID inválido", "/admin/outbox/abc/replay", nil, http.StatusBadRequest},
+		{"Erro - Não encontrada", "/admin/outbox/" + id.String() + "/replay", msgerror.AnErrOutboxNotFound, http.StatusNotFound},
+		{"Erro - Não está em dead", "/admin/outbox/" + id.String() + "/replay", msgerror.AnErrOutboxNotDead, http.StatusConflict},
+		{"Erro - Falha interna", "/admin/outbox/" + id.String() + "/replay", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUseCase := new(mocks.MockReplayOutboxMessageUseCase)
+			mockUseCase.On("Execute", mock.Anything, id).
+				Return(dto.OutboxMessageOutput{ID: id.String(), Status: "pending"}, tt.err)
+
+			req, _ := http.NewRequest(http.MethodPost, tt.path, nil)
+			resp := httptest.NewRecorder()
+			newRouter(handlers.NewReplayOutboxMessageHandler(mockUseCase)).ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/middleware"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRouter monta o roteador com o ProblemMiddleware, como em cmd/server.
+func newTestRouter() *gin.Engine {
+	router := gin.New()
+	router.Use(middleware.ProblemMiddleware(nil))
+	return router
+}
+
+// assertProblem confere o status, o tipo de conteúdo e o código estável da
+// resposta de erro e devolve o problema para verificações adicionais.
+func assertProblem(t *testing.T, resp *httptest.ResponseRecorder, status int, code string) dto.Problem {
+	t.Helper()
+	assert.Equal(t, status, resp.Code)
+	assert.Equal(t, middleware.ProblemContentType, resp.Header().Get("Content-Type"))
+
+	var problem dto.Problem
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &problem), resp.Body.String())
+	assert.Equal(t, status, problem.Status)
+	assert.Equal(t, code, problem.Code)
+	assert.Equal(t, middleware.ProblemTypePrefix+code, problem.Type)
+	return problem
+}
@@ -0,0 +1,161 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetProfileHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := vo.NewID()
+
+	serve := func(uc *mocks.MockGetProfileUseCase) *httptest.ResponseRecorder {
+		router := newTestRouter()
+		router.GET("/user/me", func(c *gin.Context) {
+			c.Set("userID", userID.String())
+			handlers.NewGetProfileHandler(uc).Handle(c)
+		})
+		req, _ := http.NewRequest(http.MethodGet, "/user/me", nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	t.Run("Success - Returns the user", func(t *testing.T) {
+		uc := new(mocks.MockGetProfileUseCase)
+		uc.On("Execute", mock.Anything, userID).Return(dto.UserOutput{
+			Id:         userID.String(),
+			Name:       "Maria Silva",
+			Email:      "maria@test.com",
+			ImageURL:   "https://cdn.example.com/me.png",
+			Locale:     "pt-BR",
+			Attributes: map[string]string{},
+			CreatedAt:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		}, nil)
+
+		resp := serve(uc)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.JSONEq(t, `{
+			"id": "`+userID.String()+`",
+			"name": "Maria Silva",
+			"email": "maria@test.com",
+			"image_url": "https://cdn.example.com/me.png",
+			"locale": "pt-BR",
+			"timezone": "",
+			"attributes": {},
+			"created_at": "2024-05-01T12:00:00Z"
+		}`, resp.Body.String())
+	})
+
+	t.Run("Error - User not found", func(t *testing.T) {
+		uc := new(mocks.MockGetProfileUseCase)
+		uc.On("Execute", mock.Anything, userID).Return(dto.UserOutput{}, msgerror.AnErrUserNotFound)
+
+		assert.Equal(t, http.StatusNotFound, serve(uc).Code)
+	})
+
+	t.Run("Error - Internal error", func(t *testing.T) {
+		uc := new(mocks.MockGetProfileUseCase)
+		uc.On("Execute", mock.Anything, userID).Return(dto.UserOutput{}, assert.AnError)
+
+		assert.Equal(t, http.StatusInternalServerError, serve(uc).Code)
+	})
+
+	t.Run("Error - Missing user in context", func(t *testing.T) {
+		router := newTestRouter()
+		router.GET("/user/me", handlers.NewGetProfileHandler(nil).Handle)
+		req, _ := http.NewRequest(http.MethodGet, "/user/me", nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+}
+
+func TestUpdateProfileHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := vo.NewID()
+
+	serve := func(uc *mocks.MockUpdateProfileUseCase, body string) *httptest.ResponseRecorder {
+		router := newTestRouter()
+		router.PATCH("/user/me", func(c *gin.Context) {
+			c.Set("userID", userID.String())
+			handlers.NewUpdateProfileHandler(uc).Handle(c)
+		})
+		req, _ := http.NewRequest(http.MethodPatch, "/user/me", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	t.Run("Success - Absent, null and set fields are told apart", func(t *testing.T) {
+		title := "Dev"
+		uc := new(mocks.MockUpdateProfileUseCase)
+		uc.On("Execute", mock.Anything, userID, dto.UpdateProfileInput{
+			Name:       dto.OptionalString{Set: true, Value: "Maria Souza"},
+			ImageURL:   dto.OptionalString{Set: true},
+			Attributes: map[string]*string{"company": nil, "title": &title},
+		}).Return(dto.UserOutput{Id: userID.String(), Name: "Maria Souza"}, nil)
+
+		resp := serve(uc, `{"name": "Maria Souza", "image_url": null, "attributes": {"company": null, "title": "Dev"}}`)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Body.String(), `"name":"Maria Souza"`)
+		uc.AssertExpectations(t)
+	})
+
+	t.Run("Error - Validation errors per field", func(t *testing.T) {
+		valErr := msgerror.NewValidationErrors()
+		valErr.AddError("timezone", msgerror.AnErrInvalidTimezone)
+		uc := new(mocks.MockUpdateProfileUseCase)
+		uc.On("Execute", mock.Anything, userID, mock.Anything).Return(dto.UserOutput{}, valErr)
+
+		resp := serve(uc, `{"timezone": "Mars/Olympus"}`)
+
+		problem := assertProblem(t, resp, http.StatusBadRequest, "validation_failed")
+		assert.Equal(t, []dto.FieldProblem{
+			{Field: "timezone", Code: "invalid_timezone", Detail: msgerror.AnErrInvalidTimezone.Error()},
+		}, problem.Errors)
+	})
+
+	t.Run("Error - Wrong JSON type", func(t *testing.T) {
+		uc := new(mocks.MockUpdateProfileUseCase)
+
+		resp := serve(uc, `{"name": 42}`)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		uc.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"User not found", msgerror.AnErrUserNotFound, http.StatusNotFound},
+		{"Concurrent modification", msgerror.AnErrConflict, http.StatusConflict},
+		{"Internal error", assert.AnError, http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		t.Run("Error - "+tc.name, func(t *testing.T) {
+			uc := new(mocks.MockUpdateProfileUseCase)
+			uc.On("Execute", mock.Anything, userID, mock.Anything).Return(dto.UserOutput{}, tc.err)
+
+			assert.Equal(t, tc.code, serve(uc, `{"locale": "en-US"}`).Code)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
 	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
 	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -24,7 +25,7 @@ func TestRegisterHandler_Handle(t *testing.T) {
 		mockUseCase := new(mocks.MockRegisterUseCase)
 		mockRepo := new(mocks.MockUserRepo)
 
-		handler := handlers.NewRegisterHandler(mockUseCase, mockRepo)
+		handler := handlers.NewRegisterHandler(mockUseCase, mockRepo, nil)
 
 		mockUseCase.On("Execute", mock.Anything, dto.RegisterParams{
 			Name:     "John Doe",
@@ -54,7 +55,7 @@ func TestRegisterHandler_Handle(t *testing.T) {
 		req.Header.Set("Content-Type", "application/json")
 		resp := httptest.NewRecorder()
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.POST("/register", handler.Handle)
 		router.ServeHTTP(resp, req)
 
@@ -63,21 +64,19 @@ func TestRegisterHandler_Handle(t *testing.T) {
 			"id": "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
 			"name": "John Doe",
 			"email": "john@example.com",
-			"image_url": "http://image.com",
-			"password_reset_token": "",
-			"password_reset_expires": ""
+			"image_url": "http://image.com"
 		}`, resp.Body.String())
 		mockUseCase.AssertExpectations(t)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("Erro - Body inválido", func(t *testing.T) {
-		handler := handlers.NewRegisterHandler(nil, nil)
+		handler := handlers.NewRegisterHandler(nil, nil, nil)
 
 		req, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewBufferString(`{invalid`))
 		resp := httptest.NewRecorder()
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.POST("/register", handler.Handle)
 		router.ServeHTTP(resp, req)
 
@@ -89,13 +88,13 @@ func TestRegisterHandler_Handle(t *testing.T) {
 		mockUseCase.On("Execute", mock.Anything, mock.Anything).
 			Return(errors.New("erro no banco"))
 
-		handler := handlers.NewRegisterHandler(mockUseCase, nil)
+		handler := handlers.NewRegisterHandler(mockUseCase, nil, nil)
 
 		reqBody := `{"name": "John", "email": "john@example.com", "password": "123"}`
 		req, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewBufferString(reqBody))
 		resp := httptest.NewRecorder()
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.POST("/register", handler.Handle)
 		router.ServeHTTP(resp, req)
 
@@ -104,9 +103,11 @@ func TestRegisterHandler_Handle(t *testing.T) {
 
 	t.Run("Erro - Formato de e-mail inválido", func(t *testing.T) {
 		mockUseCase := new(mocks.MockRegisterUseCase)
-		handler := handlers.NewRegisterHandler(mockUseCase, nil)
+		handler := handlers.NewRegisterHandler(mockUseCase, nil, nil)
 
-		mockUseCase.AssertNotCalled(t, "Execute")
+		valErr := msgerror.NewValidationErrors()
+		valErr.AddError("email", msgerror.AnErrInvalidEmail)
+		mockUseCase.On("Execute", mock.Anything, mock.Anything).Return(valErr)
 
 		reqBody := `{
 			"name": "John Doe",
@@ -117,19 +118,22 @@ func TestRegisterHandler_Handle(t *testing.T) {
 		req.Header.Set("Content-Type", "application/json")
 		resp := httptest.NewRecorder()
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.POST("/register", handler.Handle)
 		router.ServeHTTP(resp, req)
 
-		assert.Equal(t, http.StatusBadRequest, resp.Code)
-		assert.JSONEq(t, `{"error":"invalid email format"}`, resp.Body.String())
+		problem := assertProblem(t, resp, http.StatusBadRequest, "validation_failed")
+		if assert.Len(t, problem.Errors, 1) {
+			assert.Equal(t, "email", problem.Errors[0].Field)
+			assert.Equal(t, "invalid_email", problem.Errors[0].Code)
+		}
 	})
 
 	t.Run("Erro - Falha ao buscar usuário após registro", func(t *testing.T) {
 		mockUseCase := new(mocks.MockRegisterUseCase)
 		mockRepo := new(mocks.MockUserRepo)
 
-		handler := handlers.NewRegisterHandler(mockUseCase, mockRepo)
+		handler := handlers.NewRegisterHandler(mockUseCase, mockRepo, nil)
 
 		mockUseCase.On("Execute", mock.Anything, dto.RegisterParams{
 			Name:     "John Doe",
@@ -150,12 +154,11 @@ func TestRegisterHandler_Handle(t *testing.T) {
 		req.Header.Set("Content-Type", "application/json")
 		resp := httptest.NewRecorder()
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.POST("/register", handler.Handle)
 		router.ServeHTTP(resp, req)
 
-		assert.Equal(t, http.StatusInternalServerError, resp.Code)
-		assert.JSONEq(t, `{"error":"failed to fetch user"}`, resp.Body.String())
+		assertProblem(t, resp, http.StatusInternalServerError, "internal_error")
 		mockUseCase.AssertExpectations(t)
 		mockRepo.AssertExpectations(t)
 	})
@@ -1,4 +1,4 @@
-package handlers
+package handlers_test
 
 import (
 	"bytes"
@@ -20,11 +20,11 @@ func TestResetPasswordHandler_Handle(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	tests := []struct {
-		name         string
-		input        interface{}
-		mockSetup    func(uc *mocks.MockResetPasswordUseCase)
-		expectedCode int
-		expectedBody string
+		name            string
+		input           interface{}
+		mockSetup       func(uc *mocks.MockResetPasswordUseCase)
+		expectedCode    int
+		expectedProblem string
 	}{
 		{
 			name:  "invalid request body",
@@ -32,8 +32,8 @@ func TestResetPasswordHandler_Handle(t *testing.T) {
 			mockSetup: func(uc *mocks.MockResetPasswordUseCase) {
 				// Não configurar mock pois não deve ser chamado
 			},
-			expectedCode: http.StatusBadRequest,
-			expectedBody: `{"error":"invalid request body"}`,
+			expectedCode:    http.StatusBadRequest,
+			expectedProblem: "invalid_request_body",
 		},
 		{
 			name:  "invalid token",
@@ -42,8 +42,8 @@ func TestResetPasswordHandler_Handle(t *testing.T) {
 				uc.On("Execute", mock.Anything, "invalid", "newpass").
 					Return(msgerror.AnErrInvalidToken)
 			},
-			expectedCode: http.StatusBadRequest,
-			expectedBody: `{"error":"invalid token"}`,
+			expectedCode:    http.StatusBadRequest,
+			expectedProblem: "invalid_token",
 		},
 		{
 			name:  "expired token",
@@ -52,8 +52,18 @@ func TestResetPasswordHandler_Handle(t *testing.T) {
 				uc.On("Execute", mock.Anything, "expired", "newpass").
 					Return(msgerror.AnErrExpiredToken)
 			},
-			expectedCode: http.StatusBadRequest,
-			expectedBody: `{"error":"expired token"}`,
+			expectedCode:    http.StatusBadRequest,
+			expectedProblem: "expired_token",
+		},
+		{
+			name:  "concurrent modification",
+			input: dto.ResetPasswordInput{Token: "valid", Password: "newpass"},
+			mockSetup: func(uc *mocks.MockResetPasswordUseCase) {
+				uc.On("Execute", mock.Anything, "valid", "newpass").
+					Return(msgerror.AnErrConflict)
+			},
+			expectedCode:    http.StatusConflict,
+			expectedProblem: "conflict",
 		},
 		{
 			name:  "internal server error",
@@ -62,8 +72,8 @@ func TestResetPasswordHandler_Handle(t *testing.T) {
 				uc.On("Execute", mock.Anything, "valid", "newpass").
 					Return(assert.AnError)
 			},
-			expectedCode: http.StatusInternalServerError,
-			expectedBody: `{"error":"failed to reset password"}`,
+			expectedCode:    http.StatusInternalServerError,
+			expectedProblem: "internal_error",
 		},
 		{
 			name:  "success",
@@ -73,7 +83,6 @@ func TestResetPasswordHandler_Handle(t *testing.T) {
 					Return(nil)
 			},
 			expectedCode: http.StatusNoContent,
-			expectedBody: "",
 		},
 	}
 
@@ -89,7 +98,7 @@ func TestResetPasswordHandler_Handle(t *testing.T) {
 			handler := handlers.NewResetPasswordHandler(uc)
 
 			// Configura o roteador Gin
-			router := gin.New()
+			router := newTestRouter()
 			router.POST("/reset-password", handler.Handle)
 
 			// Cria a requisição
@@ -109,8 +118,12 @@ func TestResetPasswordHandler_Handle(t *testing.T) {
 			router.ServeHTTP(w, req)
 
 			// Verificações
-			assert.Equal(t, tt.expectedCode, w.Code, "Código de status HTTP incorreto")
-			assert.Equal(t, tt.expectedBody, w.Body.String(), "Corpo da resposta incorreto")
+			if tt.expectedProblem != "" {
+				assertProblem(t, w, tt.expectedCode, tt.expectedProblem)
+			} else {
+				assert.Equal(t, tt.expectedCode, w.Code, "Código de status HTTP incorreto")
+				assert.Empty(t, w.Body.String(), "Corpo da resposta incorreto")
+			}
 
 			// Verifica se o mock foi chamado conforme esperado
 			uc.AssertExpectations(t)
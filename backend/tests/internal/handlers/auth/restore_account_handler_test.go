@@ -0,0 +1,66 @@
+package handlers_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRestoreAccountHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(uc *mocks.MockRestoreAccountUseCase, body string) *httptest.ResponseRecorder {
+		router := newTestRouter()
+		router.POST("/auth/restore", handlers.NewRestoreAccountHandler(uc).Handle)
+
+		req, _ := http.NewRequest(http.MethodPost, "/auth/restore", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	t.Run("Restored", func(t *testing.T) {
+		uc := new(mocks.MockRestoreAccountUseCase)
+		uc.On("Execute", mock.Anything, "maria@test.com", "secret123").Return(vo.NewID(), nil)
+
+		resp := serve(uc, `{"email":"maria@test.com","password":"secret123"}`)
+
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+		uc.AssertExpectations(t)
+	})
+
+	t.Run("InvalidBody", func(t *testing.T) {
+		resp := serve(new(mocks.MockRestoreAccountUseCase), `{"email":"maria@test.com"}`)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("InvalidCredentials", func(t *testing.T) {
+		uc := new(mocks.MockRestoreAccountUseCase)
+		uc.On("Execute", mock.Anything, "maria@test.com", "wrong").Return(vo.ID{}, msgerror.AnErrInvalidCredentials)
+
+		resp := serve(uc, `{"email":"maria@test.com","password":"wrong"}`)
+
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+
+	t.Run("InternalError", func(t *testing.T) {
+		uc := new(mocks.MockRestoreAccountUseCase)
+		uc.On("Execute", mock.Anything, "maria@test.com", "secret123").Return(vo.ID{}, errors.New("db down"))
+
+		resp := serve(uc, `{"email":"maria@test.com","password":"secret123"}`)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
@@ -0,0 +1,67 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRevokeAPIKeyHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID, _ := vo.ParseID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	keyID, _ := vo.ParseID("7ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	newRouter := func(handler *handlers.RevokeAPIKeyHandler) *gin.Engine {
+		router := newTestRouter()
+		router.DELETE("/user/api-keys/:id", func(c *gin.Context) {
+			c.Set("userID", userID.String())
+			handler.Handle(c)
+		})
+		return router
+	}
+
+	cases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"Sucesso - Chave revogada", nil, http.StatusNoContent},
+		{"Erro - Chave inexistente", msgerror.AnErrAPIKeyNotFound, http.StatusNotFound},
+		{"Erro - Falha interna", errors.New("db error"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUseCase := new(mocks.MockRevokeAPIKeyUseCase)
+			handler := handlers.NewRevokeAPIKeyHandler(mockUseCase)
+
+			mockUseCase.On("Execute", mock.Anything, userID, keyID).Return(tc.err)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/user/api-keys/"+keyID.String(), nil)
+			resp := httptest.NewRecorder()
+			newRouter(handler).ServeHTTP(resp, req)
+
+			assert.Equal(t, tc.expected, resp.Code)
+			mockUseCase.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Erro - ID inválido", func(t *testing.T) {
+		handler := handlers.NewRevokeAPIKeyHandler(nil)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/user/api-keys/not-a-uuid", nil)
+		resp := httptest.NewRecorder()
+		newRouter(handler).ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+}
@@ -0,0 +1,71 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	handlers "github.com/eskokado/startup-auth-go/backend/internal/handlers/auth"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newRevokeRequest(form url.Values) *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, "/oauth/revoke", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func TestRevokeHandler_Handle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Sucesso - Token revogado", func(t *testing.T) {
+		mockUseCase := new(mocks.MockLogoutUseCase)
+		handler := handlers.NewRevokeHandler(mockUseCase)
+
+		mockUseCase.On("Execute", mock.Anything, "valid_token").Return(nil)
+
+		router := newTestRouter()
+		router.POST("/oauth/revoke", handler.Handle)
+
+		form := url.Values{"token": {"valid_token"}, "token_type_hint": {"access_token"}}
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, newRevokeRequest(form))
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Erro - Token ausente", func(t *testing.T) {
+		handler := handlers.NewRevokeHandler(nil)
+
+		router := newTestRouter()
+		router.POST("/oauth/revoke", handler.Handle)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, newRevokeRequest(url.Values{}))
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, resp.Body.String(), "invalid_request")
+	})
+
+	t.Run("Erro - Falha ao revogar", func(t *testing.T) {
+		mockUseCase := new(mocks.MockLogoutUseCase)
+		handler := handlers.NewRevokeHandler(mockUseCase)
+
+		mockUseCase.On("Execute", mock.Anything, "valid_token").Return(errors.New("redis down"))
+
+		router := newTestRouter()
+		router.POST("/oauth/revoke", handler.Handle)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, newRevokeRequest(url.Values{"token": {"valid_token"}}))
+
+		assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	})
+}
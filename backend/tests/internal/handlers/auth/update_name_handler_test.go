@@ -38,7 +38,7 @@ func TestUpdateNameHandler_Handle(t *testing.T) {
 
 		mockUseCase.On("Execute", mock.Anything, userID, "New Name").Return(nil)
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.PUT("/name", func(c *gin.Context) {
 			c.Set("userID", userID.String())
 			handler.Handle(c)
@@ -58,7 +58,7 @@ func TestUpdateNameHandler_Handle(t *testing.T) {
 	t.Run("Error - Invalid user ID in context", func(t *testing.T) {
 		handler := handlers.NewUpdateNameHandler(nil)
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.PUT("/name", handler.Handle)
 
 		req, _ := http.NewRequest(http.MethodPut, "/name", bytes.NewBufferString(`{"name": "New Name"}`))
@@ -77,7 +77,7 @@ func TestUpdateNameHandler_Handle(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.PUT("/name", func(c *gin.Context) {
 			c.Set("userID", userID.String())
 			handler.Handle(c)
@@ -103,7 +103,7 @@ func TestUpdateNameHandler_Handle(t *testing.T) {
 
 		mockUseCase.On("Execute", mock.Anything, userID, "Short").Return(msgerror.AnErrNameTooShort)
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.PUT("/name", func(c *gin.Context) {
 			c.Set("userID", userID.String())
 			handler.Handle(c)
@@ -116,8 +116,7 @@ func TestUpdateNameHandler_Handle(t *testing.T) {
 
 		router.ServeHTTP(resp, req)
 
-		assert.Equal(t, http.StatusBadRequest, resp.Code)
-		assert.JSONEq(t, `{"error": "name too short"}`, resp.Body.String())
+		assertProblem(t, resp, http.StatusBadRequest, "name_too_short")
 		mockUseCase.AssertExpectations(t)
 	})
 
@@ -133,7 +132,7 @@ func TestUpdateNameHandler_Handle(t *testing.T) {
 		// Simula erro não mapeado
 		mockUseCase.On("Execute", mock.Anything, userID, "newName").Return(assert.AnError)
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.PUT("/name", func(c *gin.Context) {
 			c.Set("userID", userID.String())
 			handler.Handle(c)
@@ -146,8 +145,7 @@ func TestUpdateNameHandler_Handle(t *testing.T) {
 
 		router.ServeHTTP(resp, req)
 
-		assert.Equal(t, http.StatusInternalServerError, resp.Code)
-		assert.JSONEq(t, `{"error": "failed to update name"}`, resp.Body.String())
+		assertProblem(t, resp, http.StatusInternalServerError, "internal_error")
 		mockUseCase.AssertExpectations(t)
 	})
 
@@ -163,7 +161,7 @@ func TestUpdateNameHandler_Handle(t *testing.T) {
 		// Simula erro de usuário não encontrado
 		mockUseCase.On("Execute", mock.Anything, userID, "newName").Return(msgerror.AnErrUserNotFound)
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.PUT("/name", func(c *gin.Context) {
 			c.Set("userID", userID.String())
 			handler.Handle(c)
@@ -176,15 +174,41 @@ func TestUpdateNameHandler_Handle(t *testing.T) {
 
 		router.ServeHTTP(resp, req)
 
-		assert.Equal(t, http.StatusNotFound, resp.Code)
-		assert.JSONEq(t, `{"error": "user not found"}`, resp.Body.String())
+		assertProblem(t, resp, http.StatusNotFound, "user_not_found")
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Error - Concurrent modification", func(t *testing.T) {
+		mockUseCase := new(MockUpdateNameUseCase)
+		handler := handlers.NewUpdateNameHandler(mockUseCase)
+
+		userID, err := vo.ParseID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mockUseCase.On("Execute", mock.Anything, userID, "newName").Return(msgerror.AnErrConflict)
+
+		router := newTestRouter()
+		router.PUT("/name", func(c *gin.Context) {
+			c.Set("userID", userID.String())
+			handler.Handle(c)
+		})
+
+		req, _ := http.NewRequest(http.MethodPut, "/name", bytes.NewBufferString(`{"name": "newName"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+
+		assertProblem(t, resp, http.StatusConflict, "conflict")
 		mockUseCase.AssertExpectations(t)
 	})
 
 	t.Run("Error - Invalid user ID format in context", func(t *testing.T) {
 		handler := handlers.NewUpdateNameHandler(nil)
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.PUT("/name", func(c *gin.Context) {
 			c.Set("userID", "invalid-uuid-format") // ID inválido
 			handler.Handle(c)
@@ -197,7 +221,6 @@ func TestUpdateNameHandler_Handle(t *testing.T) {
 
 		router.ServeHTTP(resp, req)
 
-		assert.Equal(t, http.StatusBadRequest, resp.Code)
-		assert.JSONEq(t, `{"error": "invalid ID format"}`, resp.Body.String())
+		assertProblem(t, resp, http.StatusBadRequest, "invalid_id")
 	})
 }
@@ -38,7 +38,7 @@ func TestUpdatePasswordHandler_Handle(t *testing.T) {
 
 		mockUseCase.On("Execute", mock.Anything, userID, "oldPass", "newPass").Return(nil)
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.PUT("/password", func(c *gin.Context) {
 			c.Set("userID", userID.String())
 			handler.Handle(c)
@@ -58,7 +58,7 @@ func TestUpdatePasswordHandler_Handle(t *testing.T) {
 	t.Run("Error - Invalid user ID in context", func(t *testing.T) {
 		handler := handlers.NewUpdatePasswordHandler(nil)
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.PUT("/password", handler.Handle)
 
 		reqBody := `{"current_password": "old", "new_password": "new"}`
@@ -78,7 +78,7 @@ func TestUpdatePasswordHandler_Handle(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.PUT("/password", func(c *gin.Context) {
 			c.Set("userID", userID.String())
 			handler.Handle(c)
@@ -104,7 +104,7 @@ func TestUpdatePasswordHandler_Handle(t *testing.T) {
 
 		mockUseCase.On("Execute", mock.Anything, userID, "wrong", "newPass").Return(msgerror.AnErrInvalidCredentials)
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.PUT("/password", func(c *gin.Context) {
 			c.Set("userID", userID.String())
 			handler.Handle(c)
@@ -117,8 +117,7 @@ func TestUpdatePasswordHandler_Handle(t *testing.T) {
 
 		router.ServeHTTP(resp, req)
 
-		assert.Equal(t, http.StatusUnauthorized, resp.Code)
-		assert.JSONEq(t, `{"error": "invalid credentials"}`, resp.Body.String())
+		assertProblem(t, resp, http.StatusUnauthorized, "invalid_credentials")
 		mockUseCase.AssertExpectations(t)
 	})
 
@@ -133,7 +132,7 @@ func TestUpdatePasswordHandler_Handle(t *testing.T) {
 
 		mockUseCase.On("Execute", mock.Anything, userID, "oldPass", "weak").Return(msgerror.AnErrWeakPassword)
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.PUT("/password", func(c *gin.Context) {
 			c.Set("userID", userID.String())
 			handler.Handle(c)
@@ -146,15 +145,14 @@ func TestUpdatePasswordHandler_Handle(t *testing.T) {
 
 		router.ServeHTTP(resp, req)
 
-		assert.Equal(t, http.StatusBadRequest, resp.Code)
-		assert.JSONEq(t, `{"error": "password does not meet security requirements"}`, resp.Body.String())
+		assertProblem(t, resp, http.StatusBadRequest, "weak_password")
 		mockUseCase.AssertExpectations(t)
 	})
 
 	t.Run("Error - Invalid user ID format in context", func(t *testing.T) {
 		handler := handlers.NewUpdatePasswordHandler(nil)
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.PUT("/password", func(c *gin.Context) {
 			c.Set("userID", "invalid-uuid-format") // ID inválido
 			handler.Handle(c)
@@ -167,8 +165,7 @@ func TestUpdatePasswordHandler_Handle(t *testing.T) {
 
 		router.ServeHTTP(resp, req)
 
-		assert.Equal(t, http.StatusBadRequest, resp.Code)
-		assert.JSONEq(t, `{"error": "invalid ID format"}`, resp.Body.String())
+		assertProblem(t, resp, http.StatusBadRequest, "invalid_id")
 	})
 
 	t.Run("Error - User not found", func(t *testing.T) {
@@ -183,7 +180,35 @@ func TestUpdatePasswordHandler_Handle(t *testing.T) {
 		// Simula erro de usuário não encontrado
 		mockUseCase.On("Execute", mock.Anything, userID, "oldPass", "newPass").Return(msgerror.AnErrUserNotFound)
 
-		router := gin.Default()
+		router := newTestRouter()
+		router.PUT("/password", func(c *gin.Context) {
+			c.Set("userID", userID.String())
+			handler.Handle(c)
+		})
+
+		reqBody := `{"current_password": "oldPass", "new_password": "newPass"}`
+		req, _ := http.NewRequest(http.MethodPut, "/password", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+
+		assertProblem(t, resp, http.StatusNotFound, "user_not_found")
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Error - Concurrent modification", func(t *testing.T) {
+		mockUseCase := new(MockUpdatePasswordUseCase)
+		handler := handlers.NewUpdatePasswordHandler(mockUseCase)
+
+		userID, err := vo.ParseID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mockUseCase.On("Execute", mock.Anything, userID, "oldPass", "newPass").Return(msgerror.AnErrConflict)
+
+		router := newTestRouter()
 		router.PUT("/password", func(c *gin.Context) {
 			c.Set("userID", userID.String())
 			handler.Handle(c)
@@ -196,8 +221,7 @@ func TestUpdatePasswordHandler_Handle(t *testing.T) {
 
 		router.ServeHTTP(resp, req)
 
-		assert.Equal(t, http.StatusNotFound, resp.Code)
-		assert.JSONEq(t, `{"error": "user not found"}`, resp.Body.String())
+		assert.Equal(t, http.StatusConflict, resp.Code)
 		mockUseCase.AssertExpectations(t)
 	})
 
@@ -213,7 +237,7 @@ func TestUpdatePasswordHandler_Handle(t *testing.T) {
 		// Simula erro não mapeado
 		mockUseCase.On("Execute", mock.Anything, userID, "oldPass", "newPass").Return(assert.AnError)
 
-		router := gin.Default()
+		router := newTestRouter()
 		router.PUT("/password", func(c *gin.Context) {
 			c.Set("userID", userID.String())
 			handler.Handle(c)
@@ -226,8 +250,7 @@ func TestUpdatePasswordHandler_Handle(t *testing.T) {
 
 		router.ServeHTTP(resp, req)
 
-		assert.Equal(t, http.StatusInternalServerError, resp.Code)
-		assert.JSONEq(t, `{"error": "failed to update password"}`, resp.Body.String())
+		assertProblem(t, resp, http.StatusInternalServerError, "internal_error")
 		mockUseCase.AssertExpectations(t)
 	})
 }
@@ -0,0 +1,61 @@
+package jobs_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/jobs"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAccountPurger_RunOnceDrainsBatches(t *testing.T) {
+	uc := new(mocks.MockPurgeAccountsUseCase)
+	uc.On("Execute", mock.Anything).Return(100, nil).Once()
+	uc.On("Execute", mock.Anything).Return(3, nil).Once()
+	uc.On("Execute", mock.Anything).Return(0, nil).Once()
+
+	var buf bytes.Buffer
+	jobs.NewAccountPurger(uc, time.Hour, slog.New(slog.NewTextHandler(&buf, nil))).RunOnce(context.Background())
+
+	uc.AssertExpectations(t)
+	assert.Contains(t, buf.String(), "count=100")
+	assert.Contains(t, buf.String(), "count=3")
+}
+
+func TestAccountPurger_RunOnceStopsOnError(t *testing.T) {
+	uc := new(mocks.MockPurgeAccountsUseCase)
+	uc.On("Execute", mock.Anything).Return(1, errors.New("db down")).Once()
+
+	var buf bytes.Buffer
+	jobs.NewAccountPurger(uc, time.Hour, slog.New(slog.NewTextHandler(&buf, nil))).RunOnce(context.Background())
+
+	uc.AssertNumberOfCalls(t, "Execute", 1)
+	assert.Contains(t, buf.String(), "db down")
+}
+
+func TestAccountPurger_RunStopsWhenContextIsCancelled(t *testing.T) {
+	uc := new(mocks.MockPurgeAccountsUseCase)
+	uc.On("Execute", mock.Anything).Return(0, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		jobs.NewAccountPurger(uc, time.Millisecond, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))).Run(ctx)
+		close(done)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("purger não parou após o cancelamento")
+	}
+}
@@ -0,0 +1,55 @@
+package jobs_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/jobs"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportCleaner_RunOnceDeletesExpiredExports(t *testing.T) {
+	store := new(mocks.MockExportStore)
+	store.On("DeleteOlderThan", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= 15*time.Minute && time.Since(before) < 16*time.Minute
+	})).Return(nil).Once()
+
+	jobs.NewExportCleaner(store, 15*time.Minute, nil).RunOnce(context.Background())
+
+	store.AssertExpectations(t)
+}
+
+func TestExportCleaner_RunOnceLogsErrors(t *testing.T) {
+	store := new(mocks.MockExportStore)
+	store.On("DeleteOlderThan", mock.Anything, mock.Anything).Return(errors.New("disk full"))
+
+	var buf bytes.Buffer
+	jobs.NewExportCleaner(store, time.Minute, slog.New(slog.NewTextHandler(&buf, nil))).RunOnce(context.Background())
+
+	assert.Contains(t, buf.String(), "disk full")
+}
+
+func TestExportCleaner_RunStopsWhenContextIsCancelled(t *testing.T) {
+	store := new(mocks.MockExportStore)
+	store.On("DeleteOlderThan", mock.Anything, mock.Anything).Return(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		jobs.NewExportCleaner(store, time.Millisecond, nil).Run(ctx)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("cleaner did not stop")
+	}
+}
@@ -0,0 +1,171 @@
+package jobs_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/jobs"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var outboxOptions = jobs.OutboxOptions{
+	PollInterval: time.Hour,
+	BatchSize:    2,
+	MaxAttempts:  3,
+	BackoffBase:  time.Second,
+	BackoffMax:   10 * time.Second,
+}
+
+func newOutboxMessage(t *testing.T, topic string) *entity.OutboxMessage {
+	t.Helper()
+	message, err := entity.NewOutboxMessage(topic, map[string]string{"k": "v"})
+	require.NoError(t, err)
+	return message
+}
+
+func TestOutboxDispatcher_Backoff(t *testing.T) {
+	d := jobs.NewOutboxDispatcher(nil, nil, outboxOptions, nil)
+
+	assert.Equal(t, time.Second, d.Backoff(1))
+	assert.Equal(t, 2*time.Second, d.Backoff(2))
+	assert.Equal(t, 8*time.Second, d.Backoff(4))
+	assert.Equal(t, 10*time.Second, d.Backoff(5))
+	assert.Equal(t, 10*time.Second, d.Backoff(60))
+}
+
+func TestOutboxDispatcher_RunOnce(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("DeliversAndDrainsBatches", func(t *testing.T) {
+		repo := new(mocks.MockOutboxRepo)
+		first, second, third := newOutboxMessage(t, "a"), newOutboxMessage(t, "a"), newOutboxMessage(t, "a")
+		repo.On("ClaimDue", ctx, mock.Anything, 5*time.Minute, 2).Return([]*entity.OutboxMessage{first, second}, nil).Once()
+		repo.On("ClaimDue", ctx, mock.Anything, 5*time.Minute, 2).Return([]*entity.OutboxMessage{third}, nil).Once()
+		repo.On("Update", ctx, mock.MatchedBy(func(m *entity.OutboxMessage) bool {
+			return m.Status == entity.OutboxStatusSent && m.Attempts == 1
+		})).Return(nil).Times(3)
+
+		var delivered int
+		handlers := map[string]jobs.OutboxHandler{
+			"a": func(context.Context, []byte) error { delivered++; return nil },
+		}
+
+		processed := jobs.NewOutboxDispatcher(repo, handlers, outboxOptions, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))).RunOnce(ctx)
+
+		assert.Equal(t, 3, processed)
+		assert.Equal(t, 3, delivered)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("SchedulesRetryWithBackoff", func(t *testing.T) {
+		repo := new(mocks.MockOutboxRepo)
+		message := newOutboxMessage(t, "a")
+		message.Attempts = 1
+		repo.On("ClaimDue", ctx, mock.Anything, mock.Anything, 2).Return([]*entity.OutboxMessage{message}, nil).Once()
+		repo.On("Update", ctx, message).Return(nil)
+
+		handlers := map[string]jobs.OutboxHandler{
+			"a": func(context.Context, []byte) error { return errors.New("smtp down") },
+		}
+
+		before := time.Now()
+		jobs.NewOutboxDispatcher(repo, handlers, outboxOptions, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))).RunOnce(ctx)
+
+		assert.Equal(t, entity.OutboxStatusPending, message.Status)
+		assert.Equal(t, 2, message.Attempts)
+		assert.Equal(t, "smtp down", message.LastError)
+		assert.WithinDuration(t, before.Add(2*time.Second), message.NextAttemptAt, time.Second)
+	})
+
+	t.Run("MovesToDeadLetter", func(t *testing.T) {
+		repo := new(mocks.MockOutboxRepo)
+		exhausted, unknown := newOutboxMessage(t, "a"), newOutboxMessage(t, "unknown")
+		exhausted.Attempts = 2
+		repo.On("ClaimDue", ctx, mock.Anything, mock.Anything, 2).Return([]*entity.OutboxMessage{exhausted, unknown}, nil).Once()
+		repo.On("ClaimDue", ctx, mock.Anything, mock.Anything, 2).Return([]*entity.OutboxMessage{}, nil).Once()
+		repo.On("Update", ctx, mock.Anything).Return(nil)
+
+		handlers := map[string]jobs.OutboxHandler{
+			"a": func(context.Context, []byte) error { return errors.New("smtp down") },
+		}
+
+		var buf bytes.Buffer
+		jobs.NewOutboxDispatcher(repo, handlers, outboxOptions, slog.New(slog.NewTextHandler(&buf, nil))).RunOnce(ctx)
+
+		assert.True(t, exhausted.IsDead())
+		assert.True(t, unknown.IsDead())
+		assert.Equal(t, 1, unknown.Attempts)
+		assert.Contains(t, unknown.LastError, `no handler for topic "unknown"`)
+		assert.Contains(t, buf.String(), "moved to dead letter")
+	})
+
+	t.Run("StopsOnClaimError", func(t *testing.T) {
+		repo := new(mocks.MockOutboxRepo)
+		repo.On("ClaimDue", ctx, mock.Anything, mock.Anything, 2).Return(nil, errors.New("db down")).Once()
+
+		var buf bytes.Buffer
+		processed := jobs.NewOutboxDispatcher(repo, nil, outboxOptions, slog.New(slog.NewTextHandler(&buf, nil))).RunOnce(ctx)
+
+		assert.Zero(t, processed)
+		assert.Contains(t, buf.String(), "db down")
+	})
+}
+
+func TestEmailOutboxHandlers_PasswordReset(t *testing.T) {
+	emailService := new(mocks.MockEmailService)
+	email, _ := vo.NewEmail("maria@test.com")
+	emailService.On("SendResetPasswordEmail", mock.Anything, email, "en-US", "abc").Return(nil)
+
+	message, err := entity.NewOutboxMessage(entity.OutboxTopicPasswordResetEmail, entity.PasswordResetEmailPayload{
+		Email:  "maria@test.com",
+		Token:  "abc",
+		Locale: "en-US",
+	})
+	require.NoError(t, err)
+
+	handler := jobs.EmailOutboxHandlers(emailService)[entity.OutboxTopicPasswordResetEmail]
+	require.NotNil(t, handler)
+	assert.NoError(t, handler(context.Background(), message.Payload))
+	assert.Error(t, handler(context.Background(), []byte("{")))
+	emailService.AssertExpectations(t)
+}
+
+func TestEmailOutboxHandlers_EmailChange(t *testing.T) {
+	emailService := new(mocks.MockEmailService)
+	oldEmail, _ := vo.NewEmail("maria@test.com")
+	newEmail, _ := vo.NewEmail("maria.nova@test.com")
+	emailService.On("SendEmailChangeConfirmation", mock.Anything, newEmail, "pt-BR", "confirm").Return(nil)
+	emailService.On("SendEmailChangeNotice", mock.Anything, oldEmail, "pt-BR", newEmail, "cancel").Return(nil)
+
+	confirmation, err := entity.NewOutboxMessage(entity.OutboxTopicEmailChangeConfirmationEmail, entity.EmailChangeConfirmationPayload{
+		Email:  "maria.nova@test.com",
+		Token:  "confirm",
+		Locale: "pt-BR",
+	})
+	require.NoError(t, err)
+	notice, err := entity.NewOutboxMessage(entity.OutboxTopicEmailChangeNoticeEmail, entity.EmailChangeNoticePayload{
+		Email:       "maria@test.com",
+		NewEmail:    "maria.nova@test.com",
+		CancelToken: "cancel",
+		Locale:      "pt-BR",
+	})
+	require.NoError(t, err)
+
+	handlers := jobs.EmailOutboxHandlers(emailService)
+	for _, message := range []*entity.OutboxMessage{confirmation, notice} {
+		handler := handlers[message.Topic]
+		require.NotNil(t, handler, message.Topic)
+		assert.NoError(t, handler(context.Background(), message.Payload))
+		assert.Error(t, handler(context.Background(), []byte("{")))
+	}
+	emailService.AssertExpectations(t)
+}
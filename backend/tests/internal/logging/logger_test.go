@@ -0,0 +1,59 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/logging"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, logging.FormatJSON, "info")
+	require.NoError(t, err)
+
+	logger.Debug("hidden")
+	logger.Info("login", slog.String("user_id", "u1"))
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "login", record["msg"])
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, "u1", record["user_id"])
+}
+
+func TestNew_Text(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, logging.FormatText, "DEBUG")
+	require.NoError(t, err)
+
+	logger.Debug("visible", slog.String("password", "hunter22"))
+	assert.Contains(t, buf.String(), "msg=visible")
+	assert.Contains(t, buf.String(), "password="+logging.Redacted)
+	assert.NotContains(t, buf.String(), "hunter22")
+}
+
+func TestNew_Invalid(t *testing.T) {
+	_, err := logging.New(&bytes.Buffer{}, "xml", "info")
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidLogFormat)
+
+	_, err = logging.New(&bytes.Buffer{}, logging.FormatJSON, "verbose")
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidLogLevel)
+}
+
+func TestFromContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Same(t, slog.Default(), logging.FromContext(ctx))
+	assert.Empty(t, logging.RequestIDFromContext(ctx))
+
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	ctx = logging.WithRequestID(logging.WithLogger(ctx, logger), "req-1")
+	assert.Same(t, logger, logging.FromContext(ctx))
+	assert.Equal(t, "req-1", logging.RequestIDFromContext(ctx))
+}
@@ -0,0 +1,63 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSensitiveKey(t *testing.T) {
+	for _, key := range []string{
+		"password", "new_password", "currentPassword", "token", "reset_token", "refresh-token",
+		"Authorization", "X-Api-Key", "client_secret", "Cookie", "Set-Cookie", "X-CSRF-Token",
+	} {
+		assert.True(t, logging.IsSensitiveKey(key), key)
+	}
+	for _, key := range []string{"email", "user_id", "request_id", "status", "path"} {
+		assert.False(t, logging.IsSensitiveKey(key), key)
+	}
+}
+
+func TestReplaceAttr_RedactsSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, logging.FormatJSON, "info")
+	require.NoError(t, err)
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer eyJhbGciOi")
+	header.Set("Content-Type", "application/json")
+	header.Set("X-Forwarded-Auth", "ApiKey sk_live_123")
+
+	logger.With(slog.String("reset_token", "rt-123")).Info("event",
+		slog.String("password", "hunter22"),
+		slog.String("note", "Bearer eyJhbGciOi"),
+		slog.Group("request", slog.String("email", "ana@test.com"), slog.String("access_token", "at-1")),
+		slog.Any("headers", header),
+		slog.Any("error", errors.New("boom")),
+	)
+
+	raw := buf.String()
+	for _, secret := range []string{"hunter22", "rt-123", "eyJhbGciOi", "at-1", "sk_live_123"} {
+		assert.NotContains(t, raw, secret)
+	}
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, logging.Redacted, record["password"])
+	assert.Equal(t, logging.Redacted, record["reset_token"])
+	assert.Equal(t, logging.Redacted, record["note"])
+	assert.Equal(t, map[string]any{"email": "ana@test.com", "access_token": logging.Redacted}, record["request"])
+	assert.Equal(t, map[string]any{
+		"Authorization":    logging.Redacted,
+		"Content-Type":     "application/json",
+		"X-Forwarded-Auth": logging.Redacted,
+	}, record["headers"])
+	assert.Equal(t, "boom", record["error"])
+}
@@ -0,0 +1,379 @@
+package metrics_test
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// O registro é escrito à mão; estes testes conferem a saída com as regras do
+// formato de texto 0.0.4 do Prometheus
+// (https://prometheus.io/docs/instrumenting/exposition_formats/), lida por um
+// parser independente do código que a gera.
+
+var (
+	metricNamePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelNamePattern  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+type expositionSample struct {
+	name   string
+	labels map[string]string
+	value  float64
+}
+
+type expositionFamily struct {
+	name    string
+	kind    string
+	help    string
+	samples []expositionSample
+}
+
+// parseExposition segue a gramática do formato e as regras de agrupamento:
+// TYPE e HELP no máximo uma vez e antes das amostras, amostras de uma família
+// contíguas e nenhuma série repetida.
+func parseExposition(text string) ([]*expositionFamily, error) {
+	var families []*expositionFamily
+	byName := map[string]*expositionFamily{}
+	seen := map[string]bool{}
+	var current *expositionFamily
+
+	family := func(name string) (*expositionFamily, error) {
+		if f, ok := byName[name]; ok {
+			if f != current {
+				return nil, fmt.Errorf("family %s is not contiguous", name)
+			}
+			return f, nil
+		}
+		f := &expositionFamily{name: name, kind: "untyped"}
+		byName[name] = f
+		families = append(families, f)
+		current = f
+		return f, nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		switch {
+		case strings.TrimSpace(line) == "":
+			continue
+		case strings.HasPrefix(line, "# HELP ") || strings.HasPrefix(line, "# TYPE "):
+			fields := strings.SplitN(line[2:], " ", 3)
+			if len(fields) < 2 || !metricNamePattern.MatchString(fields[1]) {
+				return nil, fmt.Errorf("line %d: bad metric name", n)
+			}
+			f, err := family(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			if len(f.samples) > 0 {
+				return nil, fmt.Errorf("line %d: %s after samples", n, fields[0])
+			}
+			doc := ""
+			if len(fields) == 3 {
+				doc = fields[2]
+			}
+			if fields[0] == "HELP" {
+				if f.help != "" {
+					return nil, fmt.Errorf("line %d: HELP repeated", n)
+				}
+				help, err := unescape(doc, false)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", n, err)
+				}
+				f.help = help
+				continue
+			}
+			if f.kind != "untyped" {
+				return nil, fmt.Errorf("line %d: TYPE repeated", n)
+			}
+			switch doc {
+			case "counter", "gauge", "histogram", "summary", "untyped":
+				f.kind = doc
+			default:
+				return nil, fmt.Errorf("line %d: unknown type %q", n, doc)
+			}
+		case strings.HasPrefix(line, "#"):
+			continue
+		default:
+			sample, err := parseSample(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			f, err := family(familyName(sample.name, byName))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			key := sample.name + fmt.Sprint(sample.labels)
+			if seen[key] {
+				return nil, fmt.Errorf("line %d: duplicated series %s", n, key)
+			}
+			seen[key] = true
+			f.samples = append(f.samples, sample)
+		}
+	}
+	return families, scanner.Err()
+}
+
+// familyName liga _bucket, _sum e _count ao histograma declarado.
+func familyName(sample string, byName map[string]*expositionFamily) string {
+	for _, suffix := range []string{"_bucket", "_sum", "_count"} {
+		if base, ok := strings.CutSuffix(sample, suffix); ok {
+			if f, ok := byName[base]; ok && f.kind == "histogram" {
+				return base
+			}
+		}
+	}
+	return sample
+}
+
+func parseSample(line string) (expositionSample, error) {
+	sample := expositionSample{labels: map[string]string{}}
+	end := strings.IndexAny(line, "{ ")
+	if end < 0 {
+		return sample, fmt.Errorf("sample without value")
+	}
+	sample.name = line[:end]
+	if !metricNamePattern.MatchString(sample.name) {
+		return sample, fmt.Errorf("bad metric name %q", sample.name)
+	}
+	rest := line[end:]
+
+	if strings.HasPrefix(rest, "{") {
+		rest = rest[1:]
+		for !strings.HasPrefix(rest, "}") {
+			eq := strings.Index(rest, `="`)
+			if eq < 0 {
+				return sample, fmt.Errorf("bad label pair in %q", line)
+			}
+			name := rest[:eq]
+			if !labelNamePattern.MatchString(name) || strings.HasPrefix(name, "__") {
+				return sample, fmt.Errorf("bad label name %q", name)
+			}
+			if _, dup := sample.labels[name]; dup {
+				return sample, fmt.Errorf("label %q repeated", name)
+			}
+			rest = rest[eq+2:]
+			closing := -1
+			for i := 0; i < len(rest); i++ {
+				if rest[i] == '\\' {
+					i++
+					continue
+				}
+				if rest[i] == '"' {
+					closing = i
+					break
+				}
+			}
+			if closing < 0 {
+				return sample, fmt.Errorf("unterminated label value in %q", line)
+			}
+			value, err := unescape(rest[:closing], true)
+			if err != nil {
+				return sample, err
+			}
+			sample.labels[name] = value
+			rest = rest[closing+1:]
+			if strings.HasPrefix(rest, ",") {
+				rest = rest[1:]
+			} else if !strings.HasPrefix(rest, "}") {
+				return sample, fmt.Errorf("expected , or } in %q", line)
+			}
+		}
+		rest = rest[1:]
+	}
+
+	fields := strings.Fields(rest)
+	if !strings.HasPrefix(rest, " ") || len(fields) < 1 || len(fields) > 2 {
+		return sample, fmt.Errorf("expected value [timestamp] in %q", line)
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return sample, fmt.Errorf("bad value %q", fields[0])
+	}
+	sample.value = value
+	if len(fields) == 2 {
+		if _, err := strconv.ParseInt(fields[1], 10, 64); err != nil {
+			return sample, fmt.Errorf("bad timestamp %q", fields[1])
+		}
+	}
+	return sample, nil
+}
+
+// unescape aceita só \\, \n e, em valores de rótulo, \".
+func unescape(s string, quotes bool) (string, error) {
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if quotes && c == '"' {
+			return "", fmt.Errorf("unescaped quote in %q", s)
+		}
+		if c != '\\' {
+			out.WriteByte(c)
+			continue
+		}
+		if i+1 == len(s) {
+			return "", fmt.Errorf("dangling escape in %q", s)
+		}
+		i++
+		switch s[i] {
+		case '\\':
+			out.WriteByte('\\')
+		case 'n':
+			out.WriteByte('\n')
+		case '"':
+			if !quotes {
+				out.WriteString(`\"`)
+				continue
+			}
+			out.WriteByte('"')
+		default:
+			return "", fmt.Errorf("unknown escape \\%c in %q", s[i], s)
+		}
+	}
+	return out.String(), nil
+}
+
+// checkHistogram confere buckets crescentes e cumulativos, o +Inf igual a
+// _count e a presença de _sum para cada combinação de rótulos.
+func checkHistogram(t *testing.T, f *expositionFamily) {
+	t.Helper()
+
+	type group struct {
+		bounds, counts []float64
+		sum, count     *float64
+	}
+	groups := map[string]*group{}
+	get := func(labels map[string]string) *group {
+		rest := map[string]string{}
+		for k, v := range labels {
+			if k != "le" {
+				rest[k] = v
+			}
+		}
+		key := fmt.Sprint(rest)
+		if groups[key] == nil {
+			groups[key] = &group{}
+		}
+		return groups[key]
+	}
+
+	for _, s := range f.samples {
+		value := s.value
+		switch s.name {
+		case f.name + "_bucket":
+			le, ok := s.labels["le"]
+			require.True(t, ok, "%s bucket without le", f.name)
+			bound, err := strconv.ParseFloat(le, 64)
+			require.NoError(t, err, "%s le=%q", f.name, le)
+			g := get(s.labels)
+			g.bounds = append(g.bounds, bound)
+			g.counts = append(g.counts, value)
+		case f.name + "_sum":
+			assert.NotContains(t, s.labels, "le")
+			get(s.labels).sum = &value
+		case f.name + "_count":
+			assert.NotContains(t, s.labels, "le")
+			get(s.labels).count = &value
+		default:
+			t.Errorf("%s: unexpected sample %s in histogram", f.name, s.name)
+		}
+	}
+
+	for key, g := range groups {
+		require.NotEmpty(t, g.bounds, "%s%s has no buckets", f.name, key)
+		require.NotNil(t, g.sum, "%s%s has no _sum", f.name, key)
+		require.NotNil(t, g.count, "%s%s has no _count", f.name, key)
+		assert.True(t, math.IsInf(g.bounds[len(g.bounds)-1], 1), "%s%s must end with le=+Inf", f.name, key)
+		assert.Equal(t, *g.count, g.counts[len(g.counts)-1], "%s%s +Inf bucket must equal _count", f.name, key)
+		for i := 1; i < len(g.bounds); i++ {
+			assert.Greater(t, g.bounds[i], g.bounds[i-1], "%s%s le must increase", f.name, key)
+			assert.GreaterOrEqual(t, g.counts[i], g.counts[i-1], "%s%s buckets must be cumulative", f.name, key)
+		}
+	}
+}
+
+func checkExposition(t *testing.T, r *metrics.Registry) []*expositionFamily {
+	t.Helper()
+
+	families, err := parseExposition(render(t, r))
+	require.NoError(t, err)
+	for _, f := range families {
+		switch f.kind {
+		case "histogram":
+			checkHistogram(t, f)
+		case "counter":
+			for _, s := range f.samples {
+				assert.Equal(t, f.name, s.name)
+				assert.False(t, s.value < 0, "%s: counters never decrease", f.name)
+			}
+		}
+	}
+	return families
+}
+
+func TestExposition_ServiceMetricsConform(t *testing.T) {
+	m := metrics.New()
+	m.HTTPRequestDuration.Observe(0.003, "GET", "/user/me", "200")
+	m.HTTPRequestDuration.Observe(12, "GET", "/user/me", "200")
+	m.HTTPRequestDuration.Observe(0.25, "POST", "/auth/login", "401")
+	m.Logins.Inc("failure", "invalid_credentials")
+	m.Registrations.Inc("success", "none")
+	m.PasswordResets.Inc("requested", "success", "none")
+	m.TokenValidations.Inc("success")
+	m.TokenRevocations.Inc("user", "success")
+	m.BlacklistDuration.Observe(0.0005, "add", "success")
+	m.UserRepoDuration.Observe(math.Inf(1), "get_by_id", "failure")
+
+	families := checkExposition(t, m.Registry)
+
+	require.Len(t, families, 8)
+	for _, f := range families {
+		assert.NotEmpty(t, f.help, f.name)
+		assert.NotEqual(t, "untyped", f.kind, f.name)
+		assert.True(t, strings.HasPrefix(f.name, "startup_auth_"), f.name)
+	}
+}
+
+func TestExposition_EscapingRoundTrips(t *testing.T) {
+	tricky := "quote\" back\\slash\nnew line, {braces}=ünïcode"
+	r := metrics.NewRegistry()
+	r.NewCounterVec("escapes_total", "Help with \\ and\nnewline and \"quotes\".", "value").Inc(tricky)
+	r.NewHistogramVec("escapes_seconds", "Histogram.", []float64{0.1}, "value").Observe(0.05, tricky)
+	r.NewCounterVec("empty_total", "")
+
+	families := checkExposition(t, r)
+
+	require.Len(t, families, 3)
+	assert.Equal(t, "Help with \\ and\nnewline and \"quotes\".", families[0].help)
+	assert.Equal(t, tricky, families[0].samples[0].labels["value"])
+	for _, s := range families[1].samples {
+		assert.Equal(t, tricky, s.labels["value"])
+	}
+	assert.Empty(t, families[2].samples)
+}
+
+func TestExposition_ParserRejectsMalformedInput(t *testing.T) {
+	for name, text := range map[string]string{
+		"bad name":          "1metric 1\n",
+		"unknown escape":    "m{a=\"\\t\"} 1\n",
+		"unterminated":      "m{a=\"x} 1\n",
+		"bad value":         "m one\n",
+		"type after sample": "m 1\n# TYPE m counter\n",
+		"split family":      "a 1\nb 1\na{x=\"1\"} 1\n",
+		"duplicated series": "m 1\nm 2\n",
+		"reserved label":    "m{__name__=\"x\"} 1\n",
+	} {
+		_, err := parseExposition(text)
+		assert.Error(t, err, name)
+	}
+}
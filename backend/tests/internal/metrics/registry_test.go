@@ -0,0 +1,111 @@
+package metrics_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/metrics"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func render(t *testing.T, r *metrics.Registry) string {
+	t.Helper()
+	var out strings.Builder
+	n, err := r.WriteTo(&out)
+	require.NoError(t, err)
+	assert.Equal(t, int64(out.Len()), n)
+	return out.String()
+}
+
+func TestCounterVec(t *testing.T) {
+	r := metrics.NewRegistry()
+	c := r.NewCounterVec("logins_total", "Login attempts.", "result", "reason")
+
+	c.Inc("success", "none")
+	c.Inc("failure", "invalid_credentials")
+	c.Add(2, "failure", "invalid_credentials")
+	c.Add(-1, "failure", "invalid_credentials")
+
+	assert.Equal(t, 3.0, c.Value("failure", "invalid_credentials"))
+	assert.Equal(t, 0.0, c.Value("failure", "unknown"))
+	assert.Equal(t, `# HELP logins_total Login attempts.
+# TYPE logins_total counter
+logins_total{result="failure",reason="invalid_credentials"} 3
+logins_total{result="success",reason="none"} 1
+`, render(t, r))
+}
+
+func TestHistogramVec(t *testing.T) {
+	r := metrics.NewRegistry()
+	h := r.NewHistogramVec("latency_seconds", "Latency.", []float64{1, 0.1}, "op")
+
+	h.Observe(0.05, "get")
+	h.Observe(0.1, "get")
+	h.Observe(0.5, "get")
+	h.Observe(3, "get")
+
+	assert.Equal(t, uint64(4), h.Count("get"))
+	assert.Equal(t, uint64(0), h.Count("del"))
+	assert.Equal(t, `# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{op="get",le="0.1"} 2
+latency_seconds_bucket{op="get",le="1"} 3
+latency_seconds_bucket{op="get",le="+Inf"} 4
+latency_seconds_sum{op="get"} 3.65
+latency_seconds_count{op="get"} 4
+`, render(t, r))
+}
+
+func TestRegistry(t *testing.T) {
+	t.Run("Label values are escaped", func(t *testing.T) {
+		r := metrics.NewRegistry()
+		r.NewCounterVec("requests_total", "Line one\nline two.", "path").Inc("/a\"b\\c\n")
+
+		assert.Equal(t, `# HELP requests_total Line one\nline two.
+# TYPE requests_total counter
+requests_total{path="/a\"b\\c\n"} 1
+`, render(t, r))
+	})
+
+	t.Run("Metrics without labels", func(t *testing.T) {
+		r := metrics.NewRegistry()
+		r.NewCounterVec("up_total", "Up.").Inc()
+
+		assert.Contains(t, render(t, r), "up_total 1\n")
+	})
+
+	t.Run("Duplicated names panic", func(t *testing.T) {
+		r := metrics.NewRegistry()
+		r.NewCounterVec("dup_total", "Dup.")
+		assert.Panics(t, func() { r.NewHistogramVec("dup_total", "Dup.", nil) })
+	})
+
+	t.Run("Wrong number of label values panics", func(t *testing.T) {
+		r := metrics.NewRegistry()
+		c := r.NewCounterVec("labels_total", "Labels.", "a", "b")
+		assert.Panics(t, func() { c.Inc("only-one") })
+	})
+}
+
+func TestResultAndReason(t *testing.T) {
+	assert.Equal(t, "success", metrics.Result(nil))
+	assert.Equal(t, "none", metrics.Reason(nil))
+
+	err := fmt.Errorf("login: %w", msgerror.AnErrInvalidCredentials)
+	assert.Equal(t, "failure", metrics.Result(err))
+	assert.Equal(t, "invalid_credentials", metrics.Reason(err))
+	assert.Equal(t, "internal_error", metrics.Reason(errors.New("db down")))
+}
+
+func TestNew(t *testing.T) {
+	m := metrics.New()
+	m.Logins.Inc("success", "none")
+
+	out := render(t, m.Registry)
+	assert.Contains(t, out, "# TYPE startup_auth_http_request_duration_seconds histogram\n")
+	assert.Contains(t, out, `startup_auth_logins_total{result="success",reason="none"} 1`)
+}
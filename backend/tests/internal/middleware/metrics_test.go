@@ -0,0 +1,37 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/metrics"
+	"github.com/eskokado/startup-auth-go/backend/internal/middleware"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := metrics.New()
+	router := gin.New()
+	router.Use(middleware.MetricsMiddleware(m))
+	router.Use(middleware.ProblemMiddleware(nil))
+	router.GET("/users/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	router.POST("/auth/login", func(c *gin.Context) { _ = c.Error(msgerror.AnErrInvalidCredentials) })
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/users/1", nil),
+		httptest.NewRequest(http.MethodGet, "/users/2", nil),
+		httptest.NewRequest(http.MethodPost, "/auth/login", nil),
+		httptest.NewRequest(http.MethodGet, "/nowhere", nil),
+	} {
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// O modelo da rota agrupa os IDs; o status é o do problem+json
+	assert.Equal(t, uint64(2), m.HTTPRequestDuration.Count("GET", "/users/:id", "204"))
+	assert.Equal(t, uint64(1), m.HTTPRequestDuration.Count("POST", "/auth/login", "401"))
+	assert.Equal(t, uint64(1), m.HTTPRequestDuration.Count("GET", "unmatched", "404"))
+}
@@ -0,0 +1,60 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/middleware"
+	"github.com/eskokado/startup-auth-go/backend/internal/openapi"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestValidationMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	spec, err := openapi.NewSpec()
+	require.NoError(t, err)
+
+	serve := func(body string) (*httptest.ResponseRecorder, bool) {
+		called := false
+		router := gin.New()
+		router.Use(middleware.ProblemMiddleware(nil), middleware.RequestValidationMiddleware(spec))
+		router.POST("/auth/login", func(c *gin.Context) {
+			called = true
+			var input dto.LoginInput
+			require.NoError(t, c.ShouldBindJSON(&input))
+			c.JSON(http.StatusOK, input)
+		})
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body)))
+		return resp, called
+	}
+
+	t.Run("Valid request reaches the handler with its body", func(t *testing.T) {
+		resp, called := serve(`{"email": "a@b.com", "password": "secret123"}`)
+
+		assert.True(t, called)
+		assert.JSONEq(t, `{"email": "a@b.com", "password": "secret123"}`, resp.Body.String())
+	})
+
+	t.Run("Invalid request stops with a problem", func(t *testing.T) {
+		resp, called := serve(`{"email": 1}`)
+
+		assert.False(t, called)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+		var problem dto.Problem
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &problem))
+		assert.Equal(t, "validation_failed", problem.Code)
+		assert.Equal(t, []dto.FieldProblem{
+			{Field: "email", Code: "invalid_type", Detail: "invalid type"},
+		}, problem.Errors)
+	})
+}
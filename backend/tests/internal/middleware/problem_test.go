@@ -0,0 +1,200 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/middleware"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/i18n"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProblem(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"Sentinel", msgerror.AnErrUserNotFound, http.StatusNotFound, "user_not_found"},
+		{"Wrapped sentinel", fmt.Errorf("lookup: %w", msgerror.AnErrConflict), http.StatusConflict, "conflict"},
+		{"Unauthorized wins over token", fmt.Errorf("%w: %w", msgerror.AnErrUnauthorized, msgerror.AnErrInvalidToken), http.StatusUnauthorized, "unauthorized"},
+		{"Scope", fmt.Errorf("%w: user:write", msgerror.AnErrInsufficientScope), http.StatusForbidden, "insufficient_scope"},
+		{"Client error defaults to 400", msgerror.AnErrInvalidEmail, http.StatusBadRequest, "invalid_email"},
+		{"Unknown error", errors.New("db down"), http.StatusInternalServerError, "internal_error"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			problem := middleware.NewProblem(tc.err, "/x")
+
+			assert.Equal(t, tc.status, problem.Status)
+			assert.Equal(t, tc.code, problem.Code)
+			assert.Equal(t, middleware.ProblemTypePrefix+tc.code, problem.Type)
+			assert.Equal(t, http.StatusText(tc.status), problem.Title)
+			assert.Equal(t, "/x", problem.Instance)
+		})
+	}
+
+	t.Run("Internal detail is hidden", func(t *testing.T) {
+		problem := middleware.NewProblem(errors.New("password=secret"), "/x")
+		assert.Equal(t, "internal server error", problem.Detail)
+	})
+
+	t.Run("Validation errors are listed by field", func(t *testing.T) {
+		valErr := msgerror.NewValidationErrors()
+		valErr.AddError("timezone", msgerror.AnErrInvalidTimezone)
+		valErr.AddError("email", msgerror.AnErrInvalidEmail)
+		valErr.Add("name", "legacy message")
+
+		problem := middleware.NewProblem(valErr, "/x")
+
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+		assert.Equal(t, "validation_failed", problem.Code)
+		assert.Equal(t, []dto.FieldProblem{
+			{Field: "email", Code: "invalid_email", Detail: msgerror.AnErrInvalidEmail.Error()},
+			{Field: "name", Code: "invalid", Detail: "legacy message"},
+			{Field: "timezone", Code: "invalid_timezone", Detail: msgerror.AnErrInvalidTimezone.Error()},
+		}, problem.Errors)
+	})
+}
+
+func TestProblemMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(handlers ...gin.HandlerFunc) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.Use(middleware.ProblemMiddleware(nil))
+		router.GET("/x", handlers...)
+
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/x", nil))
+		return resp
+	}
+
+	t.Run("Writes the last registered error", func(t *testing.T) {
+		resp := serve(func(c *gin.Context) {
+			_ = c.Error(msgerror.AnErrUserNotFound)
+		})
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		assert.Equal(t, middleware.ProblemContentType, resp.Header().Get("Content-Type"))
+
+		var problem dto.Problem
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &problem))
+		assert.Equal(t, "user_not_found", problem.Code)
+	})
+
+	t.Run("Keeps a response already written", func(t *testing.T) {
+		resp := serve(func(c *gin.Context) {
+			_ = c.Error(assert.AnError)
+			c.JSON(http.StatusAccepted, gin.H{"ok": true})
+		})
+
+		assert.Equal(t, http.StatusAccepted, resp.Code)
+		assert.JSONEq(t, `{"ok": true}`, resp.Body.String())
+	})
+
+	t.Run("Aborted chain from middleware", func(t *testing.T) {
+		called := false
+		resp := serve(middleware.RequireAdmin(nil), func(c *gin.Context) { called = true })
+
+		assert.False(t, called)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+	})
+}
+
+func TestProblemMiddleware_Localized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	translator, err := i18n.NewTranslator("pt-BR")
+	require.NoError(t, err)
+
+	userID := vo.NewID()
+	serve := func(users *mocks.MockUserRepo, userIDValue, acceptLanguage string, handlerErr error) (*httptest.ResponseRecorder, dto.Problem) {
+		router := gin.New()
+		router.Use(middleware.ProblemMiddleware(middleware.NewLocalizer(translator, users)))
+		router.GET("/x", func(c *gin.Context) {
+			if userIDValue != "" {
+				c.Set("userID", userIDValue)
+			}
+			_ = c.Error(handlerErr)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/x", nil)
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		var problem dto.Problem
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &problem))
+		return resp, problem
+	}
+
+	t.Run("Accept-Language", func(t *testing.T) {
+		resp, problem := serve(nil, "", "en-GB,en;q=0.9", msgerror.AnErrUserNotFound)
+
+		assert.Equal(t, "en-US", resp.Header().Get("Content-Language"))
+		assert.Equal(t, "user_not_found", problem.Code)
+		assert.Equal(t, "User not found.", problem.Detail)
+	})
+
+	t.Run("Default locale", func(t *testing.T) {
+		resp, problem := serve(nil, "", "", msgerror.AnErrUserNotFound)
+
+		assert.Equal(t, "pt-BR", resp.Header().Get("Content-Language"))
+		assert.Equal(t, "Usuário não encontrado.", problem.Detail)
+	})
+
+	t.Run("Profile locale wins over Accept-Language", func(t *testing.T) {
+		locale, _ := vo.NewLocale("en-US")
+		users := new(mocks.MockUserRepo)
+		users.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, Locale: locale}, nil)
+
+		resp, problem := serve(users, userID.String(), "pt-BR", msgerror.AnErrConflict)
+
+		assert.Equal(t, "en-US", resp.Header().Get("Content-Language"))
+		assert.Equal(t, "The resource was modified by another request. Reload and try again.", problem.Detail)
+		users.AssertExpectations(t)
+	})
+
+	t.Run("Profile lookup failure falls back to Accept-Language", func(t *testing.T) {
+		users := new(mocks.MockUserRepo)
+		users.On("GetByID", mock.Anything, userID).Return(nil, assert.AnError)
+
+		_, problem := serve(users, userID.String(), "en", msgerror.AnErrForbidden)
+
+		assert.Equal(t, "You do not have permission to perform this action.", problem.Detail)
+	})
+
+	t.Run("Validation field messages", func(t *testing.T) {
+		_, nameErr := vo.NewName("ab", 3, 10)
+		valErr := msgerror.NewValidationErrors()
+		valErr.AddError("name", nameErr)
+		valErr.AddError("email", msgerror.AnErrInvalidEmail)
+		valErr.Add("legacy", "mensagem original")
+
+		_, problem := serve(nil, "", "pt-BR", valErr)
+
+		assert.Equal(t, "validation_failed", problem.Code)
+		assert.Equal(t, "Um ou mais campos são inválidos.", problem.Detail)
+		assert.Equal(t, []dto.FieldProblem{
+			{Field: "email", Code: "invalid_email", Detail: "Formato de e-mail inválido."},
+			{Field: "legacy", Code: "invalid", Detail: "mensagem original"},
+			{Field: "name", Code: "name_too_short", Detail: "O nome deve ter no mínimo 3 caracteres."},
+		}, problem.Errors)
+	})
+}
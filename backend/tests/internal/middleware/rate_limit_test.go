@@ -0,0 +1,193 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/middleware"
+	"github.com/eskokado/startup-auth-go/backend/internal/providers"
+	domain "github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func rateLimitRouter(limiter domain.RateLimiter, rules []middleware.RateLimitRule, allowlist []netip.Prefix, auth ...gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	// Como no servidor sem TRUSTED_PROXIES
+	_ = router.SetTrustedProxies(nil)
+	router.Use(middleware.ProblemMiddleware(nil), middleware.RateLimitMiddleware(limiter, rules, allowlist))
+
+	echo := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	}
+	router.POST("/auth/login", echo)
+	router.GET("/open", echo)
+	router.PUT("/user/email", append(auth, echo)...)
+	return router
+}
+
+func rateLimitRequest(router *gin.Engine, method, path, remoteAddr, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.RemoteAddr = remoteAddr
+	req.Header.Set("Authorization", "Bearer token")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	return resp
+}
+
+func TestRateLimitMiddleware_IP(t *testing.T) {
+	limiter := providers.NewMemoryRateLimiter(domain.RateLimitTokenBucket, nil)
+	router := rateLimitRouter(limiter, []middleware.RateLimitRule{
+		{Method: http.MethodPost, Path: "/auth/login", Key: middleware.RateLimitByIP, Limit: domain.RateLimit{Limit: 2, Window: time.Minute}},
+	}, nil)
+
+	resp := rateLimitRequest(router, http.MethodPost, "/auth/login", "192.0.2.1:1000", `{}`)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "2", resp.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", resp.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", resp.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "2;w=60", resp.Header().Get("RateLimit-Policy"))
+
+	rateLimitRequest(router, http.MethodPost, "/auth/login", "192.0.2.1:1000", `{}`)
+	resp = rateLimitRequest(router, http.MethodPost, "/auth/login", "192.0.2.1:1000", `{}`)
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+	assert.Equal(t, "30", resp.Header().Get("Retry-After"))
+	assert.Equal(t, "0", resp.Header().Get("RateLimit-Remaining"))
+	var problem dto.Problem
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &problem))
+	assert.Equal(t, "rate_limited", problem.Code)
+
+	// Outro IP e rotas sem regra não são afetados
+	assert.Equal(t, http.StatusOK, rateLimitRequest(router, http.MethodPost, "/auth/login", "192.0.2.2:1000", `{}`).Code)
+	resp = rateLimitRequest(router, http.MethodGet, "/open", "192.0.2.1:1000", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, resp.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimitMiddleware_Email(t *testing.T) {
+	limiter := providers.NewMemoryRateLimiter(domain.RateLimitTokenBucket, nil)
+	router := rateLimitRouter(limiter, []middleware.RateLimitRule{
+		{Method: http.MethodPost, Path: "/auth/login", Key: middleware.RateLimitByEmail, Limit: domain.RateLimit{Limit: 1, Window: time.Hour}},
+	}, nil)
+
+	body := `{"email": "Maria@Example.com", "password": "secret123"}`
+	resp := rateLimitRequest(router, http.MethodPost, "/auth/login", "192.0.2.1:1000", body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, body, resp.Body.String(), "the handler still reads the body")
+
+	// Mesmo e-mail de outro IP e com outra grafia
+	resp = rateLimitRequest(router, http.MethodPost, "/auth/login", "192.0.2.2:1000", `{"email": "maria@example.com "}`)
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+
+	resp = rateLimitRequest(router, http.MethodPost, "/auth/login", "192.0.2.1:1000", `{"email": "joao@example.com"}`)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestRateLimitMiddleware_User(t *testing.T) {
+	tokenProvider := new(mocks.MockTokenProvider)
+	tokenStore := new(mocks.MockTokenStore)
+	claims := domain.Claims{UserID: "user-1"}
+	tokenProvider.On("Validate", mock.Anything, "token").Return(claims, nil)
+	tokenStore.On("IsActive", mock.Anything, claims).Return(true, nil)
+
+	limiter := providers.NewMemoryRateLimiter(domain.RateLimitTokenBucket, nil)
+	router := rateLimitRouter(limiter, []middleware.RateLimitRule{
+		{Method: http.MethodPut, Path: "/user/email", Key: middleware.RateLimitByIP, Limit: domain.RateLimit{Limit: 10, Window: time.Hour}},
+		{Method: http.MethodPut, Path: "/user/email", Key: middleware.RateLimitByUser, Limit: domain.RateLimit{Limit: 1, Window: time.Hour}},
+	}, nil, middleware.JWTAuthMiddleware(tokenProvider, tokenStore, nil))
+
+	resp := rateLimitRequest(router, http.MethodPut, "/user/email", "192.0.2.1:1000", `{}`)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "0", resp.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "10;w=3600, 1;w=3600", resp.Header().Get("RateLimit-Policy"))
+
+	// A cota segue o usuário mesmo trocando de IP
+	resp = rateLimitRequest(router, http.MethodPut, "/user/email", "192.0.2.2:1000", `{}`)
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+	assert.Equal(t, "3600", resp.Header().Get("Retry-After"))
+}
+
+func TestRateLimitMiddleware_Allowlist(t *testing.T) {
+	limiter := new(mocks.MockRateLimiter)
+	router := rateLimitRouter(limiter, []middleware.RateLimitRule{
+		{Method: http.MethodPost, Path: "/auth/login", Key: middleware.RateLimitByRoute, Limit: domain.RateLimit{Limit: 1, Window: time.Hour}},
+	}, []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")})
+
+	resp := rateLimitRequest(router, http.MethodPost, "/auth/login", "10.1.2.3:1000", `{}`)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, resp.Header().Get("RateLimit-Limit"))
+	limiter.AssertNotCalled(t, "Allow", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRateLimitMiddleware_IgnoresSpoofedForwardedFor(t *testing.T) {
+	limiter := new(mocks.MockRateLimiter)
+	var keys []string
+	limiter.On("Allow", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		keys = append(keys, args.String(1))
+	}).Return(domain.RateLimitResult{Allowed: true, Remaining: 4}, nil)
+	router := rateLimitRouter(limiter, []middleware.RateLimitRule{
+		{Method: http.MethodPost, Path: "/auth/login", Key: middleware.RateLimitByIP, Limit: domain.RateLimit{Limit: 5, Window: time.Hour}},
+	}, []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")})
+
+	// Um cliente qualquer envia um IP da allowlist e depois troca o cabeçalho a
+	// cada requisição: nem escapa das cotas nem ganha um balde novo
+	for _, forwarded := range []string{"10.0.0.1", "198.51.100.7", "198.51.100.8"} {
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(`{}`))
+		req.RemoteAddr = "192.0.2.1:1000"
+		req.Header.Set("X-Forwarded-For", forwarded)
+		req.Header.Set("X-Real-IP", forwarded)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	require.Len(t, keys, 3)
+	for _, key := range keys {
+		assert.True(t, strings.HasSuffix(key, "ip:192.0.2.1"), key)
+	}
+}
+
+func TestRateLimitMiddleware_TrustedProxyForwardedFor(t *testing.T) {
+	limiter := new(mocks.MockRateLimiter)
+	router := rateLimitRouter(limiter, []middleware.RateLimitRule{
+		{Method: http.MethodPost, Path: "/auth/login", Key: middleware.RateLimitByIP, Limit: domain.RateLimit{Limit: 5, Window: time.Hour}},
+	}, []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")})
+	require.NoError(t, router.SetTrustedProxies([]string{"192.0.2.0/24"}))
+
+	// Atrás do proxy confiável vale o IP que ele informa
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(`{}`))
+	req.RemoteAddr = "192.0.2.1:1000"
+	req.Header.Set("X-Forwarded-For", "10.0.0.1")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	limiter.AssertNotCalled(t, "Allow", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRateLimitMiddleware_BackendFailureFailsOpen(t *testing.T) {
+	limiter := new(mocks.MockRateLimiter)
+	limiter.On("Allow", mock.Anything, mock.Anything, mock.Anything).
+		Return(domain.RateLimitResult{}, errors.New("connection refused"))
+	router := rateLimitRouter(limiter, []middleware.RateLimitRule{
+		{Method: http.MethodPost, Path: "/auth/login", Key: middleware.RateLimitByIP, Limit: domain.RateLimit{Limit: 1, Window: time.Hour}},
+	}, nil)
+
+	resp := rateLimitRequest(router, http.MethodPost, "/auth/login", "192.0.2.1:1000", `{}`)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, resp.Header().Get("RateLimit-Limit"))
+}
@@ -0,0 +1,132 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/logging"
+	"github.com/eskokado/startup-auth-go/backend/internal/middleware"
+	"github.com/eskokado/startup-auth-go/backend/internal/tracing"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLoggedRouter monta a cadeia na ordem usada pelo servidor.
+func newLoggedRouter(t *testing.T, buf *bytes.Buffer) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	logger, err := logging.New(buf, logging.FormatJSON, "info")
+	require.NoError(t, err)
+	tracer, _ := mocks.NewRecordingTracer()
+
+	router := gin.New()
+	router.Use(middleware.TracingMiddleware(tracer))
+	router.Use(middleware.RequestLogMiddleware(logger))
+	router.Use(middleware.RecoveryMiddleware())
+	router.Use(middleware.ProblemMiddleware(nil))
+	return router
+}
+
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestRequestLogMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	router := newLoggedRouter(t, &buf)
+	router.POST("/auth/login", func(c *gin.Context) {
+		c.Set("userID", "u1")
+		// O logger do contexto chega ao caso de uso já com request_id e trace_id
+		logging.FromContext(c.Request.Context()).Info("use case", "password", "hunter22")
+		_ = c.Error(msgerror.AnErrInvalidCredentials)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/login?token=secret-query", nil)
+	req.Header.Set(middleware.RequestIDHeader, "client-req-1")
+	req.Header.Set(tracing.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set("Authorization", "Bearer eyJhbGciOi")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, "client-req-1", rec.Header().Get(middleware.RequestIDHeader))
+	assert.NotContains(t, buf.String(), "hunter22")
+	assert.NotContains(t, buf.String(), "secret-query")
+	assert.NotContains(t, buf.String(), "eyJhbGciOi")
+
+	records := logRecords(t, &buf)
+	require.Len(t, records, 2)
+	assert.Equal(t, "use case", records[0]["msg"])
+	assert.Equal(t, "client-req-1", records[0]["request_id"])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", records[0]["trace_id"])
+	assert.Equal(t, logging.Redacted, records[0]["password"])
+
+	access := records[1]
+	assert.Equal(t, "request", access["msg"])
+	assert.Equal(t, "WARN", access["level"])
+	assert.Equal(t, "client-req-1", access["request_id"])
+	assert.Equal(t, "POST", access["method"])
+	assert.Equal(t, "/auth/login", access["route"])
+	assert.Equal(t, "/auth/login", access["path"])
+	assert.Equal(t, float64(http.StatusUnauthorized), access["status"])
+	assert.Equal(t, "u1", access["user_id"])
+	assert.Equal(t, "invalid_credentials", access["error_code"])
+}
+
+func TestRequestLogMiddleware_GeneratesRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	router := newLoggedRouter(t, &buf)
+	router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	for _, received := range []string{"", "bad id\r\nX-Injected: 1", strings.Repeat("a", 129)} {
+		buf.Reset()
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		if received != "" {
+			req.Header[middleware.RequestIDHeader] = []string{received}
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		id := rec.Header().Get(middleware.RequestIDHeader)
+		assert.NotEmpty(t, id)
+		assert.NotEqual(t, received, id)
+		records := logRecords(t, &buf)
+		require.Len(t, records, 1)
+		assert.Equal(t, id, records[0]["request_id"])
+		assert.Equal(t, "INFO", records[0]["level"])
+	}
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	router := newLoggedRouter(t, &buf)
+	router.GET("/panic", func(c *gin.Context) { panic("boom") })
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, middleware.ProblemContentType, rec.Header().Get("Content-Type"))
+	assert.NotContains(t, rec.Body.String(), "boom")
+
+	records := logRecords(t, &buf)
+	require.Len(t, records, 2)
+	assert.Equal(t, "panic recovered", records[0]["msg"])
+	assert.Equal(t, "boom", records[0]["panic"])
+	assert.NotEmpty(t, records[0]["stack"])
+	assert.Equal(t, "ERROR", records[1]["level"])
+	assert.Equal(t, float64(http.StatusInternalServerError), records[1]["status"])
+}
@@ -0,0 +1,56 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/middleware"
+	"github.com/eskokado/startup-auth-go/backend/internal/tracing"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tracer, recorder := mocks.NewRecordingTracer()
+	var handlerSpan tracing.SpanContext
+	router := gin.New()
+	router.Use(middleware.TracingMiddleware(tracer))
+	router.Use(middleware.ProblemMiddleware(nil))
+	router.GET("/users/:id", func(c *gin.Context) {
+		handlerSpan = tracing.SpanContextFromContext(c.Request.Context())
+		c.Set("userID", "u1")
+		c.Status(http.StatusNoContent)
+	})
+	router.GET("/boom", func(c *gin.Context) { _ = c.Error(assert.AnError) })
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	req.Header.Set(tracing.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/boom", nil))
+
+	spans := recorder.Spans(tracer)
+	require.Len(t, spans, 2)
+
+	// O span do servidor continua o trace recebido e chega ao handler pelo contexto
+	span := spans[0]
+	assert.Equal(t, "GET /users/:id", span.Name)
+	assert.Equal(t, tracing.KindServer, span.Kind)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext.TraceID.String())
+	assert.Equal(t, "00f067aa0ba902b7", span.ParentSpanID.String())
+	assert.Equal(t, span.SpanContext, handlerSpan)
+	assert.Equal(t, "/users/42", mocks.SpanAttribute(span, "url.path"))
+	assert.Equal(t, int64(http.StatusNoContent), mocks.SpanAttribute(span, "http.response.status_code"))
+	assert.Equal(t, "u1", mocks.SpanAttribute(span, "enduser.id"))
+	assert.Equal(t, tracing.StatusUnset, span.Status)
+
+	// Erro inesperado vira 500 pelo ProblemMiddleware e marca o span
+	span = spans[1]
+	assert.False(t, span.ParentSpanID.IsValid())
+	assert.Equal(t, int64(http.StatusInternalServerError), mocks.SpanAttribute(span, "http.response.status_code"))
+	assert.Equal(t, tracing.StatusError, span.Status)
+	assert.Equal(t, assert.AnError.Error(), span.StatusMessage)
+}
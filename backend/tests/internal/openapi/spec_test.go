@@ -0,0 +1,228 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	mainFile    = "../../../cmd/server/main.go"
+	handlersDir = "../../../internal/handlers/auth"
+)
+
+// registeredRoutes lê as chamadas router.<MÉTODO>("caminho", ...) do main.go.
+func registeredRoutes(t *testing.T) []string {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), mainFile, nil, 0)
+	require.NoError(t, err)
+
+	var routes []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if recv, ok := sel.X.(*ast.Ident); !ok || recv.Name != "router" {
+			return true
+		}
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		switch sel.Sel.Name {
+		case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			path, _ := strconv.Unquote(lit.Value)
+			routes = append(routes, sel.Sel.Name+" "+path)
+		}
+		return true
+	})
+	sort.Strings(routes)
+	return routes
+}
+
+func documentedRoutes() []string {
+	var routes []string
+	for _, route := range openapi.Routes() {
+		routes = append(routes, route.Method+" "+route.Path)
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+// Falha quando uma rota é registrada sem documentação ou vice-versa.
+func TestRoutesMatchServer(t *testing.T) {
+	registered := registeredRoutes(t)
+	require.NotEmpty(t, registered)
+	assert.Equal(t, registered, documentedRoutes())
+}
+
+// boundDTOs lê os DTOs passados a ShouldBindJSON/ShouldBindQuery nos handlers.
+func boundDTOs(t *testing.T) map[string]string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(handlersDir, "*.go"))
+	require.NoError(t, err)
+
+	bound := make(map[string]string)
+	for _, path := range files {
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		require.NoError(t, err)
+
+		// Tipo declarado de cada variável: var input dto.X
+		varTypes := make(map[string]string)
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.ValueSpec)
+			if !ok {
+				return true
+			}
+			if sel, ok := spec.Type.(*ast.SelectorExpr); ok {
+				for _, name := range spec.Names {
+					varTypes[name.Name] = sel.Sel.Name
+				}
+			}
+			return true
+		})
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 1 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || (sel.Sel.Name != "ShouldBindJSON" && sel.Sel.Name != "ShouldBindQuery") {
+				return true
+			}
+			if arg, ok := call.Args[0].(*ast.UnaryExpr); ok {
+				if ident, ok := arg.X.(*ast.Ident); ok {
+					bound[varTypes[ident.Name]] = filepath.Base(path)
+				}
+			}
+			return true
+		})
+	}
+	return bound
+}
+
+// Todo DTO lido por um handler precisa estar no documento.
+func TestBoundDTOsAreDocumented(t *testing.T) {
+	documented := make(map[string]bool)
+	for _, route := range openapi.Routes() {
+		for _, v := range []any{route.Body, route.Query} {
+			if v != nil {
+				documented[reflect.TypeOf(v).Name()] = true
+			}
+		}
+	}
+
+	bound := boundDTOs(t)
+	require.NotEmpty(t, bound)
+	for dtoName, file := range bound {
+		assert.True(t, documented[dtoName], "%s binds dto.%s, which is not in openapi.Routes", file, dtoName)
+	}
+}
+
+func TestSpecDocument(t *testing.T) {
+	spec, err := openapi.NewSpec()
+	require.NoError(t, err)
+
+	var document map[string]any
+	require.NoError(t, json.Unmarshal(spec.JSON(), &document))
+	assert.Equal(t, "3.1.0", document["openapi"])
+
+	doc := spec.Document()
+
+	t.Run("References resolve", func(t *testing.T) {
+		var refs []string
+		collectRefs(document, &refs)
+		require.NotEmpty(t, refs)
+		for _, ref := range refs {
+			name := strings.TrimPrefix(ref, "#/components/schemas/")
+			assert.Contains(t, doc.Components.Schemas, name, "unresolved %s", ref)
+		}
+	})
+
+	t.Run("Path parameters are declared", func(t *testing.T) {
+		for path, item := range doc.Paths {
+			for method, op := range *item {
+				for _, segment := range strings.Split(path, "/") {
+					if !strings.HasPrefix(segment, "{") {
+						continue
+					}
+					name := strings.Trim(segment, "{}")
+					found := false
+					for _, param := range op.Parameters {
+						found = found || (param.In == "path" && param.Name == name)
+					}
+					assert.True(t, found, "%s %s: missing path parameter %s", method, path, name)
+				}
+			}
+		}
+	})
+
+	t.Run("Operation IDs are unique", func(t *testing.T) {
+		seen := make(map[string]bool)
+		for _, item := range doc.Paths {
+			for _, op := range *item {
+				assert.False(t, seen[op.OperationID], "duplicated operationId %s", op.OperationID)
+				seen[op.OperationID] = true
+			}
+		}
+	})
+
+	t.Run("Register output does not expose reset tokens", func(t *testing.T) {
+		output := doc.Components.Schemas["RegisterUserOutput"]
+		require.NotNil(t, output)
+		assert.NotContains(t, output.Properties, "password_reset_token")
+		assert.ElementsMatch(t, []string{"id", "name", "email", "image_url"}, output.Required)
+	})
+
+	t.Run("Request requirements come from binding tags", func(t *testing.T) {
+		reset := doc.Components.Schemas["ResetPasswordInput"]
+		require.NotNil(t, reset)
+		assert.ElementsMatch(t, []string{"reset_password_token", "password"}, reset.Required)
+
+		patch := doc.Components.Schemas["UpdateProfileInput"]
+		require.NotNil(t, patch)
+		assert.Empty(t, patch.Required)
+		assert.Equal(t, []string{"string", "null"}, patch.Properties["timezone"].Type)
+	})
+
+	t.Run("OAuth errors are not problem+json", func(t *testing.T) {
+		op := (*doc.Paths["/oauth/revoke"])["post"]
+		require.NotNil(t, op)
+		assert.Contains(t, op.Responses["default"].Content, "application/json")
+		assert.Contains(t, op.RequestBody.Content, "application/x-www-form-urlencoded")
+	})
+}
+
+func collectRefs(node any, refs *[]string) {
+	switch v := node.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if ref, ok := value.(string); ok && key == "$ref" {
+				*refs = append(*refs, ref)
+			}
+			collectRefs(value, refs)
+		}
+	case []any:
+		for _, value := range v {
+			collectRefs(value, refs)
+		}
+	}
+}
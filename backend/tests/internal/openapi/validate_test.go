@@ -0,0 +1,122 @@
+package openapi_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/openapi"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpec_ValidateRequest(t *testing.T) {
+	spec, err := openapi.NewSpec()
+	require.NoError(t, err)
+
+	validate := func(method, ginPath, target, body string) error {
+		var reader io.Reader
+		if body != "" {
+			reader = strings.NewReader(body)
+		}
+		return spec.ValidateRequest(method, ginPath, httptest.NewRequest(method, target, reader))
+	}
+
+	fieldCodes := func(t *testing.T, err error) map[string]string {
+		t.Helper()
+		var valErr *msgerror.ValidationErrors
+		require.ErrorAs(t, err, &valErr)
+		return valErr.FieldCodes
+	}
+
+	t.Run("Valid body", func(t *testing.T) {
+		err := validate(http.MethodPatch, "/user/me", "/user/me",
+			`{"name": "Maria", "timezone": null, "attributes": {"team": "core", "old": null}}`)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Body is kept for the handler", func(t *testing.T) {
+		body := `{"email": "a@b.com", "password": "secret123"}`
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body))
+
+		require.NoError(t, spec.ValidateRequest(http.MethodPost, "/auth/login", req))
+
+		read, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, body, string(read))
+	})
+
+	t.Run("Wrong types per field", func(t *testing.T) {
+		err := validate(http.MethodPatch, "/user/me", "/user/me",
+			`{"name": 10, "attributes": {"team": 1}}`)
+
+		assert.Equal(t, map[string]string{
+			"name":            "invalid_type",
+			"attributes.team": "invalid_type",
+		}, fieldCodes(t, err))
+	})
+
+	t.Run("Required fields from binding tags", func(t *testing.T) {
+		err := validate(http.MethodPost, "/auth/reset-password", "/auth/reset-password", `{}`)
+
+		assert.Equal(t, map[string]string{
+			"reset_password_token": "required",
+			"password":             "required",
+		}, fieldCodes(t, err))
+	})
+
+	t.Run("Array items and nullable date-time", func(t *testing.T) {
+		assert.NoError(t, validate(http.MethodPost, "/user/api-keys", "/user/api-keys",
+			`{"name": "ci", "scopes": ["user:read"], "expires_at": null}`))
+
+		err := validate(http.MethodPost, "/user/api-keys", "/user/api-keys",
+			`{"name": "ci", "scopes": ["user:read", 3], "expires_at": "tomorrow"}`)
+		assert.Equal(t, map[string]string{
+			"scopes[1]":  "invalid_type",
+			"expires_at": "invalid_value",
+		}, fieldCodes(t, err))
+	})
+
+	t.Run("Malformed JSON", func(t *testing.T) {
+		err := validate(http.MethodPost, "/auth/login", "/auth/login", `{invalid`)
+		assert.ErrorIs(t, err, msgerror.AnErrInvalidRequestBody)
+	})
+
+	t.Run("Body must be an object", func(t *testing.T) {
+		err := validate(http.MethodPost, "/auth/login", "/auth/login", `["a"]`)
+		assert.ErrorIs(t, err, msgerror.AnErrInvalidRequestBody)
+	})
+
+	t.Run("Unknown fields are ignored", func(t *testing.T) {
+		err := validate(http.MethodPost, "/auth/login", "/auth/login", `{"email": "a@b.com", "remember": true}`)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Query enum, range and types", func(t *testing.T) {
+		assert.NoError(t, validate(http.MethodGet, "/admin/audit", "/admin/audit?outcome=failure&limit=10&from=2025-01-01T00:00:00Z", ""))
+
+		err := validate(http.MethodGet, "/admin/audit", "/admin/audit?outcome=maybe&limit=1000&offset=x&from=yesterday", "")
+		assert.Equal(t, map[string]string{
+			"outcome": "invalid_value",
+			"limit":   "invalid_value",
+			"offset":  "invalid_type",
+			"from":    "invalid_value",
+		}, fieldCodes(t, err))
+	})
+
+	t.Run("Required query parameters", func(t *testing.T) {
+		err := validate(http.MethodGet, "/exports/:file", "/exports/a.json?expires=1", "")
+		assert.Equal(t, map[string]string{"signature": "required"}, fieldCodes(t, err))
+	})
+
+	t.Run("Non JSON bodies are left to the handler", func(t *testing.T) {
+		assert.NoError(t, validate(http.MethodPost, "/oauth/revoke", "/oauth/revoke", "token=abc"))
+	})
+
+	t.Run("Unknown route", func(t *testing.T) {
+		assert.NoError(t, validate(http.MethodGet, "/nope", "/nope", ""))
+	})
+}
@@ -0,0 +1,107 @@
+package providers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLinesAuditLogger_WritesOneEventPerLine(t *testing.T) {
+	var buf bytes.Buffer
+	logger := providers.NewJSONLinesAuditLogger(&buf)
+
+	first := entity.NewAuditEvent(entity.AuditActionLogin, nil)
+	first.ActorID = "user-1"
+	first.IP = "203.0.113.7"
+	second := entity.NewAuditEvent(entity.AuditActionLogin, errors.New("invalid credentials"))
+
+	assert.NoError(t, logger.Log(context.Background(), first))
+	assert.NoError(t, logger.Log(context.Background(), second))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+
+	var decoded map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &decoded))
+	assert.Equal(t, "auth.login", decoded["action"])
+	assert.Equal(t, "success", decoded["outcome"])
+	assert.Equal(t, "user-1", decoded["actor_id"])
+	assert.Equal(t, "203.0.113.7", decoded["ip"])
+	assert.NotEmpty(t, decoded["timestamp"])
+
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &decoded))
+	assert.Equal(t, "failure", decoded["outcome"])
+	assert.Equal(t, "invalid credentials", decoded["reason"])
+}
+
+func TestJSONLinesAuditLogger_Anonymize(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	logger, err := providers.OpenJSONLinesAuditFile(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = logger.Close() })
+
+	// Login com um e-mail antigo: ele também deixa de aparecer
+	login := entity.NewAuditEvent(entity.AuditActionLogin, nil)
+	login.ActorID, login.Target, login.IP, login.UserAgent = "user-1", "old@test.com", "198.51.100.1", "Firefox/128.0"
+	failed := entity.NewAuditEvent(entity.AuditActionLogin, errors.New("invalid credentials"))
+	failed.Target, failed.IP = "old@test.com", "198.51.100.2"
+	other := entity.NewAuditEvent(entity.AuditActionLogin, nil)
+	other.ActorID, other.Target, other.IP = "user-2", "joao@test.com", "203.0.113.9"
+	for _, event := range []*entity.AuditEvent{login, failed, other} {
+		require.NoError(t, logger.Log(ctx, event))
+	}
+
+	require.NoError(t, logger.Anonymize(ctx, []string{"user-1", "maria@test.com", ""}, "pseudonym"))
+	later := entity.NewAuditEvent(entity.AuditActionLogout, nil)
+	require.NoError(t, logger.Log(ctx, later))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 4)
+	for _, value := range []string{"user-1", "old@test.com", "198.51.100.", "Firefox"} {
+		assert.NotContains(t, string(data), value)
+	}
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &decoded))
+	assert.Equal(t, "pseudonym", decoded["actor_id"])
+	assert.Equal(t, "pseudonym", decoded["target"])
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &decoded))
+	assert.Equal(t, "203.0.113.9", decoded["ip"])
+	require.NoError(t, json.Unmarshal([]byte(lines[3]), &decoded))
+	assert.Equal(t, later.ID.String(), decoded["id"])
+}
+
+func TestJSONLinesAuditLogger_AnonymizeWithoutFile(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, providers.NewJSONLinesAuditLogger(&buf).Anonymize(context.Background(), []string{"user-1"}, "pseudonym"))
+}
+
+func TestMultiAuditLogger_FansOutAndJoinsErrors(t *testing.T) {
+	ok := new(mocks.MockAuditLogger)
+	failing := new(mocks.MockAuditLogger)
+	event := entity.NewAuditEvent(entity.AuditActionLogout, nil)
+
+	ok.On("Log", mock.Anything, event).Return(nil)
+	failing.On("Log", mock.Anything, event).Return(errors.New("disk full"))
+
+	err := providers.NewMultiAuditLogger(failing, ok).Log(context.Background(), event)
+
+	assert.ErrorContains(t, err, "disk full")
+	ok.AssertExpectations(t)
+	failing.AssertExpectations(t)
+}
@@ -1,6 +1,7 @@
 package providers_test
 
 import (
+	"context"
 	"testing"
 
 	crypto "github.com/eskokado/startup-auth-go/backend/internal/providers"
@@ -12,28 +13,28 @@ func TestBcryptProvider(t *testing.T) {
 	t.Run("Encrypt and Compare", func(t *testing.T) {
 		password := "SecurePass123!"
 
-		hash, err := provider.Encrypt(password)
+		hash, err := provider.Encrypt(context.Background(), password)
 		if err != nil {
 			t.Fatalf("Encrypt failed: %v", err)
 		}
 
-		match, err := provider.Compare(password, hash)
+		match, err := provider.Compare(context.Background(), password, hash)
 		if err != nil || !match {
 			t.Errorf("Password comparison failed: %v", err)
 		}
 	})
 
 	t.Run("Compare Invalid Password", func(t *testing.T) {
-		hash, _ := provider.Encrypt("GoodPass123!")
+		hash, _ := provider.Encrypt(context.Background(), "GoodPass123!")
 
-		match, err := provider.Compare("WrongPass456@", hash)
+		match, err := provider.Compare(context.Background(), "WrongPass456@", hash)
 		if err == nil || match {
 			t.Error("Expected password mismatch")
 		}
 	})
 
 	t.Run("Empty Password", func(t *testing.T) {
-		_, err := provider.Encrypt("")
+		_, err := provider.Encrypt(context.Background(), "")
 		if err == nil {
 			t.Error("Expected error for empty password")
 		}
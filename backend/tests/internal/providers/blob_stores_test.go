@@ -0,0 +1,160 @@
+package providers_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/providers"
+	domain "github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 imita o suficiente da API do S3 (PUT, GET e DELETE de objetos em
+// URLs no estilo path) e confere os cabeçalhos da assinatura SigV4.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]domain.Blob
+	auth    []string
+}
+
+var sigV4Header = regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=minio/\d{8}/sa-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=[0-9a-f]{64}$`)
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	sum := sha256.Sum256(body)
+	if !sigV4Header.MatchString(r.Header.Get("Authorization")) ||
+		r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) ||
+		r.Header.Get("X-Amz-Date") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("<Error><Code>SignatureDoesNotMatch</Code></Error>"))
+		return
+	}
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+
+	key := r.URL.Path
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[key] = domain.Blob{Data: body, ContentType: r.Header.Get("Content-Type")}
+	case http.MethodGet:
+		object, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", object.ContentType)
+		_, _ = w.Write(object.Data)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestNewBlobStore(t *testing.T) {
+	store, err := providers.NewBlobStore(providers.BlobStoreConfig{Dir: t.TempDir()})
+	require.NoError(t, err)
+	assert.IsType(t, &providers.FileBlobStore{}, store)
+
+	store, err = providers.NewBlobStore(providers.BlobStoreConfig{Store: providers.BlobStoreS3})
+	require.NoError(t, err)
+	assert.IsType(t, &providers.S3BlobStore{}, store)
+
+	_, err = providers.NewBlobStore(providers.BlobStoreConfig{Store: "ftp"})
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidBlobStore)
+}
+
+// testBlobStore cobre o contrato comum às implementações de BlobStore.
+func testBlobStore(t *testing.T, store domain.BlobStore) {
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "avatars/abc/64.png", []byte("png-data"), "image/png"))
+
+	blob, err := store.Get(ctx, "avatars/abc/64.png")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("png-data"), blob.Data)
+	assert.Equal(t, "image/png", blob.ContentType)
+
+	// Sobrescrever mantém só o conteúdo novo
+	require.NoError(t, store.Put(ctx, "avatars/abc/64.png", []byte("v2"), "image/png"))
+	blob, err = store.Get(ctx, "avatars/abc/64.png")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v2"), blob.Data)
+
+	require.NoError(t, store.Delete(ctx, "avatars/abc/64.png"))
+	_, err = store.Get(ctx, "avatars/abc/64.png")
+	assert.ErrorIs(t, err, msgerror.AnErrBlobNotFound)
+	assert.NoError(t, store.Delete(ctx, "avatars/abc/64.png"))
+
+	for _, key := range []string{"", "../etc/passwd", "avatars/../secret", "/abs", "avatars//64.png", "a b", `avatars\64.png`} {
+		assert.ErrorIs(t, store.Put(ctx, key, []byte("x"), "text/plain"), msgerror.AnErrInvalidBlobKey, key)
+		_, err := store.Get(ctx, key)
+		assert.ErrorIs(t, err, msgerror.AnErrInvalidBlobKey, key)
+	}
+}
+
+func TestFileBlobStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "blobs")
+	store, err := providers.NewFileBlobStore(dir)
+	require.NoError(t, err)
+
+	testBlobStore(t, store)
+
+	t.Run("Arquivos com permissão restrita e sem temporários", func(t *testing.T) {
+		require.NoError(t, store.Put(context.Background(), "avatars/xyz/128.png", []byte("data"), "image/png"))
+
+		info, err := os.Stat(filepath.Join(dir, "avatars", "xyz", "128.png"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+		entries, err := os.ReadDir(filepath.Join(dir, "avatars", "xyz"))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+}
+
+func TestS3BlobStore(t *testing.T) {
+	fake := &fakeS3{objects: map[string]domain.Blob{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store := providers.NewS3BlobStore(providers.S3Config{
+		Endpoint:  server.URL + "/",
+		Region:    "sa-east-1",
+		Bucket:    "media",
+		AccessKey: "minio",
+		SecretKey: "minio-secret",
+	})
+
+	testBlobStore(t, store)
+
+	t.Run("URL no estilo path com o bucket", func(t *testing.T) {
+		require.NoError(t, store.Put(context.Background(), "avatars/xyz/128.png", []byte("data"), "image/png"))
+		assert.Contains(t, fake.objects, "/media/avatars/xyz/128.png")
+	})
+
+	t.Run("Erro do serviço", func(t *testing.T) {
+		wrong := providers.NewS3BlobStore(providers.S3Config{
+			Endpoint:  server.URL,
+			Region:    "us-east-1",
+			Bucket:    "media",
+			AccessKey: "minio",
+		})
+		err := wrong.Put(context.Background(), "avatars/xyz/64.png", []byte("data"), "image/png")
+		require.Error(t, err)
+		assert.True(t, strings.Contains(err.Error(), "status 403"), err.Error())
+	})
+}
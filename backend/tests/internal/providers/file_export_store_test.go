@@ -0,0 +1,82 @@
+package providers_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileExportStore_SaveLoad(t *testing.T) {
+	ctx := context.Background()
+	store, err := providers.NewFileExportStore(filepath.Join(t.TempDir(), "exports"))
+	require.NoError(t, err)
+
+	name := vo.NewID().String() + ".json"
+	require.NoError(t, store.Save(ctx, name, []byte(`{"ok":true}`)))
+
+	data, err := store.Load(ctx, name)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ok":true}`, string(data))
+
+	_, err = store.Load(ctx, vo.NewID().String()+".json")
+	assert.ErrorIs(t, err, msgerror.AnErrExportNotFound)
+}
+
+func TestFileExportStore_RejectsUnexpectedNames(t *testing.T) {
+	store, err := providers.NewFileExportStore(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = store.Load(context.Background(), "../../etc/passwd")
+	assert.ErrorIs(t, err, msgerror.AnErrExportNotFound)
+	assert.ErrorIs(t, store.Save(context.Background(), "evil.sh", nil), msgerror.AnErrExportNotFound)
+}
+
+func TestFileExportStore_DeleteByOwner(t *testing.T) {
+	ctx := context.Background()
+	store, err := providers.NewFileExportStore(t.TempDir())
+	require.NoError(t, err)
+
+	owner, other := vo.NewID().String(), vo.NewID().String()
+	owned := []string{owner + "." + vo.NewID().String() + ".zip", owner + "." + vo.NewID().String() + ".json"}
+	kept := other + "." + vo.NewID().String() + ".zip"
+	for _, name := range append(owned, kept) {
+		require.NoError(t, store.Save(ctx, name, []byte("data")))
+	}
+
+	require.NoError(t, store.DeleteByOwner(ctx, owner))
+
+	for _, name := range owned {
+		_, err = store.Load(ctx, name)
+		assert.ErrorIs(t, err, msgerror.AnErrExportNotFound)
+	}
+	_, err = store.Load(ctx, kept)
+	assert.NoError(t, err)
+}
+
+func TestFileExportStore_DeleteOlderThan(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := providers.NewFileExportStore(dir)
+	require.NoError(t, err)
+
+	oldName, newName := vo.NewID().String()+".zip", vo.NewID().String()+".zip"
+	require.NoError(t, store.Save(ctx, oldName, []byte("old")))
+	require.NoError(t, store.Save(ctx, newName, []byte("new")))
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, oldName), past, past))
+
+	require.NoError(t, store.DeleteOlderThan(ctx, time.Now().Add(-time.Minute)))
+
+	_, err = store.Load(ctx, oldName)
+	assert.ErrorIs(t, err, msgerror.AnErrExportNotFound)
+	_, err = store.Load(ctx, newName)
+	assert.NoError(t, err)
+}
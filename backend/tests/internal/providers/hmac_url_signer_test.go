@@ -0,0 +1,23 @@
+package providers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHMACURLSigner(t *testing.T) {
+	signer := providers.NewHMACURLSigner("6f1c1f0b8a0e4d5cb0b5e3a1c2d4e6f8a9b0c1d2")
+	expiresAt := time.Now().Add(time.Minute)
+	signature := signer.Sign("export.zip", expiresAt)
+
+	assert.True(t, signer.Verify("export.zip", expiresAt, signature))
+	assert.False(t, signer.Verify("other.zip", expiresAt, signature), "recurso diferente")
+	assert.False(t, signer.Verify("export.zip", expiresAt.Add(time.Hour), signature), "validade adulterada")
+	assert.False(t, providers.NewHMACURLSigner("outro-segredo").Verify("export.zip", expiresAt, signature))
+
+	expired := time.Now().Add(-time.Second)
+	assert.False(t, signer.Verify("export.zip", expired, signer.Sign("export.zip", expired)))
+}
@@ -0,0 +1,258 @@
+package providers_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/providers"
+	domain "github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// solve faz o trabalho do cliente: procura a solução por força bruta.
+func solve(challenge string, difficulty int) string {
+	for i := 0; ; i++ {
+		proof := challenge + ":" + strconv.Itoa(i)
+		sum := sha256.Sum256([]byte(proof))
+		zeros := 0
+		for _, b := range sum {
+			if b != 0 {
+				for mask := byte(0x80); b&mask == 0; mask >>= 1 {
+					zeros++
+				}
+				break
+			}
+			zeros += 8
+		}
+		if zeros >= difficulty {
+			return proof
+		}
+	}
+}
+
+var humanSubject = domain.HumanSubject{IP: "192.0.2.1", Email: "maria@example.com"}
+
+func TestProofOfWorkVerifier(t *testing.T) {
+	ctx := context.Background()
+	signer := providers.NewHMACURLSigner("a-very-long-secret-used-only-in-tests")
+
+	newChallenge := func(t *testing.T, used *mocks.MockBlacklist, difficulty int) (*providers.ProofOfWorkVerifier, domain.HumanChallenge) {
+		t.Helper()
+		verifier := providers.NewProofOfWorkVerifier(signer, used, difficulty, time.Minute)
+		challenge, err := verifier.Challenge(ctx)
+		require.NoError(t, err)
+		return verifier, challenge
+	}
+
+	t.Run("Challenge", func(t *testing.T) {
+		_, challenge := newChallenge(t, nil, 8)
+
+		assert.Equal(t, domain.HumanVerifierProofOfWork, challenge.Type)
+		assert.Equal(t, 8, challenge.Difficulty)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), challenge.ExpiresAt, 2*time.Second)
+		assert.Len(t, strings.Split(challenge.Challenge, "."), 4)
+	})
+
+	t.Run("Solved challenge is accepted once", func(t *testing.T) {
+		used := new(mocks.MockBlacklist)
+		verifier, challenge := newChallenge(t, used, 8)
+		key := "human-challenge:" + strings.Split(challenge.Challenge, ".")[0]
+		used.On("AddIfAbsent", ctx, key, mock.AnythingOfType("time.Duration")).Return(true, nil).Once()
+		used.On("AddIfAbsent", ctx, key, mock.AnythingOfType("time.Duration")).Return(false, nil)
+
+		proof := solve(challenge.Challenge, 8)
+		assert.NoError(t, verifier.Verify(ctx, proof, humanSubject))
+		assert.ErrorIs(t, verifier.Verify(ctx, proof, humanSubject), msgerror.AnErrHumanVerificationFailed)
+		used.AssertExpectations(t)
+	})
+
+	t.Run("Concurrent replays are accepted once", func(t *testing.T) {
+		verifier := providers.NewProofOfWorkVerifier(signer, providers.NewMemoryBlacklist(nil), 8, time.Minute)
+		challenge, err := verifier.Challenge(ctx)
+		require.NoError(t, err)
+		proof := solve(challenge.Challenge, 8)
+
+		const senders = 50
+		var accepted atomic.Int32
+		var wg sync.WaitGroup
+		start := make(chan struct{})
+		for i := 0; i < senders; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				switch err := verifier.Verify(ctx, proof, humanSubject); {
+				case err == nil:
+					accepted.Add(1)
+				default:
+					assert.ErrorIs(t, err, msgerror.AnErrHumanVerificationFailed)
+				}
+			}()
+		}
+		close(start)
+		wg.Wait()
+
+		assert.EqualValues(t, 1, accepted.Load())
+	})
+
+	t.Run("Missing proof", func(t *testing.T) {
+		verifier, _ := newChallenge(t, nil, 8)
+		assert.ErrorIs(t, verifier.Verify(ctx, "", humanSubject), msgerror.AnErrHumanVerificationRequired)
+	})
+
+	t.Run("Invalid proofs", func(t *testing.T) {
+		verifier, challenge := newChallenge(t, new(mocks.MockBlacklist), 20)
+		parts := strings.Split(challenge.Challenge, ".")
+
+		easier := strings.Join([]string{parts[0], "1", parts[2], parts[3]}, ".")
+		for name, proof := range map[string]string{
+			"no solution":       challenge.Challenge,
+			"malformed":         "abc:1",
+			"wrong solution":    challenge.Challenge + ":not-a-solution",
+			"tampered":          solve(easier, 1),
+			"solution too long": challenge.Challenge + ":" + strings.Repeat("1", 100),
+		} {
+			assert.ErrorIs(t, verifier.Verify(ctx, proof, humanSubject), msgerror.AnErrHumanVerificationFailed, name)
+		}
+	})
+
+	t.Run("Challenges from a lower difficulty are refused", func(t *testing.T) {
+		_, challenge := newChallenge(t, nil, 4)
+		verifier := providers.NewProofOfWorkVerifier(signer, new(mocks.MockBlacklist), 8, time.Minute)
+
+		err := verifier.Verify(ctx, solve(challenge.Challenge, 4), humanSubject)
+		assert.ErrorIs(t, err, msgerror.AnErrHumanVerificationFailed)
+	})
+
+	t.Run("Expired challenge", func(t *testing.T) {
+		// A validade é contada em segundos inteiros: 1ns já nasce vencido
+		verifier := providers.NewProofOfWorkVerifier(signer, new(mocks.MockBlacklist), 4, time.Nanosecond)
+		challenge, err := verifier.Challenge(ctx)
+		require.NoError(t, err)
+
+		err = verifier.Verify(ctx, solve(challenge.Challenge, 4), humanSubject)
+		assert.ErrorIs(t, err, msgerror.AnErrHumanVerificationFailed)
+	})
+}
+
+func TestCaptchaVerifier(t *testing.T) {
+	ctx := context.Background()
+	var form map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		form = map[string]string{
+			"secret":   r.PostForm.Get("secret"),
+			"response": r.PostForm.Get("response"),
+			"remoteip": r.PostForm.Get("remoteip"),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.PostForm.Get("response") {
+		case "valid":
+			_, _ = w.Write([]byte(`{"success": true}`))
+		case "broken":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			_, _ = w.Write([]byte(`{"success": false, "error-codes": ["invalid-input-response"]}`))
+		}
+	}))
+	defer server.Close()
+
+	verifier := providers.NewCaptchaVerifier(providers.CaptchaConfig{
+		Kind: domain.HumanVerifierTurnstile, SiteKey: "site", Secret: "shh", VerifyURL: server.URL,
+	})
+
+	challenge, err := verifier.Challenge(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.HumanChallenge{Type: "turnstile", SiteKey: "site"}, challenge)
+
+	require.NoError(t, verifier.Verify(ctx, "valid", humanSubject))
+	assert.Equal(t, map[string]string{"secret": "shh", "response": "valid", "remoteip": "192.0.2.1"}, form)
+
+	err = verifier.Verify(ctx, "forged", humanSubject)
+	assert.ErrorIs(t, err, msgerror.AnErrHumanVerificationFailed)
+	assert.Contains(t, err.Error(), "invalid-input-response")
+
+	assert.ErrorIs(t, verifier.Verify(ctx, "", humanSubject), msgerror.AnErrHumanVerificationRequired)
+
+	// Falhas do serviço não são confundidas com prova inválida
+	err = verifier.Verify(ctx, "broken", humanSubject)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, msgerror.AnErrHumanVerificationFailed)
+}
+
+func TestRiskBasedHumanVerifier(t *testing.T) {
+	ctx := context.Background()
+	inner := new(mocks.MockHumanVerifier)
+	inner.On("Verify", ctx, "", mock.Anything).Return(msgerror.AnErrHumanVerificationRequired)
+	inner.On("Verify", ctx, "proof", mock.Anything).Return(nil)
+
+	attempts := providers.NewMemoryRateLimiter(domain.RateLimitSlidingWindow, nil)
+	verifier := providers.NewRiskBasedHumanVerifier(inner, attempts, domain.RateLimit{Limit: 2, Window: time.Hour})
+
+	assert.NoError(t, verifier.Verify(ctx, "", humanSubject))
+	assert.NoError(t, verifier.Verify(ctx, "", humanSubject))
+	assert.ErrorIs(t, verifier.Verify(ctx, "", humanSubject), msgerror.AnErrHumanVerificationRequired)
+	assert.NoError(t, verifier.Verify(ctx, "proof", humanSubject))
+
+	// Outro IP com outro e-mail tem a própria contagem
+	assert.NoError(t, verifier.Verify(ctx, "", domain.HumanSubject{IP: "192.0.2.2", Email: "joao@example.com"}))
+
+	t.Run("Rotating addresses does not reset the email count", func(t *testing.T) {
+		verifier := providers.NewRiskBasedHumanVerifier(inner, providers.NewMemoryRateLimiter(domain.RateLimitSlidingWindow, nil), domain.RateLimit{Limit: 2, Window: time.Hour})
+
+		assert.NoError(t, verifier.Verify(ctx, "", domain.HumanSubject{IP: "198.51.100.1", Email: "maria@example.com"}))
+		assert.NoError(t, verifier.Verify(ctx, "", domain.HumanSubject{IP: "198.51.100.2", Email: " Maria@Example.com"}))
+		err := verifier.Verify(ctx, "", domain.HumanSubject{IP: "198.51.100.3", Email: "maria@example.com"})
+		assert.ErrorIs(t, err, msgerror.AnErrHumanVerificationRequired)
+	})
+
+	t.Run("Rotating emails does not reset the address count", func(t *testing.T) {
+		verifier := providers.NewRiskBasedHumanVerifier(inner, providers.NewMemoryRateLimiter(domain.RateLimitSlidingWindow, nil), domain.RateLimit{Limit: 2, Window: time.Hour})
+
+		assert.NoError(t, verifier.Verify(ctx, "", domain.HumanSubject{IP: "198.51.100.1", Email: "a@example.com"}))
+		assert.NoError(t, verifier.Verify(ctx, "", domain.HumanSubject{IP: "198.51.100.1", Email: "b@example.com"}))
+		err := verifier.Verify(ctx, "", domain.HumanSubject{IP: "198.51.100.1", Email: "c@example.com"})
+		assert.ErrorIs(t, err, msgerror.AnErrHumanVerificationRequired)
+	})
+
+	t.Run("Counter failure requires the proof", func(t *testing.T) {
+		failing := new(mocks.MockRateLimiter)
+		failing.On("Allow", ctx, mock.Anything, mock.Anything).Return(domain.RateLimitResult{}, assert.AnError)
+		verifier := providers.NewRiskBasedHumanVerifier(inner, failing, domain.RateLimit{Limit: 2, Window: time.Hour})
+
+		assert.ErrorIs(t, verifier.Verify(ctx, "", humanSubject), msgerror.AnErrHumanVerificationRequired)
+	})
+}
+
+func TestNewHumanVerifier(t *testing.T) {
+	signer := providers.NewHMACURLSigner("a-very-long-secret-used-only-in-tests")
+
+	verifier, err := providers.NewHumanVerifier(providers.HumanVerifierConfig{Kind: "none"}, signer, nil)
+	require.NoError(t, err)
+	assert.Nil(t, verifier)
+
+	verifier, err = providers.NewHumanVerifier(providers.HumanVerifierConfig{Kind: "pow", PowDifficulty: 8, PowTTL: time.Minute}, signer, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &providers.ProofOfWorkVerifier{}, verifier)
+
+	verifier, err = providers.NewHumanVerifier(providers.HumanVerifierConfig{Kind: "hcaptcha"}, signer, nil)
+	require.NoError(t, err)
+	challenge, err := verifier.Challenge(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "hcaptcha", challenge.Type)
+
+	_, err = providers.NewHumanVerifier(providers.HumanVerifierConfig{Kind: "recaptcha"}, signer, nil)
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidHumanVerifier)
+}
@@ -0,0 +1,156 @@
+package providers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	red  = color.RGBA{R: 255, A: 255}
+	blue = color.RGBA{B: 255, A: 255}
+)
+
+// halves pinta a metade de cima de vermelho e a de baixo de azul.
+func halves(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if y < h/2 {
+				img.Set(x, y, red)
+			} else {
+				img.Set(x, y, blue)
+			}
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// jpegWithExif insere, logo após o SOI, um APP1 com a orientação e um
+// comentário que não pode sobreviver ao processamento.
+func jpegWithExif(t *testing.T, img image.Image, orientation uint16) []byte {
+	t.Helper()
+	var encoded bytes.Buffer
+	require.NoError(t, jpeg.Encode(&encoded, img, &jpeg.Options{Quality: 95}))
+
+	var tiff bytes.Buffer
+	tiff.WriteString("MM")
+	_ = binary.Write(&tiff, binary.BigEndian, uint16(42))
+	_ = binary.Write(&tiff, binary.BigEndian, uint32(8))
+	_ = binary.Write(&tiff, binary.BigEndian, uint16(1))
+	_ = binary.Write(&tiff, binary.BigEndian, []uint16{0x0112, 3})
+	_ = binary.Write(&tiff, binary.BigEndian, uint32(1))
+	_ = binary.Write(&tiff, binary.BigEndian, []uint16{orientation, 0})
+	_ = binary.Write(&tiff, binary.BigEndian, uint32(0))
+	tiff.WriteString("GPS-SECRET")
+
+	segment := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	var out bytes.Buffer
+	out.Write(encoded.Bytes()[:2])
+	out.Write([]byte{0xFF, 0xE1})
+	_ = binary.Write(&out, binary.BigEndian, uint16(len(segment)+2))
+	out.Write(segment)
+	out.Write(encoded.Bytes()[2:])
+	return out.Bytes()
+}
+
+func decodeThumbnail(t *testing.T, data []byte) image.Image {
+	t.Helper()
+	img, format, err := image.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, "png", format)
+	return img
+}
+
+func assertColor(t *testing.T, want color.RGBA, got color.Color, msg string) {
+	t.Helper()
+	r, g, b, _ := got.RGBA()
+	wr, wg, wb, _ := want.RGBA()
+	near := func(a, b uint32) bool { return a>>8 < b>>8+40 && b>>8 < a>>8+40 }
+	assert.True(t, near(r, wr) && near(g, wg) && near(b, wb), "%s: got %v", msg, got)
+}
+
+func TestStdImageProcessor_Thumbnails(t *testing.T) {
+	processor := providers.NewStdImageProcessor(1_000_000)
+
+	t.Run("Recorta no centro e gera cada tamanho", func(t *testing.T) {
+		// 300x100: o recorte central fica com 100x100 e as duas metades
+		thumbnails, err := processor.Thumbnails(encodePNG(t, halves(300, 100)), []int{16, 64})
+		require.NoError(t, err)
+		require.Len(t, thumbnails, 2)
+
+		for size, data := range thumbnails {
+			img := decodeThumbnail(t, data)
+			assert.Equal(t, image.Rect(0, 0, size, size), img.Bounds())
+			assertColor(t, red, img.At(size/2, 1), "topo")
+			assertColor(t, blue, img.At(size/2, size-2), "base")
+		}
+	})
+
+	t.Run("Amplia imagens menores que a miniatura", func(t *testing.T) {
+		thumbnails, err := processor.Thumbnails(encodePNG(t, halves(4, 4)), []int{64})
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 64, 64), decodeThumbnail(t, thumbnails[64]).Bounds())
+	})
+
+	t.Run("Aplica a orientação EXIF e descarta os metadados", func(t *testing.T) {
+		// Orientação 6: girar 90° no sentido horário, o topo vai para a direita
+		data := jpegWithExif(t, halves(40, 40), 6)
+		require.Contains(t, string(data), "GPS-SECRET")
+
+		thumbnails, err := processor.Thumbnails(data, []int{32})
+		require.NoError(t, err)
+
+		img := decodeThumbnail(t, thumbnails[32])
+		assertColor(t, blue, img.At(2, 16), "esquerda")
+		assertColor(t, red, img.At(29, 16), "direita")
+		assert.NotContains(t, string(thumbnails[32]), "GPS-SECRET")
+		assert.NotContains(t, string(thumbnails[32]), "Exif")
+	})
+
+	t.Run("GIF usa o primeiro quadro", func(t *testing.T) {
+		palette := image.NewPaletted(image.Rect(0, 0, 10, 10), color.Palette{red, blue})
+		var buf bytes.Buffer
+		require.NoError(t, gif.Encode(&buf, palette, nil))
+
+		thumbnails, err := processor.Thumbnails(buf.Bytes(), []int{8})
+		require.NoError(t, err)
+		assertColor(t, red, decodeThumbnail(t, thumbnails[8]).At(4, 4), "centro")
+	})
+
+	t.Run("Tipo identificado pelo conteúdo", func(t *testing.T) {
+		_, err := processor.Thumbnails([]byte("<svg xmlns='http://www.w3.org/2000/svg'></svg>"), []int{64})
+		assert.ErrorIs(t, err, msgerror.AnErrUnsupportedImage)
+
+		_, err = processor.Thumbnails([]byte("%PDF-1.4"), []int{64})
+		assert.ErrorIs(t, err, msgerror.AnErrUnsupportedImage)
+	})
+
+	t.Run("Imagem corrompida", func(t *testing.T) {
+		data := encodePNG(t, halves(10, 10))
+		_, err := processor.Thumbnails(data[:40], []int{64})
+		assert.ErrorIs(t, err, msgerror.AnErrInvalidImage)
+	})
+
+	t.Run("Dimensões acima do limite", func(t *testing.T) {
+		_, err := providers.NewStdImageProcessor(99).Thumbnails(encodePNG(t, halves(10, 10)), []int{64})
+		assert.ErrorIs(t, err, msgerror.AnErrImageTooLarge)
+	})
+}
@@ -0,0 +1,74 @@
+package providers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/metrics"
+	"github.com/eskokado/startup-auth-go/backend/internal/providers"
+	domain "github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestInstrumentedBlacklist(t *testing.T) {
+	ctx := context.Background()
+	m := metrics.New()
+	inner := new(mocks.MockBlacklist)
+	inner.On("Add", ctx, "jti", time.Minute).Return(nil)
+	inner.On("Exists", ctx, "jti").Return(true, nil)
+	inner.On("Get", ctx, "missing").Return("", assert.AnError)
+	blacklist := providers.NewInstrumentedBlacklist(inner, m)
+
+	assert.NoError(t, blacklist.Add(ctx, "jti", time.Minute))
+	exists, err := blacklist.Exists(ctx, "jti")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	_, err = blacklist.Get(ctx, "missing")
+	assert.ErrorIs(t, err, assert.AnError)
+
+	assert.Equal(t, uint64(1), m.BlacklistDuration.Count("add", "success"))
+	assert.Equal(t, uint64(1), m.BlacklistDuration.Count("exists", "success"))
+	assert.Equal(t, uint64(1), m.BlacklistDuration.Count("get", "failure"))
+	inner.AssertExpectations(t)
+}
+
+func TestInstrumentedTokenProvider(t *testing.T) {
+	m := metrics.New()
+	inner := new(mocks.MockTokenProvider)
+	inner.On("Validate", mock.Anything, "good").Return(&domain.Claims{UserID: "u1"}, nil)
+	inner.On("Validate", mock.Anything, "bad").Return(nil, msgerror.AnErrInvalidToken)
+	tokens := providers.NewInstrumentedTokenProvider(inner, m)
+
+	claims, err := tokens.Validate(context.Background(), "good")
+	assert.NoError(t, err)
+	assert.Equal(t, "u1", claims.(*domain.Claims).UserID)
+	_, err = tokens.Validate(context.Background(), "bad")
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidToken)
+
+	assert.Equal(t, 1.0, m.TokenValidations.Value("valid"))
+	assert.Equal(t, 1.0, m.TokenValidations.Value("invalid"))
+}
+
+func TestInstrumentedTokenStore(t *testing.T) {
+	ctx := context.Background()
+	m := metrics.New()
+	claims := domain.Claims{UserID: "u1"}
+	inner := new(mocks.MockTokenStore)
+	inner.On("Revoke", ctx, claims).Return(nil)
+	inner.On("RevokeUser", ctx, "u1", time.Hour).Return(assert.AnError)
+	inner.On("IsActive", ctx, claims).Return(true, nil)
+	store := providers.NewInstrumentedTokenStore(inner, m)
+
+	assert.NoError(t, store.Revoke(ctx, claims))
+	assert.ErrorIs(t, store.RevokeUser(ctx, "u1", time.Hour), assert.AnError)
+	active, err := store.IsActive(ctx, claims)
+	assert.NoError(t, err)
+	assert.True(t, active)
+
+	assert.Equal(t, 1.0, m.TokenRevocations.Value("token", "success"))
+	assert.Equal(t, 1.0, m.TokenRevocations.Value("user", "failure"))
+}
@@ -1,6 +1,7 @@
 package providers_test
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
@@ -22,12 +23,12 @@ func TestJWTProvider(t *testing.T) {
 				Subject: userID,
 			},
 		}
-		token, err := provider.Generate(claims)
+		token, err := provider.Generate(context.Background(), claims)
 		if err != nil {
 			t.Fatalf("Token generation failed: %v", err)
 		}
 
-		validatedClaims, err := provider.Validate(token)
+		validatedClaims, err := provider.Validate(context.Background(), token)
 		if err != nil {
 			t.Fatalf("Token validation failed: %v", err)
 		}
@@ -41,6 +42,43 @@ func TestJWTProvider(t *testing.T) {
 		}
 	})
 
+	t.Run("Round Trip JTI And Explicit Expiry", func(t *testing.T) {
+		expiresAt := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+		claims := providers.Claims{
+			UserID: userID,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        "jti-abc",
+				Subject:   userID,
+				ExpiresAt: jwt.NewNumericDate(expiresAt),
+			},
+		}
+		before := time.Now().Truncate(time.Millisecond)
+		token, err := provider.Generate(context.Background(), claims)
+		if err != nil {
+			t.Fatalf("Token generation failed: %v", err)
+		}
+
+		validatedClaims, err := provider.Validate(context.Background(), token)
+		if err != nil {
+			t.Fatalf("Token validation failed: %v", err)
+		}
+
+		vc := validatedClaims.(providers.Claims)
+		if vc.ID != "jti-abc" {
+			t.Errorf("Expected jti jti-abc, got %s", vc.ID)
+		}
+		if vc.IssuedAt == nil {
+			t.Fatal("Expected iat to be set")
+		}
+		// iat mantém os milissegundos
+		if vc.IssuedAt.Before(before) || vc.IssuedAt.After(time.Now()) {
+			t.Errorf("Expected iat with millisecond precision after %v, got %v", before, vc.IssuedAt.Time)
+		}
+		if !vc.ExpiresAt.Time.Equal(expiresAt) {
+			t.Errorf("Expected exp %v, got %v", expiresAt, vc.ExpiresAt.Time)
+		}
+	})
+
 	t.Run("Expired Token", func(t *testing.T) {
 		expiredProvider := auth.NewJWTProvider("test-secret-key", -5*time.Minute)
 		claims := providers.Claims{
@@ -49,9 +87,9 @@ func TestJWTProvider(t *testing.T) {
 				Subject: userID,
 			},
 		}
-		token, _ := expiredProvider.Generate(claims)
+		token, _ := expiredProvider.Generate(context.Background(), claims)
 
-		_, err := provider.Validate(token)
+		_, err := provider.Validate(context.Background(), token)
 		if err == nil {
 			t.Error("Expected expired token error")
 		} else {
@@ -69,10 +107,10 @@ func TestJWTProvider(t *testing.T) {
 				Subject: userID,
 			},
 		}
-		token, _ := provider.Generate(claims)
+		token, _ := provider.Generate(context.Background(), claims)
 		invalidProvider := auth.NewJWTProvider("wrong-secret-key", 15*time.Minute)
 
-		_, err := invalidProvider.Validate(token)
+		_, err := invalidProvider.Validate(context.Background(), token)
 		if err == nil {
 			t.Error("Expected signature validation error")
 		}
@@ -80,7 +118,7 @@ func TestJWTProvider(t *testing.T) {
 
 	t.Run("Generate with Invalid Claims Type", func(t *testing.T) {
 		invalidClaims := "not_a_struct"
-		_, err := provider.Generate(invalidClaims)
+		_, err := provider.Generate(context.Background(), invalidClaims)
 		if err == nil {
 			t.Error("Expected error for invalid claims type")
 		} else if err.Error() != "tipo de claims inválido" {
@@ -89,7 +127,7 @@ func TestJWTProvider(t *testing.T) {
 	})
 
 	t.Run("Malformed Token", func(t *testing.T) {
-		_, err := provider.Validate("invalid.token.string")
+		_, err := provider.Validate(context.Background(), "invalid.token.string")
 		if err == nil {
 			t.Error("Expected error for malformed token")
 		}
@@ -103,7 +141,7 @@ func TestJWTProvider(t *testing.T) {
 		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 		tokenString, _ := token.SignedString([]byte("test-secret-key"))
 
-		_, err := provider.Validate(tokenString)
+		_, err := provider.Validate(context.Background(), tokenString)
 		if err == nil {
 			t.Error("Expected error for missing subject")
 		} else if err.Error() != "subject não encontrado ou inválido" {
@@ -120,7 +158,7 @@ func TestJWTProvider(t *testing.T) {
 		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 		tokenString, _ := token.SignedString([]byte("test-secret-key"))
 
-		_, err := provider.Validate(tokenString)
+		_, err := provider.Validate(context.Background(), tokenString)
 		if err == nil {
 			t.Error("Expected error for invalid subject type")
 		} else if err.Error() == "subject não encontrado ou inválido" {
@@ -138,7 +176,7 @@ func TestJWTProvider(t *testing.T) {
 		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 		tokenString, _ := token.SignedString([]byte("test-secret-key"))
 
-		_, err := provider.Validate(tokenString)
+		_, err := provider.Validate(context.Background(), tokenString)
 		if err == nil {
 			t.Error("Expected error for missing user_id")
 		} else if err.Error() != "user_id não encontrado ou inválido" {
@@ -155,7 +193,7 @@ func TestJWTProvider(t *testing.T) {
 		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 		tokenString, _ := token.SignedString([]byte("test-secret-key"))
 
-		_, err := provider.Validate(tokenString)
+		_, err := provider.Validate(context.Background(), tokenString)
 		if err == nil {
 			t.Error("Expected error for invalid user_id type")
 		} else if err.Error() == "user_id não encontrado ou inválido" {
@@ -173,7 +211,7 @@ func TestJWTProvider(t *testing.T) {
 		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 		tokenString, _ := token.SignedString([]byte("test-secret-key"))
 
-		_, err := provider.Validate(tokenString)
+		_, err := provider.Validate(context.Background(), tokenString)
 		if err == nil {
 			t.Error("Expected error for missing exp")
 		} else if err.Error() != "exp não encontrado ou inválido" {
@@ -182,7 +220,7 @@ func TestJWTProvider(t *testing.T) {
 	})
 
 	t.Run("Invalid Token Format", func(t *testing.T) {
-		_, err := provider.Validate("invalid-token-format")
+		_, err := provider.Validate(context.Background(), "invalid-token-format")
 		if err == nil {
 			t.Error("Expected error for invalid token format")
 		}
@@ -193,7 +231,7 @@ func TestJWTProvider(t *testing.T) {
 		parts := strings.Split(validToken, ".")
 		invalidToken := parts[0] + "." + parts[1] + ".invalid_signature"
 
-		_, err := provider.Validate(invalidToken)
+		_, err := provider.Validate(context.Background(), invalidToken)
 		if err == nil {
 			t.Error("Expected signature validation error")
 		}
@@ -208,28 +246,28 @@ func TestJWTProvider(t *testing.T) {
 		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
 		tokenString, _ := token.SignedString([]byte("test-secret-key"))
 
-		_, err := provider.Validate(tokenString)
+		_, err := provider.Validate(context.Background(), tokenString)
 		if err == nil {
 			t.Error("Expected error for unsupported signing method")
 		}
 	})
 
 	t.Run("Invalid Token Structure", func(t *testing.T) {
-		_, err := provider.Validate("header.claims")
+		_, err := provider.Validate(context.Background(), "header.claims")
 		if err == nil {
 			t.Error("Expected error for invalid token structure")
 		}
 	})
 
 	t.Run("Empty Token", func(t *testing.T) {
-		_, err := provider.Validate("")
+		_, err := provider.Validate(context.Background(), "")
 		if err == nil {
 			t.Error("Expected error for empty token")
 		}
 	})
 
 	t.Run("Nil Claims", func(t *testing.T) {
-		_, err := provider.Generate(nil)
+		_, err := provider.Generate(context.Background(), nil)
 		if err == nil {
 			t.Error("Expected error for nil claims")
 		}
@@ -247,7 +285,7 @@ func TestJWTProvider(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		_, err = provider.Validate(tokenString)
+		_, err = provider.Validate(context.Background(), tokenString)
 		if err == nil {
 			t.Error("Expected error for invalid token")
 		} else if !strings.Contains(err.Error(), "token") {
@@ -265,7 +303,7 @@ func TestJWTProvider(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		_, err = provider.Validate(tokenString)
+		_, err = provider.Validate(context.Background(), tokenString)
 		if err == nil {
 			t.Error("Expected error for invalid claims type")
 		} else if err.Error() != "subject não encontrado ou inválido" {
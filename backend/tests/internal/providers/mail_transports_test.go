@@ -0,0 +1,140 @@
+package providers_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/providers"
+	service "github.com/eskokado/startup-auth-go/backend/pkg/domain/services"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/gomail.v2"
+)
+
+func newTestMessage(to, subject string) *gomail.Message {
+	m := gomail.NewMessage()
+	m.SetHeader("From", "no-reply@example.com")
+	m.SetHeader("To", to)
+	m.SetHeader("Subject", subject)
+	m.SetBody("text/plain", "Acesse https://app.example.com/reset?token=secret-token")
+	m.AddAlternative("text/html", `<p>Acesse <a href="https://app.example.com/reset?token=secret-token">aqui</a></p>`)
+	return m
+}
+
+func TestNewMailSender(t *testing.T) {
+	cases := map[string]any{
+		"":                           &gomail.Dialer{},
+		service.MailTransportSMTP:    &gomail.Dialer{},
+		service.MailTransportMailbox: &providers.MemoryMailbox{},
+		service.MailTransportFile:    &providers.FileMailTransport{},
+		service.MailTransportLog:     &providers.LogMailTransport{},
+	}
+	for transport, want := range cases {
+		sender, err := providers.NewMailSender(providers.MailTransportConfig{
+			Transport: transport,
+			FileDir:   t.TempDir(),
+		})
+		require.NoError(t, err, transport)
+		assert.IsType(t, want, sender, transport)
+	}
+
+	_, err := providers.NewMailSender(providers.MailTransportConfig{Transport: "pigeon"})
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidTransport)
+}
+
+func TestMemoryMailbox(t *testing.T) {
+	mailbox := providers.NewMemoryMailbox(2)
+
+	require.NoError(t, mailbox.DialAndSend(newTestMessage("ana@test.com", "Redefinição de Senha")))
+	require.NoError(t, mailbox.DialAndSend(newTestMessage("bia@test.com", "Segundo")))
+
+	t.Run("Decodifica cabeçalhos e partes", func(t *testing.T) {
+		messages := mailbox.Messages("ANA@test.com")
+		require.Len(t, messages, 1)
+		message := messages[0]
+		assert.NotEmpty(t, message.ID)
+		assert.Equal(t, []string{"ana@test.com"}, message.To)
+		assert.Equal(t, "Redefinição de Senha", message.Subject)
+		assert.Equal(t, "Acesse https://app.example.com/reset?token=secret-token", message.Text)
+		assert.Contains(t, message.HTML, `href="https://app.example.com/reset?token=secret-token"`)
+	})
+
+	t.Run("Mais recentes primeiro e capacidade limitada", func(t *testing.T) {
+		require.NoError(t, mailbox.DialAndSend(newTestMessage("ana@test.com", "Terceiro")))
+
+		messages := mailbox.Messages("")
+		require.Len(t, messages, 2)
+		assert.Equal(t, "Terceiro", messages[0].Subject)
+		assert.Equal(t, "Segundo", messages[1].Subject)
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		mailbox.Clear()
+		assert.Empty(t, mailbox.Messages(""))
+	})
+}
+
+func TestMemoryMailbox_ThroughEmailService(t *testing.T) {
+	mailbox := providers.NewMemoryMailbox(10)
+	emailService := service.NewEmailService(mailbox, service.EmailConfig{
+		From:        "no-reply@example.com",
+		FrontendURL: "https://app.example.com/reset-password",
+	})
+
+	email, err := vo.NewEmail("ana@test.com")
+	require.NoError(t, err)
+	require.NoError(t, emailService.SendResetPasswordEmail(context.Background(), email, "en-US", "tok-123"))
+
+	messages := mailbox.Messages("ana@test.com")
+	require.Len(t, messages, 1)
+	assert.Equal(t, "Password Reset", messages[0].Subject)
+	assert.Contains(t, messages[0].Text, "https://app.example.com/reset-password?reset_password_token=tok-123")
+	assert.Contains(t, messages[0].HTML, "<html")
+}
+
+func TestFileMailTransport(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "maildir")
+	transport, err := providers.NewFileMailTransport(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, transport.DialAndSend(
+		newTestMessage("ana@test.com", "Um"),
+		newTestMessage("bia@test.com", "Dois"),
+	))
+
+	entries, err := os.ReadDir(filepath.Join(dir, "new"))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.True(t, strings.HasSuffix(entries[0].Name(), ".eml"))
+
+	tmp, err := os.ReadDir(filepath.Join(dir, "tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, tmp)
+
+	content, err := os.ReadFile(filepath.Join(dir, "new", entries[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Subject: ")
+	assert.Contains(t, string(content), "multipart/alternative")
+
+	info, err := os.Stat(filepath.Join(dir, "new", entries[0].Name()))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestLogMailTransport_OmitsBody(t *testing.T) {
+	var buf bytes.Buffer
+	transport := providers.NewLogMailTransport(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	require.NoError(t, transport.DialAndSend(newTestMessage("ana@test.com", "Redefinição")))
+
+	assert.Contains(t, buf.String(), "to=ana@test.com")
+	assert.Contains(t, buf.String(), "subject=Redefinição")
+	assert.NotContains(t, buf.String(), "secret-token")
+}
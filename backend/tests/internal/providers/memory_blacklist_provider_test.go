@@ -0,0 +1,82 @@
+package providers_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/providers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryBlacklist(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	blacklist := providers.NewMemoryBlacklist(func() time.Time { return now })
+
+	require.NoError(t, blacklist.Add(ctx, "jti-1", time.Minute))
+	exists, err := blacklist.Exists(ctx, "jti-1")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	// Add usa o mesmo prefixo do Redis
+	exists, _ = blacklist.ExistsKey(ctx, "startup-auth-go:jti-1")
+	assert.True(t, exists)
+
+	require.NoError(t, blacklist.SetWithKey(ctx, "session:1", 42, 0))
+	value, err := blacklist.Get(ctx, "session:1")
+	require.NoError(t, err)
+	assert.Equal(t, "42", value)
+	values, err := blacklist.MGet(ctx, "session:1", "missing")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"42", nil}, values)
+
+	now = now.Add(time.Minute)
+	exists, _ = blacklist.Exists(ctx, "jti-1")
+	assert.False(t, exists, "expired")
+	exists, _ = blacklist.ExistsKey(ctx, "session:1")
+	assert.True(t, exists, "no ttl never expires")
+
+	require.NoError(t, blacklist.Del(ctx, "session:1"))
+	value, _ = blacklist.Get(ctx, "session:1")
+	assert.Empty(t, value)
+}
+
+func TestMemoryBlacklist_AddIfAbsent(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	blacklist := providers.NewMemoryBlacklist(func() time.Time { return now })
+
+	added, err := blacklist.AddIfAbsent(ctx, "challenge", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, added)
+	added, _ = blacklist.AddIfAbsent(ctx, "challenge", time.Minute)
+	assert.False(t, added)
+
+	// Depois de expirar a chave pode ser registrada de novo
+	now = now.Add(time.Minute)
+	added, _ = blacklist.AddIfAbsent(ctx, "challenge", time.Minute)
+	assert.True(t, added)
+}
+
+func TestMemoryBlacklist_AddIfAbsentConcurrent(t *testing.T) {
+	ctx := context.Background()
+	blacklist := providers.NewMemoryBlacklist(nil)
+
+	var added atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, _ := blacklist.AddIfAbsent(ctx, "challenge", time.Minute); ok {
+				added.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, 1, added.Load())
+}
@@ -0,0 +1,187 @@
+package providers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/providers"
+	domain "github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Relógio começando no início de um minuto, para as janelas ficarem alinhadas
+type fakeClock struct{ now time.Time }
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (f *fakeClock) Now() time.Time          { return f.now }
+func (f *fakeClock) Advance(d time.Duration) { f.now = f.now.Add(d) }
+
+func allowN(t *testing.T, limiter domain.RateLimiter, key string, limit domain.RateLimit, n int) domain.RateLimitResult {
+	t.Helper()
+	var result domain.RateLimitResult
+	for i := 0; i < n; i++ {
+		var err error
+		result, err = limiter.Allow(context.Background(), key, limit)
+		require.NoError(t, err)
+	}
+	return result
+}
+
+func TestMemoryRateLimiter_TokenBucket(t *testing.T) {
+	clock := newFakeClock()
+	limiter := providers.NewMemoryRateLimiter(domain.RateLimitTokenBucket, clock.Now)
+	limit := domain.RateLimit{Limit: 3, Window: time.Minute}
+
+	result := allowN(t, limiter, "a", limit, 1)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 2, result.Remaining)
+	assert.Equal(t, 20*time.Second, result.ResetAfter)
+
+	result = allowN(t, limiter, "a", limit, 2)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	result = allowN(t, limiter, "a", limit, 1)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 20*time.Second, result.RetryAfter)
+	assert.Equal(t, time.Minute, result.ResetAfter)
+
+	// Outras chaves têm a própria cota
+	assert.True(t, allowN(t, limiter, "b", limit, 1).Allowed)
+
+	// Um token volta a cada Window/Limit
+	clock.Advance(20 * time.Second)
+	assert.True(t, allowN(t, limiter, "a", limit, 1).Allowed)
+	assert.False(t, allowN(t, limiter, "a", limit, 1).Allowed)
+
+	// Depois de uma janela inteira a cota está cheia, sem passar do limite
+	clock.Advance(time.Hour)
+	result = allowN(t, limiter, "a", limit, 1)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 2, result.Remaining)
+}
+
+func TestMemoryRateLimiter_SlidingWindow(t *testing.T) {
+	clock := newFakeClock()
+	limiter := providers.NewMemoryRateLimiter(domain.RateLimitSlidingWindow, clock.Now)
+	limit := domain.RateLimit{Limit: 3, Window: time.Minute}
+
+	result := allowN(t, limiter, "a", limit, 3)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	result = allowN(t, limiter, "a", limit, 1)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, time.Minute, result.RetryAfter)
+
+	// Na metade da janela seguinte a anterior ainda pesa 1,5 requisição
+	clock.Advance(90 * time.Second)
+	result = allowN(t, limiter, "a", limit, 1)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	result = allowN(t, limiter, "a", limit, 1)
+	assert.False(t, result.Allowed)
+	assert.InDelta(t, 10*time.Second, result.RetryAfter, float64(time.Millisecond))
+
+	clock.Advance(11 * time.Second)
+	assert.True(t, allowN(t, limiter, "a", limit, 1).Allowed)
+
+	// Depois de duas janelas sem requisições a cota recomeça
+	clock.Advance(2 * time.Minute)
+	result = allowN(t, limiter, "a", limit, 1)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 2, result.Remaining)
+}
+
+func TestMemoryRateLimiter_InvalidLimit(t *testing.T) {
+	limiter := providers.NewMemoryRateLimiter(domain.RateLimitTokenBucket, nil)
+
+	_, err := limiter.Allow(context.Background(), "a", domain.RateLimit{Limit: 0, Window: time.Minute})
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidRateLimit)
+}
+
+func TestRedisRateLimiter_Allow(t *testing.T) {
+	ctx := context.Background()
+	limit := domain.RateLimit{Limit: 5, Window: time.Minute}
+	keys := []string{"startup-auth-go:ratelimit:login:ip:1.2.3.4"}
+	args := mock.MatchedBy(func(args []interface{}) bool {
+		return len(args) == 3 && args[0] == 5 && args[1] == int64(60000)
+	})
+
+	t.Run("Reply is converted to a result", func(t *testing.T) {
+		client := new(MockRedisCmdable)
+		client.On("Eval", ctx, mock.AnythingOfType("string"), keys, args).
+			Return(redis.NewCmdResult([]interface{}{int64(0), int64(0), int64(60000), int64(12001)}, nil))
+
+		result, err := providers.NewRedisRateLimiter(domain.RateLimitTokenBucket, client).
+			Allow(ctx, "login:ip:1.2.3.4", limit)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.RateLimitResult{
+			Allowed:    false,
+			Remaining:  0,
+			ResetAfter: time.Minute,
+			RetryAfter: 12001 * time.Millisecond,
+		}, result)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Each algorithm has its own script", func(t *testing.T) {
+		scripts := map[string]bool{}
+		for _, algorithm := range []string{domain.RateLimitTokenBucket, domain.RateLimitSlidingWindow} {
+			client := new(MockRedisCmdable)
+			client.On("Eval", ctx, mock.AnythingOfType("string"), keys, args).
+				Run(func(call mock.Arguments) { scripts[call.String(1)] = true }).
+				Return(redis.NewCmdResult([]interface{}{int64(1), int64(4), int64(12000), int64(0)}, nil))
+
+			result, err := providers.NewRedisRateLimiter(algorithm, client).Allow(ctx, "login:ip:1.2.3.4", limit)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 4, result.Remaining)
+		}
+		assert.Len(t, scripts, 2)
+	})
+
+	t.Run("Redis errors are returned", func(t *testing.T) {
+		redisErr := errors.New("connection refused")
+		client := new(MockRedisCmdable)
+		client.On("Eval", ctx, mock.AnythingOfType("string"), keys, args).Return(redis.NewCmdResult(nil, redisErr))
+
+		_, err := providers.NewRedisRateLimiter(domain.RateLimitTokenBucket, client).Allow(ctx, "login:ip:1.2.3.4", limit)
+		assert.ErrorIs(t, err, redisErr)
+	})
+
+	t.Run("Unexpected reply", func(t *testing.T) {
+		client := new(MockRedisCmdable)
+		client.On("Eval", ctx, mock.AnythingOfType("string"), keys, args).Return(redis.NewCmdResult("OK", nil))
+
+		_, err := providers.NewRedisRateLimiter(domain.RateLimitTokenBucket, client).Allow(ctx, "login:ip:1.2.3.4", limit)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	limiter, err := providers.NewRateLimiter("memory", domain.RateLimitSlidingWindow, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &providers.MemoryRateLimiter{}, limiter)
+
+	limiter, err = providers.NewRateLimiter("redis", "", new(MockRedisCmdable))
+	require.NoError(t, err)
+	assert.IsType(t, &providers.RedisRateLimiter{}, limiter)
+
+	_, err = providers.NewRateLimiter("memcached", domain.RateLimitTokenBucket, nil)
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidRateLimit)
+
+	_, err = providers.NewRateLimiter("memory", "leaky_bucket", nil)
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidRateLimit)
+}
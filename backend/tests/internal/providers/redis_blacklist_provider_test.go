@@ -31,6 +31,11 @@ func (m *MockRedisCmdable) Set(ctx context.Context, key string, value interface{
 	return args.Get(0).(*redis.StatusCmd)
 }
 
+func (m *MockRedisCmdable) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	args := m.Called(ctx, key, value, expiration)
+	return args.Get(0).(*redis.BoolCmd)
+}
+
 func (m *MockRedisCmdable) Exists(ctx context.Context, keys ...string) *redis.IntCmd {
 	args := m.Called(ctx, keys)
 	return args.Get(0).(*redis.IntCmd)
@@ -41,6 +46,11 @@ func (m *MockRedisCmdable) Del(ctx context.Context, keys ...string) *redis.IntCm
 	return args.Get(0).(*redis.IntCmd)
 }
 
+func (m *MockRedisCmdable) Eval(ctx context.Context, script string, keys []string, args ...interface{}) *redis.Cmd {
+	called := m.Called(ctx, script, keys, args)
+	return called.Get(0).(*redis.Cmd)
+}
+
 // ===== Testes existentes para Add e Exists =====
 
 func TestRedisBlacklist_Add_Success(t *testing.T) {
@@ -76,6 +86,27 @@ func TestRedisBlacklist_Add_Error(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestRedisBlacklist_AddIfAbsent(t *testing.T) {
+	mockClient := new(MockRedisCmdable)
+	provider := providers.NewRedisBlacklist(mockClient)
+
+	ctx := context.Background()
+	token := "test_token"
+	ttl := 5 * time.Minute
+
+	mockClient.On("SetNX", ctx, "startup-auth-go:"+token, token, ttl).Return(redis.NewBoolResult(true, nil)).Once()
+	mockClient.On("SetNX", ctx, "startup-auth-go:"+token, token, ttl).Return(redis.NewBoolResult(false, nil))
+
+	added, err := provider.AddIfAbsent(ctx, token, ttl)
+	assert.NoError(t, err)
+	assert.True(t, added)
+
+	added, err = provider.AddIfAbsent(ctx, token, ttl)
+	assert.NoError(t, err)
+	assert.False(t, added)
+	mockClient.AssertExpectations(t)
+}
+
 func TestRedisBlacklist_Exists_True(t *testing.T) {
 	mockClient := new(MockRedisCmdable)
 	provider := providers.NewRedisBlacklist(mockClient)
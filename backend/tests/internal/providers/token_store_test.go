@@ -0,0 +1,203 @@
+package providers_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/providers"
+	domain "github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func storeClaims(jti string, ttl time.Duration) domain.Claims {
+	return domain.Claims{
+		UserID: "user-123",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+		},
+	}
+}
+
+const userRevokedKey = "startup-auth-go:user-revoked:user-123"
+
+func TestNewTokenStore(t *testing.T) {
+	store := new(mocks.MockBlacklist)
+
+	s, err := providers.NewTokenStore("", store)
+	assert.NoError(t, err)
+	assert.IsType(t, &providers.AllowlistTokenStore{}, s)
+
+	s, err = providers.NewTokenStore(domain.TokenStoreDenylist, store)
+	assert.NoError(t, err)
+	assert.IsType(t, &providers.DenylistTokenStore{}, s)
+
+	_, err = providers.NewTokenStore("bogus", store)
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidTokenStore)
+}
+
+func TestAllowlistTokenStore_Lifecycle(t *testing.T) {
+	store := new(mocks.MockBlacklist)
+	s := providers.NewAllowlistTokenStore(store)
+	ctx := context.Background()
+	claims := storeClaims("jti-1", time.Hour)
+	key := "startup-auth-go:session:jti-1"
+
+	store.On("SetWithKey", ctx, key, "user-123", mock.MatchedBy(func(ttl time.Duration) bool {
+		return ttl > 59*time.Minute && ttl <= time.Hour
+	})).Return(nil)
+	store.On("ExistsKey", ctx, key).Return(true, nil).Once()
+	store.On("Del", ctx, []string{key}).Return(nil)
+	store.On("Get", ctx, userRevokedKey).Return("", nil)
+
+	assert.NoError(t, s.Register(ctx, claims))
+	active, err := s.IsActive(ctx, claims)
+	assert.NoError(t, err)
+	assert.True(t, active)
+	assert.NoError(t, s.Revoke(ctx, claims))
+	store.AssertExpectations(t)
+}
+
+func TestAllowlistTokenStore_MissingJTI(t *testing.T) {
+	store := new(mocks.MockBlacklist)
+	s := providers.NewAllowlistTokenStore(store)
+	claims := storeClaims("", time.Hour)
+
+	assert.ErrorIs(t, s.Register(context.Background(), claims), msgerror.AnErrMissingTokenID)
+	active, err := s.IsActive(context.Background(), claims)
+	assert.NoError(t, err)
+	assert.False(t, active)
+	store.AssertNotCalled(t, "ExistsKey")
+}
+
+func TestDenylistTokenStore_Revoke(t *testing.T) {
+	store := new(mocks.MockBlacklist)
+	s := providers.NewDenylistTokenStore(store)
+	ctx := context.Background()
+	claims := storeClaims("jti-2", time.Hour)
+
+	store.On("Add", ctx, "revoked:jti-2", mock.AnythingOfType("time.Duration")).Return(nil)
+	store.On("Exists", ctx, "revoked:jti-2").Return(false, nil).Once()
+	store.On("Exists", ctx, "revoked:jti-2").Return(true, nil).Once()
+	store.On("Get", ctx, userRevokedKey).Return("", nil).Once()
+
+	assert.NoError(t, s.Register(ctx, claims))
+	active, err := s.IsActive(ctx, claims)
+	assert.NoError(t, err)
+	assert.True(t, active)
+
+	assert.NoError(t, s.Revoke(ctx, claims))
+	active, err = s.IsActive(ctx, claims)
+	assert.NoError(t, err)
+	assert.False(t, active)
+	store.AssertExpectations(t)
+}
+
+func TestDenylistTokenStore_ExpiredTokenNotStored(t *testing.T) {
+	store := new(mocks.MockBlacklist)
+	s := providers.NewDenylistTokenStore(store)
+
+	assert.NoError(t, s.Revoke(context.Background(), storeClaims("jti-3", -time.Minute)))
+	store.AssertNotCalled(t, "Add")
+}
+
+func TestDenylistTokenStore_StoreError(t *testing.T) {
+	store := new(mocks.MockBlacklist)
+	s := providers.NewDenylistTokenStore(store)
+	ctx := context.Background()
+	claims := storeClaims("jti-4", time.Hour)
+
+	store.On("Exists", ctx, "revoked:jti-4").Return(false, errors.New("redis down"))
+
+	active, err := s.IsActive(ctx, claims)
+	assert.Error(t, err)
+	assert.False(t, active)
+}
+
+func TestTokenStore_RevokeUser(t *testing.T) {
+	ctx := context.Background()
+	claims := storeClaims("jti-5", time.Hour)
+	claims.IssuedAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+
+	t.Run("allowlist", func(t *testing.T) {
+		store := new(mocks.MockBlacklist)
+		s := providers.NewAllowlistTokenStore(store)
+
+		store.On("SetWithKey", ctx, userRevokedKey, mock.AnythingOfType("string"), 24*time.Hour).
+			Run(func(args mock.Arguments) {
+				store.On("Get", ctx, userRevokedKey).Return(args.String(2), nil)
+			}).Return(nil)
+		store.On("ExistsKey", ctx, "startup-auth-go:session:jti-5").Return(true, nil)
+
+		require.NoError(t, s.RevokeUser(ctx, "user-123", 24*time.Hour))
+		active, err := s.IsActive(ctx, claims)
+		assert.NoError(t, err)
+		assert.False(t, active)
+	})
+
+	t.Run("denylist", func(t *testing.T) {
+		store := new(mocks.MockBlacklist)
+		s := providers.NewDenylistTokenStore(store)
+
+		store.On("SetWithKey", ctx, userRevokedKey, mock.AnythingOfType("string"), 24*time.Hour).
+			Run(func(args mock.Arguments) {
+				store.On("Get", ctx, userRevokedKey).Return(args.String(2), nil)
+			}).Return(nil)
+		store.On("Exists", ctx, "revoked:jti-5").Return(false, nil)
+
+		require.NoError(t, s.RevokeUser(ctx, "user-123", 24*time.Hour))
+		active, err := s.IsActive(ctx, claims)
+		assert.NoError(t, err)
+		assert.False(t, active)
+	})
+}
+
+func TestTokenStore_TokensIssuedInTheSameSecondAsRevocation(t *testing.T) {
+	ctx := context.Background()
+	revokedAt := time.Date(2025, 1, 1, 12, 0, 0, 400*int(time.Millisecond), time.UTC)
+	store := new(mocks.MockBlacklist)
+	s := providers.NewDenylistTokenStore(store)
+	store.On("Exists", ctx, mock.Anything).Return(false, nil)
+	store.On("Get", ctx, userRevokedKey).Return(strconv.FormatInt(revokedAt.UnixMilli(), 10), nil)
+
+	for name, tc := range map[string]struct {
+		issuedAt time.Time
+		active   bool
+	}{
+		"before":         {revokedAt.Add(-time.Millisecond), false},
+		"same instant":   {revokedAt, false},
+		"right after":    {revokedAt.Add(time.Millisecond), true},
+		"later that sec": {revokedAt.Add(500 * time.Millisecond), true},
+	} {
+		claims := storeClaims("jti-7", time.Hour)
+		claims.IssuedAt = &jwt.NumericDate{Time: tc.issuedAt}
+
+		active, err := s.IsActive(ctx, claims)
+		assert.NoError(t, err, name)
+		assert.Equal(t, tc.active, active, name)
+	}
+}
+
+func TestTokenStore_TokensIssuedAfterUserRevocationStayActive(t *testing.T) {
+	store := new(mocks.MockBlacklist)
+	s := providers.NewDenylistTokenStore(store)
+	ctx := context.Background()
+	claims := storeClaims("jti-6", time.Hour)
+	cutoff := time.Now().Add(-time.Hour).UnixMilli()
+
+	store.On("Exists", ctx, "revoked:jti-6").Return(false, nil)
+	store.On("Get", ctx, userRevokedKey).Return(strconv.FormatInt(cutoff, 10), nil)
+
+	active, err := s.IsActive(ctx, claims)
+	assert.NoError(t, err)
+	assert.True(t, active)
+}
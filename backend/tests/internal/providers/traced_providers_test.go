@@ -0,0 +1,99 @@
+package providers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/providers"
+	"github.com/eskokado/startup-auth-go/backend/internal/tracing"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// withSpan confere que o provider decorado recebe o contexto com o span aberto.
+var withSpan = mock.MatchedBy(func(ctx context.Context) bool {
+	return tracing.SpanFromContext(ctx) != nil
+})
+
+func TestTracedBlacklist(t *testing.T) {
+	tracer, recorder := mocks.NewRecordingTracer()
+	inner := new(mocks.MockBlacklist)
+	inner.On("Exists", withSpan, "jti").Return(true, nil)
+	inner.On("Del", withSpan, []string{"a", "b"}).Return(assert.AnError)
+	blacklist := providers.NewTracedBlacklist(inner, tracer)
+
+	ctx, parent := tracer.Start(context.Background(), "request", tracing.KindServer)
+	exists, err := blacklist.Exists(ctx, "jti")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.ErrorIs(t, blacklist.Del(ctx, "a", "b"), assert.AnError)
+	parent.End()
+
+	spans := recorder.Spans(tracer)
+	require.Len(t, spans, 3)
+	assert.Equal(t, "BlacklistProvider.Exists", spans[0].Name)
+	assert.Equal(t, parent.SpanContext().SpanID, spans[0].ParentSpanID)
+	assert.Equal(t, "redis", mocks.SpanAttribute(spans[0], "db.system"))
+	assert.Equal(t, "BlacklistProvider.Del", spans[1].Name)
+	assert.Equal(t, int64(2), mocks.SpanAttribute(spans[1], "db.operation.batch.size"))
+	assert.Equal(t, tracing.StatusError, spans[1].Status)
+	inner.AssertExpectations(t)
+}
+
+func TestTracedCrypto(t *testing.T) {
+	tracer, recorder := mocks.NewRecordingTracer()
+	inner := new(mocks.MockCrypto)
+	inner.On("Compare", withSpan, "secret", "hash").Return(false, nil)
+	crypto := providers.NewTracedCrypto(inner, tracer)
+
+	match, err := crypto.Compare(context.Background(), "secret", "hash")
+	require.NoError(t, err)
+	assert.False(t, match)
+
+	spans := recorder.Spans(tracer)
+	require.Len(t, spans, 1)
+	assert.Equal(t, "CryptoProvider.Compare", spans[0].Name)
+	assert.Equal(t, false, mocks.SpanAttribute(spans[0], "auth.password.match"))
+	// A senha e o hash não aparecem no span
+	for _, attr := range spans[0].Attributes {
+		assert.NotEqual(t, "secret", attr.Value)
+		assert.NotEqual(t, "hash", attr.Value)
+	}
+}
+
+func TestTracedTokenProvider(t *testing.T) {
+	tracer, recorder := mocks.NewRecordingTracer()
+	inner := new(mocks.MockTokenProvider)
+	inner.On("Validate", withSpan, "bad").Return(nil, msgerror.AnErrInvalidToken)
+	tokens := providers.NewTracedTokenProvider(inner, tracer)
+
+	_, err := tokens.Validate(context.Background(), "bad")
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidToken)
+
+	spans := recorder.Spans(tracer)
+	require.Len(t, spans, 1)
+	assert.Equal(t, "TokenProvider.Validate", spans[0].Name)
+	assert.Equal(t, tracing.StatusError, spans[0].Status)
+}
+
+func TestTracedEmailService(t *testing.T) {
+	tracer, recorder := mocks.NewRecordingTracer()
+	email, err := vo.NewEmail("user@example.com")
+	require.NoError(t, err)
+	inner := new(mocks.MockEmailService)
+	inner.On("SendResetPasswordEmail", withSpan, email, "pt-BR", "reset-token").Return(nil)
+	emails := providers.NewTracedEmailService(inner, tracer)
+
+	require.NoError(t, emails.SendResetPasswordEmail(context.Background(), email, "pt-BR", "reset-token"))
+
+	spans := recorder.Spans(tracer)
+	require.Len(t, spans, 1)
+	assert.Equal(t, "EmailService.SendResetPasswordEmail", spans[0].Name)
+	assert.Equal(t, tracing.KindClient, spans[0].Kind)
+	assert.Equal(t, "pt-BR", mocks.SpanAttribute(spans[0], "email.locale"))
+	inner.AssertExpectations(t)
+}
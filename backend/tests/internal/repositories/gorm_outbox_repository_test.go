@@ -0,0 +1,117 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	repository "github.com/eskokado/startup-auth-go/backend/internal/repositories"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	repo "github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGormOutboxRepository(t *testing.T) {
+	ctx := context.Background()
+	db := newMigratedDB(t)
+	outbox := repository.NewGormOutboxRepository(db)
+
+	due, err := entity.NewOutboxMessage(entity.OutboxTopicPasswordResetEmail, entity.PasswordResetEmailPayload{Email: "a@test.com"})
+	require.NoError(t, err)
+	later, err := entity.NewOutboxMessage(entity.OutboxTopicPasswordResetEmail, nil)
+	require.NoError(t, err)
+	later.NextAttemptAt = time.Now().Add(time.Hour)
+	require.NoError(t, outbox.Enqueue(ctx, due))
+	require.NoError(t, outbox.Enqueue(ctx, later))
+
+	now := time.Now()
+	claimed, err := outbox.ClaimDue(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, due.ID, claimed[0].ID)
+	assert.JSONEq(t, `{"email":"a@test.com","token":""}`, string(claimed[0].Payload))
+
+	// Reservada: outra instância não a recebe até a reserva expirar
+	again, err := outbox.ClaimDue(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+
+	claimed[0].MarkFailed(errors.New("smtp down"), now, 1)
+	require.NoError(t, outbox.Update(ctx, claimed[0]))
+
+	dead, err := outbox.List(ctx, repo.OutboxFilter{Status: entity.OutboxStatusDead})
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, "smtp down", dead[0].LastError)
+
+	found, err := outbox.GetByID(ctx, later.ID)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, entity.OutboxStatusPending, found.Status)
+
+	missing, err := outbox.GetByID(ctx, vo.NewID())
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	// Depois de enviada a mensagem não guarda mais o conteúdo
+	found.MarkSent(now)
+	require.NoError(t, outbox.Update(ctx, found))
+	sent, err := outbox.GetByID(ctx, later.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.OutboxStatusSent, sent.Status)
+	assert.Empty(t, sent.Payload)
+}
+
+func TestGormOutboxRepository_ListByUserID(t *testing.T) {
+	ctx := context.Background()
+	db := newMigratedDB(t)
+	outbox := repository.NewGormOutboxRepository(db)
+	userID := vo.NewID()
+
+	first, _ := entity.NewOutboxMessage(entity.OutboxTopicPasswordResetEmail, nil)
+	first.UserID = userID
+	second, _ := entity.NewOutboxMessage(entity.OutboxTopicEmailChangeNoticeEmail, nil)
+	second.UserID = userID
+	second.CreatedAt = first.CreatedAt.Add(time.Second)
+	other, _ := entity.NewOutboxMessage(entity.OutboxTopicPasswordResetEmail, nil)
+	other.UserID = vo.NewID()
+	// Mensagens anteriores à coluna não têm dono
+	orphan, _ := entity.NewOutboxMessage(entity.OutboxTopicPasswordResetEmail, nil)
+	for _, m := range []*entity.OutboxMessage{second, first, other, orphan} {
+		require.NoError(t, outbox.Enqueue(ctx, m))
+	}
+
+	messages, err := outbox.ListByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, first.ID, messages[0].ID)
+	assert.Equal(t, second.ID, messages[1].ID)
+	assert.Equal(t, userID, messages[0].UserID)
+
+	found, err := outbox.GetByID(ctx, orphan.ID)
+	require.NoError(t, err)
+	assert.Equal(t, vo.ID{}, found.UserID)
+}
+
+func TestGormOutboxRepository_EnqueueRollsBackWithTransaction(t *testing.T) {
+	ctx := context.Background()
+	db := newMigratedDB(t)
+	outbox := repository.NewGormOutboxRepository(db)
+	message, _ := entity.NewOutboxMessage(entity.OutboxTopicPasswordResetEmail, nil)
+
+	failure := errors.New("user update failed")
+	err := repository.NewGormTxManager(db).WithinTx(ctx, func(ctx context.Context) error {
+		if err := outbox.Enqueue(ctx, message); err != nil {
+			return err
+		}
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	found, err := outbox.GetByID(ctx, message.ID)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
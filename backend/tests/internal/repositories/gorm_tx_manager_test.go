@@ -0,0 +1,122 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/database"
+	repository "github.com/eskokado/startup-auth-go/backend/internal/repositories"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newMigratedDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := database.Open(database.Config{
+		Driver: database.DriverSQLite,
+		Name:   filepath.Join(t.TempDir(), "test.db"),
+	})
+	require.NoError(t, err)
+	migrator, err := database.NewMigrator(db)
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+	return db
+}
+
+func newUser(t *testing.T, address string) *entity.User {
+	t.Helper()
+
+	name, _ := vo.NewName("Maria Silva", 3, 50)
+	email, err := vo.NewEmail(address)
+	require.NoError(t, err)
+	hash, _ := vo.NewPasswordHash("$2a$10$0MwrQkGO0Bw6dYpVfiX4mefEVgTdgtCYCJ7LxltXfzj5qscr4sive")
+	return &entity.User{ID: vo.NewID(), Name: name, Email: email, PasswordHash: hash}
+}
+
+func TestGormTxManager_WithinTx(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("CommitsAllWrites", func(t *testing.T) {
+		db := newMigratedDB(t)
+		users := repository.NewGormUserRepository(db)
+		keys := repository.NewGormAPIKeyRepository(db)
+		user := newUser(t, "commit@test.com")
+
+		err := repository.NewGormTxManager(db).WithinTx(ctx, func(ctx context.Context) error {
+			if _, err := users.Save(ctx, user); err != nil {
+				return err
+			}
+			keyName, _ := vo.NewName("ci key", 3, 50)
+			key, _, err := entity.NewAPIKey(user.ID, keyName, []string{entity.ScopeUserRead}, time.Time{})
+			if err != nil {
+				return err
+			}
+			_, err = keys.Save(ctx, key)
+			return err
+		})
+		require.NoError(t, err)
+
+		found, err := users.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, found)
+		list, err := keys.ListByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Len(t, list, 1)
+	})
+
+	t.Run("RollsBackOnError", func(t *testing.T) {
+		db := newMigratedDB(t)
+		users := repository.NewGormUserRepository(db)
+		user := newUser(t, "rollback@test.com")
+		failure := errors.New("email failed")
+
+		err := repository.NewGormTxManager(db).WithinTx(ctx, func(ctx context.Context) error {
+			if _, err := users.Save(ctx, user); err != nil {
+				return err
+			}
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+
+		found, err := users.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("NestedFailureOnlyUndoesInnerWrites", func(t *testing.T) {
+		db := newMigratedDB(t)
+		users := repository.NewGormUserRepository(db)
+		txManager := repository.NewGormTxManager(db)
+		outer, inner := newUser(t, "outer@test.com"), newUser(t, "inner@test.com")
+
+		err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+			if _, err := users.Save(ctx, outer); err != nil {
+				return err
+			}
+			innerErr := txManager.WithinTx(ctx, func(ctx context.Context) error {
+				if _, err := users.Save(ctx, inner); err != nil {
+					return err
+				}
+				return errors.New("inner failed")
+			})
+			assert.Error(t, innerErr)
+			return nil
+		})
+		require.NoError(t, err)
+
+		found, err := users.GetByID(ctx, outer.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, found)
+		found, err = users.GetByID(ctx, inner.ID)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+}
@@ -0,0 +1,40 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/metrics"
+	repository "github.com/eskokado/startup-auth-go/backend/internal/repositories"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentedUserRepository(t *testing.T) {
+	ctx := context.Background()
+	m := metrics.New()
+	db := newMigratedDB(t)
+	repo := repository.NewInstrumentedUserRepository(repository.NewGormUserRepository(db), m)
+
+	saved, err := repo.Save(ctx, newUser(t, "metrics@test.com"))
+	require.NoError(t, err)
+	found, err := repo.GetByEmail(ctx, saved.Email)
+	require.NoError(t, err)
+	assert.Equal(t, saved.ID, found.ID)
+	missing, err := repo.GetByID(ctx, vo.NewID())
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	// Com a conexão fechada a consulta falha e é medida como failure
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+	_, err = repo.GetByEmail(ctx, saved.Email)
+	assert.Error(t, err)
+
+	assert.Equal(t, uint64(1), m.UserRepoDuration.Count("save", "success"))
+	assert.Equal(t, uint64(1), m.UserRepoDuration.Count("get_by_email", "success"))
+	assert.Equal(t, uint64(1), m.UserRepoDuration.Count("get_by_id", "success"))
+	assert.Equal(t, uint64(1), m.UserRepoDuration.Count("get_by_email", "failure"))
+}
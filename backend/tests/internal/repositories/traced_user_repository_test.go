@@ -0,0 +1,38 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	repository "github.com/eskokado/startup-auth-go/backend/internal/repositories"
+	"github.com/eskokado/startup-auth-go/backend/internal/tracing"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracedUserRepository(t *testing.T) {
+	tracer, recorder := mocks.NewRecordingTracer()
+	db := newMigratedDB(t)
+	repo := repository.NewTracedUserRepository(repository.NewGormUserRepository(db), tracer)
+
+	ctx, parent := tracer.Start(context.Background(), "request", tracing.KindServer)
+	saved, err := repo.Save(ctx, newUser(t, "traced@test.com"))
+	require.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+	_, err = repo.GetByEmail(ctx, saved.Email)
+	assert.Error(t, err)
+	parent.End()
+
+	spans := recorder.Spans(tracer)
+	require.Len(t, spans, 3)
+	assert.Equal(t, "UserRepository.Save", spans[0].Name)
+	assert.Equal(t, parent.SpanContext().SpanID, spans[0].ParentSpanID)
+	assert.Equal(t, "Save", mocks.SpanAttribute(spans[0], "db.operation.name"))
+	assert.Equal(t, tracing.StatusUnset, spans[0].Status)
+	assert.Equal(t, "UserRepository.GetByEmail", spans[1].Name)
+	assert.Equal(t, tracing.StatusError, spans[1].Status)
+}
@@ -0,0 +1,297 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// O exportador OTLP e o propagador são escritos à mão; estes testes conferem a
+// saída com o esquema do opentelemetry-proto (trace/v1, mapeamento JSON do
+// proto3) e o traceparent com os casos da suíte do W3C Trace Context.
+
+// Espelho de ExportTraceServiceRequest com todos os campos do esquema: campo
+// desconhecido ou com tipo errado falha na decodificação.
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   *otlpResource    `json:"resource,omitempty"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+		SchemaURL  string           `json:"schemaUrl,omitempty"`
+	}
+	otlpResource struct {
+		Attributes             []otlpKeyValue `json:"attributes,omitempty"`
+		DroppedAttributesCount uint32         `json:"droppedAttributesCount,omitempty"`
+	}
+	otlpScopeSpans struct {
+		Scope     *otlpScope `json:"scope,omitempty"`
+		Spans     []otlpSpan `json:"spans"`
+		SchemaURL string     `json:"schemaUrl,omitempty"`
+	}
+	otlpScope struct {
+		Name                   string         `json:"name,omitempty"`
+		Version                string         `json:"version,omitempty"`
+		Attributes             []otlpKeyValue `json:"attributes,omitempty"`
+		DroppedAttributesCount uint32         `json:"droppedAttributesCount,omitempty"`
+	}
+	otlpSpan struct {
+		TraceID                string            `json:"traceId"`
+		SpanID                 string            `json:"spanId"`
+		TraceState             string            `json:"traceState,omitempty"`
+		ParentSpanID           string            `json:"parentSpanId,omitempty"`
+		Flags                  uint32            `json:"flags,omitempty"`
+		Name                   string            `json:"name"`
+		Kind                   int               `json:"kind"`
+		StartTimeUnixNano      string            `json:"startTimeUnixNano"`
+		EndTimeUnixNano        string            `json:"endTimeUnixNano"`
+		Attributes             []otlpKeyValue    `json:"attributes,omitempty"`
+		DroppedAttributesCount uint32            `json:"droppedAttributesCount,omitempty"`
+		Events                 []json.RawMessage `json:"events,omitempty"`
+		DroppedEventsCount     uint32            `json:"droppedEventsCount,omitempty"`
+		Links                  []json.RawMessage `json:"links,omitempty"`
+		DroppedLinksCount      uint32            `json:"droppedLinksCount,omitempty"`
+		Status                 *otlpStatus       `json:"status,omitempty"`
+	}
+	otlpStatus struct {
+		Message string `json:"message,omitempty"`
+		Code    int    `json:"code,omitempty"`
+	}
+	otlpKeyValue struct {
+		Key   string       `json:"key"`
+		Value otlpAnyValue `json:"value"`
+	}
+	// AnyValue é um oneof: exatamente um campo preenchido
+	otlpAnyValue struct {
+		StringValue *string         `json:"stringValue,omitempty"`
+		BoolValue   *bool           `json:"boolValue,omitempty"`
+		IntValue    *string         `json:"intValue,omitempty"`
+		DoubleValue json.RawMessage `json:"doubleValue,omitempty"`
+		ArrayValue  json.RawMessage `json:"arrayValue,omitempty"`
+		KvlistValue json.RawMessage `json:"kvlistValue,omitempty"`
+		BytesValue  *string         `json:"bytesValue,omitempty"`
+	}
+)
+
+var (
+	traceIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+	spanIDPattern  = regexp.MustCompile(`^[0-9a-f]{16}$`)
+)
+
+func checkAnyValue(t *testing.T, key string, v otlpAnyValue) {
+	t.Helper()
+
+	set := 0
+	for _, present := range []bool{
+		v.StringValue != nil, v.BoolValue != nil, v.IntValue != nil, v.DoubleValue != nil,
+		v.ArrayValue != nil, v.KvlistValue != nil, v.BytesValue != nil,
+	} {
+		if present {
+			set++
+		}
+	}
+	assert.Equal(t, 1, set, "%s must set exactly one AnyValue field", key)
+
+	if v.IntValue != nil {
+		_, err := strconv.ParseInt(*v.IntValue, 10, 64)
+		assert.NoError(t, err, "%s intValue", key)
+	}
+	if v.DoubleValue != nil {
+		var number float64
+		var special string
+		if json.Unmarshal(v.DoubleValue, &number) != nil {
+			require.NoError(t, json.Unmarshal(v.DoubleValue, &special), "%s doubleValue", key)
+			assert.Contains(t, []string{"NaN", "Infinity", "-Infinity"}, special, "%s doubleValue", key)
+		}
+	}
+}
+
+func checkOTLPRequest(t *testing.T, body []byte) otlpRequest {
+	t.Helper()
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	var request otlpRequest
+	require.NoError(t, decoder.Decode(&request), string(body))
+
+	// Ida e volta pelo esquema sem perder nem mudar nada
+	encoded, err := json.Marshal(request)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(body), string(encoded))
+
+	for _, rs := range request.ResourceSpans {
+		if rs.Resource != nil {
+			for _, kv := range rs.Resource.Attributes {
+				checkAnyValue(t, kv.Key, kv.Value)
+			}
+		}
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				assert.Regexp(t, traceIDPattern, span.TraceID)
+				assert.Regexp(t, spanIDPattern, span.SpanID)
+				if span.ParentSpanID != "" {
+					assert.Regexp(t, spanIDPattern, span.ParentSpanID)
+				}
+				assert.NotEmpty(t, span.Name)
+				assert.True(t, span.Kind >= 0 && span.Kind <= 5, "kind %d", span.Kind)
+				start, err := strconv.ParseUint(span.StartTimeUnixNano, 10, 64)
+				require.NoError(t, err)
+				end, err := strconv.ParseUint(span.EndTimeUnixNano, 10, 64)
+				require.NoError(t, err)
+				assert.LessOrEqual(t, start, end)
+				if span.Status != nil {
+					assert.True(t, span.Status.Code >= 0 && span.Status.Code <= 2, "status %d", span.Status.Code)
+				}
+				for _, kv := range span.Attributes {
+					checkAnyValue(t, kv.Key, kv.Value)
+				}
+			}
+		}
+	}
+	return request
+}
+
+func TestOTLPExporter_MatchesSchema(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	tracer := tracing.NewTracer(tracing.TracerConfig{
+		Exporter:    tracing.NewOTLPExporter(tracing.OTLPConfig{Endpoint: server.URL, ServiceName: "auth-test"}),
+		SampleRatio: 1,
+	})
+	remote, ok := tracing.ParseTraceparent(validTraceparent)
+	require.True(t, ok)
+	remote.TraceState = "vendor=value"
+
+	ctx, root := tracer.Start(tracing.ContextWithRemoteSpanContext(context.Background(), remote), "GET /user/me", tracing.KindServer,
+		tracing.String("http.route", "/user/me"), tracing.Int("http.response.status_code", 500), tracing.Bool("retry", false),
+		tracing.Attribute{Key: "ratio", Value: 0.25}, tracing.Attribute{Key: "nan", Value: math.NaN()},
+		tracing.Attribute{Key: "inf", Value: math.Inf(-1)})
+	_, child := tracer.Start(ctx, "UserRepository.GetByID", tracing.KindClient)
+	child.Finish(errors.New("db down"))
+	root.End()
+	require.NoError(t, tracer.Shutdown(context.Background()))
+
+	request := checkOTLPRequest(t, body)
+
+	require.Len(t, request.ResourceSpans, 1)
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+	assert.Equal(t, remote.TraceID.String(), spans[1].TraceID)
+	assert.Equal(t, remote.SpanID.String(), spans[1].ParentSpanID)
+	assert.Equal(t, "vendor=value", spans[1].TraceState)
+	assert.Equal(t, spans[1].SpanID, spans[0].ParentSpanID)
+	assert.Equal(t, 2, spans[0].Status.Code)
+	assert.Equal(t, "db down", spans[0].Status.Message)
+}
+
+func TestOTLPSchema_RejectsNonConformantPayloads(t *testing.T) {
+	for name, body := range map[string]string{
+		"unknown field":   `{"resourceSpans":[{"scopeSpans":[{"spans":[{"traceId":"x","spanId":"y","name":"n","kind":1,"startTimeUnixNano":"1","endTimeUnixNano":"2","duration":1}]}]}]}`,
+		"numeric time":    `{"resourceSpans":[{"scopeSpans":[{"spans":[{"traceId":"x","spanId":"y","name":"n","kind":1,"startTimeUnixNano":1,"endTimeUnixNano":"2"}]}]}]}`,
+		"snake case keys": `{"resource_spans":[]}`,
+	} {
+		decoder := json.NewDecoder(bytes.NewReader([]byte(body)))
+		decoder.DisallowUnknownFields()
+		var request otlpRequest
+		assert.Error(t, decoder.Decode(&request), name)
+	}
+}
+
+// Casos da suíte de testes do W3C Trace Context (test/test.py).
+func TestTraceparent_W3CVectors(t *testing.T) {
+	const traceID, spanID = "12345678901234567890123456789012", "1234567890123456"
+
+	valid := map[string]bool{ // cabeçalho -> sampled
+		"00-" + traceID + "-" + spanID + "-01":               true,
+		"00-" + traceID + "-" + spanID + "-00":               false,
+		"00-" + traceID + "-" + spanID + "-09":               true, // flags desconhecidas são ignoradas
+		" 00-" + traceID + "-" + spanID + "-01\t":            true, // espaços em volta (OWS)
+		"cc-" + traceID + "-" + spanID + "-01":               true, // versão futura
+		"cc-" + traceID + "-" + spanID + "-01-what-the-fuzz": true,
+	}
+	for header, sampled := range valid {
+		sc, ok := tracing.ParseTraceparent(header)
+		require.True(t, ok, header)
+		assert.Equal(t, traceID, sc.TraceID.String(), header)
+		assert.Equal(t, spanID, sc.SpanID.String(), header)
+		assert.Equal(t, sampled, sc.Sampled, header)
+		// Repassado sempre na versão 00 e só com a flag conhecida
+		assert.Regexp(t, `^00-`+traceID+`-`+spanID+`-0[01]$`, sc.Traceparent())
+	}
+
+	for _, header := range []string{
+		"00-" + traceID + "-" + spanID + "-01-",              // versão 00 não admite campos extras
+		"00-" + traceID + "-" + spanID + "-01.",              // separador errado
+		"cc-" + traceID + "-" + spanID + "-01.what-the-fuzz", // extra sem hífen
+		"0-" + traceID + "-" + spanID + "-01",
+		"000-" + traceID + "-" + spanID + "-01",
+		"0A-" + traceID + "-" + spanID + "-01", // versão em maiúsculas
+		"0g-" + traceID + "-" + spanID + "-01",
+		"ff-" + traceID + "-" + spanID + "-01",
+		"00-" + traceID + "0-" + spanID + "-01",
+		"00-" + traceID[1:] + "-" + spanID + "-01",
+		"00-" + traceID + "-" + spanID + "0-01",
+		"00-" + traceID + "-" + spanID[1:] + "-01",
+		"00-" + traceID + "-" + spanID + "-1",
+		"00-" + traceID + "-" + spanID + "-001",
+		"00-" + traceID + "-" + spanID + "-0g",
+		"00-" + "ABCDEF78901234567890123456789012" + "-" + spanID + "-01",
+		"00-" + traceID + "-" + "ABCDEF7890123456" + "-01",
+		"00-" + traceID + "-" + spanID + "-0A",
+		"00-00000000000000000000000000000000-" + spanID + "-01",
+		"00-" + traceID + "-0000000000000000-01",
+		"00_" + traceID + "_" + spanID + "_01",
+	} {
+		_, ok := tracing.ParseTraceparent(header)
+		assert.False(t, ok, header)
+	}
+}
+
+func TestExtract_W3CHeaderRules(t *testing.T) {
+	t.Run("Duplicated traceparent is ignored", func(t *testing.T) {
+		header := http.Header{}
+		header.Add(tracing.TraceparentHeader, validTraceparent)
+		header.Add(tracing.TraceparentHeader, "00-12345678901234567890123456789012-1234567890123456-01")
+
+		_, ok := tracing.Extract(header)
+		assert.False(t, ok)
+	})
+
+	t.Run("Multiple tracestate headers are combined", func(t *testing.T) {
+		header := http.Header{}
+		header.Set(tracing.TraceparentHeader, validTraceparent)
+		header.Add(tracing.TracestateHeader, "foo=1")
+		header.Add(tracing.TracestateHeader, "bar=2,baz=3")
+
+		sc, ok := tracing.Extract(header)
+		require.True(t, ok)
+		assert.Equal(t, "foo=1,bar=2,baz=3", sc.TraceState)
+	})
+
+	t.Run("Tracestate without a valid traceparent is dropped", func(t *testing.T) {
+		header := http.Header{}
+		header.Set(tracing.TraceparentHeader, "garbage")
+		header.Set(tracing.TracestateHeader, "foo=1")
+
+		sc, ok := tracing.Extract(header)
+		assert.False(t, ok)
+		assert.Empty(t, sc.TraceState)
+	})
+}
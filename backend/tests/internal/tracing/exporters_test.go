@@ -0,0 +1,102 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/tracing"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExporter_Unknown(t *testing.T) {
+	_, err := tracing.NewExporter("zipkin", tracing.OTLPConfig{})
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidTraceExporter)
+}
+
+func TestStdoutExporter(t *testing.T) {
+	var out bytes.Buffer
+	tracer := tracing.NewTracer(tracing.TracerConfig{Exporter: tracing.NewStdoutExporter(&out), SampleRatio: 1})
+
+	ctx, root := tracer.Start(context.Background(), "root", tracing.KindServer)
+	_, child := tracer.Start(ctx, "child", tracing.KindClient, tracing.Int("n", 2))
+	child.Finish(errors.New("failed"))
+	root.End()
+	require.NoError(t, tracer.Shutdown(context.Background()))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	var span map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &span))
+	assert.Equal(t, "child", span["name"])
+	assert.Equal(t, "client", span["kind"])
+	assert.Equal(t, root.SpanContext().SpanID.String(), span["parent_span_id"])
+	assert.Equal(t, "error", span["status"])
+	assert.Equal(t, "failed", span["error"])
+	assert.Equal(t, map[string]any{"n": float64(2)}, span["attributes"])
+}
+
+func TestOTLPExporter(t *testing.T) {
+	var body map[string]any
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		header = r.Header
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &body)
+	}))
+	defer server.Close()
+
+	tracer := tracing.NewTracer(tracing.TracerConfig{
+		Exporter: tracing.NewOTLPExporter(tracing.OTLPConfig{
+			Endpoint:    server.URL + "/",
+			ServiceName: "auth-test",
+			Headers:     map[string]string{"X-Collector-Key": "k"},
+		}),
+		SampleRatio: 1,
+	})
+	_, span := tracer.Start(context.Background(), "POST /auth/login", tracing.KindServer,
+		tracing.String("http.route", "/auth/login"), tracing.Int("http.response.status_code", 200))
+	span.End()
+	require.NoError(t, tracer.Shutdown(context.Background()))
+
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+	assert.Equal(t, "k", header.Get("X-Collector-Key"))
+
+	resourceSpans := body["resourceSpans"].([]any)[0].(map[string]any)
+	resource := resourceSpans["resource"].(map[string]any)
+	assert.Equal(t, []any{map[string]any{
+		"key": "service.name", "value": map[string]any{"stringValue": "auth-test"},
+	}}, resource["attributes"])
+
+	spans := resourceSpans["scopeSpans"].([]any)[0].(map[string]any)["spans"].([]any)
+	require.Len(t, spans, 1)
+	got := spans[0].(map[string]any)
+	assert.Equal(t, span.SpanContext().TraceID.String(), got["traceId"])
+	assert.Equal(t, span.SpanContext().SpanID.String(), got["spanId"])
+	assert.Equal(t, "POST /auth/login", got["name"])
+	assert.Equal(t, float64(tracing.KindServer), got["kind"])
+	assert.NotContains(t, got, "parentSpanId")
+	assert.Contains(t, got["attributes"], map[string]any{
+		"key": "http.response.status_code", "value": map[string]any{"intValue": "200"},
+	})
+}
+
+func TestOTLPExporter_UnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	exporter := tracing.NewOTLPExporter(tracing.OTLPConfig{Endpoint: server.URL})
+	err := exporter.Export(context.Background(), []tracing.SpanData{{Name: "x"}})
+	assert.ErrorContains(t, err, "unexpected status 503")
+}
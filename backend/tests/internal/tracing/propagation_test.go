@@ -0,0 +1,67 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestParseTraceparent(t *testing.T) {
+	sc, ok := tracing.ParseTraceparent(validTraceparent)
+	require.True(t, ok)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID.String())
+	assert.Equal(t, "00f067aa0ba902b7", sc.SpanID.String())
+	assert.True(t, sc.Sampled)
+	assert.Equal(t, validTraceparent, sc.Traceparent())
+
+	sc, ok = tracing.ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	require.True(t, ok)
+	assert.False(t, sc.Sampled)
+
+	// Versões futuras podem acrescentar campos
+	_, ok = tracing.ParseTraceparent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra")
+	assert.True(t, ok)
+}
+
+func TestParseTraceparent_Invalid(t *testing.T) {
+	for _, header := range []string{
+		"",
+		"garbage",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01",
+		"zz-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	} {
+		_, ok := tracing.ParseTraceparent(header)
+		assert.False(t, ok, header)
+	}
+}
+
+func TestExtractAndInject(t *testing.T) {
+	in := http.Header{}
+	in.Set(tracing.TraceparentHeader, validTraceparent)
+	in.Set(tracing.TracestateHeader, "vendor=value")
+
+	sc, ok := tracing.Extract(in)
+	require.True(t, ok)
+	assert.Equal(t, "vendor=value", sc.TraceState)
+
+	out := http.Header{}
+	tracing.Inject(tracing.ContextWithRemoteSpanContext(context.Background(), sc), out)
+	assert.Equal(t, validTraceparent, out.Get(tracing.TraceparentHeader))
+	assert.Equal(t, "vendor=value", out.Get(tracing.TracestateHeader))
+
+	// Sem span no contexto nada é escrito
+	empty := http.Header{}
+	tracing.Inject(context.Background(), empty)
+	assert.Empty(t, empty)
+}
@@ -0,0 +1,119 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/tracing"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracer_ChildSpans(t *testing.T) {
+	tracer, recorder := mocks.NewRecordingTracer()
+	defer tracer.Shutdown(context.Background())
+
+	ctx, root := tracer.Start(context.Background(), "root", tracing.KindServer)
+	_, child := tracer.Start(ctx, "child", tracing.KindInternal, tracing.String("k", "v"))
+	child.Finish(errors.New("boom"))
+	child.End() // idempotente
+	root.End()
+
+	spans := recorder.Spans(tracer)
+	require.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, spans[1].SpanContext.TraceID, spans[0].SpanContext.TraceID)
+	assert.Equal(t, spans[1].SpanContext.SpanID, spans[0].ParentSpanID)
+	assert.False(t, spans[1].ParentSpanID.IsValid())
+	assert.Equal(t, tracing.StatusError, spans[0].Status)
+	assert.Equal(t, "boom", spans[0].StatusMessage)
+	assert.Equal(t, "v", mocks.SpanAttribute(spans[0], "k"))
+	assert.Equal(t, tracing.StatusUnset, spans[1].Status)
+}
+
+func TestTracer_RemoteParent(t *testing.T) {
+	tracer, recorder := mocks.NewRecordingTracer()
+	defer tracer.Shutdown(context.Background())
+
+	remote, ok := tracing.ParseTraceparent(validTraceparent)
+	require.True(t, ok)
+	_, span := tracer.Start(tracing.ContextWithRemoteSpanContext(context.Background(), remote), "server", tracing.KindServer)
+	span.End()
+
+	spans := recorder.Spans(tracer)
+	require.Len(t, spans, 1)
+	assert.Equal(t, remote.TraceID, spans[0].SpanContext.TraceID)
+	assert.Equal(t, remote.SpanID, spans[0].ParentSpanID)
+
+	// O chamador decidiu não amostrar: o span propaga mas não é exportado
+	remote.Sampled = false
+	ctx, span := tracer.Start(tracing.ContextWithRemoteSpanContext(context.Background(), remote), "server", tracing.KindServer)
+	span.End()
+	assert.False(t, tracing.SpanContextFromContext(ctx).Sampled)
+	assert.Len(t, recorder.Spans(tracer), 1)
+}
+
+func TestTracer_SampleRatio(t *testing.T) {
+	recorder := &mocks.SpanRecorder{}
+	tracer := tracing.NewTracer(tracing.TracerConfig{Exporter: recorder, SampleRatio: 0})
+	defer tracer.Shutdown(context.Background())
+
+	ctx, span := tracer.Start(context.Background(), "ignored", tracing.KindServer)
+	span.End()
+	assert.True(t, tracing.SpanContextFromContext(ctx).IsValid())
+	assert.Empty(t, recorder.Spans(tracer))
+}
+
+func TestTracer_WithoutExporterOnlyPropagates(t *testing.T) {
+	tracer := tracing.NewTracer(tracing.TracerConfig{SampleRatio: 1})
+	defer tracer.Shutdown(context.Background())
+
+	ctx, span := tracer.Start(context.Background(), "op", tracing.KindInternal)
+	span.End()
+	sc := tracing.SpanContextFromContext(ctx)
+	assert.True(t, sc.IsValid())
+	assert.False(t, sc.Sampled)
+}
+
+func TestSpan_NilIsSafe(t *testing.T) {
+	var span *tracing.Span
+	span.SetName("x")
+	span.SetAttributes(tracing.Bool("b", true))
+	span.Finish(errors.New("ignored"))
+	assert.False(t, span.SpanContext().IsValid())
+}
+
+type failingExporter struct{}
+
+func (failingExporter) Export(context.Context, []tracing.SpanData) error {
+	return errors.New("collector returned 503")
+}
+
+func TestTracer_ReportsExportFailures(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := tracing.NewTracer(tracing.TracerConfig{
+		Exporter:    failingExporter{},
+		SampleRatio: 1,
+		Logger:      slog.New(slog.NewTextHandler(&buf, nil)),
+	})
+
+	for _, name := range []string{"first", "second"} {
+		_, span := tracer.Start(context.Background(), name, tracing.KindInternal)
+		span.End()
+		_, span = tracer.Start(context.Background(), name, tracing.KindInternal)
+		span.End()
+		require.NoError(t, tracer.ForceFlush(context.Background()))
+	}
+	require.NoError(t, tracer.Shutdown(context.Background()))
+
+	assert.Contains(t, buf.String(), "level=WARN")
+	assert.Contains(t, buf.String(), "collector returned 503")
+	assert.Contains(t, buf.String(), "spans=2")
+	// A segunda falha, dentro do mesmo minuto, não repete o aviso
+	assert.Equal(t, 1, strings.Count(buf.String(), "tracing export failed"))
+}
@@ -0,0 +1,47 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/tracing"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(tracing.TraceparentHeader)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	tracer, recorder := mocks.NewRecordingTracer()
+	defer tracer.Shutdown(context.Background())
+	client := &http.Client{Transport: tracing.NewTransport(nil, tracer)}
+
+	ctx, parent := tracer.Start(context.Background(), "parent", tracing.KindServer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/verify?secret=s3cr3t", nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	parent.End()
+
+	// A requisição original não é alterada
+	assert.Empty(t, req.Header.Get(tracing.TraceparentHeader))
+
+	spans := recorder.Spans(tracer)
+	require.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "HTTP POST", span.Name)
+	assert.Equal(t, parent.SpanContext().SpanID, span.ParentSpanID)
+	assert.Equal(t, span.SpanContext.Traceparent(), received)
+	assert.Equal(t, "/verify", mocks.SpanAttribute(span, "url.path"))
+	assert.Equal(t, int64(http.StatusBadGateway), mocks.SpanAttribute(span, "http.response.status_code"))
+	assert.Equal(t, tracing.StatusError, span.Status)
+}
@@ -0,0 +1,238 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func auditContext() context.Context {
+	return providers.WithRequestInfo(context.Background(), providers.RequestInfo{
+		IP:        "203.0.113.7",
+		UserAgent: "test-agent",
+	})
+}
+
+func captureAudit(logger *mocks.MockAuditLogger, err error) *entity.AuditEvent {
+	captured := &entity.AuditEvent{}
+	logger.On("Log", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { *captured = *args.Get(1).(*entity.AuditEvent) }).
+		Return(err)
+	return captured
+}
+
+func TestAuditedLogin_Success(t *testing.T) {
+	inner := new(mocks.MockLoginUseCase)
+	logger := new(mocks.MockAuditLogger)
+	userID := vo.NewID()
+
+	inner.On("Execute", mock.Anything, "user@test.com", "secret123").
+		Return(dto.LoginResult{UserID: userID, Token: "tok"}, nil)
+	event := captureAudit(logger, nil)
+
+	result, err := usecase.NewAuditedLogin(inner, logger).Execute(auditContext(), "user@test.com", "secret123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "tok", result.Token)
+	assert.Equal(t, entity.AuditActionLogin, event.Action)
+	assert.Equal(t, entity.AuditOutcomeSuccess, event.Outcome)
+	assert.Equal(t, userID.String(), event.ActorID)
+	assert.Equal(t, "user@test.com", event.Target)
+	assert.Equal(t, "203.0.113.7", event.IP)
+	assert.Equal(t, "test-agent", event.UserAgent)
+	assert.False(t, event.CreatedAt.IsZero())
+}
+
+func TestAuditedLogin_Failure(t *testing.T) {
+	inner := new(mocks.MockLoginUseCase)
+	logger := new(mocks.MockAuditLogger)
+
+	inner.On("Execute", mock.Anything, "user@test.com", "wrong-pass").
+		Return(dto.LoginResult{}, msgerror.AnErrInvalidCredentials)
+	event := captureAudit(logger, nil)
+
+	_, err := usecase.NewAuditedLogin(inner, logger).Execute(auditContext(), "user@test.com", "wrong-pass")
+
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidCredentials)
+	assert.Equal(t, entity.AuditOutcomeFailure, event.Outcome)
+	assert.Empty(t, event.ActorID)
+	assert.Equal(t, msgerror.AnErrInvalidCredentials.Error(), event.Reason)
+}
+
+func TestAuditedLogin_LoggerErrorDoesNotBreakFlow(t *testing.T) {
+	inner := new(mocks.MockLoginUseCase)
+	logger := new(mocks.MockAuditLogger)
+
+	inner.On("Execute", mock.Anything, "user@test.com", "secret123").
+		Return(dto.LoginResult{UserID: vo.NewID(), Token: "tok"}, nil)
+	captureAudit(logger, errors.New("disk full"))
+
+	_, err := usecase.NewAuditedLogin(inner, logger).Execute(auditContext(), "user@test.com", "secret123")
+
+	assert.NoError(t, err)
+	logger.AssertExpectations(t)
+}
+
+func TestAuditedLogout_UsesActorFromContext(t *testing.T) {
+	inner := new(mocks.MockLogoutUseCase)
+	logger := new(mocks.MockAuditLogger)
+
+	inner.On("Execute", mock.Anything, "tok").Return(nil)
+	event := captureAudit(logger, nil)
+
+	ctx := providers.WithActorID(auditContext(), "user-123")
+	err := usecase.NewAuditedLogout(inner, logger).Execute(ctx, "tok")
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.AuditActionLogout, event.Action)
+	assert.Equal(t, "user-123", event.ActorID)
+	assert.Equal(t, "user-123", event.Target)
+	assert.Equal(t, "203.0.113.7", event.IP)
+}
+
+func TestAuditedRegister_TargetsEmail(t *testing.T) {
+	inner := new(mocks.MockRegisterUseCase)
+	logger := new(mocks.MockAuditLogger)
+	params := dto.RegisterParams{Email: "new@test.com"}
+
+	inner.On("Execute", mock.Anything, params).Return(msgerror.AnErrUserExists)
+	event := captureAudit(logger, nil)
+
+	err := usecase.NewAuditedRegister(inner, logger).Execute(auditContext(), params)
+
+	assert.Error(t, err)
+	assert.Equal(t, entity.AuditActionRegister, event.Action)
+	assert.Equal(t, entity.AuditOutcomeFailure, event.Outcome)
+	assert.Equal(t, "new@test.com", event.Target)
+}
+
+func TestAuditedResetPassword_ResolvesTargetBeforeReset(t *testing.T) {
+	inner := new(mocks.MockResetPasswordUseCase)
+	repo := new(mocks.MockUserRepo)
+	logger := new(mocks.MockAuditLogger)
+	user := &entity.User{ID: vo.NewID()}
+
+	repo.On("GetByResetToken", mock.Anything, "reset-token").Return(user, nil)
+	inner.On("Execute", mock.Anything, "reset-token", "new-password").Return(nil)
+	event := captureAudit(logger, nil)
+
+	err := usecase.NewAuditedResetPassword(inner, repo, logger).
+		Execute(auditContext(), "reset-token", "new-password")
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.AuditActionPasswordResetCompleted, event.Action)
+	assert.Equal(t, user.ID.String(), event.Target)
+	assert.Equal(t, user.ID.String(), event.ActorID)
+}
+
+func TestAuditedListAuditEvents_RecordsAdminAction(t *testing.T) {
+	inner := new(mocks.MockListAuditEventsUseCase)
+	logger := new(mocks.MockAuditLogger)
+	params := dto.AuditQueryParams{Action: entity.AuditActionLogin}
+
+	inner.On("Execute", mock.Anything, params).Return([]dto.AuditEventOutput{}, nil)
+	event := captureAudit(logger, nil)
+
+	ctx := providers.WithActorID(auditContext(), "admin-1")
+	_, err := usecase.NewAuditedListAuditEvents(inner, logger).Execute(ctx, params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.AuditActionAdminAuditQuery, event.Action)
+	assert.Equal(t, "admin-1", event.ActorID)
+}
+
+func TestAuditedDeactivateAccount_RecordsTarget(t *testing.T) {
+	inner := new(mocks.MockDeactivateAccountUseCase)
+	logger := new(mocks.MockAuditLogger)
+	userID := vo.NewID()
+
+	inner.On("Execute", mock.Anything, userID, "wrong").Return(time.Time{}, msgerror.AnErrInvalidCredentials)
+	event := captureAudit(logger, nil)
+
+	ctx := providers.WithActorID(auditContext(), userID.String())
+	_, err := usecase.NewAuditedDeactivateAccount(inner, logger).Execute(ctx, userID, "wrong")
+
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidCredentials)
+	assert.Equal(t, entity.AuditActionAccountDeactivate, event.Action)
+	assert.Equal(t, entity.AuditOutcomeFailure, event.Outcome)
+	assert.Equal(t, userID.String(), event.Target)
+	assert.Equal(t, userID.String(), event.ActorID)
+}
+
+func TestAuditedRestoreAccount_RecordsActorOnSuccess(t *testing.T) {
+	inner := new(mocks.MockRestoreAccountUseCase)
+	logger := new(mocks.MockAuditLogger)
+	userID := vo.NewID()
+
+	inner.On("Execute", mock.Anything, "maria@test.com", "secret123").Return(userID, nil)
+	event := captureAudit(logger, nil)
+
+	_, err := usecase.NewAuditedRestoreAccount(inner, logger).Execute(auditContext(), "maria@test.com", "secret123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.AuditActionAccountRestore, event.Action)
+	assert.Equal(t, userID.String(), event.ActorID)
+	assert.Equal(t, "maria@test.com", event.Target)
+}
+
+func TestAuditedConfirmEmailChange_ResolvesTargetBeforeConfirm(t *testing.T) {
+	inner := new(mocks.MockConfirmEmailChangeUseCase)
+	repo := new(mocks.MockUserRepo)
+	logger := new(mocks.MockAuditLogger)
+	user := &entity.User{ID: vo.NewID()}
+
+	repo.On("GetByEmailChangeToken", mock.Anything, "change-token").Return(user, nil)
+	inner.On("Execute", mock.Anything, "change-token").Return(msgerror.AnErrExpiredToken)
+	event := captureAudit(logger, nil)
+
+	err := usecase.NewAuditedConfirmEmailChange(inner, repo, logger).Execute(auditContext(), "change-token")
+
+	assert.ErrorIs(t, err, msgerror.AnErrExpiredToken)
+	assert.Equal(t, entity.AuditActionEmailChangeConfirmed, event.Action)
+	assert.Equal(t, entity.AuditOutcomeFailure, event.Outcome)
+	assert.Equal(t, user.ID.String(), event.Target)
+}
+
+func TestAuditedUploadAvatar_RecordsTarget(t *testing.T) {
+	inner := new(mocks.MockUploadAvatarUseCase)
+	logger := new(mocks.MockAuditLogger)
+	userID := vo.NewID()
+
+	inner.On("Execute", mock.Anything, userID, []byte("img")).Return("https://api.example.com/avatars/x", nil)
+	event := captureAudit(logger, nil)
+
+	imageURL, err := usecase.NewAuditedUploadAvatar(inner, logger).Execute(auditContext(), userID, []byte("img"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.example.com/avatars/x", imageURL)
+	assert.Equal(t, entity.AuditActionAvatarChange, event.Action)
+	assert.Equal(t, userID.String(), event.Target)
+}
+
+func TestAuditedUpdateProfile_RecordsFailure(t *testing.T) {
+	inner := new(mocks.MockUpdateProfileUseCase)
+	logger := new(mocks.MockAuditLogger)
+	userID := vo.NewID()
+	input := dto.UpdateProfileInput{Name: dto.OptionalString{Set: true, Value: "Al"}}
+
+	inner.On("Execute", mock.Anything, userID, input).Return(dto.UserOutput{}, msgerror.AnErrConflict)
+	event := captureAudit(logger, nil)
+
+	_, err := usecase.NewAuditedUpdateProfile(inner, logger).Execute(auditContext(), userID, input)
+
+	assert.ErrorIs(t, err, msgerror.AnErrConflict)
+	assert.Equal(t, entity.AuditActionProfileUpdate, event.Action)
+	assert.Equal(t, entity.AuditOutcomeFailure, event.Outcome)
+	assert.Equal(t, userID.String(), event.Target)
+}
@@ -0,0 +1,105 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestAPIKey(t *testing.T, expiresAt time.Time) (*entity.APIKey, string) {
+	t.Helper()
+	name, _ := vo.NewName("CI script", 3, 50)
+	key, rawKey, err := entity.NewAPIKey(vo.NewID(), name, []string{entity.ScopeUserRead}, expiresAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, rawKey
+}
+
+func TestAuthenticateAPIKeyUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should authenticate valid key and track usage", func(t *testing.T) {
+		key, rawKey := newTestAPIKey(t, time.Time{})
+
+		repo := new(mocks.MockAPIKeyRepo)
+		repo.On("GetByPrefix", ctx, key.Prefix).Return(key, nil)
+		repo.On("TouchLastUsed", ctx, key.ID, mock.AnythingOfType("time.Time")).Return(nil)
+
+		uc := usecase.NewAuthenticateAPIKeyUsecase(repo)
+		principal, err := uc.Execute(ctx, rawKey)
+
+		assert.NoError(t, err)
+		assert.Equal(t, key.UserID.String(), principal.UserID)
+		assert.Equal(t, key.ID.String(), principal.APIKeyID)
+		assert.Equal(t, []string{entity.ScopeUserRead}, principal.Scopes)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should reject malformed key", func(t *testing.T) {
+		repo := new(mocks.MockAPIKeyRepo)
+		uc := usecase.NewAuthenticateAPIKeyUsecase(repo)
+
+		_, err := uc.Execute(ctx, "not-a-key")
+
+		assert.ErrorIs(t, err, msgerror.AnErrInvalidAPIKey)
+		repo.AssertNotCalled(t, "GetByPrefix")
+	})
+
+	t.Run("should reject unknown prefix", func(t *testing.T) {
+		repo := new(mocks.MockAPIKeyRepo)
+		repo.On("GetByPrefix", ctx, "abc123").Return(nil, nil)
+
+		uc := usecase.NewAuthenticateAPIKeyUsecase(repo)
+		_, err := uc.Execute(ctx, "sag_abc123_secret")
+
+		assert.ErrorIs(t, err, msgerror.AnErrInvalidAPIKey)
+	})
+
+	t.Run("should reject wrong secret", func(t *testing.T) {
+		key, _ := newTestAPIKey(t, time.Time{})
+
+		repo := new(mocks.MockAPIKeyRepo)
+		repo.On("GetByPrefix", ctx, key.Prefix).Return(key, nil)
+
+		uc := usecase.NewAuthenticateAPIKeyUsecase(repo)
+		_, err := uc.Execute(ctx, "sag_"+key.Prefix+"_wrong-secret")
+
+		assert.ErrorIs(t, err, msgerror.AnErrInvalidAPIKey)
+		repo.AssertNotCalled(t, "TouchLastUsed")
+	})
+
+	t.Run("should reject revoked key", func(t *testing.T) {
+		key, rawKey := newTestAPIKey(t, time.Time{})
+		key.Revoke()
+
+		repo := new(mocks.MockAPIKeyRepo)
+		repo.On("GetByPrefix", ctx, key.Prefix).Return(key, nil)
+
+		uc := usecase.NewAuthenticateAPIKeyUsecase(repo)
+		_, err := uc.Execute(ctx, rawKey)
+
+		assert.ErrorIs(t, err, msgerror.AnErrInvalidAPIKey)
+	})
+
+	t.Run("should wrap repository error", func(t *testing.T) {
+		repo := new(mocks.MockAPIKeyRepo)
+		repo.On("GetByPrefix", ctx, "abc123").Return(nil, errors.New("db error"))
+
+		uc := usecase.NewAuthenticateAPIKeyUsecase(repo)
+		_, err := uc.Execute(ctx, "sag_abc123_secret")
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, msgerror.AnErrInvalidAPIKey)
+		assert.Contains(t, err.Error(), "failed to get api key")
+	})
+}
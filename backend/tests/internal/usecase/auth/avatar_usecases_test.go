@@ -0,0 +1,197 @@
+package usecase_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const avatarBaseURL = "https://api.example.com/avatars"
+
+func TestUploadAvatarUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	userID := vo.NewID()
+	upload := []byte("image-bytes")
+	thumbnails := map[int][]byte{64: []byte("t64"), 128: []byte("t128"), 256: []byte("t256")}
+
+	type fixture struct {
+		users  *mocks.MockUserRepo
+		store  *mocks.MockBlobStore
+		images *mocks.MockImageProcessor
+	}
+	newFixture := func() fixture {
+		return fixture{new(mocks.MockUserRepo), new(mocks.MockBlobStore), new(mocks.MockImageProcessor)}
+	}
+	newUC := func(f fixture) *usecase.UploadAvatarUsecase {
+		return usecase.NewUploadAvatarUsecase(f.users, f.store, f.images, usecase.AvatarOptions{
+			MaxBytes:  1024,
+			PublicURL: avatarBaseURL + "/",
+		})
+	}
+	userWithImage := func(raw string) *entity.User {
+		imageURL, err := vo.NewURL(raw)
+		require.NoError(t, err)
+		return &entity.User{ID: userID, ImageURL: imageURL, Version: 1}
+	}
+
+	t.Run("Success - Stores thumbnails and removes the previous avatar", func(t *testing.T) {
+		f := newFixture()
+		oldID := vo.NewID().String()
+		user := userWithImage(avatarBaseURL + "/" + oldID)
+
+		f.users.On("GetByID", ctx, userID).Return(user, nil)
+		f.images.On("Thumbnails", upload, usecase.AvatarSizes).Return(thumbnails, nil)
+		var keys []string
+		f.store.On("Put", ctx, mock.Anything, mock.Anything, "image/png").
+			Run(func(args mock.Arguments) {
+				key := args.String(1)
+				keys = append(keys, key)
+				assert.True(t, strings.HasSuffix(key, ".png"))
+			}).
+			Return(nil)
+		f.users.On("Update", ctx, mock.MatchedBy(func(u *entity.User) bool {
+			return strings.HasPrefix(u.ImageURL.String(), avatarBaseURL+"/") && u.ImageURL != user.ImageURL
+		}), []string{repository.UserFieldImageURL}).Return(user, nil)
+		for _, size := range usecase.AvatarSizes {
+			f.store.On("Delete", ctx, "avatars/"+oldID+"/"+strconv.Itoa(size)+".png").Return(nil).Once()
+		}
+
+		imageURL, err := newUC(f).Execute(ctx, userID, upload)
+
+		require.NoError(t, err)
+		avatarID := strings.TrimPrefix(imageURL, avatarBaseURL+"/")
+		_, err = vo.ParseID(avatarID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"avatars/" + avatarID + "/64.png",
+			"avatars/" + avatarID + "/128.png",
+			"avatars/" + avatarID + "/256.png",
+		}, keys)
+		f.store.AssertExpectations(t)
+	})
+
+	t.Run("Success - External image URL is left alone", func(t *testing.T) {
+		f := newFixture()
+		user := userWithImage("https://cdn.example.org/me.png")
+
+		f.users.On("GetByID", ctx, userID).Return(user, nil)
+		f.images.On("Thumbnails", upload, usecase.AvatarSizes).Return(thumbnails, nil)
+		f.store.On("Put", ctx, mock.Anything, mock.Anything, "image/png").Return(nil)
+		f.users.On("Update", ctx, mock.Anything, []string{repository.UserFieldImageURL}).Return(user, nil)
+
+		_, err := newUC(f).Execute(ctx, userID, upload)
+
+		require.NoError(t, err)
+		f.store.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - File too large", func(t *testing.T) {
+		f := newFixture()
+
+		_, err := newUC(f).Execute(ctx, userID, make([]byte, 1025))
+
+		assert.ErrorIs(t, err, msgerror.AnErrImageTooLarge)
+		f.users.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - User not found", func(t *testing.T) {
+		f := newFixture()
+		f.users.On("GetByID", ctx, userID).Return(nil, nil)
+
+		_, err := newUC(f).Execute(ctx, userID, upload)
+
+		assert.ErrorIs(t, err, msgerror.AnErrUserNotFound)
+	})
+
+	t.Run("Error - Unsupported image", func(t *testing.T) {
+		f := newFixture()
+		f.users.On("GetByID", ctx, userID).Return(&entity.User{ID: userID}, nil)
+		f.images.On("Thumbnails", upload, usecase.AvatarSizes).Return(nil, msgerror.AnErrUnsupportedImage)
+
+		_, err := newUC(f).Execute(ctx, userID, upload)
+
+		assert.ErrorIs(t, err, msgerror.AnErrUnsupportedImage)
+		f.store.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Storage failure removes partial uploads", func(t *testing.T) {
+		f := newFixture()
+		f.users.On("GetByID", ctx, userID).Return(&entity.User{ID: userID}, nil)
+		f.images.On("Thumbnails", upload, usecase.AvatarSizes).Return(thumbnails, nil)
+		f.store.On("Put", ctx, mock.Anything, mock.Anything, "image/png").Return(nil).Once()
+		f.store.On("Put", ctx, mock.Anything, mock.Anything, "image/png").Return(assert.AnError).Once()
+		f.store.On("Delete", ctx, mock.Anything).Return(nil)
+
+		_, err := newUC(f).Execute(ctx, userID, upload)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		f.store.AssertNumberOfCalls(t, "Delete", len(usecase.AvatarSizes))
+		f.users.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Concurrent modification removes the new avatar", func(t *testing.T) {
+		f := newFixture()
+		f.users.On("GetByID", ctx, userID).Return(&entity.User{ID: userID}, nil)
+		f.images.On("Thumbnails", upload, usecase.AvatarSizes).Return(thumbnails, nil)
+		f.store.On("Put", ctx, mock.Anything, mock.Anything, "image/png").Return(nil)
+		f.users.On("Update", ctx, mock.Anything, []string{repository.UserFieldImageURL}).Return(nil, msgerror.AnErrConflict)
+		f.store.On("Delete", ctx, mock.Anything).Return(nil)
+
+		_, err := newUC(f).Execute(ctx, userID, upload)
+
+		assert.ErrorIs(t, err, msgerror.AnErrConflict)
+		f.store.AssertNumberOfCalls(t, "Delete", len(usecase.AvatarSizes))
+	})
+}
+
+func TestGetAvatarUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	avatarID := vo.NewID().String()
+
+	t.Run("Success - Default size", func(t *testing.T) {
+		store := new(mocks.MockBlobStore)
+		store.On("Get", ctx, "avatars/"+avatarID+"/256.png").
+			Return(providers.Blob{Data: []byte("png"), ContentType: "image/png"}, nil)
+
+		image, err := usecase.NewGetAvatarUsecase(store).Execute(ctx, avatarID, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, dto.AvatarImage{ContentType: "image/png", Data: []byte("png")}, image)
+	})
+
+	t.Run("Error - Size not generated", func(t *testing.T) {
+		_, err := usecase.NewGetAvatarUsecase(new(mocks.MockBlobStore)).Execute(ctx, avatarID, 100)
+		assert.ErrorIs(t, err, msgerror.AnErrInvalidImageSize)
+	})
+
+	t.Run("Error - Invalid ID never reaches the store", func(t *testing.T) {
+		store := new(mocks.MockBlobStore)
+
+		_, err := usecase.NewGetAvatarUsecase(store).Execute(ctx, "../secret", 64)
+
+		assert.ErrorIs(t, err, msgerror.AnErrAvatarNotFound)
+		store.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Missing blob", func(t *testing.T) {
+		store := new(mocks.MockBlobStore)
+		store.On("Get", ctx, "avatars/"+avatarID+"/64.png").Return(providers.Blob{}, msgerror.AnErrBlobNotFound)
+
+		_, err := usecase.NewGetAvatarUsecase(store).Execute(ctx, avatarID, 64)
+
+		assert.ErrorIs(t, err, msgerror.AnErrAvatarNotFound)
+	})
+}
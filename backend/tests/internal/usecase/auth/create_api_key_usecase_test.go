@@ -0,0 +1,95 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateAPIKeyUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	userID := vo.NewID()
+
+	t.Run("should create key and return plaintext once", func(t *testing.T) {
+		saved := &entity.APIKey{}
+		repo := new(mocks.MockAPIKeyRepo)
+		repo.On("Save", ctx, mock.AnythingOfType("*entity.APIKey")).
+			Run(func(args mock.Arguments) { *saved = *args.Get(1).(*entity.APIKey) }).
+			Return(saved, nil)
+
+		uc := usecase.NewCreateAPIKeyUsecase(repo)
+		result, err := uc.Execute(ctx, userID, dto.CreateAPIKeyParams{
+			Name:      "CI script",
+			Scopes:    []string{entity.ScopeUserRead},
+			ExpiresAt: time.Now().Add(24 * time.Hour),
+		})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result.Key)
+		assert.Equal(t, "CI script", result.Name)
+		assert.Equal(t, []string{entity.ScopeUserRead}, result.Scopes)
+
+		assert.Equal(t, entity.HashAPIKey(result.Key), saved.KeyHash)
+		assert.True(t, saved.UserID.Equal(userID))
+	})
+
+	t.Run("should return validation error for invalid name", func(t *testing.T) {
+		repo := new(mocks.MockAPIKeyRepo)
+		uc := usecase.NewCreateAPIKeyUsecase(repo)
+
+		_, err := uc.Execute(ctx, userID, dto.CreateAPIKeyParams{Name: "", Scopes: []string{entity.ScopeUserRead}})
+
+		var valErr *msgerror.ValidationErrors
+		assert.ErrorAs(t, err, &valErr)
+		assert.Contains(t, valErr.FieldErrors, "name")
+		repo.AssertNotCalled(t, "Save")
+	})
+
+	t.Run("should return validation error for unknown scope", func(t *testing.T) {
+		repo := new(mocks.MockAPIKeyRepo)
+		uc := usecase.NewCreateAPIKeyUsecase(repo)
+
+		_, err := uc.Execute(ctx, userID, dto.CreateAPIKeyParams{Name: "CI script", Scopes: []string{"root"}})
+
+		var valErr *msgerror.ValidationErrors
+		assert.ErrorAs(t, err, &valErr)
+		assert.Equal(t, msgerror.AnErrInvalidScope.Error(), valErr.FieldErrors["scopes"])
+		repo.AssertNotCalled(t, "Save")
+	})
+
+	t.Run("should return validation error for past expiration", func(t *testing.T) {
+		repo := new(mocks.MockAPIKeyRepo)
+		uc := usecase.NewCreateAPIKeyUsecase(repo)
+
+		_, err := uc.Execute(ctx, userID, dto.CreateAPIKeyParams{
+			Name:      "CI script",
+			Scopes:    []string{entity.ScopeUserRead},
+			ExpiresAt: time.Now().Add(-time.Hour),
+		})
+
+		var valErr *msgerror.ValidationErrors
+		assert.ErrorAs(t, err, &valErr)
+		assert.Contains(t, valErr.FieldErrors, "expires_at")
+	})
+
+	t.Run("should wrap save error", func(t *testing.T) {
+		repo := new(mocks.MockAPIKeyRepo)
+		repo.On("Save", ctx, mock.Anything).Return(nil, errors.New("db error"))
+
+		uc := usecase.NewCreateAPIKeyUsecase(repo)
+		_, err := uc.Execute(ctx, userID, dto.CreateAPIKeyParams{Name: "CI script", Scopes: []string{entity.ScopeUserRead}})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to save api key")
+	})
+}
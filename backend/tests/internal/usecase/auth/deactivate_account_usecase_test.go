@@ -0,0 +1,108 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeactivateAccountUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	userID := vo.NewID()
+	hash, _ := vo.NewPasswordHash("valid_hash")
+	grace := 30 * 24 * time.Hour
+
+	newUser := func() *entity.User {
+		return &entity.User{ID: userID, PasswordHash: hash}
+	}
+	newUC := func(users *mocks.MockUserRepo, keys *mocks.MockAPIKeyRepo, crypto *mocks.MockCrypto, store *mocks.MockTokenStore) *usecase.DeactivateAccountUsecase {
+		return usecase.NewDeactivateAccountUsecase(users, keys, mocks.NewMockTxManager(), crypto, store, time.Hour, grace)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		users, keys := new(mocks.MockUserRepo), new(mocks.MockAPIKeyRepo)
+		crypto, store := new(mocks.MockCrypto), new(mocks.MockTokenStore)
+
+		users.On("GetByID", ctx, userID).Return(newUser(), nil)
+		crypto.On("Compare", mock.Anything, "secret123", hash.String()).Return(true, nil)
+		users.On("Deactivate", ctx, userID, mock.AnythingOfType("time.Time")).Return(nil)
+		store.On("RevokeUser", ctx, userID.String(), time.Hour).Return(nil)
+		keys.On("RevokeAllByUserID", ctx, userID, mock.AnythingOfType("time.Time")).Return(nil)
+
+		before := time.Now()
+		purgeAfter, err := newUC(users, keys, crypto, store).Execute(ctx, userID, "secret123")
+
+		assert.NoError(t, err)
+		assert.WithinDuration(t, before.Add(grace), purgeAfter, time.Minute)
+		users.AssertExpectations(t)
+		keys.AssertExpectations(t)
+		store.AssertExpectations(t)
+	})
+
+	t.Run("WrongPassword", func(t *testing.T) {
+		users, keys := new(mocks.MockUserRepo), new(mocks.MockAPIKeyRepo)
+		crypto, store := new(mocks.MockCrypto), new(mocks.MockTokenStore)
+
+		users.On("GetByID", ctx, userID).Return(newUser(), nil)
+		crypto.On("Compare", mock.Anything, "wrong", hash.String()).Return(false, nil)
+
+		_, err := newUC(users, keys, crypto, store).Execute(ctx, userID, "wrong")
+
+		assert.ErrorIs(t, err, msgerror.AnErrInvalidCredentials)
+		users.AssertNotCalled(t, "Deactivate", mock.Anything, mock.Anything, mock.Anything)
+		store.AssertNotCalled(t, "RevokeUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("UserNotFound", func(t *testing.T) {
+		users, keys := new(mocks.MockUserRepo), new(mocks.MockAPIKeyRepo)
+		crypto, store := new(mocks.MockCrypto), new(mocks.MockTokenStore)
+
+		users.On("GetByID", ctx, userID).Return(nil, nil)
+
+		_, err := newUC(users, keys, crypto, store).Execute(ctx, userID, "secret123")
+
+		assert.ErrorIs(t, err, msgerror.AnErrUserNotFound)
+	})
+
+	t.Run("RevokeSessionsError", func(t *testing.T) {
+		users, keys := new(mocks.MockUserRepo), new(mocks.MockAPIKeyRepo)
+		crypto, store := new(mocks.MockCrypto), new(mocks.MockTokenStore)
+		storeErr := errors.New("redis down")
+
+		users.On("GetByID", ctx, userID).Return(newUser(), nil)
+		crypto.On("Compare", mock.Anything, "secret123", hash.String()).Return(true, nil)
+		users.On("Deactivate", ctx, userID, mock.AnythingOfType("time.Time")).Return(nil)
+		keys.On("RevokeAllByUserID", ctx, userID, mock.AnythingOfType("time.Time")).Return(nil)
+		store.On("RevokeUser", ctx, userID.String(), time.Hour).Return(storeErr)
+
+		_, err := newUC(users, keys, crypto, store).Execute(ctx, userID, "secret123")
+
+		assert.ErrorIs(t, err, storeErr)
+	})
+
+	t.Run("RevokeAPIKeysErrorRollsBack", func(t *testing.T) {
+		users, keys := new(mocks.MockUserRepo), new(mocks.MockAPIKeyRepo)
+		crypto, store := new(mocks.MockCrypto), new(mocks.MockTokenStore)
+		dbErr := errors.New("db down")
+
+		users.On("GetByID", ctx, userID).Return(newUser(), nil)
+		crypto.On("Compare", mock.Anything, "secret123", hash.String()).Return(true, nil)
+		users.On("Deactivate", ctx, userID, mock.AnythingOfType("time.Time")).Return(nil)
+		keys.On("RevokeAllByUserID", ctx, userID, mock.AnythingOfType("time.Time")).Return(dbErr)
+
+		_, err := newUC(users, keys, crypto, store).Execute(ctx, userID, "secret123")
+
+		// O erro sai da transação, então a desativação é desfeita e as sessões continuam
+		assert.ErrorIs(t, err, dbErr)
+		store.AssertNotCalled(t, "RevokeUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
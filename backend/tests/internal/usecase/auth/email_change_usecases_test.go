@@ -0,0 +1,292 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestEmailChangeUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	userID := vo.NewID()
+	hash, _ := vo.NewPasswordHash("valid_hash")
+	current, _ := vo.NewEmail("old@test.com")
+	target, _ := vo.NewEmail("new@test.com")
+
+	locale, _ := vo.NewLocale("en-US")
+
+	newUser := func() *entity.User {
+		return &entity.User{ID: userID, Email: current, PasswordHash: hash, Locale: locale, Version: 1}
+	}
+	type fixture struct {
+		users  *mocks.MockUserRepo
+		outbox *mocks.MockOutboxRepo
+		crypto *mocks.MockCrypto
+	}
+	newFixture := func() fixture {
+		return fixture{new(mocks.MockUserRepo), new(mocks.MockOutboxRepo), new(mocks.MockCrypto)}
+	}
+	newUC := func(f fixture) *usecase.RequestEmailChangeUsecase {
+		return usecase.NewRequestEmailChangeUsecase(f.users, f.outbox, mocks.NewMockTxManager(), f.crypto, 24*time.Hour)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		f := newFixture()
+		user := newUser()
+		f.users.On("GetByID", ctx, userID).Return(user, nil)
+		f.crypto.On("Compare", mock.Anything, "secret123", hash.String()).Return(true, nil)
+		f.users.On("GetByEmail", ctx, target).Return(nil, nil)
+		f.users.On("GetDeactivatedByEmail", ctx, target).Return(nil, nil)
+		f.users.On("Update", ctx, mock.MatchedBy(func(u *entity.User) bool {
+			return u.PendingEmail == target && u.Email == current && u.EmailChangeToken != ""
+		}), []string{repository.UserFieldEmailChange}).Return(user, nil)
+
+		var enqueued []*entity.OutboxMessage
+		f.outbox.On("Enqueue", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { enqueued = append(enqueued, args.Get(1).(*entity.OutboxMessage)) }).
+			Return(nil)
+
+		err := newUC(f).Execute(ctx, userID, "NEW@test.com", "secret123")
+
+		require.NoError(t, err)
+		require.Len(t, enqueued, 2)
+		assert.Equal(t, userID, enqueued[0].UserID)
+		assert.Equal(t, userID, enqueued[1].UserID)
+
+		var confirmation entity.EmailChangeConfirmationPayload
+		assert.Equal(t, entity.OutboxTopicEmailChangeConfirmationEmail, enqueued[0].Topic)
+		require.NoError(t, json.Unmarshal(enqueued[0].Payload, &confirmation))
+		assert.Equal(t, "new@test.com", confirmation.Email)
+		assert.Equal(t, user.EmailChangeToken, confirmation.Token)
+		assert.Equal(t, "en-US", confirmation.Locale)
+
+		var notice entity.EmailChangeNoticePayload
+		assert.Equal(t, entity.OutboxTopicEmailChangeNoticeEmail, enqueued[1].Topic)
+		require.NoError(t, json.Unmarshal(enqueued[1].Payload, &notice))
+		assert.Equal(t, "old@test.com", notice.Email)
+		assert.Equal(t, "new@test.com", notice.NewEmail)
+		assert.Equal(t, user.EmailChangeCancelToken, notice.CancelToken)
+	})
+
+	t.Run("InvalidEmail", func(t *testing.T) {
+		f := newFixture()
+
+		err := newUC(f).Execute(ctx, userID, "not-an-email", "secret123")
+
+		assert.ErrorIs(t, err, msgerror.AnErrInvalidEmail)
+		f.users.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("WrongPassword", func(t *testing.T) {
+		f := newFixture()
+		f.users.On("GetByID", ctx, userID).Return(newUser(), nil)
+		f.crypto.On("Compare", mock.Anything, "wrong", hash.String()).Return(false, nil)
+
+		err := newUC(f).Execute(ctx, userID, "new@test.com", "wrong")
+
+		assert.ErrorIs(t, err, msgerror.AnErrInvalidCredentials)
+		f.outbox.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("SameEmail", func(t *testing.T) {
+		f := newFixture()
+		f.users.On("GetByID", ctx, userID).Return(newUser(), nil)
+		f.crypto.On("Compare", mock.Anything, "secret123", hash.String()).Return(true, nil)
+
+		err := newUC(f).Execute(ctx, userID, "old@test.com", "secret123")
+
+		assert.ErrorIs(t, err, msgerror.AnErrEmailUnchanged)
+	})
+
+	t.Run("EmailTaken", func(t *testing.T) {
+		f := newFixture()
+		f.users.On("GetByID", ctx, userID).Return(newUser(), nil)
+		f.crypto.On("Compare", mock.Anything, "secret123", hash.String()).Return(true, nil)
+		f.users.On("GetByEmail", ctx, target).Return(&entity.User{ID: vo.NewID()}, nil)
+
+		err := newUC(f).Execute(ctx, userID, "new@test.com", "secret123")
+
+		assert.ErrorIs(t, err, msgerror.AnErrUserExists)
+		f.users.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("EmailReservedByDeactivatedAccount", func(t *testing.T) {
+		f := newFixture()
+		f.users.On("GetByID", ctx, userID).Return(newUser(), nil)
+		f.crypto.On("Compare", mock.Anything, "secret123", hash.String()).Return(true, nil)
+		f.users.On("GetByEmail", ctx, target).Return(nil, nil)
+		f.users.On("GetDeactivatedByEmail", ctx, target).Return(&entity.User{ID: vo.NewID()}, nil)
+
+		err := newUC(f).Execute(ctx, userID, "new@test.com", "secret123")
+
+		assert.ErrorIs(t, err, msgerror.AnErrUserExists)
+	})
+
+	t.Run("EnqueueErrorRollsBack", func(t *testing.T) {
+		f := newFixture()
+		f.users.On("GetByID", ctx, userID).Return(newUser(), nil)
+		f.crypto.On("Compare", mock.Anything, "secret123", hash.String()).Return(true, nil)
+		f.users.On("GetByEmail", ctx, target).Return(nil, nil)
+		f.users.On("GetDeactivatedByEmail", ctx, target).Return(nil, nil)
+		f.users.On("Update", ctx, mock.Anything, mock.Anything).Return(newUser(), nil)
+		f.outbox.On("Enqueue", ctx, mock.Anything).Return(errors.New("db down"))
+
+		err := newUC(f).Execute(ctx, userID, "new@test.com", "secret123")
+
+		assert.ErrorContains(t, err, "failed to enqueue email change emails")
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		f := newFixture()
+		f.users.On("GetByID", ctx, userID).Return(newUser(), nil)
+		f.crypto.On("Compare", mock.Anything, "secret123", hash.String()).Return(true, nil)
+		f.users.On("GetByEmail", ctx, target).Return(nil, nil)
+		f.users.On("GetDeactivatedByEmail", ctx, target).Return(nil, nil)
+		f.users.On("Update", ctx, mock.Anything, mock.Anything).Return(nil, msgerror.AnErrConflict)
+
+		err := newUC(f).Execute(ctx, userID, "new@test.com", "secret123")
+
+		assert.Equal(t, msgerror.AnErrConflict, err)
+	})
+}
+
+func TestConfirmEmailChangeUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	userID := vo.NewID()
+	current, _ := vo.NewEmail("old@test.com")
+	target, _ := vo.NewEmail("new@test.com")
+
+	newUser := func(expires time.Time) *entity.User {
+		return &entity.User{
+			ID:                     userID,
+			Email:                  current,
+			PendingEmail:           target,
+			EmailChangeToken:       "confirm-token",
+			EmailChangeCancelToken: "cancel-token",
+			EmailChangeExpires:     expires,
+			Version:                2,
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		users, store := new(mocks.MockUserRepo), new(mocks.MockTokenStore)
+		users.On("GetByEmailChangeToken", ctx, "confirm-token").Return(newUser(time.Now().Add(time.Hour)), nil)
+		users.On("GetByEmail", ctx, target).Return(nil, nil)
+		users.On("GetDeactivatedByEmail", ctx, target).Return(nil, nil)
+		users.On("Update", ctx, mock.MatchedBy(func(u *entity.User) bool {
+			return u.Email == target && u.PendingEmail.IsEmpty() && u.EmailChangeToken == ""
+		}), []string{repository.UserFieldEmail, repository.UserFieldEmailChange}).Return(&entity.User{}, nil)
+		store.On("RevokeUser", ctx, userID.String(), time.Hour).Return(nil)
+
+		err := usecase.NewConfirmEmailChangeUsecase(users, store, time.Hour).Execute(ctx, "confirm-token")
+
+		assert.NoError(t, err)
+		users.AssertExpectations(t)
+		store.AssertExpectations(t)
+	})
+
+	t.Run("InvalidToken", func(t *testing.T) {
+		users, store := new(mocks.MockUserRepo), new(mocks.MockTokenStore)
+		users.On("GetByEmailChangeToken", ctx, "bogus").Return(nil, nil)
+
+		err := usecase.NewConfirmEmailChangeUsecase(users, store, time.Hour).Execute(ctx, "bogus")
+
+		assert.ErrorIs(t, err, msgerror.AnErrInvalidToken)
+	})
+
+	t.Run("ExpiredToken", func(t *testing.T) {
+		users, store := new(mocks.MockUserRepo), new(mocks.MockTokenStore)
+		users.On("GetByEmailChangeToken", ctx, "confirm-token").Return(newUser(time.Now().Add(-time.Minute)), nil)
+
+		err := usecase.NewConfirmEmailChangeUsecase(users, store, time.Hour).Execute(ctx, "confirm-token")
+
+		assert.ErrorIs(t, err, msgerror.AnErrExpiredToken)
+		users.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("EmailTakenMeanwhile", func(t *testing.T) {
+		users, store := new(mocks.MockUserRepo), new(mocks.MockTokenStore)
+		users.On("GetByEmailChangeToken", ctx, "confirm-token").Return(newUser(time.Now().Add(time.Hour)), nil)
+		users.On("GetByEmail", ctx, target).Return(&entity.User{ID: vo.NewID()}, nil)
+
+		err := usecase.NewConfirmEmailChangeUsecase(users, store, time.Hour).Execute(ctx, "confirm-token")
+
+		assert.ErrorIs(t, err, msgerror.AnErrUserExists)
+		store.AssertNotCalled(t, "RevokeUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("UniqueIndexViolation", func(t *testing.T) {
+		users, store := new(mocks.MockUserRepo), new(mocks.MockTokenStore)
+		users.On("GetByEmailChangeToken", ctx, "confirm-token").Return(newUser(time.Now().Add(time.Hour)), nil)
+		users.On("GetByEmail", ctx, target).Return(nil, nil)
+		users.On("GetDeactivatedByEmail", ctx, target).Return(nil, nil)
+		users.On("Update", ctx, mock.Anything, mock.Anything).Return(nil, msgerror.AnErrUserExists)
+
+		err := usecase.NewConfirmEmailChangeUsecase(users, store, time.Hour).Execute(ctx, "confirm-token")
+
+		assert.Equal(t, msgerror.AnErrUserExists, err)
+		store.AssertNotCalled(t, "RevokeUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RevokeSessionsError", func(t *testing.T) {
+		users, store := new(mocks.MockUserRepo), new(mocks.MockTokenStore)
+		users.On("GetByEmailChangeToken", ctx, "confirm-token").Return(newUser(time.Now().Add(time.Hour)), nil)
+		users.On("GetByEmail", ctx, target).Return(nil, nil)
+		users.On("GetDeactivatedByEmail", ctx, target).Return(nil, nil)
+		users.On("Update", ctx, mock.Anything, mock.Anything).Return(&entity.User{}, nil)
+		store.On("RevokeUser", ctx, userID.String(), time.Hour).Return(errors.New("redis down"))
+
+		err := usecase.NewConfirmEmailChangeUsecase(users, store, time.Hour).Execute(ctx, "confirm-token")
+
+		assert.ErrorContains(t, err, "failed to revoke sessions")
+	})
+}
+
+func TestCancelEmailChangeUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	target, _ := vo.NewEmail("new@test.com")
+
+	t.Run("Success", func(t *testing.T) {
+		users := new(mocks.MockUserRepo)
+		user := &entity.User{ID: vo.NewID(), PendingEmail: target, EmailChangeCancelToken: "cancel-token", Version: 2}
+		users.On("GetByEmailChangeCancelToken", ctx, "cancel-token").Return(user, nil)
+		users.On("Update", ctx, mock.MatchedBy(func(u *entity.User) bool {
+			return u.PendingEmail.IsEmpty() && u.EmailChangeCancelToken == ""
+		}), []string{repository.UserFieldEmailChange}).Return(user, nil)
+
+		err := usecase.NewCancelEmailChangeUsecase(users).Execute(ctx, "cancel-token")
+
+		assert.NoError(t, err)
+		users.AssertExpectations(t)
+	})
+
+	t.Run("InvalidToken", func(t *testing.T) {
+		users := new(mocks.MockUserRepo)
+		users.On("GetByEmailChangeCancelToken", ctx, "bogus").Return(nil, nil)
+
+		err := usecase.NewCancelEmailChangeUsecase(users).Execute(ctx, "bogus")
+
+		assert.ErrorIs(t, err, msgerror.AnErrInvalidToken)
+	})
+
+	t.Run("LookupError", func(t *testing.T) {
+		users := new(mocks.MockUserRepo)
+		users.On("GetByEmailChangeCancelToken", ctx, "cancel-token").Return(nil, errors.New("db down"))
+
+		err := usecase.NewCancelEmailChangeUsecase(users).Execute(ctx, "cancel-token")
+
+		assert.ErrorContains(t, err, "failed to get user by token")
+	})
+}
@@ -0,0 +1,208 @@
+package usecase_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type exportFixture struct {
+	user   *entity.User
+	users  *mocks.MockUserRepo
+	keys   *mocks.MockAPIKeyRepo
+	audit  *mocks.MockAuditRepo
+	outbox *mocks.MockOutboxRepo
+	store  *mocks.MockExportStore
+	signer *mocks.MockURLSigner
+	email  *mocks.MockEmailService
+}
+
+func newExportFixture(auditCount int64) *exportFixture {
+	name, _ := vo.NewName("Maria Silva", 3, 50)
+	email, _ := vo.NewEmail("maria@test.com")
+	pending, _ := vo.NewEmail("maria.silva@test.com")
+	f := &exportFixture{
+		user: &entity.User{
+			ID: vo.NewID(), Name: name, Email: email, CreatedAt: time.Now(),
+			PendingEmail: pending, EmailChangeToken: "confirm-token", EmailChangeExpires: time.Now().Add(time.Hour),
+		},
+		users:  new(mocks.MockUserRepo),
+		keys:   new(mocks.MockAPIKeyRepo),
+		audit:  new(mocks.MockAuditRepo),
+		outbox: new(mocks.MockOutboxRepo),
+		store:  new(mocks.MockExportStore),
+		signer: new(mocks.MockURLSigner),
+		email:  new(mocks.MockEmailService),
+	}
+
+	login := entity.NewAuditEvent(entity.AuditActionLogin, nil)
+	login.Target = email.String()
+
+	f.users.On("GetByID", mock.Anything, f.user.ID).Return(f.user, nil)
+	f.keys.On("ListByUserID", mock.Anything, f.user.ID).Return([]*entity.APIKey{}, nil)
+	f.audit.On("Count", mock.Anything, mock.Anything).Return(auditCount, nil)
+	f.audit.On("List", mock.Anything, repository.AuditFilter{Target: email.String(), Limit: 500}).
+		Return([]*entity.AuditEvent{login}, nil)
+	// O mesmo evento aparece por dois filtros e deve ser exportado uma vez
+	f.audit.On("List", mock.Anything, repository.AuditFilter{Target: f.user.ID.String(), Limit: 500}).
+		Return([]*entity.AuditEvent{login}, nil)
+	f.audit.On("List", mock.Anything, mock.Anything).Return([]*entity.AuditEvent{}, nil)
+
+	confirmation, _ := entity.NewOutboxMessage(entity.OutboxTopicEmailChangeConfirmationEmail,
+		entity.EmailChangeConfirmationPayload{Email: pending.String(), Token: "confirm-token"})
+	reset, _ := entity.NewOutboxMessage(entity.OutboxTopicPasswordResetEmail,
+		entity.PasswordResetEmailPayload{Email: email.String(), Token: "reset-token"})
+	reset.MarkSent(time.Now())
+	f.outbox.On("ListByUserID", mock.Anything, f.user.ID).Return([]*entity.OutboxMessage{confirmation, reset}, nil)
+	return f
+}
+
+func (f *exportFixture) usecase() *usecase.ExportUserDataUsecase {
+	return usecase.NewExportUserDataUsecase(f.users, f.keys, f.audit, f.outbox, f.store, f.signer, f.email, usecase.ExportOptions{
+		AsyncThreshold: 10,
+		LinkTTL:        15 * time.Minute,
+		DownloadURL:    "https://api.example.com/exports",
+	})
+}
+
+func TestExportUserDataUsecase_SyncJSON(t *testing.T) {
+	f := newExportFixture(2)
+
+	result, err := f.usecase().Execute(context.Background(), f.user.ID, "")
+
+	require.NoError(t, err)
+	assert.False(t, result.Pending)
+	assert.Equal(t, "application/json", result.ContentType)
+	assert.True(t, strings.HasSuffix(result.FileName, ".json"))
+
+	var export dto.UserDataExport
+	require.NoError(t, json.Unmarshal(result.Data, &export))
+	assert.Equal(t, f.user.ID.String(), export.Profile.ID)
+	assert.Equal(t, "maria@test.com", export.Profile.Email)
+	assert.Len(t, export.AuditEvents, 1)
+	assert.NotNil(t, export.APIKeys)
+
+	require.NotNil(t, export.EmailChange)
+	assert.Equal(t, "maria.silva@test.com", export.EmailChange.PendingEmail)
+	require.Len(t, export.Messages, 2)
+	assert.Equal(t, entity.OutboxTopicEmailChangeConfirmationEmail, export.Messages[0].Topic)
+	assert.Equal(t, "maria.silva@test.com", export.Messages[0].To)
+	assert.Equal(t, entity.OutboxStatusSent, export.Messages[1].Status)
+	assert.NotNil(t, export.Messages[1].SentAt)
+	// Tokens ainda válidos não saem no arquivo
+	assert.NotContains(t, string(result.Data), "confirm-token")
+	f.email.AssertNotCalled(t, "SendDataExportEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExportUserDataUsecase_SyncZIP(t *testing.T) {
+	f := newExportFixture(2)
+
+	result, err := f.usecase().Execute(context.Background(), f.user.ID, dto.ExportFormatZIP)
+
+	require.NoError(t, err)
+	assert.Equal(t, "application/zip", result.ContentType)
+
+	archive, err := zip.NewReader(bytes.NewReader(result.Data), int64(len(result.Data)))
+	require.NoError(t, err)
+	var names []string
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+	}
+	assert.ElementsMatch(t, []string{"profile.json", "email_change.json", "api_keys.json", "audit_events.json", "messages.json"}, names)
+}
+
+func TestExportUserDataUsecase_LargeAccountIsEmailed(t *testing.T) {
+	original := usecase.RunInBackground
+	usecase.RunInBackground = func(task func()) { task() }
+	t.Cleanup(func() { usecase.RunInBackground = original })
+
+	f := newExportFixture(50)
+	var stored string
+	f.store.On("Save", mock.Anything, mock.MatchedBy(func(name string) bool {
+		stored = name
+		// O dono vai no nome para o apagamento da conta achar o arquivo
+		return strings.HasPrefix(name, f.user.ID.String()+".") && strings.HasSuffix(name, ".zip")
+	}), mock.Anything).Return(nil)
+	f.signer.On("Sign", mock.Anything, mock.MatchedBy(func(expiresAt time.Time) bool {
+		return time.Until(expiresAt) > 14*time.Minute
+	})).Return("sig")
+	f.email.On("SendDataExportEmail", mock.Anything, f.user.Email, f.user.Locale.String(), mock.MatchedBy(func(link string) bool {
+		u, err := url.Parse(link)
+		return err == nil &&
+			u.Path == "/exports/"+stored &&
+			u.Query().Get("signature") == "sig" &&
+			u.Query().Get("expires") != ""
+	})).Return(nil)
+
+	result, err := f.usecase().Execute(context.Background(), f.user.ID, dto.ExportFormatZIP)
+
+	require.NoError(t, err)
+	assert.True(t, result.Pending)
+	assert.Empty(t, result.Data)
+	f.store.AssertExpectations(t)
+	f.email.AssertExpectations(t)
+}
+
+func TestExportUserDataUsecase_UserNotFound(t *testing.T) {
+	users := new(mocks.MockUserRepo)
+	userID := vo.NewID()
+	users.On("GetByID", mock.Anything, userID).Return(nil, nil)
+
+	uc := usecase.NewExportUserDataUsecase(users, nil, nil, nil, nil, nil, nil, usecase.ExportOptions{})
+	_, err := uc.Execute(context.Background(), userID, "")
+
+	assert.ErrorIs(t, err, msgerror.AnErrUserNotFound)
+}
+
+func TestDownloadUserDataExportUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Minute)
+
+	t.Run("ValidSignature", func(t *testing.T) {
+		store, signer := new(mocks.MockExportStore), new(mocks.MockURLSigner)
+		signer.On("Verify", "a.zip", expiresAt, "sig").Return(true)
+		store.On("Load", ctx, "a.zip").Return([]byte("zip"), nil)
+
+		result, err := usecase.NewDownloadUserDataExportUsecase(store, signer).Execute(ctx, "a.zip", expiresAt, "sig")
+
+		require.NoError(t, err)
+		assert.Equal(t, "application/zip", result.ContentType)
+		assert.Equal(t, []byte("zip"), result.Data)
+	})
+
+	t.Run("InvalidSignature", func(t *testing.T) {
+		store, signer := new(mocks.MockExportStore), new(mocks.MockURLSigner)
+		signer.On("Verify", "a.zip", expiresAt, "forged").Return(false)
+
+		_, err := usecase.NewDownloadUserDataExportUsecase(store, signer).Execute(ctx, "a.zip", expiresAt, "forged")
+
+		assert.ErrorIs(t, err, msgerror.AnErrInvalidSignature)
+		store.AssertNotCalled(t, "Load", mock.Anything, mock.Anything)
+	})
+
+	t.Run("FileGone", func(t *testing.T) {
+		store, signer := new(mocks.MockExportStore), new(mocks.MockURLSigner)
+		signer.On("Verify", "a.zip", expiresAt, "sig").Return(true)
+		store.On("Load", ctx, "a.zip").Return(nil, msgerror.AnErrExportNotFound)
+
+		_, err := usecase.NewDownloadUserDataExportUsecase(store, signer).Execute(ctx, "a.zip", expiresAt, "sig")
+
+		assert.ErrorIs(t, err, msgerror.AnErrExportNotFound)
+	})
+}
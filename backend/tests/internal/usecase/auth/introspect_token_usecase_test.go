@@ -0,0 +1,108 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIntrospectToken_Active(t *testing.T) {
+	mockToken := new(mocks.MockTokenProvider)
+	mockStore := new(mocks.MockTokenStore)
+	uc := usecase.NewIntrospectTokenUsecase(mockToken, mockStore)
+
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	claims := providers.Claims{
+		UserID: "user-123",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti-123",
+			Subject:   "user@test.com",
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	mockToken.On("Validate", mock.Anything, "valid_token").Return(claims, nil)
+	mockStore.On("IsActive", ctx, claims).Return(true, nil)
+
+	result, err := uc.Execute(ctx, "valid_token")
+
+	assert.NoError(t, err)
+	assert.True(t, result.Active)
+	assert.Equal(t, "user-123", result.UserID)
+	assert.Equal(t, "user@test.com", result.Subject)
+	assert.Equal(t, expiresAt.Unix(), result.ExpiresAt)
+	assert.Equal(t, "Bearer", result.TokenType)
+	mockToken.AssertExpectations(t)
+	mockStore.AssertExpectations(t)
+}
+
+func TestIntrospectToken_EmptyToken(t *testing.T) {
+	mockToken := new(mocks.MockTokenProvider)
+	mockStore := new(mocks.MockTokenStore)
+	uc := usecase.NewIntrospectTokenUsecase(mockToken, mockStore)
+
+	result, err := uc.Execute(context.Background(), "")
+
+	assert.NoError(t, err)
+	assert.False(t, result.Active)
+	mockToken.AssertNotCalled(t, "Validate")
+}
+
+func TestIntrospectToken_InvalidSignature(t *testing.T) {
+	mockToken := new(mocks.MockTokenProvider)
+	mockStore := new(mocks.MockTokenStore)
+	uc := usecase.NewIntrospectTokenUsecase(mockToken, mockStore)
+
+	mockToken.On("Validate", mock.Anything, "bad_token").Return(nil, errors.New("signature is invalid"))
+
+	result, err := uc.Execute(context.Background(), "bad_token")
+
+	assert.NoError(t, err)
+	assert.False(t, result.Active)
+	mockStore.AssertNotCalled(t, "IsActive")
+}
+
+func TestIntrospectToken_Revoked(t *testing.T) {
+	mockToken := new(mocks.MockTokenProvider)
+	mockStore := new(mocks.MockTokenStore)
+	uc := usecase.NewIntrospectTokenUsecase(mockToken, mockStore)
+
+	ctx := context.Background()
+	claims := providers.Claims{UserID: "user-123"}
+
+	mockToken.On("Validate", mock.Anything, "revoked_token").Return(claims, nil)
+	mockStore.On("IsActive", ctx, claims).Return(false, nil)
+
+	result, err := uc.Execute(ctx, "revoked_token")
+
+	assert.NoError(t, err)
+	assert.False(t, result.Active)
+	assert.Empty(t, result.UserID)
+}
+
+func TestIntrospectToken_StoreError(t *testing.T) {
+	mockToken := new(mocks.MockTokenProvider)
+	mockStore := new(mocks.MockTokenStore)
+	uc := usecase.NewIntrospectTokenUsecase(mockToken, mockStore)
+
+	ctx := context.Background()
+	claims := providers.Claims{UserID: "user-123"}
+
+	mockToken.On("Validate", mock.Anything, "valid_token").Return(claims, nil)
+	mockStore.On("IsActive", ctx, claims).Return(false, errors.New("redis down"))
+
+	_, err := uc.Execute(ctx, "valid_token")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to check token session")
+	assert.Contains(t, err.Error(), "redis down")
+}
@@ -0,0 +1,53 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListAPIKeysUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	userID := vo.NewID()
+
+	t.Run("should list keys without secrets", func(t *testing.T) {
+		name, _ := vo.NewName("CI script", 3, 50)
+		key := &entity.APIKey{
+			ID:      vo.NewID(),
+			UserID:  userID,
+			Name:    name,
+			Prefix:  "abc123",
+			KeyHash: "hash",
+			Scopes:  []string{entity.ScopeUserRead},
+		}
+
+		repo := new(mocks.MockAPIKeyRepo)
+		repo.On("ListByUserID", ctx, userID).Return([]*entity.APIKey{key}, nil)
+
+		uc := usecase.NewListAPIKeysUsecase(repo)
+		results, err := uc.Execute(ctx, userID)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, key.ID.String(), results[0].ID)
+		assert.Equal(t, "abc123", results[0].Prefix)
+		assert.Equal(t, "CI script", results[0].Name)
+	})
+
+	t.Run("should wrap repository error", func(t *testing.T) {
+		repo := new(mocks.MockAPIKeyRepo)
+		repo.On("ListByUserID", ctx, userID).Return(nil, errors.New("db error"))
+
+		uc := usecase.NewListAPIKeysUsecase(repo)
+		_, err := uc.Execute(ctx, userID)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list api keys")
+	})
+}
@@ -0,0 +1,58 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListAuditEvents_MapsFilterAndEvents(t *testing.T) {
+	mockRepo := new(mocks.MockAuditRepo)
+	uc := usecase.NewListAuditEventsUsecase(mockRepo)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	event := entity.NewAuditEvent(entity.AuditActionLogin, nil)
+	event.ActorID = "user-1"
+	event.IP = "203.0.113.7"
+
+	mockRepo.On("List", context.Background(), repository.AuditFilter{
+		Action:  entity.AuditActionLogin,
+		ActorID: "user-1",
+		From:    from,
+		Limit:   10,
+	}).Return([]*entity.AuditEvent{event}, nil)
+
+	output, err := uc.Execute(context.Background(), dto.AuditQueryParams{
+		Action:  entity.AuditActionLogin,
+		ActorID: "user-1",
+		From:    from,
+		Limit:   10,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, output, 1)
+	assert.Equal(t, event.ID.String(), output[0].ID)
+	assert.Equal(t, entity.AuditOutcomeSuccess, output[0].Outcome)
+	assert.Equal(t, "203.0.113.7", output[0].IP)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListAuditEvents_RepositoryError(t *testing.T) {
+	mockRepo := new(mocks.MockAuditRepo)
+	uc := usecase.NewListAuditEventsUsecase(mockRepo)
+
+	mockRepo.On("List", context.Background(), repository.AuditFilter{}).Return(nil, errors.New("db error"))
+
+	_, err := uc.Execute(context.Background(), dto.AuditQueryParams{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list audit events")
+}
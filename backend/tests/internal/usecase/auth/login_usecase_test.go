@@ -12,7 +12,6 @@ import (
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
 	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
 	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -21,9 +20,9 @@ func TestLoginWithInvalidEmail(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepo)
 	mockCrypto := new(mocks.MockCrypto)
 	mockToken := new(mocks.MockTokenProvider)
-	mockBlacklist := new(mocks.MockBlacklist)
+	mockStore := new(mocks.MockTokenStore)
 
-	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockBlacklist)
+	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockStore, 24*time.Hour)
 
 	// Teste com e-mail inválido
 	_, err := handler.Execute(context.Background(), "invalid-email", "any")
@@ -34,16 +33,16 @@ func TestLoginWithInvalidEmail(t *testing.T) {
 
 	mockRepo.AssertNotCalled(t, "GetByEmail")
 	mockCrypto.AssertNotCalled(t, "Compare")
-	mockBlacklist.AssertNotCalled(t, "SetWithKey")
+	mockStore.AssertNotCalled(t, "Register")
 }
 
 func TestLoginWithEmptyEmail(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepo)
 	mockCrypto := new(mocks.MockCrypto)
 	mockToken := new(mocks.MockTokenProvider)
-	mockBlacklist := new(mocks.MockBlacklist)
+	mockStore := new(mocks.MockTokenStore)
 
-	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockBlacklist)
+	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockStore, 24*time.Hour)
 
 	_, err := handler.Execute(context.Background(), "", "any")
 
@@ -53,16 +52,16 @@ func TestLoginWithEmptyEmail(t *testing.T) {
 
 	mockRepo.AssertNotCalled(t, "GetByEmail")
 	mockCrypto.AssertNotCalled(t, "Compare")
-	mockBlacklist.AssertNotCalled(t, "SetWithKey")
+	mockStore.AssertNotCalled(t, "Register")
 }
 
 func TestLoginWithShortPassword(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepo)
 	mockCrypto := new(mocks.MockCrypto)
 	mockToken := new(mocks.MockTokenProvider)
-	mockBlacklist := new(mocks.MockBlacklist)
+	mockStore := new(mocks.MockTokenStore)
 
-	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockBlacklist)
+	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockStore, 24*time.Hour)
 
 	_, err := handler.Execute(context.Background(), "valid@test.com", "short")
 
@@ -72,16 +71,16 @@ func TestLoginWithShortPassword(t *testing.T) {
 
 	mockRepo.AssertNotCalled(t, "GetByEmail")
 	mockCrypto.AssertNotCalled(t, "Compare")
-	mockBlacklist.AssertNotCalled(t, "SetWithKey")
+	mockStore.AssertNotCalled(t, "Register")
 }
 
 func TestLoginWithEmptyPassword(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepo)
 	mockCrypto := new(mocks.MockCrypto)
 	mockToken := new(mocks.MockTokenProvider)
-	mockBlacklist := new(mocks.MockBlacklist)
+	mockStore := new(mocks.MockTokenStore)
 
-	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockBlacklist)
+	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockStore, 24*time.Hour)
 	_, err := handler.Execute(context.Background(), "valid@test.com", "")
 
 	var valErr *msgerror.ValidationErrors
@@ -90,38 +89,38 @@ func TestLoginWithEmptyPassword(t *testing.T) {
 
 	mockRepo.AssertNotCalled(t, "GetByEmail")
 	mockCrypto.AssertNotCalled(t, "Compare")
-	mockBlacklist.AssertNotCalled(t, "SetWithKey")
+	mockStore.AssertNotCalled(t, "Register")
 }
 
 func TestLoginWithNonExistentUser(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepo)
 	mockCrypto := new(mocks.MockCrypto)
 	mockToken := new(mocks.MockTokenProvider)
-	mockBlacklist := new(mocks.MockBlacklist)
+	mockStore := new(mocks.MockTokenStore)
 
 	email, _ := vo.NewEmail("nonexistent@test.com")
 	mockRepo.On("GetByEmail", mock.Anything, email).Return(nil, msgerror.AnErrNotFound)
 
-	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockBlacklist)
+	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockStore, 24*time.Hour)
 	_, err := handler.Execute(context.Background(), "nonexistent@test.com", "valid-password")
 
 	assert.ErrorIs(t, err, msgerror.AnErrInvalidCredentials)
 	mockRepo.AssertExpectations(t)
 	mockCrypto.AssertNotCalled(t, "Compare")
-	mockBlacklist.AssertNotCalled(t, "SetWithKey")
+	mockStore.AssertNotCalled(t, "Register")
 }
 
 func TestLoginWithUnexpectedErrorOnGetByEmail(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepo)
 	mockCrypto := new(mocks.MockCrypto)
 	mockToken := new(mocks.MockTokenProvider)
-	mockBlacklist := new(mocks.MockBlacklist)
+	mockStore := new(mocks.MockTokenStore)
 
 	email, _ := vo.NewEmail("test@test.com")
 	expectedErr := errors.New("unexpected error")
 	mockRepo.On("GetByEmail", mock.Anything, email).Return(nil, expectedErr)
 
-	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockBlacklist)
+	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockStore, 24*time.Hour)
 	_, err := handler.Execute(context.Background(), "test@test.com", "valid-password")
 
 	assert.Error(t, err)
@@ -129,14 +128,14 @@ func TestLoginWithUnexpectedErrorOnGetByEmail(t *testing.T) {
 	assert.Contains(t, err.Error(), "unexpected error")
 	mockRepo.AssertExpectations(t)
 	mockCrypto.AssertNotCalled(t, "Compare")
-	mockBlacklist.AssertNotCalled(t, "SetWithKey")
+	mockStore.AssertNotCalled(t, "Register")
 }
 
 func TestLoginWithInvalidPassword(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepo)
 	mockCrypto := new(mocks.MockCrypto)
 	mockToken := new(mocks.MockTokenProvider)
-	mockBlacklist := new(mocks.MockBlacklist)
+	mockStore := new(mocks.MockTokenStore)
 
 	validHash := "$2a$10$0MwrQkGO0Bw6dYpVfiX4mefEVgTdgtCYCJ7LxltXfzj5qscr4sive"
 	passwordHash, _ := vo.NewPasswordHash(validHash)
@@ -147,22 +146,22 @@ func TestLoginWithInvalidPassword(t *testing.T) {
 	}
 
 	mockRepo.On("GetByEmail", mock.Anything, email).Return(user, nil)
-	mockCrypto.On("Compare", "wrong-password", mock.Anything).Return(false, nil)
+	mockCrypto.On("Compare", mock.Anything, "wrong-password", mock.Anything).Return(false, nil)
 
-	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockBlacklist)
+	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockStore, 24*time.Hour)
 	_, err := handler.Execute(context.Background(), "user@test.com", "wrong-password")
 
 	assert.ErrorIs(t, err, msgerror.AnErrInvalidCredentials)
 	mockRepo.AssertExpectations(t)
 	mockCrypto.AssertExpectations(t)
-	mockBlacklist.AssertNotCalled(t, "SetWithKey")
+	mockStore.AssertNotCalled(t, "Register")
 }
 
 func TestLoginWithCompareError(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepo)
 	mockCrypto := new(mocks.MockCrypto)
 	mockToken := new(mocks.MockTokenProvider)
-	mockBlacklist := new(mocks.MockBlacklist)
+	mockStore := new(mocks.MockTokenStore)
 
 	validHash := "$2a$10$0MwrQkGO0Bw6dYpVfiX4mefEVgTdgtCYCJ7LxltXfzj5qscr4sive"
 	passwordHash, _ := vo.NewPasswordHash(validHash)
@@ -174,9 +173,9 @@ func TestLoginWithCompareError(t *testing.T) {
 
 	mockRepo.On("GetByEmail", mock.Anything, email).Return(user, nil)
 	compareErr := errors.New("comparison failed")
-	mockCrypto.On("Compare", "any-password", mock.Anything).Return(false, compareErr)
+	mockCrypto.On("Compare", mock.Anything, "any-password", mock.Anything).Return(false, compareErr)
 
-	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockBlacklist)
+	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockStore, 24*time.Hour)
 	_, err := handler.Execute(context.Background(), "user@test.com", "any-password")
 
 	assert.Error(t, err)
@@ -184,42 +183,67 @@ func TestLoginWithCompareError(t *testing.T) {
 	assert.Contains(t, err.Error(), "comparison failed")
 	mockRepo.AssertExpectations(t)
 	mockCrypto.AssertExpectations(t)
-	mockBlacklist.AssertNotCalled(t, "SetWithKey")
+	mockStore.AssertNotCalled(t, "Register")
 }
 
-func TestLoginTokenGenerationFailure(t *testing.T) {
+func TestLoginWithNilUser(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepo)
 	mockCrypto := new(mocks.MockCrypto)
 	mockToken := new(mocks.MockTokenProvider)
-	mockBlacklist := new(mocks.MockBlacklist)
+	mockStore := new(mocks.MockTokenStore)
+
+	email, _ := vo.NewEmail("ghost@test.com")
+	mockRepo.On("GetByEmail", mock.Anything, email).Return(nil, nil)
+
+	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockStore, 24*time.Hour)
+	_, err := handler.Execute(context.Background(), "ghost@test.com", "valid-password")
 
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidCredentials)
+	mockCrypto.AssertNotCalled(t, "Compare")
+	mockStore.AssertNotCalled(t, "Register")
+}
+
+func newLoginTestUser() *entity.User {
 	name, _ := vo.NewName("Test User", 0, 0)
 	email, _ := vo.NewEmail("user@test.com")
-	validHash := "$2a$10$0MwrQkGO0Bw6dYpVfiX4mefEVgTdgtCYCJ7LxltXfzj5qscr4sive"
-	passwordHash, _ := vo.NewPasswordHash(validHash)
-	userID := vo.NewID()
+	passwordHash, _ := vo.NewPasswordHash(loginTestHash)
 
-	user := &entity.User{
-		ID:           userID,
+	return &entity.User{
+		ID:           vo.NewID(),
 		Name:         name,
 		Email:        email,
 		PasswordHash: passwordHash,
 		CreatedAt:    time.Now(),
 	}
+}
 
-	expectedClaims := providers.Claims{
-		UserID: userID.String(),
-		RegisteredClaims: jwt.RegisteredClaims{
-			Subject:   user.Email.String(),
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-		},
-	}
+const loginTestHash = "$2a$10$0MwrQkGO0Bw6dYpVfiX4mefEVgTdgtCYCJ7LxltXfzj5qscr4sive"
+
+// Claims esperadas para o usuário, com jti gerado a cada login
+func loginClaimsFor(user *entity.User) interface{} {
+	return mock.MatchedBy(func(c providers.Claims) bool {
+		return c.UserID == user.ID.String() &&
+			c.Subject == user.Email.String() &&
+			c.ID != "" &&
+			c.IssuedAt != nil &&
+			c.ExpiresAt != nil &&
+			c.ExpiresAt.Sub(c.IssuedAt.Time) == 24*time.Hour
+	})
+}
 
-	mockRepo.On("GetByEmail", mock.Anything, email).Return(user, nil)
-	mockCrypto.On("Compare", "valid-password", validHash).Return(true, nil)
-	mockToken.On("Generate", expectedClaims).Return("", errors.New("token generation error"))
+func TestLoginTokenGenerationFailure(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepo)
+	mockCrypto := new(mocks.MockCrypto)
+	mockToken := new(mocks.MockTokenProvider)
+	mockStore := new(mocks.MockTokenStore)
+
+	user := newLoginTestUser()
 
-	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockBlacklist)
+	mockRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockCrypto.On("Compare", mock.Anything, "valid-password", loginTestHash).Return(true, nil)
+	mockToken.On("Generate", mock.Anything, loginClaimsFor(user)).Return("", errors.New("token generation error"))
+
+	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockStore, 24*time.Hour)
 	_, err := handler.Execute(context.Background(), "user@test.com", "valid-password")
 
 	assert.Error(t, err)
@@ -229,211 +253,84 @@ func TestLoginTokenGenerationFailure(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 	mockCrypto.AssertExpectations(t)
 	mockToken.AssertExpectations(t)
-	mockBlacklist.AssertNotCalled(t, "SetWithKey")
+	mockStore.AssertNotCalled(t, "Register")
 }
 
 func TestLoginSuccessfully(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepo)
 	mockCrypto := new(mocks.MockCrypto)
 	mockToken := new(mocks.MockTokenProvider)
-	mockBlacklist := new(mocks.MockBlacklist)
-
-	generatedToken := "generated_token"
+	mockStore := new(mocks.MockTokenStore)
 
-	name, _ := vo.NewName("Test User", 0, 0)
-	email, _ := vo.NewEmail("user@test.com")
-	validHash := "$2a$10$0MwrQkGO0Bw6dYpVfiX4mefEVgTdgtCYCJ7LxltXfzj5qscr4sive"
-	passwordHash, _ := vo.NewPasswordHash(validHash)
-	userID := vo.NewID()
-	createdAt := time.Now()
+	user := newLoginTestUser()
 
-	user := &entity.User{
-		ID:           userID,
-		Name:         name,
-		Email:        email,
-		PasswordHash: passwordHash,
-		CreatedAt:    createdAt,
-	}
-
-	expectedClaims := providers.Claims{
-		UserID: userID.String(),
-		RegisteredClaims: jwt.RegisteredClaims{
-			Subject:   user.Email.String(),
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-		},
-	}
+	mockRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockCrypto.On("Compare", mock.Anything, "valid-password", loginTestHash).Return(true, nil)
+	mockToken.On("Generate", mock.Anything, loginClaimsFor(user)).Return("generated_token", nil)
+	mockStore.On("Register", mock.Anything, loginClaimsFor(user)).Return(nil)
 
-	mockRepo.On("GetByEmail", mock.Anything, email).Return(user, nil)
-	mockCrypto.On("Compare", "valid-password", validHash).Return(true, nil)
-	mockToken.On("Generate", expectedClaims).Return(generatedToken, nil)
-
-	// Expectativas para salvamento no Redis
-	prefix := "startup-auth-go"
-	mockBlacklist.On("SetWithKey", mock.Anything, prefix+":"+generatedToken+":UserID", userID.String(), 24*time.Hour).Return(nil)
-	mockBlacklist.On("SetWithKey", mock.Anything, prefix+":"+generatedToken+":Name", name.String(), 24*time.Hour).Return(nil)
-	mockBlacklist.On("SetWithKey", mock.Anything, prefix+":"+generatedToken+":Email", email.String(), 24*time.Hour).Return(nil)
-	mockBlacklist.On("SetWithKey", mock.Anything, prefix+":"+generatedToken+":Token", generatedToken, 24*time.Hour).Return(nil)
-	mockBlacklist.On("SetWithKey", mock.Anything, prefix+":"+generatedToken+":CreatedAt", createdAt.Format(time.RFC3339), 24*time.Hour).Return(nil)
-
-	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockBlacklist)
+	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockStore, 24*time.Hour)
 	result, err := handler.Execute(context.Background(), "user@test.com", "valid-password")
 
 	assert.NoError(t, err)
 	assert.Equal(t, user.ID, result.UserID)
 	assert.Equal(t, user.Name, result.Name)
 	assert.Equal(t, user.Email, result.Email)
+	assert.Equal(t, user.ImageURL, result.ImageURL)
+	assert.Equal(t, user.Locale, result.Locale)
 	assert.Equal(t, user.CreatedAt, result.CreatedAt)
 	assert.Equal(t, "generated_token", result.Token)
 
 	mockRepo.AssertExpectations(t)
 	mockCrypto.AssertExpectations(t)
 	mockToken.AssertExpectations(t)
-	mockBlacklist.AssertExpectations(t)
-}
-
-func TestLoginBlacklistAddFailure_UserID(t *testing.T) {
-	testRedisSaveFailure(t, "UserID", "failed to save UserID")
-}
-
-func TestLoginBlacklistAddFailure_Name(t *testing.T) {
-	testRedisSaveFailure(t, "Name", "failed to save Name")
-}
-
-func TestLoginBlacklistAddFailure_Email(t *testing.T) {
-	testRedisSaveFailure(t, "Email", "failed to save Email")
-}
-
-func TestLoginBlacklistAddFailure_Token(t *testing.T) {
-	testRedisSaveFailure(t, "Token", "failed to save Token")
-}
-
-func TestLoginBlacklistAddFailure_CreatedAt(t *testing.T) {
-	testRedisSaveFailure(t, "CreatedAt", "failed to save CreatedAt")
+	mockStore.AssertExpectations(t)
 }
 
-func testRedisSaveFailure(t *testing.T, field string, expectedError string) {
+func TestLoginUsesSameJTIForTokenAndStore(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepo)
 	mockCrypto := new(mocks.MockCrypto)
 	mockToken := new(mocks.MockTokenProvider)
-	mockBlacklist := new(mocks.MockBlacklist)
+	mockStore := new(mocks.MockTokenStore)
 
-	name, _ := vo.NewName("Test User", 0, 0)
-	email, _ := vo.NewEmail("user@test.com")
-	validHash := "$2a$10$0MwrQkGO0Bw6dYpVfiX4mefEVgTdgtCYCJ7LxltXfzj5qscr4sive"
-	passwordHash, _ := vo.NewPasswordHash(validHash)
-	userID := vo.NewID()
-	createdAt := time.Now()
+	user := newLoginTestUser()
 
-	user := &entity.User{
-		ID:           userID,
-		Name:         name,
-		Email:        email,
-		PasswordHash: passwordHash,
-		CreatedAt:    createdAt,
-	}
+	var generated, registered providers.Claims
+	mockRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockCrypto.On("Compare", mock.Anything, "valid-password", loginTestHash).Return(true, nil)
+	mockToken.On("Generate", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { generated = args.Get(1).(providers.Claims) }).
+		Return("generated_token", nil)
+	mockStore.On("Register", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { registered = args.Get(1).(providers.Claims) }).
+		Return(nil)
 
-	expectedClaims := providers.Claims{
-		UserID: userID.String(),
-		RegisteredClaims: jwt.RegisteredClaims{
-			Subject:   user.Email.String(),
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-		},
-	}
-
-	mockRepo.On("GetByEmail", mock.Anything, email).Return(user, nil)
-	mockCrypto.On("Compare", "valid-password", validHash).Return(true, nil)
-	generatedToken := "generated_token"
-	mockToken.On("Generate", expectedClaims).Return(generatedToken, nil)
-
-	// Configurar as expectativas para o Redis
-	prefix := "startup-auth-go"
-	redisErr := errors.New("redis error")
-
-	// Ordem dos campos conforme execução
-	fieldsOrder := []string{"UserID", "Name", "Email", "Token", "CreatedAt"}
-	calls := []*mock.Call{}
-
-	for _, f := range fieldsOrder {
-		key := prefix + ":" + generatedToken + ":" + f
-		if f == field {
-			call := mockBlacklist.On("SetWithKey", mock.Anything, key, mock.Anything, 24*time.Hour)
-			call.Return(redisErr)
-			calls = append(calls, call)
-			break // Para após o campo que causa erro
-		} else {
-			call := mockBlacklist.On("SetWithKey", mock.Anything, key, mock.Anything, 24*time.Hour)
-			call.Return(nil)
-			calls = append(calls, call)
-		}
-	}
-
-	// Garantir que as chamadas são verificadas na ordem
-	for _, call := range calls {
-		call.Once()
-	}
-
-	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockBlacklist)
+	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockStore, 24*time.Hour)
 	_, err := handler.Execute(context.Background(), "user@test.com", "valid-password")
 
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), expectedError)
-	assert.Contains(t, err.Error(), "redis error")
-
-	mockRepo.AssertExpectations(t)
-	mockCrypto.AssertExpectations(t)
-	mockToken.AssertExpectations(t)
-	mockBlacklist.AssertExpectations(t)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, generated.ID)
+	assert.Equal(t, generated.ID, registered.ID)
 }
 
-func TestLoginWithCreatedAtFormat(t *testing.T) {
+func TestLoginTokenStoreFailure(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepo)
 	mockCrypto := new(mocks.MockCrypto)
 	mockToken := new(mocks.MockTokenProvider)
-	mockBlacklist := new(mocks.MockBlacklist)
+	mockStore := new(mocks.MockTokenStore)
 
-	name, _ := vo.NewName("Test User", 0, 0)
-	email, _ := vo.NewEmail("user@test.com")
-	validHash := "$2a$10$0MwrQkGO0Bw6dYpVfiX4mefEVgTdgtCYCJ7LxltXfzj5qscr4sive"
-	passwordHash, _ := vo.NewPasswordHash(validHash)
-	userID := vo.NewID()
+	user := newLoginTestUser()
 
-	// Criar um tempo específico para testar a formatação
-	testTime := time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)
-	expectedFormat := testTime.Format(time.RFC3339)
+	mockRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockCrypto.On("Compare", mock.Anything, "valid-password", loginTestHash).Return(true, nil)
+	mockToken.On("Generate", mock.Anything, loginClaimsFor(user)).Return("generated_token", nil)
+	mockStore.On("Register", mock.Anything, loginClaimsFor(user)).Return(errors.New("redis error"))
 
-	user := &entity.User{
-		ID:           userID,
-		Name:         name,
-		Email:        email,
-		PasswordHash: passwordHash,
-		CreatedAt:    testTime,
-	}
-
-	expectedClaims := providers.Claims{
-		UserID: userID.String(),
-		RegisteredClaims: jwt.RegisteredClaims{
-			Subject:   user.Email.String(),
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-		},
-	}
-
-	mockRepo.On("GetByEmail", mock.Anything, email).Return(user, nil)
-	mockCrypto.On("Compare", "valid-password", validHash).Return(true, nil)
-	generatedToken := "generated_token"
-	mockToken.On("Generate", expectedClaims).Return(generatedToken, nil)
-
-	// Expectativas para salvamento no Redis
-	prefix := "startup-auth-go"
-	mockBlacklist.On("SetWithKey", mock.Anything, prefix+":"+generatedToken+":UserID", userID.String(), 24*time.Hour).Return(nil)
-	mockBlacklist.On("SetWithKey", mock.Anything, prefix+":"+generatedToken+":Name", name.String(), 24*time.Hour).Return(nil)
-	mockBlacklist.On("SetWithKey", mock.Anything, prefix+":"+generatedToken+":Email", email.String(), 24*time.Hour).Return(nil)
-	mockBlacklist.On("SetWithKey", mock.Anything, prefix+":"+generatedToken+":Token", generatedToken, 24*time.Hour).Return(nil)
-	mockBlacklist.On("SetWithKey", mock.Anything, prefix+":"+generatedToken+":CreatedAt", expectedFormat, 24*time.Hour).Return(nil)
-
-	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockBlacklist)
-	result, err := handler.Execute(context.Background(), "user@test.com", "valid-password")
+	handler := usecase.NewLoginUsecase(mockRepo, mockCrypto, mockToken, mockStore, 24*time.Hour)
+	_, err := handler.Execute(context.Background(), "user@test.com", "valid-password")
 
-	assert.NoError(t, err)
-	assert.Equal(t, testTime, result.CreatedAt)
-	mockBlacklist.AssertCalled(t, "SetWithKey", mock.Anything, prefix+":"+generatedToken+":CreatedAt", expectedFormat, 24*time.Hour)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to register token")
+	assert.Contains(t, err.Error(), "redis error")
+	mockStore.AssertExpectations(t)
 }
@@ -6,92 +6,78 @@ import (
 	"testing"
 
 	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
 	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestLogoutSuccess(t *testing.T) {
-	mockBlacklist := new(mocks.MockBlacklist)
-	logoutUsecase := usecase.NewLogoutUsecase(mockBlacklist)
+	mockToken := new(mocks.MockTokenProvider)
+	mockStore := new(mocks.MockTokenStore)
+	logoutUsecase := usecase.NewLogoutUsecase(mockToken, mockStore)
 
 	ctx := context.Background()
-	token := "valid_token"
-
-	// Definir as chaves que serão deletadas
-	expectedKeys := []string{
-		"startup-auth-go:valid_token:UserID",
-		"startup-auth-go:valid_token:Name",
-		"startup-auth-go:valid_token:Email",
-		"startup-auth-go:valid_token:Token",
-		"startup-auth-go:valid_token:CreatedAt",
+	claims := providers.Claims{
+		UserID:           "user-123",
+		RegisteredClaims: jwt.RegisteredClaims{ID: "jti-123"},
 	}
 
-	// Configurar o mock para retornar sucesso
-	mockBlacklist.On("Del", ctx, expectedKeys).Return(nil)
+	mockToken.On("Validate", mock.Anything, "valid_token").Return(claims, nil)
+	mockStore.On("Revoke", ctx, claims).Return(nil)
 
-	err := logoutUsecase.Execute(ctx, token)
+	err := logoutUsecase.Execute(ctx, "valid_token")
 
 	assert.NoError(t, err)
-	mockBlacklist.AssertExpectations(t)
+	mockToken.AssertExpectations(t)
+	mockStore.AssertExpectations(t)
 }
 
 func TestLogoutError(t *testing.T) {
-	mockBlacklist := new(mocks.MockBlacklist)
-	logoutUsecase := usecase.NewLogoutUsecase(mockBlacklist)
+	mockToken := new(mocks.MockTokenProvider)
+	mockStore := new(mocks.MockTokenStore)
+	logoutUsecase := usecase.NewLogoutUsecase(mockToken, mockStore)
 
 	ctx := context.Background()
-	token := "valid_token"
-	expectedErr := errors.New("redis error")
-
-	expectedKeys := []string{
-		"startup-auth-go:valid_token:UserID",
-		"startup-auth-go:valid_token:Name",
-		"startup-auth-go:valid_token:Email",
-		"startup-auth-go:valid_token:Token",
-		"startup-auth-go:valid_token:CreatedAt",
+	claims := providers.Claims{
+		UserID:           "user-123",
+		RegisteredClaims: jwt.RegisteredClaims{ID: "jti-123"},
 	}
 
-	mockBlacklist.On("Del", ctx, expectedKeys).Return(expectedErr)
+	mockToken.On("Validate", mock.Anything, "valid_token").Return(claims, nil)
+	mockStore.On("Revoke", ctx, claims).Return(errors.New("redis error"))
 
-	err := logoutUsecase.Execute(ctx, token)
+	err := logoutUsecase.Execute(ctx, "valid_token")
 
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "failed to remove user session data")
+	assert.Contains(t, err.Error(), "failed to revoke token")
 	assert.Contains(t, err.Error(), "redis error")
-	mockBlacklist.AssertExpectations(t)
+	mockStore.AssertExpectations(t)
 }
 
 func TestLogoutEmptyToken(t *testing.T) {
-	mockBlacklist := new(mocks.MockBlacklist)
-	logoutUsecase := usecase.NewLogoutUsecase(mockBlacklist)
+	mockToken := new(mocks.MockTokenProvider)
+	mockStore := new(mocks.MockTokenStore)
+	logoutUsecase := usecase.NewLogoutUsecase(mockToken, mockStore)
 
-	ctx := context.Background()
-	err := logoutUsecase.Execute(ctx, "")
+	err := logoutUsecase.Execute(context.Background(), "")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "token is required")
-	mockBlacklist.AssertNotCalled(t, "Del")
+	mockToken.AssertNotCalled(t, "Validate")
+	mockStore.AssertNotCalled(t, "Revoke")
 }
 
-func TestLogoutKeysDefinition(t *testing.T) {
-	mockBlacklist := new(mocks.MockBlacklist)
-	logoutUsecase := usecase.NewLogoutUsecase(mockBlacklist)
+func TestLogoutInvalidTokenIsNoop(t *testing.T) {
+	mockToken := new(mocks.MockTokenProvider)
+	mockStore := new(mocks.MockTokenStore)
+	logoutUsecase := usecase.NewLogoutUsecase(mockToken, mockStore)
 
-	ctx := context.Background()
-	token := "token123"
-
-	expectedKeys := []string{
-		"startup-auth-go:token123:UserID",
-		"startup-auth-go:token123:Name",
-		"startup-auth-go:token123:Email",
-		"startup-auth-go:token123:Token",
-		"startup-auth-go:token123:CreatedAt",
-	}
+	mockToken.On("Validate", mock.Anything, "expired_token").Return(nil, errors.New("token is expired"))
 
-	mockBlacklist.On("Del", ctx, expectedKeys).Return(nil)
+	err := logoutUsecase.Execute(context.Background(), "expired_token")
 
-	_ = logoutUsecase.Execute(ctx, token)
-
-	// Verifica se as chaves passadas são exatamente as esperadas
-	mockBlacklist.AssertCalled(t, "Del", ctx, expectedKeys)
+	assert.NoError(t, err)
+	mockStore.AssertNotCalled(t, "Revoke")
 }
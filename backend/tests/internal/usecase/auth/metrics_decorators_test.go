@@ -0,0 +1,71 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/metrics"
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestInstrumentedLogin(t *testing.T) {
+	ctx := context.Background()
+	m := metrics.New()
+	inner := new(mocks.MockLoginUseCase)
+	inner.On("Execute", ctx, "user@test.com", "secret123").Return(dto.LoginResult{Token: "tok"}, nil)
+	inner.On("Execute", ctx, "user@test.com", "wrong").Return(dto.LoginResult{}, msgerror.AnErrInvalidCredentials)
+	login := usecase.NewInstrumentedLogin(inner, m)
+
+	result, err := login.Execute(ctx, "user@test.com", "secret123")
+	assert.NoError(t, err)
+	assert.Equal(t, "tok", result.Token)
+	_, err = login.Execute(ctx, "user@test.com", "wrong")
+	assert.ErrorIs(t, err, msgerror.AnErrInvalidCredentials)
+	_, _ = login.Execute(ctx, "user@test.com", "wrong")
+
+	assert.Equal(t, 1.0, m.Logins.Value("success", "none"))
+	assert.Equal(t, 2.0, m.Logins.Value("failure", "invalid_credentials"))
+}
+
+func TestInstrumentedRegister(t *testing.T) {
+	ctx := context.Background()
+	m := metrics.New()
+	inner := new(mocks.MockRegisterUseCase)
+	inner.On("Execute", ctx, dto.RegisterParams{Email: "new@test.com"}).Return(nil)
+	inner.On("Execute", ctx, dto.RegisterParams{Email: "taken@test.com"}).Return(msgerror.AnErrUserExists)
+	register := usecase.NewInstrumentedRegister(inner, m)
+
+	assert.NoError(t, register.Execute(ctx, dto.RegisterParams{Email: "new@test.com"}))
+	assert.Error(t, register.Execute(ctx, dto.RegisterParams{Email: "taken@test.com"}))
+
+	assert.Equal(t, 1.0, m.Registrations.Value("success", "none"))
+	assert.Equal(t, 1.0, m.Registrations.Value("failure", msgerror.Code(msgerror.AnErrUserExists)))
+}
+
+func TestInstrumentedPasswordReset(t *testing.T) {
+	ctx := context.Background()
+	m := metrics.New()
+	email, _ := vo.NewEmail("user@test.com")
+
+	request := new(mocks.MockForgotPasswordUseCase)
+	request.On("Execute", ctx, email).Return(nil)
+	reset := new(mocks.MockResetPasswordUseCase)
+	reset.On("Execute", ctx, "expired", mock.Anything).Return(msgerror.AnErrExpiredToken)
+	reset.On("Execute", ctx, "broken", mock.Anything).Return(errors.New("db down"))
+
+	assert.NoError(t, usecase.NewInstrumentedRequestPasswordReset(request, m).Execute(ctx, email))
+	resetPassword := usecase.NewInstrumentedResetPassword(reset, m)
+	assert.Error(t, resetPassword.Execute(ctx, "expired", "new-secret"))
+	assert.Error(t, resetPassword.Execute(ctx, "broken", "new-secret"))
+
+	assert.Equal(t, 1.0, m.PasswordResets.Value("requested", "success", "none"))
+	assert.Equal(t, 1.0, m.PasswordResets.Value("completed", "failure", msgerror.Code(msgerror.AnErrExpiredToken)))
+	assert.Equal(t, 1.0, m.PasswordResets.Value("completed", "failure", "internal_error"))
+}
@@ -0,0 +1,93 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListOutboxMessagesUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("MapsFilterAndOutput", func(t *testing.T) {
+		repo := new(mocks.MockOutboxRepo)
+		sent, _ := entity.NewOutboxMessage(entity.OutboxTopicPasswordResetEmail, nil)
+		sent.MarkSent(time.Now())
+		dead, _ := entity.NewOutboxMessage(entity.OutboxTopicPasswordResetEmail, nil)
+		dead.MarkFailed(errors.New("smtp down"), time.Now(), 1)
+
+		repo.On("List", ctx, repository.OutboxFilter{Status: "dead", Limit: 10}).
+			Return([]*entity.OutboxMessage{sent, dead}, nil)
+
+		output, err := usecase.NewListOutboxMessagesUsecase(repo).Execute(ctx, dto.OutboxQueryParams{Status: "dead", Limit: 10})
+
+		require.NoError(t, err)
+		require.Len(t, output, 2)
+		assert.NotNil(t, output[0].SentAt)
+		assert.Nil(t, output[1].SentAt)
+		assert.Equal(t, "smtp down", output[1].LastError)
+	})
+
+	t.Run("RepositoryError", func(t *testing.T) {
+		repo := new(mocks.MockOutboxRepo)
+		repo.On("List", ctx, mock.Anything).Return(nil, errors.New("db down"))
+
+		_, err := usecase.NewListOutboxMessagesUsecase(repo).Execute(ctx, dto.OutboxQueryParams{})
+
+		assert.ErrorContains(t, err, "failed to list outbox messages")
+	})
+}
+
+func TestReplayOutboxMessageUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("RequeuesDeadMessage", func(t *testing.T) {
+		repo := new(mocks.MockOutboxRepo)
+		message, _ := entity.NewOutboxMessage(entity.OutboxTopicPasswordResetEmail, nil)
+		message.MarkFailed(errors.New("smtp down"), time.Now(), 1)
+
+		repo.On("GetByID", ctx, message.ID).Return(message, nil)
+		repo.On("Update", ctx, mock.MatchedBy(func(m *entity.OutboxMessage) bool {
+			return m.Status == entity.OutboxStatusPending && m.Attempts == 0 && m.LastError == ""
+		})).Return(nil)
+
+		output, err := usecase.NewReplayOutboxMessageUsecase(repo).Execute(ctx, message.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.OutboxStatusPending, output.Status)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		repo := new(mocks.MockOutboxRepo)
+		id := vo.NewID()
+		repo.On("GetByID", ctx, id).Return(nil, nil)
+
+		_, err := usecase.NewReplayOutboxMessageUsecase(repo).Execute(ctx, id)
+
+		assert.Equal(t, msgerror.AnErrOutboxNotFound, err)
+	})
+
+	t.Run("RejectsMessageThatIsNotDead", func(t *testing.T) {
+		repo := new(mocks.MockOutboxRepo)
+		message, _ := entity.NewOutboxMessage(entity.OutboxTopicPasswordResetEmail, nil)
+		repo.On("GetByID", ctx, message.ID).Return(message, nil)
+
+		_, err := usecase.NewReplayOutboxMessageUsecase(repo).Execute(ctx, message.ID)
+
+		assert.Equal(t, msgerror.AnErrOutboxNotDead, err)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
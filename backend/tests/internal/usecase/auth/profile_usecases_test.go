@@ -0,0 +1,201 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func profileUser(t *testing.T, userID vo.ID) *entity.User {
+	t.Helper()
+	name, _ := vo.NewName("Maria Silva", 3, 50)
+	email, _ := vo.NewEmail("maria@test.com")
+	locale, _ := vo.NewLocale("pt-BR")
+	attributes, err := vo.NewProfileAttributes(map[string]string{"company": "Acme"})
+	require.NoError(t, err)
+	return &entity.User{
+		ID:         userID,
+		Name:       name,
+		Email:      email,
+		Locale:     locale,
+		Attributes: attributes,
+		CreatedAt:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Version:    3,
+	}
+}
+
+func set(value string) dto.OptionalString {
+	return dto.OptionalString{Set: true, Value: value}
+}
+
+func TestGetProfileUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	userID := vo.NewID()
+
+	t.Run("Success - Full representation", func(t *testing.T) {
+		users := new(mocks.MockUserRepo)
+		users.On("GetByID", ctx, userID).Return(profileUser(t, userID), nil)
+
+		output, err := usecase.NewGetProfileUsecase(users).Execute(ctx, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, dto.UserOutput{
+			Id:         userID.String(),
+			Name:       "Maria Silva",
+			Email:      "maria@test.com",
+			Locale:     "pt-BR",
+			Attributes: map[string]string{"company": "Acme"},
+			CreatedAt:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		}, output)
+	})
+
+	t.Run("Error - User not found", func(t *testing.T) {
+		users := new(mocks.MockUserRepo)
+		users.On("GetByID", ctx, userID).Return(nil, nil)
+
+		_, err := usecase.NewGetProfileUsecase(users).Execute(ctx, userID)
+
+		assert.ErrorIs(t, err, msgerror.AnErrUserNotFound)
+	})
+
+	t.Run("Error - Repository failure", func(t *testing.T) {
+		users := new(mocks.MockUserRepo)
+		users.On("GetByID", ctx, userID).Return(nil, errors.New("db down"))
+
+		_, err := usecase.NewGetProfileUsecase(users).Execute(ctx, userID)
+
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, msgerror.AnErrUserNotFound)
+	})
+}
+
+func TestUpdateProfileUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	userID := vo.NewID()
+	dev := "Dev"
+
+	t.Run("Success - Only changed fields are written", func(t *testing.T) {
+		users := new(mocks.MockUserRepo)
+		users.On("GetByID", ctx, userID).Return(profileUser(t, userID), nil)
+		users.On("Update", ctx, mock.MatchedBy(func(u *entity.User) bool {
+			return u.Name.String() == "Maria Souza" &&
+				u.Timezone.String() == "America/Sao_Paulo" &&
+				u.Locale.String() == "pt-BR" &&
+				u.Attributes.Equal(mustAttributes(t, map[string]string{"job_title": "Dev"})) &&
+				u.Version == 3
+		}), []string{repository.UserFieldName, repository.UserFieldTimezone, repository.UserFieldAttributes}).
+			Return(func() *entity.User {
+				u := profileUser(t, userID)
+				u.Name, _ = vo.NewName("Maria Souza", 3, 50)
+				u.Timezone, _ = vo.NewTimezone("America/Sao_Paulo")
+				u.Attributes = mustAttributes(t, map[string]string{"job_title": "Dev"})
+				return u
+			}(), nil)
+
+		output, err := usecase.NewUpdateProfileUsecase(users).Execute(ctx, userID, dto.UpdateProfileInput{
+			Name:       set("Maria Souza"),
+			Locale:     set("pt-br"),
+			Timezone:   set("America/Sao_Paulo"),
+			Attributes: map[string]*string{"company": nil, "job_title": &dev},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Maria Souza", output.Name)
+		assert.Equal(t, "America/Sao_Paulo", output.Timezone)
+		assert.Equal(t, map[string]string{"job_title": "Dev"}, output.Attributes)
+		users.AssertExpectations(t)
+	})
+
+	t.Run("Success - Null clears optional fields", func(t *testing.T) {
+		users := new(mocks.MockUserRepo)
+		user := profileUser(t, userID)
+		user.ImageURL, _ = vo.NewURL("https://cdn.example.com/me.png")
+		users.On("GetByID", ctx, userID).Return(user, nil)
+		users.On("Update", ctx, mock.MatchedBy(func(u *entity.User) bool {
+			return u.ImageURL.IsEmpty() && u.Locale.IsEmpty()
+		}), []string{repository.UserFieldImageURL, repository.UserFieldLocale}).Return(user, nil)
+
+		_, err := usecase.NewUpdateProfileUsecase(users).Execute(ctx, userID, dto.UpdateProfileInput{
+			ImageURL: set(""),
+			Locale:   set(""),
+		})
+
+		require.NoError(t, err)
+		users.AssertExpectations(t)
+	})
+
+	t.Run("Success - Nothing changed skips the write", func(t *testing.T) {
+		users := new(mocks.MockUserRepo)
+		users.On("GetByID", ctx, userID).Return(profileUser(t, userID), nil)
+		company := "Acme"
+
+		output, err := usecase.NewUpdateProfileUsecase(users).Execute(ctx, userID, dto.UpdateProfileInput{
+			Name:       set("Maria Silva"),
+			Attributes: map[string]*string{"company": &company},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Maria Silva", output.Name)
+		users.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Every invalid field is reported", func(t *testing.T) {
+		users := new(mocks.MockUserRepo)
+		users.On("GetByID", ctx, userID).Return(profileUser(t, userID), nil)
+		bad := "x"
+
+		_, err := usecase.NewUpdateProfileUsecase(users).Execute(ctx, userID, dto.UpdateProfileInput{
+			Name:       set("Al"),
+			ImageURL:   set("ftp://example.com/me.png"),
+			Locale:     set("not a locale"),
+			Timezone:   set("Mars/Olympus"),
+			Attributes: map[string]*string{"Bad Key": &bad},
+		})
+
+		var valErr *msgerror.ValidationErrors
+		require.ErrorAs(t, err, &valErr)
+		assert.Len(t, valErr.FieldErrors, 5)
+		for _, field := range []string{"name", "image_url", "locale", "timezone", "attributes"} {
+			assert.Contains(t, valErr.FieldErrors, field)
+		}
+		users.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error - Concurrent modification", func(t *testing.T) {
+		users := new(mocks.MockUserRepo)
+		users.On("GetByID", ctx, userID).Return(profileUser(t, userID), nil)
+		users.On("Update", ctx, mock.Anything, []string{repository.UserFieldName}).Return(nil, msgerror.AnErrConflict)
+
+		_, err := usecase.NewUpdateProfileUsecase(users).Execute(ctx, userID, dto.UpdateProfileInput{Name: set("Maria Souza")})
+
+		assert.ErrorIs(t, err, msgerror.AnErrConflict)
+	})
+
+	t.Run("Error - User not found", func(t *testing.T) {
+		users := new(mocks.MockUserRepo)
+		users.On("GetByID", ctx, userID).Return(nil, msgerror.AnErrNotFound)
+
+		_, err := usecase.NewUpdateProfileUsecase(users).Execute(ctx, userID, dto.UpdateProfileInput{Name: set("Maria Souza")})
+
+		assert.ErrorIs(t, err, msgerror.AnErrUserNotFound)
+	})
+}
+
+func mustAttributes(t *testing.T, values map[string]string) vo.ProfileAttributes {
+	t.Helper()
+	attributes, err := vo.NewProfileAttributes(values)
+	require.NoError(t, err)
+	return attributes
+}
@@ -0,0 +1,341 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/database"
+	"github.com/eskokado/startup-auth-go/backend/internal/providers"
+	repository "github.com/eskokado/startup-auth-go/backend/internal/repositories"
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	repo "github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// anyOutbox aceita a remoção das mensagens de qualquer usuário.
+func anyOutbox() *mocks.MockOutboxRepo {
+	outbox := new(mocks.MockOutboxRepo)
+	outbox.On("DeleteByUserID", mock.Anything, mock.Anything).Return(nil)
+	return outbox
+}
+
+// anyExports aceita a remoção das exportações de qualquer usuário.
+func anyExports() *mocks.MockExportStore {
+	exports := new(mocks.MockExportStore)
+	exports.On("DeleteByOwner", mock.Anything, mock.Anything).Return(nil)
+	return exports
+}
+
+func TestPurgeAccountsUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	grace := 24 * time.Hour
+	opts := usecase.PurgeOptions{Grace: grace, AvatarURL: "https://api.test/avatars"}
+
+	newDeactivated := func(address string) *entity.User {
+		email, _ := vo.NewEmail(address)
+		user := &entity.User{ID: vo.NewID(), Email: email}
+		user.Deactivate(time.Now().Add(-48 * time.Hour))
+		return user
+	}
+
+	t.Run("ErasesUsersAndKeepsAnonymizedAudit", func(t *testing.T) {
+		users, keys := new(mocks.MockUserRepo), new(mocks.MockAPIKeyRepo)
+		auditRepo, logger := new(mocks.MockAuditRepo), new(mocks.MockAuditLogger)
+		outbox, auditFile := new(mocks.MockOutboxRepo), new(mocks.MockAuditEraser)
+		user := newDeactivated("maria@test.com")
+		user.PendingEmail, _ = vo.NewEmail("maria.silva@test.com")
+		subjects := []string{user.ID.String(), "maria@test.com", "maria.silva@test.com"}
+
+		var pseudonym string
+		users.On("ListDeactivatedBefore", ctx, mock.MatchedBy(func(before time.Time) bool {
+			return time.Since(before) >= grace
+		}), 100).Return([]*entity.User{user}, nil)
+		auditFile.On("Anonymize", ctx, subjects, mock.MatchedBy(func(p string) bool {
+			pseudonym = p
+			return p != user.ID.String()
+		})).Return(nil)
+		auditRepo.On("Anonymize", ctx, subjects, mock.MatchedBy(func(p string) bool { return p == pseudonym })).Return(nil)
+		outbox.On("DeleteByUserID", ctx, user.ID).Return(nil)
+		keys.On("DeleteByUserID", ctx, user.ID).Return(nil)
+		users.On("Erase", ctx, user.ID).Return(nil)
+		logger.On("Log", ctx, mock.MatchedBy(func(e *entity.AuditEvent) bool {
+			return e.Action == entity.AuditActionAccountErase &&
+				e.Outcome == entity.AuditOutcomeSuccess &&
+				e.Target == pseudonym
+		})).Return(nil)
+
+		erased, err := usecase.NewPurgeAccountsUsecase(
+			users, keys, auditRepo, outbox, mocks.NewMockTxManager(), new(mocks.MockBlobStore), anyExports(), auditFile, logger, opts,
+		).Execute(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, erased)
+		users.AssertExpectations(t)
+		keys.AssertExpectations(t)
+		auditRepo.AssertExpectations(t)
+		auditFile.AssertExpectations(t)
+		outbox.AssertExpectations(t)
+		logger.AssertExpectations(t)
+	})
+
+	t.Run("FailureDoesNotStopOtherUsers", func(t *testing.T) {
+		users, keys := new(mocks.MockUserRepo), new(mocks.MockAPIKeyRepo)
+		auditRepo, logger := new(mocks.MockAuditRepo), new(mocks.MockAuditLogger)
+		broken, ok := newDeactivated("broken@test.com"), newDeactivated("ok@test.com")
+		dbErr := errors.New("db down")
+
+		users.On("ListDeactivatedBefore", ctx, mock.Anything, 100).Return([]*entity.User{broken, ok}, nil)
+		auditRepo.On("Anonymize", ctx, []string{broken.ID.String(), "broken@test.com", ""}, mock.Anything).Return(dbErr)
+		auditRepo.On("Anonymize", ctx, []string{ok.ID.String(), "ok@test.com", ""}, mock.Anything).Return(nil)
+		keys.On("DeleteByUserID", ctx, ok.ID).Return(nil)
+		users.On("Erase", ctx, ok.ID).Return(nil)
+		logger.On("Log", ctx, mock.Anything).Return(nil)
+
+		erased, err := usecase.NewPurgeAccountsUsecase(users, keys, auditRepo, anyOutbox(), mocks.NewMockTxManager(), new(mocks.MockBlobStore), anyExports(), nil, logger, opts).Execute(ctx)
+
+		assert.ErrorIs(t, err, dbErr)
+		assert.Equal(t, 1, erased)
+		users.AssertNotCalled(t, "Erase", ctx, broken.ID)
+	})
+
+	t.Run("DeletesAvatarFiles", func(t *testing.T) {
+		users, keys := new(mocks.MockUserRepo), new(mocks.MockAPIKeyRepo)
+		auditRepo, logger := new(mocks.MockAuditRepo), new(mocks.MockAuditLogger)
+		blobs, err := providers.NewFileBlobStore(t.TempDir())
+		require.NoError(t, err)
+
+		avatarID := vo.NewID().String()
+		for _, size := range usecase.AvatarSizes {
+			require.NoError(t, blobs.Put(ctx, fmt.Sprintf("avatars/%s/%d.png", avatarID, size), []byte("png"), "image/png"))
+		}
+		user := newDeactivated("maria@test.com")
+		user.ImageURL, _ = vo.NewURL("https://api.test/avatars/" + avatarID)
+
+		users.On("ListDeactivatedBefore", ctx, mock.Anything, 100).Return([]*entity.User{user}, nil)
+		auditRepo.On("Anonymize", ctx, mock.Anything, mock.Anything).Return(nil)
+		keys.On("DeleteByUserID", ctx, user.ID).Return(nil)
+		users.On("Erase", ctx, user.ID).Return(nil)
+		logger.On("Log", ctx, mock.Anything).Return(nil)
+
+		erased, err := usecase.NewPurgeAccountsUsecase(users, keys, auditRepo, anyOutbox(), mocks.NewMockTxManager(), blobs, anyExports(), nil, logger, opts).Execute(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, erased)
+
+		// GET /avatars/:id deixa de servir todos os tamanhos
+		for _, size := range usecase.AvatarSizes {
+			_, err := usecase.NewGetAvatarUsecase(blobs).Execute(ctx, avatarID, size)
+			assert.ErrorIs(t, err, msgerror.AnErrAvatarNotFound, size)
+		}
+	})
+
+	t.Run("DeletesDataExports", func(t *testing.T) {
+		users, keys := new(mocks.MockUserRepo), new(mocks.MockAPIKeyRepo)
+		auditRepo, logger := new(mocks.MockAuditRepo), new(mocks.MockAuditLogger)
+		exports, err := providers.NewFileExportStore(t.TempDir())
+		require.NoError(t, err)
+		user := newDeactivated("maria@test.com")
+		owned := user.ID.String() + "." + vo.NewID().String() + ".zip"
+		other := vo.NewID().String() + "." + vo.NewID().String() + ".zip"
+		require.NoError(t, exports.Save(ctx, owned, []byte("export")))
+		require.NoError(t, exports.Save(ctx, other, []byte("export")))
+
+		users.On("ListDeactivatedBefore", ctx, mock.Anything, 100).Return([]*entity.User{user}, nil)
+		auditRepo.On("Anonymize", ctx, mock.Anything, mock.Anything).Return(nil)
+		keys.On("DeleteByUserID", ctx, user.ID).Return(nil)
+		users.On("Erase", ctx, user.ID).Return(nil)
+		logger.On("Log", ctx, mock.Anything).Return(nil)
+
+		_, err = usecase.NewPurgeAccountsUsecase(users, keys, auditRepo, anyOutbox(), mocks.NewMockTxManager(), new(mocks.MockBlobStore), exports, nil, logger, opts).Execute(ctx)
+		require.NoError(t, err)
+
+		_, err = exports.Load(ctx, owned)
+		assert.ErrorIs(t, err, msgerror.AnErrExportNotFound)
+		_, err = exports.Load(ctx, other)
+		assert.NoError(t, err)
+	})
+
+	t.Run("AvatarFailureKeepsUser", func(t *testing.T) {
+		users, keys := new(mocks.MockUserRepo), new(mocks.MockAPIKeyRepo)
+		auditRepo, logger := new(mocks.MockAuditRepo), new(mocks.MockAuditLogger)
+		blobs := new(mocks.MockBlobStore)
+		storeErr := errors.New("bucket unavailable")
+		user := newDeactivated("maria@test.com")
+		user.ImageURL, _ = vo.NewURL("https://api.test/avatars/" + vo.NewID().String())
+
+		users.On("ListDeactivatedBefore", ctx, mock.Anything, 100).Return([]*entity.User{user}, nil)
+		blobs.On("Delete", ctx, mock.Anything).Return(storeErr)
+		logger.On("Log", ctx, mock.Anything).Return(nil)
+
+		erased, err := usecase.NewPurgeAccountsUsecase(users, keys, auditRepo, anyOutbox(), mocks.NewMockTxManager(), blobs, anyExports(), nil, logger, opts).Execute(ctx)
+
+		assert.ErrorIs(t, err, storeErr)
+		assert.Zero(t, erased)
+		blobs.AssertNumberOfCalls(t, "Delete", len(usecase.AvatarSizes))
+		users.AssertNotCalled(t, "Erase", mock.Anything, mock.Anything)
+	})
+
+	t.Run("TransactionFailureKeepsUser", func(t *testing.T) {
+		users, keys := new(mocks.MockUserRepo), new(mocks.MockAPIKeyRepo)
+		auditRepo, logger := new(mocks.MockAuditRepo), new(mocks.MockAuditLogger)
+		tx := new(mocks.MockTxManager)
+		user := newDeactivated("maria@test.com")
+		txErr := errors.New("cannot begin")
+
+		users.On("ListDeactivatedBefore", ctx, mock.Anything, 100).Return([]*entity.User{user}, nil)
+		tx.On("WithinTx", ctx).Return(txErr)
+		logger.On("Log", ctx, mock.Anything).Return(nil)
+
+		erased, err := usecase.NewPurgeAccountsUsecase(users, keys, auditRepo, anyOutbox(), tx, new(mocks.MockBlobStore), anyExports(), nil, logger, opts).Execute(ctx)
+
+		assert.ErrorIs(t, err, txErr)
+		assert.Zero(t, erased)
+		users.AssertNotCalled(t, "Erase", mock.Anything, mock.Anything)
+	})
+}
+
+// Depois do apagamento nenhuma linha, arquivo ou evento de auditoria deve
+// levar de volta à pessoa.
+func TestPurgeAccountsUsecase_LeavesNoPersonalData(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	db, err := database.Open(database.Config{Driver: database.DriverSQLite, Name: filepath.Join(dir, "test.db")})
+	require.NoError(t, err)
+	migrator, err := database.NewMigrator(db)
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	users := repository.NewGormUserRepository(db)
+	keys := repository.NewGormAPIKeyRepository(db)
+	auditRepo := repository.NewGormAuditRepository(db)
+	outbox := repository.NewGormOutboxRepository(db)
+	blobs, err := providers.NewFileBlobStore(filepath.Join(dir, "blobs"))
+	require.NoError(t, err)
+	exports, err := providers.NewFileExportStore(filepath.Join(dir, "exports"))
+	require.NoError(t, err)
+	auditFile, err := providers.OpenJSONLinesAuditFile(filepath.Join(dir, "audit.jsonl"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = auditFile.Close() })
+	auditLogger := providers.NewMultiAuditLogger(auditRepo, auditFile)
+
+	name, _ := vo.NewName("Maria Silva", 3, 50)
+	email, _ := vo.NewEmail("maria@test.com")
+	pending, _ := vo.NewEmail("maria.silva@test.com")
+	hash, _ := vo.NewPasswordHash("$2a$10$0MwrQkGO0Bw6dYpVfiX4mefEVgTdgtCYCJ7LxltXfzj5qscr4sive")
+	avatarID := vo.NewID().String()
+	imageURL, _ := vo.NewURL("https://api.test/avatars/" + avatarID)
+	user, err := users.Save(ctx, &entity.User{
+		ID: vo.NewID(), Name: name, Email: email, PasswordHash: hash, ImageURL: imageURL,
+		PendingEmail: pending, EmailChangeToken: "confirm-token", EmailChangeExpires: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.NoError(t, users.Deactivate(ctx, user.ID, time.Now().Add(-48*time.Hour)))
+
+	keyName, _ := vo.NewName("ci key", 3, 50)
+	key, _, err := entity.NewAPIKey(user.ID, keyName, []string{entity.ScopeUserRead}, time.Time{})
+	require.NoError(t, err)
+	_, err = keys.Save(ctx, key)
+	require.NoError(t, err)
+
+	for _, size := range usecase.AvatarSizes {
+		require.NoError(t, blobs.Put(ctx, fmt.Sprintf("avatars/%s/%d.png", avatarID, size), []byte("png"), "image/png"))
+	}
+	require.NoError(t, exports.Save(ctx, user.ID.String()+"."+vo.NewID().String()+".json", []byte(`{"email":"maria@test.com"}`)))
+
+	confirmation, _ := entity.NewOutboxMessage(entity.OutboxTopicEmailChangeConfirmationEmail,
+		entity.EmailChangeConfirmationPayload{Email: pending.String(), Token: "confirm-token"})
+	confirmation.UserID = user.ID
+	require.NoError(t, outbox.Enqueue(ctx, confirmation))
+
+	// Eventos da pessoa, inclusive com um e-mail usado antes da troca
+	events := []struct{ action, actor, target, ip string }{
+		{entity.AuditActionLogin, user.ID.String(), "old@test.com", "198.51.100.1"},
+		{entity.AuditActionLogin, user.ID.String(), email.String(), "198.51.100.2"},
+		{entity.AuditActionLogin, "", "old@test.com", "198.51.100.3"},
+		{entity.AuditActionAccountDeactivate, user.ID.String(), user.ID.String(), "198.51.100.4"},
+	}
+	for _, e := range events {
+		event := entity.NewAuditEvent(e.action, nil)
+		event.ActorID, event.Target, event.IP, event.UserAgent = e.actor, e.target, e.ip, "Firefox/128.0"
+		require.NoError(t, auditLogger.Log(ctx, event))
+	}
+	other := entity.NewAuditEvent(entity.AuditActionLogin, nil)
+	other.Target, other.IP = "joao@test.com", "203.0.113.9"
+	require.NoError(t, auditLogger.Log(ctx, other))
+
+	erased, err := usecase.NewPurgeAccountsUsecase(
+		users, keys, auditRepo, outbox, repository.NewGormTxManager(db), blobs, exports, auditFile, auditLogger,
+		usecase.PurgeOptions{Grace: 24 * time.Hour, AvatarURL: "https://api.test/avatars"},
+	).Execute(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, erased)
+
+	personal := []string{user.ID.String(), email.String(), pending.String(), "old@test.com", "198.51.100.", "Firefox", avatarID, "confirm-token"}
+	assertClean := func(where, content string) {
+		for _, value := range personal {
+			assert.NotContains(t, content, value, where)
+		}
+	}
+
+	var tables []string
+	require.NoError(t, db.Raw("SELECT name FROM sqlite_master WHERE type = 'table'").Scan(&tables).Error)
+	for _, table := range tables {
+		rows, err := db.Raw("SELECT * FROM " + table).Rows()
+		require.NoError(t, err)
+		columns, err := rows.Columns()
+		require.NoError(t, err)
+		for rows.Next() {
+			values := make([]any, len(columns))
+			pointers := make([]any, len(columns))
+			for i := range values {
+				pointers[i] = &values[i]
+			}
+			require.NoError(t, rows.Scan(pointers...))
+			for i, value := range values {
+				if b, ok := value.([]byte); ok {
+					value = string(b)
+				}
+				assertClean(table+"."+columns[i], fmt.Sprint(value))
+			}
+		}
+		require.NoError(t, rows.Close())
+	}
+
+	require.NoError(t, filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) == ".db" {
+			return err
+		}
+		assertClean(path, path)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assertClean(path, string(data))
+		return nil
+	}))
+
+	// A auditoria continua lá, sob o pseudônimo; a de outras pessoas fica intacta
+	total, err := auditRepo.Count(ctx, repo.AuditFilter{})
+	require.NoError(t, err)
+	assert.EqualValues(t, len(events)+2, total)
+	kept, err := auditRepo.List(ctx, repo.AuditFilter{Target: "joao@test.com"})
+	require.NoError(t, err)
+	require.Len(t, kept, 1)
+	assert.Equal(t, "203.0.113.9", kept[0].IP)
+	logged, err := os.ReadFile(filepath.Join(dir, "audit.jsonl"))
+	require.NoError(t, err)
+	assert.Contains(t, string(logged), "203.0.113.9")
+	assert.Equal(t, int(total), strings.Count(string(logged), "\n"))
+}
@@ -171,8 +171,9 @@ func TestRegisterWithEncryptError(t *testing.T) {
 
 	email, _ := vo.NewEmail("test@test.com")
 	mockRepo.On("GetByEmail", mock.Anything, email).Return((*entity.User)(nil), msgerror.AnErrNotFound)
+	mockRepo.On("GetDeactivatedByEmail", mock.Anything, email).Return((*entity.User)(nil), nil)
 
-	mockCrypto.On("Encrypt", "valid-password").Return("", errors.New("encryption failed"))
+	mockCrypto.On("Encrypt", mock.Anything, "valid-password").Return("", errors.New("encryption failed"))
 
 	handler := usecase.NewRegisterUsecase(mockRepo, mockCrypto)
 	err := handler.Execute(context.Background(), dto.RegisterParams{
@@ -195,9 +196,10 @@ func TestRegisterWithPasswordHashError(t *testing.T) {
 
 	email, _ := vo.NewEmail("test@test.com")
 	mockRepo.On("GetByEmail", mock.Anything, email).Return((*entity.User)(nil), msgerror.AnErrNotFound)
+	mockRepo.On("GetDeactivatedByEmail", mock.Anything, email).Return((*entity.User)(nil), nil)
 
 	// Forçar erro na criação do PasswordHash
-	mockCrypto.On("Encrypt", "valid-password").Return("", errors.New("invalid hash format"))
+	mockCrypto.On("Encrypt", mock.Anything, "valid-password").Return("", errors.New("invalid hash format"))
 
 	handler := usecase.NewRegisterUsecase(mockRepo, mockCrypto)
 	err := handler.Execute(context.Background(), dto.RegisterParams{
@@ -221,9 +223,10 @@ func TestRegisterWithEmptyPasswordHash(t *testing.T) {
 
 	email, _ := vo.NewEmail("test@test.com")
 	mockRepo.On("GetByEmail", mock.Anything, email).Return((*entity.User)(nil), msgerror.AnErrNotFound)
+	mockRepo.On("GetDeactivatedByEmail", mock.Anything, email).Return((*entity.User)(nil), nil)
 
 	// Simular situação onde o Encrypt retorna string vazia sem erro
-	mockCrypto.On("Encrypt", "valid-password").Return("", nil)
+	mockCrypto.On("Encrypt", mock.Anything, "valid-password").Return("", nil)
 
 	handler := usecase.NewRegisterUsecase(mockRepo, mockCrypto)
 	err := handler.Execute(context.Background(), dto.RegisterParams{
@@ -247,8 +250,9 @@ func TestRegisterWithSaveError(t *testing.T) {
 
 	email, _ := vo.NewEmail("test@test.com")
 	mockRepo.On("GetByEmail", mock.Anything, email).Return((*entity.User)(nil), msgerror.AnErrNotFound)
+	mockRepo.On("GetDeactivatedByEmail", mock.Anything, email).Return((*entity.User)(nil), nil)
 
-	mockCrypto.On("Encrypt", "valid-password").Return("hashed-password", nil)
+	mockCrypto.On("Encrypt", mock.Anything, "valid-password").Return("hashed-password", nil)
 	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
 
 	handler := usecase.NewRegisterUsecase(mockRepo, mockCrypto)
@@ -278,7 +282,8 @@ func TestRegisterWhenSaveReturnsNilUser(t *testing.T) {
 	// Configurar mocks para fluxo completo
 	email, _ := vo.NewEmail(validEmail)
 	mockRepo.On("GetByEmail", mock.Anything, email).Return((*entity.User)(nil), msgerror.AnErrNotFound)
-	mockCrypto.On("Encrypt", validPassword).Return("hashed-password", nil)
+	mockRepo.On("GetDeactivatedByEmail", mock.Anything, email).Return((*entity.User)(nil), nil)
+	mockCrypto.On("Encrypt", mock.Anything, validPassword).Return("hashed-password", nil)
 	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil, nil) // Simular retorno nil do Save
 
 	handler := usecase.NewRegisterUsecase(mockRepo, mockCrypto)
@@ -345,7 +350,8 @@ func TestRegisterWithValidImageURL(t *testing.T) {
 	validURL, _ := vo.NewURL("https://example.com/image.jpg")
 
 	mockRepo.On("GetByEmail", mock.Anything, email).Return((*entity.User)(nil), msgerror.AnErrNotFound)
-	mockCrypto.On("Encrypt", "valid-password").Return("hashed-password", nil)
+	mockRepo.On("GetDeactivatedByEmail", mock.Anything, email).Return((*entity.User)(nil), nil)
+	mockCrypto.On("Encrypt", mock.Anything, "valid-password").Return("hashed-password", nil)
 
 	newUser := &entity.User{
 		ID:           vo.NewID(),
@@ -381,7 +387,8 @@ func TestRegisterWithEmptyImageURL(t *testing.T) {
 	email, _ := vo.NewEmail("new@test.com")
 
 	mockRepo.On("GetByEmail", mock.Anything, email).Return((*entity.User)(nil), msgerror.AnErrNotFound)
-	mockCrypto.On("Encrypt", "valid-password").Return("hashed-password", nil)
+	mockRepo.On("GetDeactivatedByEmail", mock.Anything, email).Return((*entity.User)(nil), nil)
+	mockCrypto.On("Encrypt", mock.Anything, "valid-password").Return("hashed-password", nil)
 
 	newUser := &entity.User{
 		ID:           vo.NewID(),
@@ -453,7 +460,8 @@ func TestRegisterWithValidDataButCryptoError(t *testing.T) {
 
 	email, _ := vo.NewEmail("test@test.com")
 	mockRepo.On("GetByEmail", mock.Anything, email).Return((*entity.User)(nil), msgerror.AnErrNotFound)
-	mockCrypto.On("Encrypt", "valid-password").Return("", errors.New("crypto error"))
+	mockRepo.On("GetDeactivatedByEmail", mock.Anything, email).Return((*entity.User)(nil), nil)
+	mockCrypto.On("Encrypt", mock.Anything, "valid-password").Return("", errors.New("crypto error"))
 
 	handler := usecase.NewRegisterUsecase(mockRepo, mockCrypto)
 	err := handler.Execute(context.Background(), dto.RegisterParams{
@@ -469,3 +477,25 @@ func TestRegisterWithValidDataButCryptoError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 	mockCrypto.AssertExpectations(t)
 }
+
+func TestRegisterWithEmailOfDeactivatedAccount(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepo)
+	mockCrypto := new(mocks.MockCrypto)
+	email, _ := vo.NewEmail("test@test.com")
+
+	mockRepo.On("GetByEmail", mock.Anything, email).Return((*entity.User)(nil), nil)
+	mockRepo.On("GetDeactivatedByEmail", mock.Anything, email).Return(&entity.User{}, nil)
+
+	uc := usecase.NewRegisterUsecase(mockRepo, mockCrypto)
+	err := uc.Execute(context.Background(), dto.RegisterParams{
+		Name:                 "Valid Name",
+		Email:                "test@test.com",
+		Password:             "password123",
+		PasswordConfirmation: "password123",
+	})
+
+	var validationErr *msgerror.ValidationErrors
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, msgerror.AnErrUserExists.Error(), validationErr.FieldErrors["email"])
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
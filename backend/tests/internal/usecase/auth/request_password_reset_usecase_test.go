@@ -2,11 +2,14 @@ package usecase_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
 	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
 	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
@@ -20,11 +23,11 @@ func TestRequestPasswordResetUsecase_Execute(t *testing.T) {
 
 	t.Run("should return nil when user not found", func(t *testing.T) {
 		userRepo := new(mocks.MockUserRepo)
-		emailService := new(mocks.MockEmailService)
+		outboxRepo := new(mocks.MockOutboxRepo)
 
 		userRepo.On("GetByEmail", ctx, validEmail).Return((*entity.User)(nil), msgerror.AnErrNotFound)
 
-		uc := usecase.NewRequestPasswordReset(userRepo, emailService)
+		uc := usecase.NewRequestPasswordReset(userRepo, outboxRepo, mocks.NewMockTxManager(), time.Hour)
 		err := uc.Execute(ctx, validEmail)
 
 		assert.NoError(t, err)
@@ -32,53 +35,82 @@ func TestRequestPasswordResetUsecase_Execute(t *testing.T) {
 
 	t.Run("should handle save error", func(t *testing.T) {
 		userRepo := new(mocks.MockUserRepo)
-		emailService := new(mocks.MockEmailService)
+		outboxRepo := new(mocks.MockOutboxRepo)
 		user := &entity.User{}
 
 		userRepo.On("GetByEmail", ctx, validEmail).Return(user, nil)
-		userRepo.On("Save", ctx, user).Return(user, assert.AnError)
+		userRepo.On("Update", ctx, user, []string{repository.UserFieldPasswordReset}).Return(user, assert.AnError)
 
-		uc := usecase.NewRequestPasswordReset(userRepo, emailService)
+		uc := usecase.NewRequestPasswordReset(userRepo, outboxRepo, mocks.NewMockTxManager(), time.Hour)
 		err := uc.Execute(ctx, validEmail)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to save user")
 	})
 
-	t.Run("should send reset email successfully", func(t *testing.T) {
+	t.Run("should enqueue reset email", func(t *testing.T) {
 		userRepo := new(mocks.MockUserRepo)
-		emailService := new(mocks.MockEmailService)
-		user := &entity.User{}
+		outboxRepo := new(mocks.MockOutboxRepo)
+		locale, _ := vo.NewLocale("en-US")
+		user := &entity.User{ID: vo.NewID(), Email: validEmail, Locale: locale}
 
 		userRepo.On("GetByEmail", ctx, validEmail).Return(user, nil)
-		userRepo.On("Save", ctx, user).Return(user, nil)
-		emailService.On("SendResetPasswordEmail", mock.Anything, mock.Anything).Return(nil)
-
-		uc := usecase.NewRequestPasswordReset(userRepo, emailService)
+		userRepo.On("Update", ctx, user, []string{repository.UserFieldPasswordReset}).Return(user, nil)
+		outboxRepo.On("Enqueue", ctx, mock.MatchedBy(func(m *entity.OutboxMessage) bool {
+			var payload entity.PasswordResetEmailPayload
+			if err := json.Unmarshal(m.Payload, &payload); err != nil {
+				return false
+			}
+			return m.Topic == entity.OutboxTopicPasswordResetEmail &&
+				m.Status == entity.OutboxStatusPending &&
+				m.UserID == user.ID &&
+				payload.Email == validEmail.String() &&
+				payload.Token == user.PasswordResetToken &&
+				payload.Locale == "en-US"
+		})).Return(nil)
+
+		uc := usecase.NewRequestPasswordReset(userRepo, outboxRepo, mocks.NewMockTxManager(), time.Hour)
 		err := uc.Execute(ctx, validEmail)
 
 		assert.NoError(t, err)
+		outboxRepo.AssertExpectations(t)
 	})
 
-	t.Run("should return error when email sending fails", func(t *testing.T) {
+	t.Run("should return error when enqueue fails", func(t *testing.T) {
 		userRepo := new(mocks.MockUserRepo)
-		emailService := new(mocks.MockEmailService)
+		outboxRepo := new(mocks.MockOutboxRepo)
 		user := &entity.User{}
 
 		userRepo.On("GetByEmail", ctx, validEmail).Return(user, nil)
-		userRepo.On("Save", ctx, user).Return(user, nil)
-		emailService.On("SendResetPasswordEmail", mock.Anything, mock.Anything).Return(assert.AnError)
+		userRepo.On("Update", ctx, user, []string{repository.UserFieldPasswordReset}).Return(user, nil)
+		outboxRepo.On("Enqueue", ctx, mock.Anything).Return(assert.AnError)
 
-		uc := usecase.NewRequestPasswordReset(userRepo, emailService)
+		uc := usecase.NewRequestPasswordReset(userRepo, outboxRepo, mocks.NewMockTxManager(), time.Hour)
 		err := uc.Execute(ctx, validEmail)
 
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to send reset email")
+		assert.Contains(t, err.Error(), "failed to enqueue reset email")
+	})
+
+	t.Run("should not enqueue when transaction cannot start", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepo)
+		outboxRepo := new(mocks.MockOutboxRepo)
+		tx := new(mocks.MockTxManager)
+		user := &entity.User{}
+
+		userRepo.On("GetByEmail", ctx, validEmail).Return(user, nil)
+		tx.On("WithinTx", ctx).Return(assert.AnError)
+
+		uc := usecase.NewRequestPasswordReset(userRepo, outboxRepo, tx, time.Hour)
+		err := uc.Execute(ctx, validEmail)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		outboxRepo.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when token generation fails", func(t *testing.T) {
 		userRepo := new(mocks.MockUserRepo)
-		emailService := new(mocks.MockEmailService)
+		outboxRepo := new(mocks.MockOutboxRepo)
 		user := &entity.User{}
 
 		// Mock falha na geração de token
@@ -90,7 +122,7 @@ func TestRequestPasswordResetUsecase_Execute(t *testing.T) {
 
 		userRepo.On("GetByEmail", ctx, validEmail).Return(user, nil)
 
-		uc := usecase.NewRequestPasswordReset(userRepo, emailService)
+		uc := usecase.NewRequestPasswordReset(userRepo, outboxRepo, mocks.NewMockTxManager(), time.Hour)
 		err := uc.Execute(ctx, validEmail)
 
 		assert.Error(t, err)
@@ -99,13 +131,13 @@ func TestRequestPasswordResetUsecase_Execute(t *testing.T) {
 
 	t.Run("should return error when repository fails", func(t *testing.T) {
 		userRepo := new(mocks.MockUserRepo)
-		emailService := new(mocks.MockEmailService)
+		outboxRepo := new(mocks.MockOutboxRepo)
 
 		// Simular um erro de repositório (diferente de AnErrNotFound)
 		expectedErr := errors.New("database connection failed")
 		userRepo.On("GetByEmail", ctx, validEmail).Return((*entity.User)(nil), expectedErr)
 
-		uc := usecase.NewRequestPasswordReset(userRepo, emailService)
+		uc := usecase.NewRequestPasswordReset(userRepo, outboxRepo, mocks.NewMockTxManager(), time.Hour)
 		err := uc.Execute(ctx, validEmail)
 
 		assert.Error(t, err)
@@ -8,6 +8,7 @@ import (
 
 	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
 	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
 	mocks "github.com/eskokado/startup-auth-go/backend/tests/mocks"
 	"github.com/stretchr/testify/assert"
@@ -41,7 +42,7 @@ func TestResetPasswordUsecase_Execute(t *testing.T) {
 
 		err := uc.Execute(ctx, "invalid-token", validPassword)
 
-		assert.ErrorContains(t, err, "falha ao buscar usuário pelo token")
+		assert.ErrorContains(t, err, "failed to get user by token")
 		assert.ErrorIs(t, err, expectedErr)
 	})
 
@@ -87,7 +88,7 @@ func TestResetPasswordUsecase_Execute(t *testing.T) {
 		}
 
 		userRepo.On("GetByResetToken", ctx, validToken).Return(user, nil)
-		userRepo.On("Save", ctx, user).Return(user, nil)
+		userRepo.On("Update", ctx, user, []string{repository.UserFieldPasswordHash, repository.UserFieldPasswordReset}).Return(user, nil)
 
 		err := uc.Execute(ctx, validToken, validPassword)
 
@@ -108,13 +109,30 @@ func TestResetPasswordUsecase_Execute(t *testing.T) {
 
 		expectedErr := errors.New("save failed")
 		userRepo.On("GetByResetToken", ctx, validToken).Return(user, nil)
-		userRepo.On("Save", ctx, user).Return(user, expectedErr)
+		userRepo.On("Update", ctx, user, []string{repository.UserFieldPasswordHash, repository.UserFieldPasswordReset}).Return(user, expectedErr)
 
 		err := uc.Execute(ctx, validToken, validPassword)
 
-		assert.ErrorContains(t, err, "falha ao salvar usuário")
+		assert.ErrorContains(t, err, "failed to save user")
 		assert.ErrorIs(t, err, expectedErr)
 	})
+
+	t.Run("should return conflict unwrapped", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepo)
+		uc := usecase.NewResetPassword(userRepo)
+
+		user := &entity.User{
+			PasswordResetExpires: time.Now().Add(1 * time.Hour),
+		}
+
+		userRepo.On("GetByResetToken", ctx, validToken).Return(user, nil)
+		userRepo.On("Update", ctx, user, []string{repository.UserFieldPasswordHash, repository.UserFieldPasswordReset}).
+			Return(nil, msgerror.AnErrConflict)
+
+		err := uc.Execute(ctx, validToken, validPassword)
+
+		assert.Equal(t, msgerror.AnErrConflict, err)
+	})
 }
 
 // Helpers para simular falhas no vo.NewPasswordHash
@@ -0,0 +1,79 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRestoreAccountUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	email, _ := vo.NewEmail("maria@test.com")
+	hash, _ := vo.NewPasswordHash("valid_hash")
+	grace := 48 * time.Hour
+
+	deactivated := func(at time.Time) *entity.User {
+		user := &entity.User{ID: vo.NewID(), Email: email, PasswordHash: hash}
+		user.Deactivate(at)
+		return user
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		users, crypto := new(mocks.MockUserRepo), new(mocks.MockCrypto)
+		user := deactivated(time.Now().Add(-time.Hour))
+
+		users.On("GetDeactivatedByEmail", ctx, email).Return(user, nil)
+		crypto.On("Compare", mock.Anything, "secret123", hash.String()).Return(true, nil)
+		users.On("Restore", ctx, user.ID).Return(nil)
+
+		id, err := usecase.NewRestoreAccountUsecase(users, crypto, grace).Execute(ctx, "maria@test.com", "secret123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, id)
+		users.AssertExpectations(t)
+	})
+
+	t.Run("NoDeactivatedAccount", func(t *testing.T) {
+		users, crypto := new(mocks.MockUserRepo), new(mocks.MockCrypto)
+
+		users.On("GetDeactivatedByEmail", ctx, email).Return(nil, nil)
+
+		_, err := usecase.NewRestoreAccountUsecase(users, crypto, grace).Execute(ctx, "maria@test.com", "secret123")
+
+		assert.ErrorIs(t, err, msgerror.AnErrInvalidCredentials)
+	})
+
+	t.Run("WrongPassword", func(t *testing.T) {
+		users, crypto := new(mocks.MockUserRepo), new(mocks.MockCrypto)
+		user := deactivated(time.Now().Add(-time.Hour))
+
+		users.On("GetDeactivatedByEmail", ctx, email).Return(user, nil)
+		crypto.On("Compare", mock.Anything, "wrong", hash.String()).Return(false, nil)
+
+		_, err := usecase.NewRestoreAccountUsecase(users, crypto, grace).Execute(ctx, "maria@test.com", "wrong")
+
+		assert.ErrorIs(t, err, msgerror.AnErrInvalidCredentials)
+		users.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+
+	t.Run("GracePeriodExpired", func(t *testing.T) {
+		users, crypto := new(mocks.MockUserRepo), new(mocks.MockCrypto)
+		user := deactivated(time.Now().Add(-72 * time.Hour))
+
+		users.On("GetDeactivatedByEmail", ctx, email).Return(user, nil)
+		crypto.On("Compare", mock.Anything, "secret123", hash.String()).Return(true, nil)
+
+		_, err := usecase.NewRestoreAccountUsecase(users, crypto, grace).Execute(ctx, "maria@test.com", "secret123")
+
+		assert.ErrorIs(t, err, msgerror.AnErrInvalidCredentials)
+		users.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,71 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRevokeAPIKeyUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	userID := vo.NewID()
+	keyID := vo.NewID()
+
+	t.Run("should revoke own key", func(t *testing.T) {
+		key := &entity.APIKey{ID: keyID, UserID: userID}
+
+		repo := new(mocks.MockAPIKeyRepo)
+		repo.On("GetByID", ctx, keyID).Return(key, nil)
+		repo.On("Save", ctx, mock.MatchedBy(func(k *entity.APIKey) bool {
+			return !k.RevokedAt.IsZero()
+		})).Return(key, nil)
+
+		uc := usecase.NewRevokeAPIKeyUsecase(repo)
+		err := uc.Execute(ctx, userID, keyID)
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should not reveal keys of other users", func(t *testing.T) {
+		key := &entity.APIKey{ID: keyID, UserID: vo.NewID()}
+
+		repo := new(mocks.MockAPIKeyRepo)
+		repo.On("GetByID", ctx, keyID).Return(key, nil)
+
+		uc := usecase.NewRevokeAPIKeyUsecase(repo)
+		err := uc.Execute(ctx, userID, keyID)
+
+		assert.ErrorIs(t, err, msgerror.AnErrAPIKeyNotFound)
+		repo.AssertNotCalled(t, "Save")
+	})
+
+	t.Run("should return not found for missing key", func(t *testing.T) {
+		repo := new(mocks.MockAPIKeyRepo)
+		repo.On("GetByID", ctx, keyID).Return(nil, nil)
+
+		uc := usecase.NewRevokeAPIKeyUsecase(repo)
+		err := uc.Execute(ctx, userID, keyID)
+
+		assert.ErrorIs(t, err, msgerror.AnErrAPIKeyNotFound)
+	})
+
+	t.Run("should wrap repository error", func(t *testing.T) {
+		repo := new(mocks.MockAPIKeyRepo)
+		repo.On("GetByID", ctx, keyID).Return(nil, errors.New("db error"))
+
+		uc := usecase.NewRevokeAPIKeyUsecase(repo)
+		err := uc.Execute(ctx, userID, keyID)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get api key")
+	})
+}
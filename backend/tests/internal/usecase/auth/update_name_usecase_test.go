@@ -7,6 +7,7 @@ import (
 
 	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
 	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
 	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
@@ -30,7 +31,7 @@ func TestUpdateNameUseCase_Execute(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockRepo := new(mocks.MockUserRepo)
 		mockRepo.On("GetByID", ctx, validUserID).Return(validUser, nil)
-		mockRepo.On("Save", ctx, mock.Anything).Return(validUser, nil)
+		mockRepo.On("Update", ctx, mock.Anything, []string{repository.UserFieldName}).Return(validUser, nil)
 
 		uc := usecase.NewUpdateNameUseCase(mockRepo)
 		err := uc.Execute(ctx, validUserID, "Novo Nome Diferente")
@@ -87,7 +88,7 @@ func TestUpdateNameUseCase_Execute(t *testing.T) {
 	t.Run("SaveError", func(t *testing.T) {
 		mockRepo := new(mocks.MockUserRepo)
 		mockRepo.On("GetByID", ctx, validUserID).Return(validUser, nil)
-		mockRepo.On("Save", ctx, mock.Anything).Return((*entity.User)(nil), errors.New("save failed"))
+		mockRepo.On("Update", ctx, mock.Anything, []string{repository.UserFieldName}).Return((*entity.User)(nil), errors.New("save failed"))
 
 		uc := usecase.NewUpdateNameUseCase(mockRepo)
 		err := uc.Execute(ctx, validUserID, "Valid Name")
@@ -96,6 +97,18 @@ func TestUpdateNameUseCase_Execute(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Conflict", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepo)
+		mockRepo.On("GetByID", ctx, validUserID).Return(validUser, nil)
+		mockRepo.On("Update", ctx, mock.Anything, []string{repository.UserFieldName}).Return((*entity.User)(nil), msgerror.AnErrConflict)
+
+		uc := usecase.NewUpdateNameUseCase(mockRepo)
+		err := uc.Execute(ctx, validUserID, "Valid Name")
+
+		assert.Equal(t, msgerror.AnErrConflict, err)
+		mockRepo.AssertExpectations(t)
+	})
+
 	t.Run("SameName", func(t *testing.T) {
 		mockRepo := new(mocks.MockUserRepo)
 		mockRepo.On("GetByID", ctx, validUserID).Return(userWithName, nil)
@@ -104,6 +117,6 @@ func TestUpdateNameUseCase_Execute(t *testing.T) {
 		err := uc.Execute(ctx, validUserID, oldName) // Mesmo nome atual
 
 		assert.ErrorIs(t, err, msgerror.AnErrNameDifferent)
-		mockRepo.AssertNotCalled(t, "Save")
+		mockRepo.AssertNotCalled(t, "Update")
 	})
 }
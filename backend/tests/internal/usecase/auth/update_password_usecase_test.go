@@ -7,6 +7,7 @@ import (
 
 	usecase "github.com/eskokado/startup-auth-go/backend/internal/usecase/auth"
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
 	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
 	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
@@ -28,10 +29,10 @@ func TestUpdatePasswordUseCase_Execute(t *testing.T) {
 		mockCrypto := new(mocks.MockCrypto)
 
 		mockRepo.On("GetByID", ctx, validUserID).Return(validUser, nil)
-		mockCrypto.On("Compare", "current_password", currentHash.String()).Return(true, nil)
-		mockCrypto.On("Compare", "new_password", currentHash.String()).Return(false, nil)
-		mockCrypto.On("Encrypt", "new_password").Return("new_hash", nil)
-		mockRepo.On("Save", ctx, mock.Anything).Return(validUser, nil)
+		mockCrypto.On("Compare", mock.Anything, "current_password", currentHash.String()).Return(true, nil)
+		mockCrypto.On("Compare", mock.Anything, "new_password", currentHash.String()).Return(false, nil)
+		mockCrypto.On("Encrypt", mock.Anything, "new_password").Return("new_hash", nil)
+		mockRepo.On("Update", ctx, mock.Anything, []string{repository.UserFieldPasswordHash}).Return(validUser, nil)
 
 		uc := usecase.NewUpdatePasswordUseCase(mockRepo, mockCrypto)
 		err := uc.Execute(ctx, validUserID, "current_password", "new_password")
@@ -59,7 +60,7 @@ func TestUpdatePasswordUseCase_Execute(t *testing.T) {
 		mockCrypto := new(mocks.MockCrypto)
 
 		mockRepo.On("GetByID", ctx, validUserID).Return(validUser, nil)
-		mockCrypto.On("Compare", "wrong_password", currentHash.String()).Return(false, nil)
+		mockCrypto.On("Compare", mock.Anything, "wrong_password", currentHash.String()).Return(false, nil)
 
 		uc := usecase.NewUpdatePasswordUseCase(mockRepo, mockCrypto)
 		err := uc.Execute(ctx, validUserID, "wrong_password", "new_password")
@@ -74,8 +75,8 @@ func TestUpdatePasswordUseCase_Execute(t *testing.T) {
 		mockCrypto := new(mocks.MockCrypto)
 
 		mockRepo.On("GetByID", ctx, validUserID).Return(validUser, nil)
-		mockCrypto.On("Compare", "current_password", currentHash.String()).Return(true, nil)
-		mockCrypto.On("Compare", "current_password", currentHash.String()).Return(true, nil)
+		mockCrypto.On("Compare", mock.Anything, "current_password", currentHash.String()).Return(true, nil)
+		mockCrypto.On("Compare", mock.Anything, "current_password", currentHash.String()).Return(true, nil)
 
 		uc := usecase.NewUpdatePasswordUseCase(mockRepo, mockCrypto)
 		err := uc.Execute(ctx, validUserID, "current_password", "current_password")
@@ -90,9 +91,9 @@ func TestUpdatePasswordUseCase_Execute(t *testing.T) {
 		mockCrypto := new(mocks.MockCrypto)
 
 		mockRepo.On("GetByID", ctx, validUserID).Return(validUser, nil)
-		mockCrypto.On("Compare", "current_password", currentHash.String()).Return(true, nil)
-		mockCrypto.On("Compare", "new_password", currentHash.String()).Return(false, nil)
-		mockCrypto.On("Encrypt", "new_password").Return("", errors.New("encryption failed"))
+		mockCrypto.On("Compare", mock.Anything, "current_password", currentHash.String()).Return(true, nil)
+		mockCrypto.On("Compare", mock.Anything, "new_password", currentHash.String()).Return(false, nil)
+		mockCrypto.On("Encrypt", mock.Anything, "new_password").Return("", errors.New("encryption failed"))
 
 		uc := usecase.NewUpdatePasswordUseCase(mockRepo, mockCrypto)
 		err := uc.Execute(ctx, validUserID, "current_password", "new_password")
@@ -107,9 +108,9 @@ func TestUpdatePasswordUseCase_Execute(t *testing.T) {
 		mockCrypto := new(mocks.MockCrypto)
 
 		mockRepo.On("GetByID", ctx, validUserID).Return(validUser, nil)
-		mockCrypto.On("Compare", "current_password", currentHash.String()).Return(true, nil)
-		mockCrypto.On("Compare", "new_password", currentHash.String()).Return(false, nil)
-		mockCrypto.On("Encrypt", "new_password").Return("", nil)
+		mockCrypto.On("Compare", mock.Anything, "current_password", currentHash.String()).Return(true, nil)
+		mockCrypto.On("Compare", mock.Anything, "new_password", currentHash.String()).Return(false, nil)
+		mockCrypto.On("Encrypt", mock.Anything, "new_password").Return("", nil)
 
 		uc := usecase.NewUpdatePasswordUseCase(mockRepo, mockCrypto)
 		err := uc.Execute(ctx, validUserID, "current_password", "new_password")
@@ -124,7 +125,7 @@ func TestUpdatePasswordUseCase_Execute(t *testing.T) {
 		mockCrypto := new(mocks.MockCrypto)
 
 		mockRepo.On("GetByID", ctx, validUserID).Return(validUser, nil)
-		mockCrypto.On("Compare", "current_password", currentHash.String()).Return(false, errors.New("compare error"))
+		mockCrypto.On("Compare", mock.Anything, "current_password", currentHash.String()).Return(false, errors.New("compare error"))
 
 		uc := usecase.NewUpdatePasswordUseCase(mockRepo, mockCrypto)
 		err := uc.Execute(ctx, validUserID, "current_password", "new_password")
@@ -139,10 +140,10 @@ func TestUpdatePasswordUseCase_Execute(t *testing.T) {
 		mockCrypto := new(mocks.MockCrypto)
 
 		mockRepo.On("GetByID", ctx, validUserID).Return(validUser, nil)
-		mockCrypto.On("Compare", "current_password", currentHash.String()).Return(true, nil)
-		mockCrypto.On("Compare", "new_password", currentHash.String()).Return(false, nil)
-		mockCrypto.On("Encrypt", "new_password").Return("new_hash", nil)
-		mockRepo.On("Save", ctx, mock.Anything).Return(nil, errors.New("save error"))
+		mockCrypto.On("Compare", mock.Anything, "current_password", currentHash.String()).Return(true, nil)
+		mockCrypto.On("Compare", mock.Anything, "new_password", currentHash.String()).Return(false, nil)
+		mockCrypto.On("Encrypt", mock.Anything, "new_password").Return("new_hash", nil)
+		mockRepo.On("Update", ctx, mock.Anything, []string{repository.UserFieldPasswordHash}).Return(nil, errors.New("save error"))
 
 		uc := usecase.NewUpdatePasswordUseCase(mockRepo, mockCrypto)
 		err := uc.Execute(ctx, validUserID, "current_password", "new_password")
@@ -157,15 +158,15 @@ func TestUpdatePasswordUseCase_Execute(t *testing.T) {
 		mockCrypto := new(mocks.MockCrypto)
 
 		mockRepo.On("GetByID", ctx, validUserID).Return(validUser, nil)
-		mockCrypto.On("Compare", "current_password", currentHash.String()).Return(true, nil)
-		mockCrypto.On("Compare", "new_password", currentHash.String()).Return(false, nil)
-		mockCrypto.On("Encrypt", "new_password").Return("", nil)
+		mockCrypto.On("Compare", mock.Anything, "current_password", currentHash.String()).Return(true, nil)
+		mockCrypto.On("Compare", mock.Anything, "new_password", currentHash.String()).Return(false, nil)
+		mockCrypto.On("Encrypt", mock.Anything, "new_password").Return("", nil)
 
 		uc := usecase.NewUpdatePasswordUseCase(mockRepo, mockCrypto)
 		err := uc.Execute(ctx, validUserID, "current_password", "new_password")
 
 		assert.ErrorContains(t, err, "invalid hash")
-		mockRepo.AssertNotCalled(t, "Save")
+		mockRepo.AssertNotCalled(t, "Update")
 		mockRepo.AssertExpectations(t)
 		mockCrypto.AssertExpectations(t)
 	})
@@ -175,14 +176,14 @@ func TestUpdatePasswordUseCase_Execute(t *testing.T) {
 		mockCrypto := new(mocks.MockCrypto)
 
 		mockRepo.On("GetByID", ctx, validUserID).Return(validUser, nil)
-		mockCrypto.On("Compare", "current_password", currentHash.String()).Return(true, nil)
-		mockCrypto.On("Compare", "new_password", currentHash.String()).Return(false, errors.New("comparison error"))
+		mockCrypto.On("Compare", mock.Anything, "current_password", currentHash.String()).Return(true, nil)
+		mockCrypto.On("Compare", mock.Anything, "new_password", currentHash.String()).Return(false, errors.New("comparison error"))
 
 		uc := usecase.NewUpdatePasswordUseCase(mockRepo, mockCrypto)
 		err := uc.Execute(ctx, validUserID, "current_password", "new_password")
 
 		assert.ErrorContains(t, err, "failed to verify password difference")
-		mockRepo.AssertNotCalled(t, "Save")
+		mockRepo.AssertNotCalled(t, "Update")
 		mockCrypto.AssertNotCalled(t, "Encrypt")
 		mockRepo.AssertExpectations(t)
 		mockCrypto.AssertExpectations(t)
@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDeactivateAccountUseCase struct {
+	mock.Mock
+}
+
+func (m *MockDeactivateAccountUseCase) Execute(ctx context.Context, userID vo.ID, password string) (time.Time, error) {
+	args := m.Called(ctx, userID, password)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+type MockRestoreAccountUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRestoreAccountUseCase) Execute(ctx context.Context, email string, password string) (vo.ID, error) {
+	args := m.Called(ctx, email, password)
+	return args.Get(0).(vo.ID), args.Error(1)
+}
+
+type MockPurgeAccountsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockPurgeAccountsUseCase) Execute(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+type MockExportUserDataUseCase struct {
+	mock.Mock
+}
+
+func (m *MockExportUserDataUseCase) Execute(ctx context.Context, userID vo.ID, format string) (dto.UserDataExportResult, error) {
+	args := m.Called(ctx, userID, format)
+	return args.Get(0).(dto.UserDataExportResult), args.Error(1)
+}
+
+type MockDownloadUserDataExportUseCase struct {
+	mock.Mock
+}
+
+func (m *MockDownloadUserDataExportUseCase) Execute(
+	ctx context.Context,
+	fileName string,
+	expiresAt time.Time,
+	signature string,
+) (dto.UserDataExportResult, error) {
+	args := m.Called(ctx, fileName, expiresAt, signature)
+	return args.Get(0).(dto.UserDataExportResult), args.Error(1)
+}
@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCreateAPIKeyUseCase struct {
+	mock.Mock
+}
+
+func (m *MockCreateAPIKeyUseCase) Execute(
+	ctx context.Context,
+	userID vo.ID,
+	input dto.CreateAPIKeyParams,
+) (dto.CreatedAPIKeyResult, error) {
+	args := m.Called(ctx, userID, input)
+	return args.Get(0).(dto.CreatedAPIKeyResult), args.Error(1)
+}
+
+type MockListAPIKeysUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListAPIKeysUseCase) Execute(ctx context.Context, userID vo.ID) ([]dto.APIKeyResult, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.APIKeyResult), args.Error(1)
+}
+
+type MockRevokeAPIKeyUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRevokeAPIKeyUseCase) Execute(ctx context.Context, userID vo.ID, keyID vo.ID) error {
+	args := m.Called(ctx, userID, keyID)
+	return args.Error(0)
+}
@@ -0,0 +1,65 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditLogger struct {
+	mock.Mock
+}
+
+func (m *MockAuditLogger) Log(ctx context.Context, event *entity.AuditEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+type MockAuditRepo struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepo) List(ctx context.Context, filter repository.AuditFilter) ([]*entity.AuditEvent, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.AuditEvent), args.Error(1)
+}
+
+func (m *MockAuditRepo) Count(ctx context.Context, filter repository.AuditFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAuditRepo) Anonymize(ctx context.Context, subjects []string, pseudonym string) error {
+	args := m.Called(ctx, subjects, pseudonym)
+	return args.Error(0)
+}
+
+type MockAuditEraser struct {
+	mock.Mock
+}
+
+func (m *MockAuditEraser) Anonymize(ctx context.Context, subjects []string, pseudonym string) error {
+	args := m.Called(ctx, subjects, pseudonym)
+	return args.Error(0)
+}
+
+type MockListAuditEventsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListAuditEventsUseCase) Execute(
+	ctx context.Context,
+	params dto.AuditQueryParams,
+) ([]dto.AuditEventOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.AuditEventOutput), args.Error(1)
+}
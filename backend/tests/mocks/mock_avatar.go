@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBlobStore struct {
+	mock.Mock
+}
+
+func (m *MockBlobStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	args := m.Called(ctx, key, data, contentType)
+	return args.Error(0)
+}
+
+func (m *MockBlobStore) Get(ctx context.Context, key string) (providers.Blob, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(providers.Blob), args.Error(1)
+}
+
+func (m *MockBlobStore) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+type MockImageProcessor struct {
+	mock.Mock
+}
+
+func (m *MockImageProcessor) Thumbnails(data []byte, sizes []int) (map[int][]byte, error) {
+	args := m.Called(data, sizes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int][]byte), args.Error(1)
+}
+
+type MockUploadAvatarUseCase struct {
+	mock.Mock
+}
+
+func (m *MockUploadAvatarUseCase) Execute(ctx context.Context, userID vo.ID, data []byte) (string, error) {
+	args := m.Called(ctx, userID, data)
+	return args.String(0), args.Error(1)
+}
+
+type MockGetAvatarUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetAvatarUseCase) Execute(ctx context.Context, avatarID string, size int) (dto.AvatarImage, error) {
+	args := m.Called(ctx, avatarID, size)
+	return args.Get(0).(dto.AvatarImage), args.Error(1)
+}
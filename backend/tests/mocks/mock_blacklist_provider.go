@@ -16,6 +16,11 @@ func (m *MockBlacklist) Add(ctx context.Context, token string, ttl time.Duration
 	return args.Error(0)
 }
 
+func (m *MockBlacklist) AddIfAbsent(ctx context.Context, token string, ttl time.Duration) (bool, error) {
+	args := m.Called(ctx, token, ttl)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockBlacklist) Exists(ctx context.Context, token string) (bool, error) {
 	args := m.Called(ctx, token)
 	return args.Bool(0), args.Error(1)
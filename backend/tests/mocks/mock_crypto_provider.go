@@ -1,17 +1,21 @@
 package mocks
 
-import "github.com/stretchr/testify/mock"
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
 
 type MockCrypto struct {
 	mock.Mock
 }
 
-func (m *MockCrypto) Encrypt(password string) (string, error) {
-	args := m.Called(password)
+func (m *MockCrypto) Encrypt(ctx context.Context, password string) (string, error) {
+	args := m.Called(ctx, password)
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockCrypto) Compare(password, hash string) (bool, error) {
-	args := m.Called(password, hash)
+func (m *MockCrypto) Compare(ctx context.Context, password, hash string) (bool, error) {
+	args := m.Called(ctx, password, hash)
 	return args.Bool(0), args.Error(1)
 }
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRequestEmailChangeUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRequestEmailChangeUseCase) Execute(ctx context.Context, userID vo.ID, newEmail string, password string) error {
+	args := m.Called(ctx, userID, newEmail, password)
+	return args.Error(0)
+}
+
+type MockConfirmEmailChangeUseCase struct {
+	mock.Mock
+}
+
+func (m *MockConfirmEmailChangeUseCase) Execute(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+type MockCancelEmailChangeUseCase struct {
+	mock.Mock
+}
+
+func (m *MockCancelEmailChangeUseCase) Execute(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
 	"github.com/stretchr/testify/mock"
 )
@@ -9,7 +11,22 @@ type MockEmailService struct {
 	mock.Mock
 }
 
-func (m *MockEmailService) SendResetPasswordEmail(email vo.Email, token string) error {
-	args := m.Called(email, token)
+func (m *MockEmailService) SendResetPasswordEmail(ctx context.Context, email vo.Email, locale, token string) error {
+	args := m.Called(ctx, email, locale, token)
+	return args.Error(0)
+}
+
+func (m *MockEmailService) SendDataExportEmail(ctx context.Context, email vo.Email, locale, downloadURL string) error {
+	args := m.Called(ctx, email, locale, downloadURL)
+	return args.Error(0)
+}
+
+func (m *MockEmailService) SendEmailChangeConfirmation(ctx context.Context, email vo.Email, locale, token string) error {
+	args := m.Called(ctx, email, locale, token)
+	return args.Error(0)
+}
+
+func (m *MockEmailService) SendEmailChangeNotice(ctx context.Context, email vo.Email, locale string, newEmail vo.Email, cancelToken string) error {
+	args := m.Called(ctx, email, locale, newEmail, cancelToken)
 	return args.Error(0)
 }
@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockExportStore struct {
+	mock.Mock
+}
+
+func (m *MockExportStore) Save(ctx context.Context, name string, data []byte) error {
+	args := m.Called(ctx, name, data)
+	return args.Error(0)
+}
+
+func (m *MockExportStore) Load(ctx context.Context, name string) ([]byte, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockExportStore) DeleteOlderThan(ctx context.Context, before time.Time) error {
+	args := m.Called(ctx, before)
+	return args.Error(0)
+}
+
+func (m *MockExportStore) DeleteByOwner(ctx context.Context, ownerID string) error {
+	args := m.Called(ctx, ownerID)
+	return args.Error(0)
+}
+
+type MockURLSigner struct {
+	mock.Mock
+}
+
+func (m *MockURLSigner) Sign(resource string, expiresAt time.Time) string {
+	args := m.Called(resource, expiresAt)
+	return args.String(0)
+}
+
+func (m *MockURLSigner) Verify(resource string, expiresAt time.Time, signature string) bool {
+	args := m.Called(resource, expiresAt, signature)
+	return args.Bool(0)
+}
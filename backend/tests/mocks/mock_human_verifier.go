@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockHumanVerifier struct {
+	mock.Mock
+}
+
+func (m *MockHumanVerifier) Challenge(ctx context.Context) (providers.HumanChallenge, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(providers.HumanChallenge), args.Error(1)
+}
+
+func (m *MockHumanVerifier) Verify(ctx context.Context, proof string, subject providers.HumanSubject) error {
+	args := m.Called(ctx, proof, subject)
+	return args.Error(0)
+}
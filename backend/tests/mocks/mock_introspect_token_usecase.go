@@ -0,0 +1,17 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIntrospectTokenUseCase struct {
+	mock.Mock
+}
+
+func (m *MockIntrospectTokenUseCase) Execute(ctx context.Context, token string) (dto.IntrospectionResult, error) {
+	args := m.Called(ctx, token)
+	return args.Get(0).(dto.IntrospectionResult), args.Error(1)
+}
@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMailbox struct {
+	mock.Mock
+}
+
+func (m *MockMailbox) Messages(to string) []dto.MailboxMessage {
+	args := m.Called(to)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]dto.MailboxMessage)
+}
+
+func (m *MockMailbox) Clear() {
+	m.Called()
+}
@@ -0,0 +1,62 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/repository"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOutboxRepo struct {
+	mock.Mock
+}
+
+func (m *MockOutboxRepo) Enqueue(ctx context.Context, message *entity.OutboxMessage) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepo) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.OutboxMessage, error) {
+	args := m.Called(ctx, now, lease, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.OutboxMessage), args.Error(1)
+}
+
+func (m *MockOutboxRepo) Update(ctx context.Context, message *entity.OutboxMessage) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepo) GetByID(ctx context.Context, id vo.ID) (*entity.OutboxMessage, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.OutboxMessage), args.Error(1)
+}
+
+func (m *MockOutboxRepo) List(ctx context.Context, filter repository.OutboxFilter) ([]*entity.OutboxMessage, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.OutboxMessage), args.Error(1)
+}
+
+func (m *MockOutboxRepo) ListByUserID(ctx context.Context, userID vo.ID) ([]*entity.OutboxMessage, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.OutboxMessage), args.Error(1)
+}
+
+func (m *MockOutboxRepo) DeleteByUserID(ctx context.Context, userID vo.ID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockListOutboxMessagesUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListOutboxMessagesUseCase) Execute(ctx context.Context, params dto.OutboxQueryParams) ([]dto.OutboxMessageOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.OutboxMessageOutput), args.Error(1)
+}
+
+type MockReplayOutboxMessageUseCase struct {
+	mock.Mock
+}
+
+func (m *MockReplayOutboxMessageUseCase) Execute(ctx context.Context, id vo.ID) (dto.OutboxMessageOutput, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(dto.OutboxMessageOutput), args.Error(1)
+}
@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/dto"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGetProfileUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetProfileUseCase) Execute(ctx context.Context, userID vo.ID) (dto.UserOutput, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(dto.UserOutput), args.Error(1)
+}
+
+type MockUpdateProfileUseCase struct {
+	mock.Mock
+}
+
+func (m *MockUpdateProfileUseCase) Execute(ctx context.Context, userID vo.ID, input dto.UpdateProfileInput) (dto.UserOutput, error) {
+	args := m.Called(ctx, userID, input)
+	return args.Get(0).(dto.UserOutput), args.Error(1)
+}
@@ -0,0 +1,17 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRateLimiter struct {
+	mock.Mock
+}
+
+func (m *MockRateLimiter) Allow(ctx context.Context, key string, limit providers.RateLimit) (providers.RateLimitResult, error) {
+	args := m.Called(ctx, key, limit)
+	return args.Get(0).(providers.RateLimitResult), args.Error(1)
+}
@@ -1,17 +1,21 @@
 package mocks
 
-import "github.com/stretchr/testify/mock"
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
 
 type MockTokenProvider struct {
 	mock.Mock
 }
 
-func (m *MockTokenProvider) Generate(claims interface{}) (string, error) {
-	args := m.Called(claims)
+func (m *MockTokenProvider) Generate(ctx context.Context, claims interface{}) (string, error) {
+	args := m.Called(ctx, claims)
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockTokenProvider) Validate(token string) (interface{}, error) {
-	args := m.Called(token)
+func (m *MockTokenProvider) Validate(ctx context.Context, token string) (interface{}, error) {
+	args := m.Called(ctx, token)
 	return args.Get(0), args.Error(1)
 }
@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/providers"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTokenStore struct {
+	mock.Mock
+}
+
+func (m *MockTokenStore) Register(ctx context.Context, claims providers.Claims) error {
+	args := m.Called(ctx, claims)
+	return args.Error(0)
+}
+
+func (m *MockTokenStore) Revoke(ctx context.Context, claims providers.Claims) error {
+	args := m.Called(ctx, claims)
+	return args.Error(0)
+}
+
+func (m *MockTokenStore) IsActive(ctx context.Context, claims providers.Claims) (bool, error) {
+	args := m.Called(ctx, claims)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTokenStore) RevokeUser(ctx context.Context, userID string, ttl time.Duration) error {
+	args := m.Called(ctx, userID, ttl)
+	return args.Error(0)
+}
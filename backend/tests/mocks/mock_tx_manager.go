@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTxManager executa fn diretamente, a menos que a expectativa devolva erro
+// (simulando falha ao abrir a transação).
+type MockTxManager struct {
+	mock.Mock
+}
+
+// NewMockTxManager aceita qualquer chamada e apenas repassa para fn.
+func NewMockTxManager() *MockTxManager {
+	m := new(MockTxManager)
+	m.On("WithinTx", mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+func (m *MockTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	args := m.Called(ctx)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(ctx)
+}
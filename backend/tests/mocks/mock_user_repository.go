@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
@@ -20,6 +21,22 @@ func (m *MockUserRepo) GetByResetToken(ctx context.Context, token string) (*enti
 	return args.Get(0).(*entity.User), args.Error(1)
 }
 
+func (m *MockUserRepo) GetByEmailChangeToken(ctx context.Context, token string) (*entity.User, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepo) GetByEmailChangeCancelToken(ctx context.Context, token string) (*entity.User, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
 func (m *MockUserRepo) GetByID(ctx context.Context, userID vo.ID) (*entity.User, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -43,3 +60,42 @@ func (m *MockUserRepo) Save(ctx context.Context, user *entity.User) (*entity.Use
 	}
 	return args.Get(0).(*entity.User), args.Error(1)
 }
+
+func (m *MockUserRepo) Update(ctx context.Context, user *entity.User, fields ...string) (*entity.User, error) {
+	args := m.Called(ctx, user, fields)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepo) Deactivate(ctx context.Context, userID vo.ID, at time.Time) error {
+	args := m.Called(ctx, userID, at)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) Restore(ctx context.Context, userID vo.ID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepo) GetDeactivatedByEmail(ctx context.Context, email vo.Email) (*entity.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepo) ListDeactivatedBefore(ctx context.Context, before time.Time, limit int) ([]*entity.User, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepo) Erase(ctx context.Context, userID vo.ID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/eskokado/startup-auth-go/backend/internal/tracing"
+)
+
+// SpanRecorder guarda os spans exportados para as asserções dos testes.
+type SpanRecorder struct {
+	mu    sync.Mutex
+	spans []tracing.SpanData
+}
+
+func (r *SpanRecorder) Export(_ context.Context, spans []tracing.SpanData) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, spans...)
+	return nil
+}
+
+// Spans exporta o que estiver na fila do tracer e devolve tudo o que foi gravado.
+func (r *SpanRecorder) Spans(tracer *tracing.Tracer) []tracing.SpanData {
+	_ = tracer.ForceFlush(context.Background())
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]tracing.SpanData(nil), r.spans...)
+}
+
+// NewRecordingTracer amostra todos os traces e os entrega ao SpanRecorder.
+func NewRecordingTracer() (*tracing.Tracer, *SpanRecorder) {
+	recorder := &SpanRecorder{}
+	return tracing.NewTracer(tracing.TracerConfig{Exporter: recorder, SampleRatio: 1}), recorder
+}
+
+// SpanAttribute devolve o valor do atributo key, ou nil.
+func SpanAttribute(span tracing.SpanData, key string) any {
+	for _, attr := range span.Attributes {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package entity_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+)
+
+func TestNewAPIKey_Valid(t *testing.T) {
+	name, _ := vo.NewName("CI script", 3, 50)
+	userID := vo.NewID()
+
+	key, rawKey, err := entity.NewAPIKey(userID, name, []string{entity.ScopeUserRead}, time.Time{})
+	if err != nil {
+		t.Fatalf("NewAPIKey() falhou: %v", err)
+	}
+
+	if !strings.HasPrefix(rawKey, "sag_"+key.Prefix+"_") {
+		t.Errorf("Formato de chave inesperado: %s", rawKey)
+	}
+	if key.KeyHash != entity.HashAPIKey(rawKey) {
+		t.Error("Hash armazenado não corresponde à chave gerada")
+	}
+	if strings.Contains(key.KeyHash, rawKey) {
+		t.Error("A chave em texto puro não deve ser armazenada")
+	}
+	if !key.UserID.Equal(userID) {
+		t.Error("UserID não foi atribuído")
+	}
+	if !key.IsActive(time.Now()) {
+		t.Error("Chave recém-criada deveria estar ativa")
+	}
+}
+
+func TestNewAPIKey_UniqueKeys(t *testing.T) {
+	name, _ := vo.NewName("CI script", 3, 50)
+
+	_, first, _ := entity.NewAPIKey(vo.NewID(), name, []string{entity.ScopeUserRead}, time.Time{})
+	_, second, _ := entity.NewAPIKey(vo.NewID(), name, []string{entity.ScopeUserRead}, time.Time{})
+
+	if first == second {
+		t.Error("Duas chaves geradas são iguais")
+	}
+}
+
+func TestNewAPIKey_InvalidScope(t *testing.T) {
+	name, _ := vo.NewName("CI script", 3, 50)
+
+	_, _, err := entity.NewAPIKey(vo.NewID(), name, []string{"admin:all"}, time.Time{})
+	if !errors.Is(err, msgerror.AnErrInvalidScope) {
+		t.Errorf("Esperado AnErrInvalidScope, recebido: %v", err)
+	}
+
+	_, _, err = entity.NewAPIKey(vo.NewID(), name, nil, time.Time{})
+	if !errors.Is(err, msgerror.AnErrInvalidScope) {
+		t.Errorf("Esperado AnErrInvalidScope para escopos vazios, recebido: %v", err)
+	}
+}
+
+func TestNewAPIKey_PastExpiration(t *testing.T) {
+	name, _ := vo.NewName("CI script", 3, 50)
+
+	_, _, err := entity.NewAPIKey(vo.NewID(), name, []string{entity.ScopeUserRead}, time.Now().Add(-time.Hour))
+	if !errors.Is(err, msgerror.AnErrInvalidExpiration) {
+		t.Errorf("Esperado AnErrInvalidExpiration, recebido: %v", err)
+	}
+}
+
+func TestAPIKey_IsActive(t *testing.T) {
+	now := time.Now()
+
+	expired := &entity.APIKey{ExpiresAt: now.Add(-time.Minute)}
+	if expired.IsActive(now) {
+		t.Error("Chave expirada não deveria estar ativa")
+	}
+
+	revoked := &entity.APIKey{}
+	revoked.Revoke()
+	if revoked.IsActive(now) {
+		t.Error("Chave revogada não deveria estar ativa")
+	}
+}
+
+func TestAPIKey_HasScope(t *testing.T) {
+	key := &entity.APIKey{Scopes: []string{entity.ScopeUserRead}}
+
+	if !key.HasScope(entity.ScopeUserRead) {
+		t.Error("Esperado escopo user:read")
+	}
+	if key.HasScope(entity.ScopeUserWrite) {
+		t.Error("Escopo user:write não foi concedido")
+	}
+}
+
+func TestParseAPIKeyPrefix(t *testing.T) {
+	prefix, err := entity.ParseAPIKeyPrefix("sag_abc123_secret")
+	if err != nil || prefix != "abc123" {
+		t.Errorf("Prefixo inesperado: %q, erro: %v", prefix, err)
+	}
+
+	for _, raw := range []string{"", "sag_", "other_abc_secret", "sag__secret", "sag_abc_"} {
+		if _, err := entity.ParseAPIKeyPrefix(raw); !errors.Is(err, msgerror.AnErrInvalidAPIKey) {
+			t.Errorf("Esperado AnErrInvalidAPIKey para %q, recebido: %v", raw, err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package entity_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOutboxMessage(t *testing.T) {
+	message, err := entity.NewOutboxMessage(entity.OutboxTopicPasswordResetEmail, entity.PasswordResetEmailPayload{
+		Email: "maria@test.com",
+		Token: "abc",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, entity.OutboxStatusPending, message.Status)
+	assert.Zero(t, message.Attempts)
+	assert.False(t, message.NextAttemptAt.After(time.Now()))
+
+	var payload entity.PasswordResetEmailPayload
+	require.NoError(t, json.Unmarshal(message.Payload, &payload))
+	assert.Equal(t, "abc", payload.Token)
+}
+
+func TestOutboxMessage_MarkFailedUntilDead(t *testing.T) {
+	message, _ := entity.NewOutboxMessage("topic", nil)
+	retryAt := time.Now().Add(time.Minute)
+
+	message.MarkFailed(errors.New("smtp down"), retryAt, 2)
+	assert.Equal(t, entity.OutboxStatusPending, message.Status)
+	assert.Equal(t, 1, message.Attempts)
+	assert.Equal(t, retryAt, message.NextAttemptAt)
+	assert.Equal(t, "smtp down", message.LastError)
+
+	message.MarkFailed(errors.New(strings.Repeat("x", 2000)), retryAt, 2)
+	assert.True(t, message.IsDead())
+	assert.Len(t, message.LastError, 1024)
+
+	now := time.Now()
+	message.Replay(now)
+	assert.Equal(t, entity.OutboxStatusPending, message.Status)
+	assert.Zero(t, message.Attempts)
+	assert.Empty(t, message.LastError)
+	assert.Equal(t, now, message.NextAttemptAt)
+}
+
+func TestOutboxMessage_MarkSent(t *testing.T) {
+	message, _ := entity.NewOutboxMessage("topic", entity.PasswordResetEmailPayload{Email: "a@test.com", Token: "secret"})
+	message.MarkFailed(errors.New("timeout"), time.Now(), 5)
+
+	at := time.Now()
+	message.MarkSent(at)
+
+	assert.Equal(t, entity.OutboxStatusSent, message.Status)
+	assert.Equal(t, 2, message.Attempts)
+	assert.Equal(t, at, message.SentAt)
+	assert.Empty(t, message.LastError)
+	assert.Empty(t, message.Payload, "sent messages keep no tokens or addresses")
+}
@@ -181,6 +181,20 @@ func TestNewUser_EmptyPasswordHash(t *testing.T) {
 	}
 }
 
+func TestUser_WithImageURL(t *testing.T) {
+	user, _ := entity.CreateUser("Original", "original@example.com", "Pass123!", "https://cdn.example.com/old.png")
+	newURL, _ := vo.NewURL("https://api.example.com/avatars/abc")
+
+	updatedUser := user.WithImageURL(newURL)
+
+	if updatedUser.ImageURL != newURL {
+		t.Errorf("ImageURL não atualizada: esperado %v, recebido %v", newURL, updatedUser.ImageURL)
+	}
+	if user.ImageURL.String() != "https://cdn.example.com/old.png" {
+		t.Errorf("O usuário original não deveria mudar: %v", user.ImageURL)
+	}
+}
+
 // --- Testes de atualização de nome ---
 func TestUser_WithName_Success(t *testing.T) {
 	user, _ := entity.CreateUser(
@@ -253,6 +267,35 @@ func TestUser_WithPasswordHash_Success(t *testing.T) {
 	}
 }
 
+func TestUser_WithNameAndPasswordHash_KeepOtherFields(t *testing.T) {
+	user, _ := entity.CreateUser("Original", "keep@example.com", "Pass123!", "")
+	user.Version = 4
+	if err := user.GeneratePasswordResetToken(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	newName, _ := vo.NewName("New Name", 3, 50)
+	renamed, err := user.WithName(newName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newHash, _ := vo.NewPasswordHash("NewPass123!")
+	updated, err := renamed.WithPasswordHash(newHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updated.PasswordResetToken != user.PasswordResetToken || !updated.PasswordResetExpires.Equal(user.PasswordResetExpires) {
+		t.Error("Token de reset descartado")
+	}
+	if updated.Version != user.Version {
+		t.Errorf("Versão alterada: esperado %d, recebido %d", user.Version, updated.Version)
+	}
+	if user.Name == newName {
+		t.Error("Usuário original modificado")
+	}
+}
+
 func TestUser_WithPasswordHash_EmptyHash(t *testing.T) {
 	user, _ := entity.CreateUser(
 		"Test",
@@ -279,7 +322,7 @@ func TestUser_PasswordResetToken_GenerateAndClear(t *testing.T) {
 	)
 
 	// Geração do token
-	if err := user.GeneratePasswordResetToken(); err != nil {
+	if err := user.GeneratePasswordResetToken(time.Hour); err != nil {
 		t.Fatalf("GeneratePasswordResetToken falhou: %v", err)
 	}
 
@@ -314,7 +357,7 @@ func TestUser_GeneratePasswordResetToken_Error(t *testing.T) {
 		"",
 	)
 
-	err := user.GeneratePasswordResetToken()
+	err := user.GeneratePasswordResetToken(time.Hour)
 	if err == nil {
 		t.Fatal("Esperado erro, não ocorreu")
 	}
@@ -376,3 +419,94 @@ func TestNewUser_Valid(t *testing.T) {
 		t.Error("Propriedades do usuário incorretas")
 	}
 }
+
+// --- Testes de desativação de conta ---
+func TestUser_DeactivateAndRestore(t *testing.T) {
+	user, _ := entity.CreateUser("Test", "test@example.com", "Pass123!", "")
+	_ = user.GeneratePasswordResetToken(time.Hour)
+
+	if user.IsDeactivated() {
+		t.Fatal("Conta nova não deveria estar desativada")
+	}
+	if !user.PurgeAfter(time.Hour).IsZero() {
+		t.Error("Conta ativa não deveria ter data de apagamento")
+	}
+
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	user.Deactivate(at)
+	if !user.IsDeactivated() {
+		t.Fatal("Conta deveria estar desativada")
+	}
+	if user.PasswordResetToken != "" {
+		t.Error("Token de reset deveria ser descartado na desativação")
+	}
+	if got := user.PurgeAfter(48 * time.Hour); !got.Equal(at.Add(48 * time.Hour)) {
+		t.Errorf("Data de apagamento incorreta: %v", got)
+	}
+
+	user.Restore()
+	if user.IsDeactivated() {
+		t.Error("Conta deveria estar ativa após restauração")
+	}
+}
+
+// --- Testes de troca de e-mail ---
+func TestUser_EmailChange_RequestAndConfirm(t *testing.T) {
+	user, _ := entity.CreateUser("Test", "test@example.com", "Pass123!", "")
+	newEmail, _ := vo.NewEmail("new@example.com")
+
+	if err := user.RequestEmailChange(newEmail, time.Hour); err != nil {
+		t.Fatalf("RequestEmailChange falhou: %v", err)
+	}
+	if user.Email.String() != "test@example.com" {
+		t.Error("E-mail não deveria mudar antes da confirmação")
+	}
+	if user.PendingEmail != newEmail {
+		t.Errorf("E-mail pendente incorreto: %s", user.PendingEmail)
+	}
+	if user.EmailChangeToken == "" || user.EmailChangeCancelToken == "" {
+		t.Fatal("Tokens não gerados")
+	}
+	if user.EmailChangeToken == user.EmailChangeCancelToken {
+		t.Error("Tokens de confirmação e cancelamento deveriam ser distintos")
+	}
+
+	if err := user.ConfirmEmailChange(time.Now()); err != nil {
+		t.Fatalf("ConfirmEmailChange falhou: %v", err)
+	}
+	if user.Email != newEmail {
+		t.Errorf("E-mail não trocado: %s", user.Email)
+	}
+	if !user.PendingEmail.IsEmpty() || user.EmailChangeToken != "" || user.EmailChangeCancelToken != "" {
+		t.Error("Troca pendente deveria ser descartada após a confirmação")
+	}
+}
+
+func TestUser_EmailChange_Errors(t *testing.T) {
+	user, _ := entity.CreateUser("Test", "test@example.com", "Pass123!", "")
+
+	same, _ := vo.NewEmail("TEST@example.com")
+	if err := user.RequestEmailChange(same, time.Hour); !errors.Is(err, msgerror.AnErrEmailUnchanged) {
+		t.Errorf("Esperado AnErrEmailUnchanged, recebido %v", err)
+	}
+	if err := user.RequestEmailChange(vo.Email{}, time.Hour); !errors.Is(err, msgerror.AnErrEmptyEmail) {
+		t.Errorf("Esperado AnErrEmptyEmail, recebido %v", err)
+	}
+	if err := user.ConfirmEmailChange(time.Now()); !errors.Is(err, msgerror.AnErrInvalidToken) {
+		t.Errorf("Sem troca pendente: esperado AnErrInvalidToken, recebido %v", err)
+	}
+
+	newEmail, _ := vo.NewEmail("new@example.com")
+	_ = user.RequestEmailChange(newEmail, time.Hour)
+	if err := user.ConfirmEmailChange(time.Now().Add(2 * time.Hour)); !errors.Is(err, msgerror.AnErrExpiredToken) {
+		t.Errorf("Esperado AnErrExpiredToken, recebido %v", err)
+	}
+	if user.Email.String() != "test@example.com" {
+		t.Error("E-mail não deveria mudar com o link expirado")
+	}
+
+	user.Deactivate(time.Now())
+	if !user.PendingEmail.IsEmpty() || user.EmailChangeToken != "" {
+		t.Error("Troca pendente deveria ser descartada na desativação")
+	}
+}
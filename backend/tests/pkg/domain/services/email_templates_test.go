@@ -0,0 +1,164 @@
+package service_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	service "github.com/eskokado/startup-auth-go/backend/pkg/domain/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTemplate(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestEmailTemplates_Embedded(t *testing.T) {
+	templates, err := service.NewEmailTemplates("", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"en-US", "pt-BR"}, templates.Locales())
+
+	data := service.EmailData{
+		Link:      "https://app.example.com/reset?t=1&u=2",
+		ExpiresIn: service.NewExpiry(time.Hour),
+		NewEmail:  "new@example.com",
+	}
+
+	t.Run("Todos os e-mails existem em todos os idiomas", func(t *testing.T) {
+		for _, locale := range templates.Locales() {
+			for _, name := range []string{
+				service.EmailResetPassword,
+				service.EmailDataExport,
+				service.EmailChangeConfirmation,
+				service.EmailChangeNotice,
+			} {
+				rendered, err := templates.Render(locale, name, data)
+				require.NoError(t, err, "%s/%s", locale, name)
+				assert.NotEmpty(t, rendered.Subject)
+				assert.Contains(t, rendered.HTML, `lang="`+locale+`"`)
+				assert.Contains(t, rendered.Text, data.Link)
+			}
+		}
+	})
+
+	t.Run("HTML escapa o link e texto puro não", func(t *testing.T) {
+		rendered, err := templates.Render("pt-BR", service.EmailResetPassword, data)
+		require.NoError(t, err)
+		assert.Equal(t, "Redefinição de Senha", rendered.Subject)
+		assert.Contains(t, rendered.HTML, "t=1&amp;u=2")
+		assert.Contains(t, rendered.Text, "Este link expira em 1 hora.")
+		assert.NotContains(t, rendered.Text, "<")
+		// O link já é o texto da âncora, então não se repete entre parênteses
+		assert.NotContains(t, rendered.Text, "("+data.Link+")")
+	})
+
+	t.Run("Aviso de troca mostra o novo endereço", func(t *testing.T) {
+		for _, locale := range templates.Locales() {
+			rendered, err := templates.Render(locale, service.EmailChangeNotice, data)
+			require.NoError(t, err)
+			assert.Contains(t, rendered.Text, data.NewEmail)
+			assert.Contains(t, rendered.HTML, data.NewEmail)
+		}
+	})
+
+	t.Run("Concordância da validade por idioma", func(t *testing.T) {
+		rendered, err := templates.Render("en-US", service.EmailDataExport, service.EmailData{ExpiresIn: service.NewExpiry(15 * time.Minute)})
+		require.NoError(t, err)
+		assert.Contains(t, rendered.Text, "15 minutes")
+
+		rendered, err = templates.Render("pt-BR", service.EmailResetPassword, service.EmailData{ExpiresIn: service.NewExpiry(2 * time.Hour)})
+		require.NoError(t, err)
+		assert.Contains(t, rendered.Text, "2 horas")
+	})
+
+	t.Run("Resolução do idioma", func(t *testing.T) {
+		cases := map[string]string{
+			"en-US": "Password Reset",
+			"EN-us": "Password Reset",
+			"en":    "Password Reset",
+			"en-GB": "Password Reset",
+			"pt":    "Redefinição de Senha",
+			"fr-FR": "Redefinição de Senha",
+			"":      "Redefinição de Senha",
+		}
+		for locale, subject := range cases {
+			rendered, err := templates.Render(locale, service.EmailResetPassword, data)
+			require.NoError(t, err)
+			assert.Equal(t, subject, rendered.Subject, locale)
+		}
+	})
+
+	t.Run("E-mail desconhecido", func(t *testing.T) {
+		_, err := templates.Render("pt-BR", "welcome", data)
+		assert.ErrorContains(t, err, `unknown email template "welcome"`)
+	})
+}
+
+func TestEmailTemplates_Overrides(t *testing.T) {
+	dir := t.TempDir()
+	// Substitui só o assunto em pt-BR; o corpo continua o embutido
+	writeTemplate(t, dir, "pt-BR/reset_password.subject.tmpl", "Nova senha")
+	// Novo idioma com texto puro explícito e um e-mail que só ele tem
+	writeTemplate(t, dir, "es-ES/reset_password.subject.tmpl", "Restablecer contraseña")
+	writeTemplate(t, dir, "es-ES/reset_password.html.tmpl", `{{define "content"}}<p>Hola <a href="{{.Link}}">aquí</a></p>{{end}}`)
+	writeTemplate(t, dir, "es-ES/reset_password.txt.tmpl", `{{define "content"}}Hola: {{.Link}}{{end}}`)
+	writeTemplate(t, dir, "es-ES/welcome.subject.tmpl", "Bienvenido")
+	writeTemplate(t, dir, "es-ES/welcome.html.tmpl", `{{define "content"}}<h1>Hola</h1>{{end}}`)
+
+	templates, err := service.NewEmailTemplates(dir, "pt-BR")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"en-US", "es-ES", "pt-BR"}, templates.Locales())
+
+	data := service.EmailData{Link: "https://x.test/r", ExpiresIn: service.NewExpiry(time.Hour)}
+
+	rendered, err := templates.Render("pt-BR", service.EmailResetPassword, data)
+	require.NoError(t, err)
+	assert.Equal(t, "Nova senha", rendered.Subject)
+	assert.Contains(t, rendered.Text, "Este link expira em 1 hora.")
+
+	rendered, err = templates.Render("es", service.EmailResetPassword, data)
+	require.NoError(t, err)
+	assert.Equal(t, "Restablecer contraseña", rendered.Subject)
+	assert.Equal(t, "Hola: https://x.test/r\n", rendered.Text)
+	assert.Contains(t, rendered.HTML, `lang="es-ES"`)
+
+	// Sem tradução no idioma pedido, o e-mail sai no idioma padrão
+	rendered, err = templates.Render("es-ES", service.EmailDataExport, data)
+	require.NoError(t, err)
+	assert.Equal(t, "Seus dados estão prontos", rendered.Subject)
+
+	rendered, err = templates.Render("es-ES", "welcome", data)
+	require.NoError(t, err)
+	assert.Equal(t, "Hola\n", rendered.Text)
+}
+
+func TestEmailTemplates_Errors(t *testing.T) {
+	t.Run("Idioma padrão inexistente", func(t *testing.T) {
+		_, err := service.NewEmailTemplates("", "fr-FR")
+		assert.ErrorContains(t, err, `default locale "fr-FR" not found`)
+	})
+
+	t.Run("Diretório inexistente", func(t *testing.T) {
+		_, err := service.NewEmailTemplates(filepath.Join(t.TempDir(), "missing"), "")
+		assert.Error(t, err)
+	})
+
+	t.Run("Modelo inválido", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTemplate(t, dir, "pt-BR/reset_password.html.tmpl", `{{define "content"}}{{.Link{{end}}`)
+		_, err := service.NewEmailTemplates(dir, "")
+		assert.ErrorContains(t, err, "pt-BR/reset_password")
+	})
+
+	t.Run("Assunto ausente", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTemplate(t, dir, "de-DE/welcome.html.tmpl", `{{define "content"}}Hallo{{end}}`)
+		_, err := service.NewEmailTemplates(dir, "")
+		assert.ErrorContains(t, err, "de-DE/welcome")
+	})
+}
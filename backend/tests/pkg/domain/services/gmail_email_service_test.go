@@ -1,66 +1,94 @@
 package service_test
 
 import (
+	"bytes"
+	"context"
 	"errors"
-	"os"
+	"strings"
 	"testing"
+	"time"
 
 	service "github.com/eskokado/startup-auth-go/backend/pkg/domain/services"
 	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
 	"github.com/eskokado/startup-auth-go/backend/tests/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gopkg.in/gomail.v2"
 )
 
 func TestEmailService_SendResetPasswordEmail(t *testing.T) {
-	// Configuração inicial para garantir ambiente limpo
-	originalEnv := map[string]string{
-		"FROM_EMAIL":         os.Getenv("FROM_EMAIL"),
-		"FRONTEND_RESET_URL": os.Getenv("FRONTEND_RESET_URL"),
-		"SMTP_HOST":          os.Getenv("SMTP_HOST"),
-		"SMTP_PORT":          os.Getenv("SMTP_PORT"),
-		"SMTP_USERNAME":      os.Getenv("SMTP_USERNAME"),
-		"SMTP_PASSWORD":      os.Getenv("SMTP_PASSWORD"),
+	validConfig := service.EmailConfig{
+		From:        "no-reply@example.com",
+		FrontendURL: "https://app.example.com/reset-password",
+		ResetTTL:    time.Hour,
 	}
 
-	defer func() {
-		for k, v := range originalEnv {
-			if v != "" {
-				os.Setenv(k, v)
-			} else {
-				os.Unsetenv(k)
-			}
-		}
-	}()
-
-	// Testes individuais com ambiente controlado
 	t.Run("Sucesso - Email enviado", func(t *testing.T) {
-		os.Setenv("FROM_EMAIL", "no-reply@example.com")
-		os.Setenv("FRONTEND_RESET_URL", "https://app.example.com/reset-password")
-
 		mockSender := new(mocks.MockSenderService)
-		emailService := service.NewEmailService(mockSender)
+		emailService := service.NewEmailService(mockSender, validConfig)
 
 		mockSender.On("DialAndSend", mock.Anything).Return(nil)
 
 		email, _ := vo.NewEmail("user@example.com")
-		err := emailService.SendResetPasswordEmail(email, "reset-token-123")
+		err := emailService.SendResetPasswordEmail(context.Background(), email, "", "reset-token-123")
 
 		assert.NoError(t, err)
 		mockSender.AssertExpectations(t)
 	})
 
-	t.Run("Erro - Falha no envio", func(t *testing.T) {
-		os.Setenv("FROM_EMAIL", "no-reply@example.com")
-		os.Setenv("FRONTEND_RESET_URL", "https://app.example.com/reset-password")
+	t.Run("Sucesso - Validade do link vem da configuração", func(t *testing.T) {
+		mockSender := new(mocks.MockSenderService)
+		cfg := validConfig
+		cfg.ResetTTL = 30 * time.Minute
+		emailService := service.NewEmailService(mockSender, cfg)
+
+		var body bytes.Buffer
+		mockSender.On("DialAndSend", mock.Anything).
+			Run(func(args mock.Arguments) {
+				_, _ = args.Get(0).([]*gomail.Message)[0].WriteTo(&body)
+			}).
+			Return(nil)
+
+		email, _ := vo.NewEmail("user@example.com")
+		err := emailService.SendResetPasswordEmail(context.Background(), email, "", "reset-token-123")
+
+		assert.NoError(t, err)
+		assert.Contains(t, body.String(), "30 minutos")
+	})
 
+	t.Run("Sucesso - Multipart no idioma do usuário", func(t *testing.T) {
 		mockSender := new(mocks.MockSenderService)
-		emailService := service.NewEmailService(mockSender)
+		emailService := service.NewEmailService(mockSender, validConfig)
+
+		var message bytes.Buffer
+		mockSender.On("DialAndSend", mock.Anything).
+			Run(func(args mock.Arguments) {
+				_, _ = args.Get(0).([]*gomail.Message)[0].WriteTo(&message)
+			}).
+			Return(nil)
+
+		email, _ := vo.NewEmail("user@example.com")
+		err := emailService.SendResetPasswordEmail(context.Background(), email, "en-US", "reset-token-123")
+
+		assert.NoError(t, err)
+		raw := message.String()
+		assert.Contains(t, raw, "Subject: Password Reset")
+		assert.Contains(t, raw, "multipart/alternative")
+		assert.Contains(t, raw, "Content-Type: text/plain")
+		assert.Contains(t, raw, "Content-Type: text/html")
+		assert.Contains(t, raw, "1 hour")
+		// text/plain vem antes do HTML, que é a alternativa preferida
+		assert.Less(t, strings.Index(raw, "text/plain"), strings.Index(raw, "text/html"))
+	})
+
+	t.Run("Erro - Falha no envio", func(t *testing.T) {
+		mockSender := new(mocks.MockSenderService)
+		emailService := service.NewEmailService(mockSender, validConfig)
 
 		mockSender.On("DialAndSend", mock.Anything).Return(errors.New("smtp error"))
 
 		email, _ := vo.NewEmail("user@example.com")
-		err := emailService.SendResetPasswordEmail(email, "reset-token-123")
+		err := emailService.SendResetPasswordEmail(context.Background(), email, "", "reset-token-123")
 
 		assert.Error(t, err)
 		assert.Equal(t, "smtp error", err.Error())
@@ -68,40 +96,40 @@ func TestEmailService_SendResetPasswordEmail(t *testing.T) {
 	})
 
 	t.Run("Erro - FROM_EMAIL não definido", func(t *testing.T) {
-		os.Unsetenv("FROM_EMAIL")
-		os.Setenv("FRONTEND_RESET_URL", "https://app.example.com/reset-password")
+		cfg := validConfig
+		cfg.From = ""
 
 		mockSender := new(mocks.MockSenderService)
-		emailService := service.NewEmailService(mockSender)
+		emailService := service.NewEmailService(mockSender, cfg)
 
 		email, _ := vo.NewEmail("user@example.com")
-		err := emailService.SendResetPasswordEmail(email, "reset-token-123")
+		err := emailService.SendResetPasswordEmail(context.Background(), email, "", "reset-token-123")
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "FROM_EMAIL não está definido")
 	})
 
 	t.Run("Erro - FRONTEND_RESET_URL não definido", func(t *testing.T) {
-		os.Setenv("FROM_EMAIL", "no-reply@example.com")
-		os.Unsetenv("FRONTEND_RESET_URL")
+		cfg := validConfig
+		cfg.FrontendURL = ""
 
 		mockSender := new(mocks.MockSenderService)
-		emailService := service.NewEmailService(mockSender)
+		emailService := service.NewEmailService(mockSender, cfg)
 
 		email, _ := vo.NewEmail("user@example.com")
-		err := emailService.SendResetPasswordEmail(email, "reset-token-123")
+		err := emailService.SendResetPasswordEmail(context.Background(), email, "", "reset-token-123")
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "FRONTEND_RESET_URL não está definido")
 	})
 
 	t.Run("Sucesso - Cria dialer quando sender é nil", func(t *testing.T) {
-		os.Setenv("SMTP_HOST", "smtp.example.com")
-		os.Setenv("SMTP_PORT", "587")
-		os.Setenv("SMTP_USERNAME", "user")
-		os.Setenv("SMTP_PASSWORD", "pass")
-
-		emailService := service.NewEmailService(nil)
+		emailService := service.NewEmailService(nil, service.EmailConfig{
+			SMTPHost:     "smtp.example.com",
+			SMTPPort:     587,
+			SMTPUsername: "user",
+			SMTPPassword: "pass",
+		})
 		assert.NotNil(t, emailService)
 	})
 }
@@ -133,16 +161,98 @@ func TestParsePort(t *testing.T) {
 }
 
 func TestNewEmailService_Fallback(t *testing.T) {
-	// Garantir ambiente limpo
-	os.Unsetenv("FROM_EMAIL")
-	os.Unsetenv("FRONTEND_RESET_URL")
-	os.Setenv("SMTP_HOST", "smtp.example.com")
-	os.Setenv("SMTP_PORT", "invalid") // Porta inválida
-	os.Setenv("SMTP_USERNAME", "user")
-	os.Setenv("SMTP_PASSWORD", "pass")
-
-	t.Run("Usa fallback quando porta é inválida", func(t *testing.T) {
-		emailService := service.NewEmailService(nil)
+	t.Run("Usa porta padrão quando não configurada", func(t *testing.T) {
+		emailService := service.NewEmailService(nil, service.EmailConfig{SMTPHost: "smtp.example.com"})
 		assert.NotNil(t, emailService)
 	})
 }
+
+func TestEmailService_SendDataExportEmail(t *testing.T) {
+	t.Run("Sucesso - Link e validade no corpo", func(t *testing.T) {
+		mockSender := new(mocks.MockSenderService)
+		emailService := service.NewEmailService(mockSender, service.EmailConfig{
+			From:      "no-reply@example.com",
+			ExportTTL: 15 * time.Minute,
+		})
+
+		var body bytes.Buffer
+		mockSender.On("DialAndSend", mock.Anything).
+			Run(func(args mock.Arguments) {
+				_, _ = args.Get(0).([]*gomail.Message)[0].WriteTo(&body)
+			}).
+			Return(nil)
+
+		email, _ := vo.NewEmail("user@example.com")
+		err := emailService.SendDataExportEmail(context.Background(), email, "", "https://api.example.com/exports/file.zip")
+
+		assert.NoError(t, err)
+		assert.Contains(t, body.String(), "https://api.example.com/exports/file.zip")
+		assert.Contains(t, body.String(), "15 minutos")
+	})
+
+	t.Run("Erro - Remetente não configurado", func(t *testing.T) {
+		mockSender := new(mocks.MockSenderService)
+		emailService := service.NewEmailService(mockSender, service.EmailConfig{})
+
+		email, _ := vo.NewEmail("user@example.com")
+		err := emailService.SendDataExportEmail(context.Background(), email, "", "https://api.example.com/exports/file.zip")
+
+		assert.Error(t, err)
+		mockSender.AssertNotCalled(t, "DialAndSend", mock.Anything)
+	})
+}
+
+func TestEmailService_EmailChange(t *testing.T) {
+	config := service.EmailConfig{
+		From:           "no-reply@example.com",
+		EmailChangeURL: "https://app.example.com/email-change",
+		EmailChangeTTL: 24 * time.Hour,
+	}
+	email, _ := vo.NewEmail("old@example.com")
+	newEmail, _ := vo.NewEmail("new@example.com")
+
+	capture := func(sender *mocks.MockSenderService, body *bytes.Buffer) {
+		sender.On("DialAndSend", mock.Anything).
+			Run(func(args mock.Arguments) {
+				_, _ = args.Get(0).([]*gomail.Message)[0].WriteTo(body)
+			}).
+			Return(nil)
+	}
+
+	t.Run("Confirmação vai para o novo endereço", func(t *testing.T) {
+		mockSender := new(mocks.MockSenderService)
+		var body bytes.Buffer
+		capture(mockSender, &body)
+
+		err := service.NewEmailService(mockSender, config).SendEmailChangeConfirmation(context.Background(), newEmail, "pt-BR", "confirm-token")
+
+		assert.NoError(t, err)
+		assert.Contains(t, body.String(), "To: new@example.com")
+		assert.Contains(t, body.String(), "email_change_token")
+		assert.Contains(t, body.String(), "confirm-token")
+		assert.Contains(t, body.String(), "24 horas")
+	})
+
+	t.Run("Aviso ao endereço atual traz o novo e o cancelamento", func(t *testing.T) {
+		mockSender := new(mocks.MockSenderService)
+		var body bytes.Buffer
+		capture(mockSender, &body)
+
+		err := service.NewEmailService(mockSender, config).SendEmailChangeNotice(context.Background(), email, "en-US", newEmail, "cancel-token")
+
+		assert.NoError(t, err)
+		assert.Contains(t, body.String(), "To: old@example.com")
+		assert.Contains(t, body.String(), "new@example.com")
+		assert.Contains(t, body.String(), "email_change_cancel_token")
+		assert.Contains(t, body.String(), "cancel-token")
+	})
+
+	t.Run("Erro - URL não configurada", func(t *testing.T) {
+		mockSender := new(mocks.MockSenderService)
+		emailService := service.NewEmailService(mockSender, service.EmailConfig{From: "no-reply@example.com"})
+
+		assert.Error(t, emailService.SendEmailChangeConfirmation(context.Background(), newEmail, "", "tok"))
+		assert.Error(t, emailService.SendEmailChangeNotice(context.Background(), email, "", newEmail, "tok"))
+		mockSender.AssertNotCalled(t, "DialAndSend", mock.Anything)
+	})
+}
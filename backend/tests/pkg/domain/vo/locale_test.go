@@ -0,0 +1,35 @@
+package vo
+
+import (
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLocale(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{"Canônico", "pt-BR", "pt-BR", nil},
+		{"Normaliza caixa e separador", "en_us", "en-US", nil},
+		{"Só o idioma", "es", "es", nil},
+		{"Vazio usa o padrão", "", "", nil},
+		{"Inválido", "not a locale", "", msgerror.AnErrInvalidLocale},
+		{"Longo demais", "en-US-u-ca-buddhist-nu-thai", "", msgerror.AnErrInvalidLocale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locale, err := vo.NewLocale(tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, locale.String())
+			assert.Equal(t, tt.want == "", locale.IsEmpty())
+		})
+	}
+}
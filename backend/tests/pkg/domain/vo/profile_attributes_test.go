@@ -0,0 +1,62 @@
+package vo
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProfileAttributes(t *testing.T) {
+	t.Run("Válidos", func(t *testing.T) {
+		attributes, err := vo.NewProfileAttributes(map[string]string{"company": "Acme", "job_title": "Dev"})
+		require.NoError(t, err)
+
+		value, ok := attributes.Get("company")
+		assert.True(t, ok)
+		assert.Equal(t, "Acme", value)
+		assert.Equal(t, 2, attributes.Len())
+	})
+
+	t.Run("Map devolve uma cópia", func(t *testing.T) {
+		attributes, err := vo.NewProfileAttributes(map[string]string{"company": "Acme"})
+		require.NoError(t, err)
+
+		copied := attributes.Map()
+		copied["company"] = "Other"
+		value, _ := attributes.Get("company")
+		assert.Equal(t, "Acme", value)
+	})
+
+	t.Run("Vazio", func(t *testing.T) {
+		attributes, err := vo.NewProfileAttributes(nil)
+		require.NoError(t, err)
+		assert.True(t, attributes.IsEmpty())
+		assert.NotNil(t, attributes.Map())
+		assert.True(t, attributes.Equal(vo.ProfileAttributes{}))
+	})
+
+	tooMany := map[string]string{}
+	for i := 0; i <= vo.MaxProfileAttributes; i++ {
+		tooMany[fmt.Sprintf("key_%d", i)] = "x"
+	}
+	invalid := map[string]map[string]string{
+		"Chave com maiúscula": {"Company": "Acme"},
+		"Chave com espaço":    {"job title": "Dev"},
+		"Chave vazia":         {"": "x"},
+		"Valor longo demais":  {"bio": strings.Repeat("a", vo.MaxProfileAttributeLength+1)},
+		"Atributos demais":    tooMany,
+		"Chave começa com _":  {"_private": "x"},
+		"Chave longa demais":  {strings.Repeat("a", 33): "x"},
+	}
+	for name, values := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := vo.NewProfileAttributes(values)
+			assert.ErrorIs(t, err, msgerror.AnErrInvalidAttributes)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package vo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTimezone(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"IANA", "America/Sao_Paulo", nil},
+		{"UTC", "UTC", nil},
+		{"Vazio", "", nil},
+		{"Desconhecido", "Mars/Olympus", msgerror.AnErrInvalidTimezone},
+		{"Fuso do servidor", "Local", msgerror.AnErrInvalidTimezone},
+		{"Caminho relativo", "../../etc/passwd", msgerror.AnErrInvalidTimezone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timezone, err := vo.NewTimezone(tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.input, timezone.String())
+			}
+		})
+	}
+}
+
+func TestTimezone_Location(t *testing.T) {
+	assert.Equal(t, time.UTC, vo.Timezone{}.Location())
+
+	timezone, err := vo.NewTimezone("Asia/Tokyo")
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", timezone.Location().String())
+}
@@ -0,0 +1,130 @@
+package i18n
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/eskokado/startup-auth-go/backend/pkg/domain/vo"
+	"github.com/eskokado/startup-auth-go/backend/pkg/i18n"
+	"github.com/eskokado/startup-auth-go/backend/pkg/msgerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTranslator(t *testing.T) {
+	t.Run("Default locale comes first", func(t *testing.T) {
+		translator, err := i18n.NewTranslator("en-us")
+		require.NoError(t, err)
+
+		assert.Equal(t, "en-US", translator.DefaultLocale())
+		assert.Equal(t, []string{"en-US", "pt-BR"}, translator.Locales())
+	})
+
+	t.Run("Empty uses pt-BR", func(t *testing.T) {
+		translator, err := i18n.NewTranslator("")
+		require.NoError(t, err)
+		assert.Equal(t, "pt-BR", translator.DefaultLocale())
+	})
+
+	t.Run("Unknown default locale", func(t *testing.T) {
+		_, err := i18n.NewTranslator("fr-FR")
+		assert.ErrorContains(t, err, "fr-FR")
+	})
+}
+
+// Todo código exposto pela API precisa de mensagem em todos os idiomas.
+func TestCatalogsCoverEveryCode(t *testing.T) {
+	translator, err := i18n.NewTranslator("")
+	require.NoError(t, err)
+
+	params := map[string]string{"min": "3", "max": "255"}
+	for _, locale := range translator.Locales() {
+		for _, code := range msgerror.Codes() {
+			_, ok := translator.Message(locale, code, params)
+			assert.True(t, ok, "%s: missing message for %s", locale, code)
+		}
+	}
+}
+
+func TestTranslator_Match(t *testing.T) {
+	translator, err := i18n.NewTranslator("pt-BR")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		preferences []string
+		want        string
+	}{
+		{"Exact tag", []string{"en-US"}, "en-US"},
+		{"Language only", []string{"en"}, "en-US"},
+		{"Regional variant", []string{"en-GB"}, "en-US"},
+		{"Accept-Language with weights", []string{"fr-FR,fr;q=0.9,en;q=0.8"}, "en-US"},
+		{"First preference wins", []string{"en-US", "pt-BR"}, "en-US"},
+		{"Empty preference is skipped", []string{"", "en"}, "en-US"},
+		{"Unsupported falls to the next", []string{"ja", "en"}, "en-US"},
+		{"Invalid header", []string{"*;q=abc"}, "pt-BR"},
+		{"Nothing matches", []string{"ja-JP"}, "pt-BR"},
+		{"No preference", nil, "pt-BR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, translator.Match(tt.preferences...))
+		})
+	}
+}
+
+func TestTranslator_Message(t *testing.T) {
+	translator, err := i18n.NewTranslator("pt-BR")
+	require.NoError(t, err)
+
+	t.Run("Localized", func(t *testing.T) {
+		message, ok := translator.Message("en-US", "user_not_found", nil)
+		assert.True(t, ok)
+		assert.Equal(t, "User not found.", message)
+
+		message, ok = translator.Message("pt-BR", "user_not_found", nil)
+		assert.True(t, ok)
+		assert.Equal(t, "Usuário não encontrado.", message)
+	})
+
+	t.Run("Unknown locale uses the default", func(t *testing.T) {
+		message, ok := translator.Message("ja-JP", "user_not_found", nil)
+		assert.True(t, ok)
+		assert.Equal(t, "Usuário não encontrado.", message)
+	})
+
+	t.Run("Unknown code", func(t *testing.T) {
+		_, ok := translator.Message("en-US", "no_such_code", nil)
+		assert.False(t, ok)
+	})
+
+	t.Run("Params from the domain error", func(t *testing.T) {
+		_, err := vo.NewName("ab", 3, 10)
+		require.Error(t, err)
+
+		message, ok := translator.Message("pt-BR", msgerror.Code(err), msgerror.Params(err))
+		assert.True(t, ok)
+		assert.Equal(t, "O nome deve ter no mínimo 3 caracteres.", message)
+	})
+
+	t.Run("Missing param is not rendered", func(t *testing.T) {
+		_, ok := translator.Message("en-US", "name_too_short", nil)
+		assert.False(t, ok)
+	})
+}
+
+func TestParams(t *testing.T) {
+	err := msgerror.WithParam(msgerror.WithParam(msgerror.AnErrNameTooShort, "min", 3), "min", 5)
+	wrapped := fmt.Errorf("register: %w", err)
+
+	assert.ErrorIs(t, wrapped, msgerror.AnErrNameTooShort)
+	assert.Equal(t, msgerror.AnErrNameTooShort.Error(), err.Error())
+	assert.Equal(t, map[string]string{"min": "5"}, msgerror.Params(wrapped))
+	assert.Nil(t, msgerror.Params(msgerror.AnErrNameTooShort))
+	assert.NoError(t, msgerror.WithParam(nil, "min", 3))
+
+	valErr := msgerror.NewValidationErrors()
+	valErr.AddError("name", wrapped)
+	assert.Equal(t, map[string]string{"min": "5"}, valErr.FieldParams["name"])
+}
@@ -1,10 +1,12 @@
 import axios from 'axios';
+import { humanProof } from './human';
 
 export const baseURL = process.env.NEXT_PUBLIC_API_URL
 
 const api = axios.create({ baseURL, withCredentials: true });
 interface ResponseData {
     access_token?: string;
+    csrf_token?: string;
     user?: UserData
 }
 
@@ -20,18 +22,29 @@ api.interceptors.request.use((config) => {
     if (token) {
         config.headers!['authorization'] = `Bearer ${token}`;
     }
+    // Modo cookie: o JWT fica no cookie HttpOnly e só o token CSRF é enviado
+    const csrfToken = localStorage.getItem('csrf-token');
+    if (csrfToken) {
+        config.headers!['x-csrf-token'] = csrfToken;
+    }
     return config;
 });
 
 api.interceptors.response.use(
     (response) => {
         const newAccessToken = (response.data as ResponseData)?.access_token;
+        const newCsrfToken = (response.data as ResponseData)?.csrf_token;
         const newUserId = (response.data as ResponseData)?.user?.id
         const newUserName = (response.data as ResponseData)?.user?.name
         const newUserEmail = (response.data as ResponseData)?.user?.email
 
-        if (newAccessToken && newUserId && newUserEmail && newUserName) {
-            localStorage.setItem('access-token', newAccessToken);
+        if ((newAccessToken || newCsrfToken) && newUserId && newUserEmail && newUserName) {
+            if (newAccessToken) {
+                localStorage.setItem('access-token', newAccessToken);
+            }
+            if (newCsrfToken) {
+                localStorage.setItem('csrf-token', newCsrfToken);
+            }
             localStorage.setItem('user-id', newUserId)
             localStorage.setItem('user-name', newUserName)
             localStorage.setItem('user-email', newUserEmail)
@@ -39,10 +52,21 @@ api.interceptors.response.use(
 
         return response;
     },
-    (error) => {
+    async (error) => {
+        // Cadastro e esqueci a senha podem exigir a prova de trabalho: resolve e reenvia uma vez
+        if (error.response?.status === 403 && error.response.data?.code === 'human_verification_required' && error.config && !error.config._humanRetry) {
+            const proof = await humanProof(baseURL).catch(() => null);
+            if (proof) {
+                error.config._humanRetry = true;
+                error.config.headers['x-human-proof'] = proof;
+                return api.request(error.config);
+            }
+        }
+
         if (error.response) {
             if (error.response?.status === 401) {
                 localStorage.removeItem('access-token');
+                localStorage.removeItem('csrf-token');
                 window.location.href = '/auth/login';
                 return Promise.reject({ code: 401, messages: ['Não autorizado'] });
             }
@@ -52,7 +76,10 @@ api.interceptors.response.use(
             let errorMessages: string[] = [];
 
             if (errorData.errors && Array.isArray(errorData.errors)) {
-                errorMessages = errorData.errors; // Array
+                // problem+json: um item por campo inválido
+                errorMessages = errorData.errors.map((e: { field: string; detail: string }) => `${e.field}: ${e.detail}`);
+            } else if (errorData.detail) {
+                errorMessages = [errorData.detail];
             } else if (errorData.error) {
                 errorMessages = [errorData.error]; // Converte string em array
             } else {
@@ -0,0 +1,41 @@
+import axios from 'axios';
+
+interface HumanChallenge {
+    type: 'pow' | 'hcaptcha' | 'turnstile';
+    challenge?: string;
+    difficulty?: number;
+}
+
+const leadingZeroBits = (hash: Uint8Array) => {
+    let bits = 0;
+    for (let i = 0; i < hash.length; i++) {
+        if (hash[i] !== 0) {
+            return bits + Math.clz32(hash[i]) - 24;
+        }
+        bits += 8;
+    }
+    return bits;
+};
+
+// Procura uma solução tal que sha256("<desafio>:<solução>") comece com
+// `difficulty` bits zero; o resultado vai no header X-Human-Proof
+export const solveProofOfWork = async (challenge: string, difficulty: number) => {
+    const encoder = new TextEncoder();
+    for (let i = 0; ; i++) {
+        const proof = `${challenge}:${i}`;
+        const hash = new Uint8Array(await crypto.subtle.digest('SHA-256', encoder.encode(proof)));
+        if (leadingZeroBits(hash) >= difficulty) {
+            return proof;
+        }
+    }
+};
+
+// Busca um desafio e o resolve; captchas precisam de um widget na página e
+// não são resolvidos aqui
+export const humanProof = async (baseURL?: string): Promise<string | null> => {
+    const { data } = await axios.get<HumanChallenge>('/auth/human-challenge', { baseURL, withCredentials: true });
+    if (data.type !== 'pow' || !data.challenge || !data.difficulty) {
+        return null;
+    }
+    return solveProofOfWork(data.challenge, data.difficulty);
+};
@@ -60,15 +60,8 @@ export const authApi = {
     },
 
     logout: async () => {
-        await api.delete('/auth/logout', {
-            headers: getAuthHeaders()
-        });
+        // O interceptor já envia o bearer token ou, no modo cookie, o token CSRF
+        await api.delete('/auth/logout');
         localStorage.clear();
     }
 };
-
-function getAuthHeaders() {
-    return {
-        'Authorization': `Bearer ${localStorage.getItem('access-token')}`,
-    };
-}
@@ -8,8 +8,10 @@ export const useAuth = (redirectPath = '/auth/login') => {
     useEffect(() => {
         const checkAuth = () => {
             const token = localStorage.getItem('access-token');
+            // No modo cookie o JWT não fica acessível ao JS; o token CSRF indica a sessão
+            const csrfToken = localStorage.getItem('csrf-token');
 
-            const authStatus = !!token;
+            const authStatus = !!token || !!csrfToken;
             setIsAuthenticated(authStatus);
 
             if (!authStatus && redirectPath) {